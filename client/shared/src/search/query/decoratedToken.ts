@@ -1008,6 +1008,7 @@ const decoratePredicateBody = (path: string[], body: string, offset: number): De
         }
         case 'has.tag':
         case 'has.owner':
+        case 'has.inferred.owner':
         case 'has.key':
         case 'has.topic':
             return [
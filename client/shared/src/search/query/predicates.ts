@@ -54,7 +54,14 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }, { name: 'owner' }],
+                fields: [
+                    { name: 'content' },
+                    { name: 'owner' },
+                    {
+                        name: 'inferred',
+                        fields: [{ name: 'owner' }],
+                    },
+                ],
             },
         ],
     },
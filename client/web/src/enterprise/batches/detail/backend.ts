@@ -44,6 +44,12 @@ import {
     CloseChangesetsVariables,
     PublishChangesetsResult,
     PublishChangesetsVariables,
+    UpdateChangesetBranchesResult,
+    UpdateChangesetBranchesVariables,
+    SetChangesetReviewersAndLabelsResult,
+    SetChangesetReviewersAndLabelsVariables,
+    RerunFailedChangesetChecksResult,
+    RerunFailedChangesetChecksVariables,
     AvailableBulkOperationsVariables,
     AvailableBulkOperationsResult,
     BulkOperationType,
@@ -790,6 +796,72 @@ export async function mergeChangesets(
     dataOrThrowErrors(result)
 }
 
+export async function updateChangesetBranches(
+    batchChange: Scalars['ID'],
+    changesets: Scalars['ID'][]
+): Promise<void> {
+    const result = await requestGraphQL<UpdateChangesetBranchesResult, UpdateChangesetBranchesVariables>(
+        gql`
+            mutation UpdateChangesetBranches($batchChange: ID!, $changesets: [ID!]!) {
+                updateChangesetBranches(batchChange: $batchChange, changesets: $changesets) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
+export async function rerunFailedChangesetChecks(
+    batchChange: Scalars['ID'],
+    changesets: Scalars['ID'][]
+): Promise<void> {
+    const result = await requestGraphQL<RerunFailedChangesetChecksResult, RerunFailedChangesetChecksVariables>(
+        gql`
+            mutation RerunFailedChangesetChecks($batchChange: ID!, $changesets: [ID!]!) {
+                rerunFailedChangesetChecks(batchChange: $batchChange, changesets: $changesets) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
+export async function setChangesetReviewersAndLabels(
+    batchChange: Scalars['ID'],
+    changesets: Scalars['ID'][],
+    reviewers: string[],
+    assignees: string[],
+    labels: string[]
+): Promise<void> {
+    const result = await requestGraphQL<SetChangesetReviewersAndLabelsResult, SetChangesetReviewersAndLabelsVariables>(
+        gql`
+            mutation SetChangesetReviewersAndLabels(
+                $batchChange: ID!
+                $changesets: [ID!]!
+                $reviewers: [String!]
+                $assignees: [String!]
+                $labels: [String!]
+            ) {
+                setChangesetReviewersAndLabels(
+                    batchChange: $batchChange
+                    changesets: $changesets
+                    reviewers: $reviewers
+                    assignees: $assignees
+                    labels: $labels
+                ) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets, reviewers, assignees, labels }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
 export async function closeChangesets(batchChange: Scalars['ID'], changesets: Scalars['ID'][]): Promise<void> {
     const result = await requestGraphQL<CloseChangesetsResult, CloseChangesetsVariables>(
         gql`
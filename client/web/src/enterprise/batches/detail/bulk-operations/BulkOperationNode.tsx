@@ -1,6 +1,16 @@
 import React from 'react'
 
-import { mdiCommentOutline, mdiLinkVariantRemove, mdiSync, mdiSourceBranch, mdiUpload, mdiOpenInNew } from '@mdi/js'
+import {
+    mdiAccountMultipleOutline,
+    mdiCommentOutline,
+    mdiLinkVariantRemove,
+    mdiSync,
+    mdiSourceBranch,
+    mdiSourceBranchSync,
+    mdiUpload,
+    mdiOpenInNew,
+    mdiRefresh,
+} from '@mdi/js'
 import classNames from 'classnames'
 
 import { Timestamp } from '@sourcegraph/branded/src/components/Timestamp'
@@ -44,6 +54,22 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <Icon aria-hidden={true} className="text-muted" svgPath={mdiUpload} /> Publish changesets
         </>
     ),
+    UPDATE_BRANCH: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiSourceBranchSync} /> Update changeset branches
+        </>
+    ),
+    SET_REVIEWERS_AND_LABELS: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiAccountMultipleOutline} /> Set reviewers and
+            labels
+        </>
+    ),
+    RERUN_FAILED_CHECKS: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiRefresh} /> Re-run failed checks
+        </>
+    ),
 }
 
 export interface BulkOperationNodeProps {
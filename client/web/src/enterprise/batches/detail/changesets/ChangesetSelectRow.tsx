@@ -21,6 +21,9 @@ import { DetachChangesetsModal } from './DetachChangesetsModal'
 import { MergeChangesetsModal } from './MergeChangesetsModal'
 import { PublishChangesetsModal } from './PublishChangesetsModal'
 import { ReenqueueChangesetsModal } from './ReenqueueChangesetsModal'
+import { RerunFailedChangesetChecksModal } from './RerunFailedChangesetChecksModal'
+import { SetChangesetReviewersAndLabelsModal } from './SetChangesetReviewersAndLabelsModal'
+import { UpdateChangesetBranchesModal } from './UpdateChangesetBranchesModal'
 
 /**
  * Describes a possible action on the changeset list.
@@ -149,6 +152,62 @@ const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
             )
         },
     },
+    [BulkOperationType.SET_REVIEWERS_AND_LABELS]: {
+        type: 'set-reviewers-and-labels',
+        experimental: true,
+        buttonLabel: 'Set reviewers and labels',
+        dropdownTitle: 'Set reviewers and labels',
+        dropdownDescription: 'Add reviewers, assignees and labels to all selected changesets on the code hosts.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_set_reviewers_and_labels:clicked')
+            return (
+                <SetChangesetReviewersAndLabelsModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                />
+            )
+        },
+    },
+    [BulkOperationType.UPDATE_BRANCH]: {
+        type: 'update-branch',
+        experimental: true,
+        buttonLabel: 'Update branches',
+        dropdownTitle: 'Update branches',
+        dropdownDescription:
+            'Bring the branches of all selected changesets up to date with their base branch. Code hosts that cannot update a branch themselves get the changeset diff re-applied on top of the latest base branch.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_update_branch:clicked')
+            return (
+                <UpdateChangesetBranchesModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                />
+            )
+        },
+    },
+    [BulkOperationType.RERUN_FAILED_CHECKS]: {
+        type: 'rerun-failed-checks',
+        experimental: true,
+        buttonLabel: 'Re-run failed checks',
+        dropdownTitle: 'Re-run failed checks',
+        dropdownDescription:
+            'Re-run the failed GitHub Actions check suites, GitLab pipelines and Azure Pipelines builds of all selected changesets.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_rerun_failed_checks:clicked')
+            return (
+                <RerunFailedChangesetChecksModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                />
+            )
+        },
+    },
 }
 
 export interface ChangesetSelectRowProps {
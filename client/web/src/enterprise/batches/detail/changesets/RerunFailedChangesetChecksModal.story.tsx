@@ -0,0 +1,36 @@
+import { action } from '@storybook/addon-actions'
+import { Story, Meta, DecoratorFn } from '@storybook/react'
+import { noop } from 'lodash'
+
+import { WebStory } from '../../../../components/WebStory'
+
+import { RerunFailedChangesetChecksModal } from './RerunFailedChangesetChecksModal'
+
+const decorator: DecoratorFn = story => <div className="p-3 container">{story()}</div>
+
+const config: Meta = {
+    title: 'web/batches/details/RerunFailedChangesetChecksModal',
+    decorators: [decorator],
+}
+
+export default config
+
+const rerunFailedChangesetChecks = () => {
+    action('RerunFailedChangesetChecks')
+    return Promise.resolve()
+}
+
+export const Confirmation: Story = () => (
+    <WebStory>
+        {props => (
+            <RerunFailedChangesetChecksModal
+                {...props}
+                afterCreate={noop}
+                batchChangeID="test-123"
+                changesetIDs={['test-123', 'test-234']}
+                onCancel={noop}
+                rerunFailedChangesetChecks={rerunFailedChangesetChecks}
+            />
+        )}
+    </WebStory>
+)
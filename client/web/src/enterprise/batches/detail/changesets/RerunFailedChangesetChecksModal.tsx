@@ -0,0 +1,72 @@
+import React, { useCallback, useState } from 'react'
+
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Modal, H3, Text, ErrorAlert } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { rerunFailedChangesetChecks as _rerunFailedChangesetChecks } from '../backend'
+
+export interface RerunFailedChangesetChecksModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    rerunFailedChangesetChecks?: typeof _rerunFailedChangesetChecks
+}
+
+export const RerunFailedChangesetChecksModal: React.FunctionComponent<
+    React.PropsWithChildren<RerunFailedChangesetChecksModalProps>
+> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    rerunFailedChangesetChecks = _rerunFailedChangesetChecks,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+
+    const onSubmit = useCallback<React.FormEventHandler>(async () => {
+        setIsLoading(true)
+        try {
+            await rerunFailedChangesetChecks(batchChangeID, changesetIDs)
+            afterCreate()
+        } catch (error) {
+            setIsLoading(asError(error))
+        }
+    }, [changesetIDs, rerunFailedChangesetChecks, batchChangeID, afterCreate])
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={MODAL_LABEL_ID}>
+            <H3 id={MODAL_LABEL_ID}>Re-run failed checks</H3>
+            <Text className="mb-4">
+                Are you sure you want to re-run the failed checks of all the selected changesets? Commit statuses
+                posted by external CI systems can't be re-run from Sourcegraph and are left untouched.
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <div className="d-flex justify-content-end">
+                <Button
+                    disabled={isLoading === true}
+                    className="mr-2"
+                    onClick={onCancel}
+                    outline={true}
+                    variant="secondary"
+                >
+                    Cancel
+                </Button>
+                <LoaderButton
+                    onClick={onSubmit}
+                    disabled={isLoading === true}
+                    variant="primary"
+                    loading={isLoading === true}
+                    alwaysShowLabel={true}
+                    label="Re-run checks"
+                />
+            </div>
+        </Modal>
+    )
+}
+
+const MODAL_LABEL_ID = 'rerun-failed-changeset-checks-modal-title'
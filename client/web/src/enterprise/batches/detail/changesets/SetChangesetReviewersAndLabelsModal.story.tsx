@@ -0,0 +1,36 @@
+import { action } from '@storybook/addon-actions'
+import { Story, Meta, DecoratorFn } from '@storybook/react'
+import { noop } from 'lodash'
+
+import { WebStory } from '../../../../components/WebStory'
+
+import { SetChangesetReviewersAndLabelsModal } from './SetChangesetReviewersAndLabelsModal'
+
+const decorator: DecoratorFn = story => <div className="p-3 container">{story()}</div>
+
+const config: Meta = {
+    title: 'web/batches/details/SetChangesetReviewersAndLabelsModal',
+    decorators: [decorator],
+}
+
+export default config
+
+const setChangesetReviewersAndLabels = () => {
+    action('SetChangesetReviewersAndLabels')
+    return Promise.resolve()
+}
+
+export const Confirmation: Story = () => (
+    <WebStory>
+        {props => (
+            <SetChangesetReviewersAndLabelsModal
+                {...props}
+                afterCreate={noop}
+                batchChangeID="test-123"
+                changesetIDs={['test-123', 'test-234']}
+                onCancel={noop}
+                setChangesetReviewersAndLabels={setChangesetReviewersAndLabels}
+            />
+        )}
+    </WebStory>
+)
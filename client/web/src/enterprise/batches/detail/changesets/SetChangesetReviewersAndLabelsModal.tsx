@@ -0,0 +1,135 @@
+import React, { useCallback, useState } from 'react'
+
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Input, Modal, H3, Text, ErrorAlert, Form } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { setChangesetReviewersAndLabels as _setChangesetReviewersAndLabels } from '../backend'
+
+export interface SetChangesetReviewersAndLabelsModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    setChangesetReviewersAndLabels?: typeof _setChangesetReviewersAndLabels
+}
+
+export const SetChangesetReviewersAndLabelsModal: React.FunctionComponent<
+    React.PropsWithChildren<SetChangesetReviewersAndLabelsModalProps>
+> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    setChangesetReviewersAndLabels = _setChangesetReviewersAndLabels,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+    const [reviewers, setReviewers] = useState<string>('')
+    const [assignees, setAssignees] = useState<string>('')
+    const [labels, setLabels] = useState<string>('')
+
+    const onChangeReviewers = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setReviewers(event.target.value)
+    }, [])
+    const onChangeAssignees = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setAssignees(event.target.value)
+    }, [])
+    const onChangeLabels = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setLabels(event.target.value)
+    }, [])
+
+    const isEmpty = [reviewers, assignees, labels].every(value => splitList(value).length === 0)
+
+    const onSubmit = useCallback<React.FormEventHandler>(
+        async event => {
+            event.preventDefault()
+            setIsLoading(true)
+            try {
+                await setChangesetReviewersAndLabels(
+                    batchChangeID,
+                    changesetIDs,
+                    splitList(reviewers),
+                    splitList(assignees),
+                    splitList(labels)
+                )
+                afterCreate()
+            } catch (error) {
+                setIsLoading(asError(error))
+            }
+        },
+        [afterCreate, assignees, batchChangeID, changesetIDs, labels, reviewers, setChangesetReviewersAndLabels]
+    )
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={LABEL_ID}>
+            <H3 id={LABEL_ID}>Set reviewers and labels</H3>
+            <Text className="mb-4">
+                Add reviewers, assignees and labels to all the selected changesets. Existing reviewers, assignees and
+                labels are kept. Separate multiple values with commas.
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <Form onSubmit={onSubmit}>
+                <Input
+                    id="set-changeset-reviewers"
+                    className="form-group"
+                    label="Reviewers"
+                    placeholder="alice, bob"
+                    value={reviewers}
+                    onChange={onChangeReviewers}
+                    disabled={isLoading === true}
+                    message="Code host usernames of the reviewers to request."
+                />
+                <Input
+                    id="set-changeset-assignees"
+                    className="form-group"
+                    label="Assignees"
+                    placeholder="alice"
+                    value={assignees}
+                    onChange={onChangeAssignees}
+                    disabled={isLoading === true}
+                    message="Only supported on GitHub and GitLab."
+                />
+                <Input
+                    id="set-changeset-labels"
+                    className="form-group"
+                    label="Labels"
+                    placeholder="batch-change, dependencies"
+                    value={labels}
+                    onChange={onChangeLabels}
+                    disabled={isLoading === true}
+                    message="Supported on GitHub, GitLab and Azure DevOps. On GitHub, labels must already exist."
+                />
+                <div className="d-flex justify-content-end">
+                    <Button
+                        disabled={isLoading === true}
+                        className="mr-2"
+                        onClick={onCancel}
+                        outline={true}
+                        variant="secondary"
+                    >
+                        Cancel
+                    </Button>
+                    <LoaderButton
+                        type="submit"
+                        disabled={isLoading === true || isEmpty}
+                        variant="primary"
+                        loading={isLoading === true}
+                        alwaysShowLabel={true}
+                        label="Update changesets"
+                    />
+                </div>
+            </Form>
+        </Modal>
+    )
+}
+
+const LABEL_ID = 'set-changeset-reviewers-and-labels-modal-id'
+
+const splitList = (value: string): string[] =>
+    value
+        .split(',')
+        .map(item => item.trim())
+        .filter(item => item.length > 0)
@@ -0,0 +1,36 @@
+import { action } from '@storybook/addon-actions'
+import { Story, Meta, DecoratorFn } from '@storybook/react'
+import { noop } from 'lodash'
+
+import { WebStory } from '../../../../components/WebStory'
+
+import { UpdateChangesetBranchesModal } from './UpdateChangesetBranchesModal'
+
+const decorator: DecoratorFn = story => <div className="p-3 container">{story()}</div>
+
+const config: Meta = {
+    title: 'web/batches/details/UpdateChangesetBranchesModal',
+    decorators: [decorator],
+}
+
+export default config
+
+const updateChangesetBranches = () => {
+    action('UpdateChangesetBranches')
+    return Promise.resolve()
+}
+
+export const Confirmation: Story = () => (
+    <WebStory>
+        {props => (
+            <UpdateChangesetBranchesModal
+                {...props}
+                afterCreate={noop}
+                batchChangeID="test-123"
+                changesetIDs={['test-123', 'test-234']}
+                onCancel={noop}
+                updateChangesetBranches={updateChangesetBranches}
+            />
+        )}
+    </WebStory>
+)
@@ -0,0 +1,73 @@
+import React, { useCallback, useState } from 'react'
+
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Modal, H3, Text, ErrorAlert } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { updateChangesetBranches as _updateChangesetBranches } from '../backend'
+
+export interface UpdateChangesetBranchesModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    updateChangesetBranches?: typeof _updateChangesetBranches
+}
+
+export const UpdateChangesetBranchesModal: React.FunctionComponent<
+    React.PropsWithChildren<UpdateChangesetBranchesModalProps>
+> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    updateChangesetBranches = _updateChangesetBranches,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+
+    const onSubmit = useCallback<React.FormEventHandler>(async () => {
+        setIsLoading(true)
+        try {
+            await updateChangesetBranches(batchChangeID, changesetIDs)
+            afterCreate()
+        } catch (error) {
+            setIsLoading(asError(error))
+        }
+    }, [changesetIDs, updateChangesetBranches, batchChangeID, afterCreate])
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={MODAL_LABEL_ID}>
+            <H3 id={MODAL_LABEL_ID}>Update changeset branches</H3>
+            <Text className="mb-4">
+                Are you sure you want to bring the branches of all the selected changesets up to date with their base
+                branch? Where the code host can't update a branch itself, the changeset diff is applied again on top
+                of the latest base branch and force-pushed.
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <div className="d-flex justify-content-end">
+                <Button
+                    disabled={isLoading === true}
+                    className="mr-2"
+                    onClick={onCancel}
+                    outline={true}
+                    variant="secondary"
+                >
+                    Cancel
+                </Button>
+                <LoaderButton
+                    onClick={onSubmit}
+                    disabled={isLoading === true}
+                    variant="primary"
+                    loading={isLoading === true}
+                    alwaysShowLabel={true}
+                    label="Update branches"
+                />
+            </div>
+        </Modal>
+    )
+}
+
+const MODAL_LABEL_ID = 'update-changeset-branches-modal-title'
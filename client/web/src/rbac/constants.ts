@@ -3,3 +3,21 @@
 export const BatchChangesReadPermission = 'BATCH_CHANGES#READ'
 
 export const BatchChangesWritePermission = 'BATCH_CHANGES#WRITE'
+
+export const CodeInsightsWritePermission = 'CODE_INSIGHTS#WRITE'
+
+export const CodeMonitorsWritePermission = 'CODE_MONITORS#WRITE'
+
+export const NotebooksWritePermission = 'NOTEBOOKS#WRITE'
+
+export const SearchContextsWritePermission = 'SEARCH_CONTEXTS#WRITE'
+
+export const ExecutorSecretsReadPermission = 'EXECUTOR_SECRETS#READ'
+
+export const ExecutorSecretsWritePermission = 'EXECUTOR_SECRETS#WRITE'
+
+export const RepoManagementReadPermission = 'REPO_MANAGEMENT#READ'
+
+export const RepoManagementWritePermission = 'REPO_MANAGEMENT#WRITE'
+
+export const SiteConfigReadPermission = 'SITE_CONFIG#READ'
@@ -269,6 +269,8 @@ export const SiteAdminFeatureFlagConfigurationPage: FunctionComponent<
     )
 }
 
+// Multivariate flags and targeting rules can only be managed with the GraphQL API.
+// Updates from this page don't send rules, so the rules of a flag are kept.
 type FeatureFlagType = 'FeatureFlagBoolean' | 'FeatureFlagRollout'
 
 interface FeatureFlagOverride {
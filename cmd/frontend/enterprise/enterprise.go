@@ -49,6 +49,7 @@ type Services struct {
 
 	PermissionsGitHubWebhook  webhooks.Registerer
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
+	NewCodeIntelLSPHandler    NewCodeIntelLSPHandler
 	RankingService            RankingService
 	NewExecutorProxyHandler   NewExecutorProxyHandler
 	NewGitHubAppSetupHandler  NewGitHubAppSetupHandler
@@ -61,6 +62,10 @@ type Services struct {
 // resulting handler skips auth checks when the internal flag is true.
 type NewCodeIntelUploadHandler func(internal bool) http.Handler
 
+// NewCodeIntelLSPHandler creates a new handler for the code navigation Language Server
+// Protocol endpoint.
+type NewCodeIntelLSPHandler func() http.Handler
+
 // RankingService is a subset of codeintel.ranking.Service methods we use.
 type RankingService interface {
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
@@ -104,6 +109,7 @@ func DefaultServices() Services {
 		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
 		SCIMHandler:                     makeNotFoundHandler("SCIM handler"),
 		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
+		NewCodeIntelLSPHandler:          func() http.Handler { return makeNotFoundHandler("code intel LSP endpoint") },
 		RankingService:                  stubRankingService{},
 		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
@@ -0,0 +1,231 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/audit/auditstore"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type auditLogEntriesArgs struct {
+	graphqlutil.ConnectionArgs
+	After  *string
+	Actor  *string
+	Entity *string
+	Action *string
+	Since  *time.Time
+	Until  *time.Time
+}
+
+// toListOpts transforms the GraphQL arguments into options for the audit log store.
+func (args *auditLogEntriesArgs) toListOpts() (auditstore.ListOpts, error) {
+	opts := auditstore.ListOpts{
+		LimitOffset: &database.LimitOffset{Limit: 50},
+		Since:       args.Since,
+		Until:       args.Until,
+	}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		id, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return opts, errors.Wrap(err, "parsing the after cursor")
+		}
+		opts.BeforeID = id
+	}
+	if args.Actor != nil {
+		opts.Actor = *args.Actor
+	}
+	if args.Entity != nil {
+		opts.Entity = *args.Entity
+	}
+	if args.Action != nil {
+		opts.Action = *args.Action
+	}
+	return opts, nil
+}
+
+// AuditLogEntries returns the audit log entries stored in the database, newest first.
+func (r *schemaResolver) AuditLogEntries(ctx context.Context, args *auditLogEntriesArgs) (*auditLogEntryConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may read the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := args.toListOpts()
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogEntryConnectionResolver{store: auditstore.New(r.db), opts: opts}, nil
+}
+
+type auditLogEntryConnectionResolver struct {
+	store auditstore.Store
+	opts  auditstore.ListOpts
+
+	once    sync.Once
+	entries []*audit.Entry
+	err     error
+}
+
+func (r *auditLogEntryConnectionResolver) compute(ctx context.Context) ([]*audit.Entry, error) {
+	r.once.Do(func() {
+		r.entries, r.err = r.store.List(ctx, r.opts)
+	})
+	return r.entries, r.err
+}
+
+func (r *auditLogEntryConnectionResolver) Nodes(ctx context.Context) ([]*auditLogEntryResolver, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*auditLogEntryResolver, 0, len(entries))
+	for _, e := range entries {
+		nodes = append(nodes, &auditLogEntryResolver{entry: e})
+	}
+	return nodes, nil
+}
+
+func (r *auditLogEntryConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts := r.opts
+	opts.LimitOffset = nil
+	opts.BeforeID = 0
+	count, err := r.store.Count(ctx, opts)
+	return int32(count), err
+}
+
+func (r *auditLogEntryConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 || len(entries) < r.opts.Limit {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(strconv.FormatInt(entries[len(entries)-1].ID, 10)), nil
+}
+
+type auditLogEntryResolver struct {
+	entry *audit.Entry
+}
+
+func marshalAuditLogEntryID(id int64) graphql.ID {
+	return relay.MarshalID("AuditLogEntry", id)
+}
+
+func (r *auditLogEntryResolver) ID() graphql.ID {
+	return marshalAuditLogEntryID(r.entry.ID)
+}
+
+func (r *auditLogEntryResolver) AuditID() string {
+	return r.entry.AuditID
+}
+
+func (r *auditLogEntryResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.entry.CreatedAt}
+}
+
+func (r *auditLogEntryResolver) Actor() string {
+	return r.entry.Actor
+}
+
+func (r *auditLogEntryResolver) IP() string {
+	return r.entry.IP
+}
+
+func (r *auditLogEntryResolver) ForwardedFor() string {
+	return r.entry.ForwardedFor
+}
+
+func (r *auditLogEntryResolver) Entity() string {
+	return r.entry.Entity
+}
+
+func (r *auditLogEntryResolver) Action() string {
+	return r.entry.Action
+}
+
+func (r *auditLogEntryResolver) Fields() JSONValue {
+	return JSONValue{r.entry.Fields}
+}
+
+func (r *auditLogEntryResolver) PreviousHash() *string {
+	if r.entry.PreviousHash == "" {
+		return nil
+	}
+	return &r.entry.PreviousHash
+}
+
+func (r *auditLogEntryResolver) Hash() *string {
+	if r.entry.Hash == "" {
+		return nil
+	}
+	return &r.entry.Hash
+}
+
+// VerifyAuditLog checks the hash chain of the tamper-evident audit log entries.
+func (r *schemaResolver) VerifyAuditLog(ctx context.Context) (*auditLogVerificationResolver, error) {
+	// 🚨 SECURITY: Only site admins may read the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	checked, err := auditstore.Verify(ctx, auditstore.New(r.db))
+	var chainErr *audit.ChainError
+	if err != nil && !errors.As(err, &chainErr) {
+		return nil, err
+	}
+	return &auditLogVerificationResolver{
+		checkedAt: time.Now(),
+		checked:   checked,
+		chainErr:  chainErr,
+	}, nil
+}
+
+type auditLogVerificationResolver struct {
+	checkedAt time.Time
+	checked   int
+	chainErr  *audit.ChainError
+}
+
+func (r *auditLogVerificationResolver) Valid() bool {
+	return r.chainErr == nil
+}
+
+func (r *auditLogVerificationResolver) EntriesChecked() int32 {
+	return int32(r.checked)
+}
+
+func (r *auditLogVerificationResolver) CheckedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.checkedAt}
+}
+
+func (r *auditLogVerificationResolver) FirstInvalidEntryID() *graphql.ID {
+	if r.chainErr == nil {
+		return nil
+	}
+	id := marshalAuditLogEntryID(r.chainErr.EntryID)
+	return &id
+}
+
+func (r *auditLogVerificationResolver) Reason() *string {
+	if r.chainErr == nil {
+		return nil
+	}
+	return &r.chainErr.Reason
+}
@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/audit/auditstore"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAuditLogEntriesArgs(t *testing.T) {
+	now := time.Date(2023, 5, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("defaults", func(t *testing.T) {
+		opts, err := (&auditLogEntriesArgs{}).toListOpts()
+		require.NoError(t, err)
+		assert.Equal(t, auditstore.ListOpts{LimitOffset: &database.LimitOffset{Limit: 50}}, opts)
+	})
+
+	t.Run("all arguments", func(t *testing.T) {
+		opts, err := (&auditLogEntriesArgs{
+			ConnectionArgs: graphqlutil.ConnectionArgs{First: int32Ptr(10)},
+			After:          stringPtr("42"),
+			Actor:          stringPtr("1"),
+			Entity:         stringPtr("site config"),
+			Action:         stringPtr("updated"),
+			Since:          timePtr(now),
+			Until:          timePtr(now.Add(time.Hour)),
+		}).toListOpts()
+		require.NoError(t, err)
+		assert.Equal(t, auditstore.ListOpts{
+			LimitOffset: &database.LimitOffset{Limit: 10},
+			Actor:       "1",
+			Entity:      "site config",
+			Action:      "updated",
+			Since:       timePtr(now),
+			Until:       timePtr(now.Add(time.Hour)),
+			BeforeID:    42,
+		}, opts)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := (&auditLogEntriesArgs{After: stringPtr("foo")}).toListOpts()
+		assert.Error(t, err)
+	})
+}
+
+func TestAuditLogSiteAdminOnly(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	r := &schemaResolver{db: db}
+
+	_, err := r.AuditLogEntries(context.Background(), &auditLogEntriesArgs{})
+	assert.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+
+	_, err = r.VerifyAuditLog(context.Background())
+	assert.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+}
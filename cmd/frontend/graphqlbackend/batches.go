@@ -93,6 +93,7 @@ type CreateBatchSpecFromRawArgs struct {
 	AllowUnsupported bool
 	Execute          bool
 	NoCache          bool
+	DryRun           bool
 	Namespace        graphql.ID
 	BatchChange      graphql.ID
 }
@@ -233,6 +234,41 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type UpdateChangesetBranchesArgs struct {
+	BulkOperationBaseArgs
+}
+
+type RerunFailedChangesetChecksArgs struct {
+	BulkOperationBaseArgs
+}
+
+type SetChangesetReviewersAndLabelsArgs struct {
+	BulkOperationBaseArgs
+	Reviewers *[]string
+	Assignees *[]string
+	Labels    *[]string
+}
+
+type SetBatchChangeAutoMergePolicyArgs struct {
+	BatchChange         graphql.ID
+	MergeMethod         string
+	RequiredReviewState *string
+	RequiredChecks      string
+	MergeWindowDays     *[]string
+	MergeWindowStart    *string
+	MergeWindowEnd      *string
+	MaxMergesPerHour    int32
+}
+
+type DeleteBatchChangeAutoMergePolicyArgs struct {
+	BatchChange graphql.ID
+}
+
+type ListAutoMergeBlockedChangesetsArgs struct {
+	First int32
+	After *string
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -284,6 +320,11 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	UpdateChangesetBranches(ctx context.Context, args *UpdateChangesetBranchesArgs) (BulkOperationResolver, error)
+	SetChangesetReviewersAndLabels(ctx context.Context, args *SetChangesetReviewersAndLabelsArgs) (BulkOperationResolver, error)
+	RerunFailedChangesetChecks(ctx context.Context, args *RerunFailedChangesetChecksArgs) (BulkOperationResolver, error)
+	SetBatchChangeAutoMergePolicy(ctx context.Context, args *SetBatchChangeAutoMergePolicyArgs) (BatchChangeResolver, error)
+	DeleteBatchChangeAutoMergePolicy(ctx context.Context, args *DeleteBatchChangeAutoMergePolicyArgs) (*EmptyResponse, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
@@ -662,6 +703,33 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	AutoMergePolicy(ctx context.Context) (BatchChangeAutoMergePolicyResolver, error)
+}
+
+type BatchChangeAutoMergePolicyResolver interface {
+	MergeMethod() string
+	RequiredReviewState() *string
+	RequiredChecks() string
+	MergeWindowDays() []string
+	MergeWindowStart() *string
+	MergeWindowEnd() *string
+	MaxMergesPerHour() int32
+	User(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+	BlockedChangesets(ctx context.Context, args *ListAutoMergeBlockedChangesetsArgs) (BatchChangeAutoMergeBlockedChangesetConnectionResolver, error)
+}
+
+type BatchChangeAutoMergeBlockedChangesetConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchChangeAutoMergeBlockedChangesetResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type BatchChangeAutoMergeBlockedChangesetResolver interface {
+	Changeset() ChangesetResolver
+	Reason() *string
+	UpdatedAt() gqlutil.DateTime
 }
 
 type BatchChangesConnectionResolver interface {
@@ -741,6 +809,17 @@ type ChangesetsConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type ChangesetCheckResolver interface {
+	Name() string
+	// State returns a value of type *btypes.ChangesetCheckState.
+	State() *string
+	URL() *string
+	StartedAt() *gqlutil.DateTime
+	CompletedAt() *gqlutil.DateTime
+	Duration() *int32
+	Rerunnable() bool
+}
+
 type ChangesetLabelResolver interface {
 	Text() string
 	Color() string
@@ -795,6 +874,7 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	Checks() []ChangesetCheckResolver
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -843,6 +923,39 @@ type BatchSpecWorkspaceResolutionResolver interface {
 
 	RecentlyCompleted(ctx context.Context, args *ListRecentlyCompletedWorkspacesArgs) BatchSpecWorkspaceConnectionResolver
 	RecentlyErrored(ctx context.Context, args *ListRecentlyErroredWorkspacesArgs) BatchSpecWorkspaceConnectionResolver
+
+	DryRun() bool
+	DryRunResult(ctx context.Context) (BatchSpecDryRunResultResolver, error)
+}
+
+type BatchSpecDryRunResultResolver interface {
+	Outcome() string
+	Workspaces() []BatchSpecDryRunWorkspaceResolver
+	Skipped() []BatchSpecDryRunSkippedWorkspaceResolver
+	MaxChangesets() int32
+}
+
+type BatchSpecDryRunWorkspaceResolver interface {
+	Repository() *RepositoryResolver
+	Branch() string
+	Path() string
+	FileMatches() []string
+	Steps() []int32
+	Files() []BatchSpecDryRunStepFileResolver
+	MaxChangesets() int32
+}
+
+type BatchSpecDryRunStepFileResolver interface {
+	Step() int32
+	Path() string
+	Content() *string
+}
+
+type BatchSpecDryRunSkippedWorkspaceResolver interface {
+	Repository() *RepositoryResolver
+	Branch() *string
+	Path() string
+	Reason() string
 }
 
 type BatchSpecWorkspaceConnectionResolver interface {
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -52,7 +53,7 @@ func executorSecretByID(ctx context.Context, db database.DB, gqlID graphql.ID) (
 	}
 
 	// 🚨 SECURITY: Only allow access to secrets if the user has access to the namespace.
-	if err := checkNamespaceAccess(ctx, db, secret.NamespaceUserID, secret.NamespaceOrgID); err != nil {
+	if err := checkNamespaceAccess(ctx, db, secret.NamespaceUserID, secret.NamespaceOrgID, rbac.ExecutorSecretsReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -43,7 +44,7 @@ func executorSecretAccessLogByID(ctx context.Context, db database.DB, gqlID grap
 	}
 
 	// 🚨 SECURITY: Only allow access if the user has access to the namespace.
-	if err := checkNamespaceAccess(ctx, db, secret.NamespaceUserID, secret.NamespaceOrgID); err != nil {
+	if err := checkNamespaceAccess(ctx, db, secret.NamespaceUserID, secret.NamespaceOrgID, rbac.ExecutorSecretsReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -50,7 +51,7 @@ func (r *schemaResolver) CreateExecutorSecret(ctx context.Context, args CreateEx
 	}
 
 	// 🚨 SECURITY: Check namespace access.
-	if err := checkNamespaceAccess(ctx, r.db, userID, orgID); err != nil {
+	if err := checkNamespaceAccess(ctx, r.db, userID, orgID, rbac.ExecutorSecretsWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -126,7 +127,7 @@ func (r *schemaResolver) UpdateExecutorSecret(ctx context.Context, args UpdateEx
 		}
 
 		// 🚨 SECURITY: Check namespace access.
-		if err := checkNamespaceAccess(ctx, database.NewDBWith(r.logger, tx), secret.NamespaceUserID, secret.NamespaceOrgID); err != nil {
+		if err := checkNamespaceAccess(ctx, database.NewDBWith(r.logger, tx), secret.NamespaceUserID, secret.NamespaceOrgID, rbac.ExecutorSecretsWritePermission); err != nil {
 			return err
 		}
 
@@ -177,7 +178,7 @@ func (r *schemaResolver) DeleteExecutorSecret(ctx context.Context, args DeleteEx
 		}
 
 		// 🚨 SECURITY: Check namespace access.
-		if err := checkNamespaceAccess(ctx, database.NewDBWith(r.logger, tx), secret.NamespaceUserID, secret.NamespaceOrgID); err != nil {
+		if err := checkNamespaceAccess(ctx, database.NewDBWith(r.logger, tx), secret.NamespaceUserID, secret.NamespaceOrgID, rbac.ExecutorSecretsWritePermission); err != nil {
 			return err
 		}
 
@@ -214,10 +215,11 @@ func (o ExecutorSecretsListArgs) LimitOffset() (*database.LimitOffset, error) {
 
 // ExecutorSecrets returns the global executor secrets.
 func (r *schemaResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecretsListArgs) (*executorSecretConnectionResolver, error) {
-	// 🚨 SECURITY: Only allow access to list global secrets if the user is admin.
+	// 🚨 SECURITY: Only allow access to list global secrets if the user is admin or has
+	// been granted access to executor secrets.
 	// This is not terribly bad, since the secrets are also part of the user's namespace
-	// secrets, but this endpoint is useless to non-admins.
-	if err := checkNamespaceAccess(ctx, r.db, 0, 0); err != nil {
+	// secrets, but this endpoint is useless to everyone else.
+	if err := checkNamespaceAccess(ctx, r.db, 0, 0, rbac.ExecutorSecretsReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -239,7 +241,7 @@ func (r *schemaResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecre
 
 func (r *UserResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecretsListArgs) (*executorSecretConnectionResolver, error) {
 	// 🚨 SECURITY: Only allow access to list secrets if the user has access to the namespace.
-	if err := checkNamespaceAccess(ctx, r.db, r.user.ID, 0); err != nil {
+	if err := checkNamespaceAccess(ctx, r.db, r.user.ID, 0, rbac.ExecutorSecretsReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -260,7 +262,7 @@ func (r *UserResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecrets
 
 func (o *OrgResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecretsListArgs) (*executorSecretConnectionResolver, error) {
 	// 🚨 SECURITY: Only allow access to list secrets if the user has access to the namespace.
-	if err := checkNamespaceAccess(ctx, o.db, 0, o.org.ID); err != nil {
+	if err := checkNamespaceAccess(ctx, o.db, 0, o.org.ID, rbac.ExecutorSecretsReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -280,7 +282,10 @@ func (o *OrgResolver) ExecutorSecrets(ctx context.Context, args ExecutorSecretsL
 	}, nil
 }
 
-func checkNamespaceAccess(ctx context.Context, db database.DB, namespaceUserID, namespaceOrgID int32) error {
+// checkNamespaceAccess returns an error if the current user can't access the secrets in
+// the given namespace. Global secrets require the user to be a site admin or to have
+// globalPermission.
+func checkNamespaceAccess(ctx context.Context, db database.DB, namespaceUserID, namespaceOrgID int32, globalPermission string) error {
 	if namespaceUserID != 0 {
 		return auth.CheckSiteAdminOrSameUser(ctx, db, namespaceUserID)
 	}
@@ -288,7 +293,7 @@ func checkNamespaceAccess(ctx context.Context, db database.DB, namespaceUserID,
 		return auth.CheckOrgAccessOrSiteAdmin(ctx, db, namespaceOrgID)
 	}
 
-	return auth.CheckCurrentUserIsSiteAdmin(ctx, db)
+	return rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, db, globalPermission)
 }
 
 // validateExecutorSecret validates that the secret value is non-empty and if the
@@ -12,13 +12,13 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
-	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -67,8 +67,8 @@ var availabilityCheck = map[string]bool{
 }
 
 func externalServiceByID(ctx context.Context, db database.DB, gqlID graphql.ID) (*externalServiceResolver, error) {
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, db, rbac.RepoManagementReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -384,8 +384,8 @@ func unmarshalExternalServiceSyncJobID(id graphql.ID) (jobID int64, err error) {
 }
 
 func externalServiceSyncJobByID(ctx context.Context, db database.DB, gqlID graphql.ID) (Node, error) {
-	// Site-admin only for now.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, db, rbac.RepoManagementReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -51,7 +52,8 @@ type addExternalServiceInput struct {
 
 func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExternalServiceArgs) (*externalServiceResolver, error) {
 	start := time.Now()
-	// 🚨 SECURITY: Only site admins may add external services. User's external services are not supported anymore.
+	// 🚨 SECURITY: Only site admins and users that can manage repositories may add external services. User's
+	// external services are not supported anymore.
 	var err error
 	defer reportExternalServiceDuration(start, Add, &err)
 
@@ -59,7 +61,7 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExtern
 		return nil, err
 	}
 
-	if auth.CheckCurrentUserIsSiteAdmin(ctx, r.db) != nil {
+	if rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission) != nil {
 		err = auth.ErrMustBeSiteAdmin
 		return nil, err
 	}
@@ -101,8 +103,8 @@ func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *update
 		return nil, err
 	}
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -163,8 +165,8 @@ type excludeRepoFromExternalServiceArgs struct {
 
 // ExcludeRepoFromExternalServices excludes the given repo from the given external service configs.
 func (r *schemaResolver) ExcludeRepoFromExternalServices(ctx context.Context, args *excludeRepoFromExternalServiceArgs) (*EmptyResponse, error) {
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -202,8 +204,8 @@ func (r *schemaResolver) DeleteExternalService(ctx context.Context, args *delete
 		return nil, err
 	}
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -241,8 +243,8 @@ type ExternalServicesArgs struct {
 }
 
 func (r *schemaResolver) ExternalServices(ctx context.Context, args *ExternalServicesArgs) (*externalServiceConnectionResolver, error) {
-	// 🚨 SECURITY: Check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: Check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -402,8 +404,8 @@ func (r *schemaResolver) SyncExternalService(ctx context.Context, args *syncExte
 	var err error
 	defer reportExternalServiceDuration(start, Update, &err)
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -435,8 +437,8 @@ func (r *schemaResolver) CancelExternalServiceSync(ctx context.Context, args *ca
 	var err error
 	defer reportExternalServiceDuration(start, Update, &err)
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or can manage repositories
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission); err != nil {
 		return nil, err
 	}
 
@@ -460,7 +462,7 @@ type externalServiceNamespacesArgs struct {
 }
 
 func (r *schemaResolver) ExternalServiceNamespaces(ctx context.Context, args *externalServiceNamespacesArgs) (*externalServiceNamespaceConnectionResolver, error) {
-	if auth.CheckCurrentUserIsSiteAdmin(ctx, r.db) != nil {
+	if rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission) != nil {
 		return nil, auth.ErrMustBeSiteAdmin
 	}
 
@@ -491,7 +493,7 @@ type externalServiceRepositoriesArgs struct {
 }
 
 func (r *schemaResolver) ExternalServiceRepositories(ctx context.Context, args *externalServiceRepositoriesArgs) (*externalServiceRepositoryConnectionResolver, error) {
-	if auth.CheckCurrentUserIsSiteAdmin(ctx, r.db) != nil {
+	if rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.RepoManagementWritePermission) != nil {
 		return nil, auth.ErrMustBeSiteAdmin
 	}
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/google/go-cmp/cmp"
 	"github.com/graph-gophers/graphql-go"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
@@ -31,6 +32,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	rtypes "github.com/sourcegraph/sourcegraph/internal/rbac/types"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -45,6 +47,7 @@ func TestAddExternalService(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		result, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).AddExternalService(ctx, &addExternalServiceArgs{})
@@ -56,6 +59,43 @@ func TestAddExternalService(t *testing.T) {
 		}
 	})
 
+	t.Run("authenticated as non-admin with repository management permission", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		permissions := database.NewMockPermissionStore()
+		permissions.GetPermissionForUserFunc.SetDefaultHook(func(_ context.Context, opts database.GetPermissionForUserOpts) (*types.Permission, error) {
+			if opts.Namespace != rtypes.RepoManagementNamespace || opts.Action != rtypes.RepoManagementWriteAction {
+				t.Fatalf("unexpected permission checked: %s#%s", opts.Namespace, opts.Action)
+			}
+			return &types.Permission{ID: 1, Namespace: opts.Namespace, Action: opts.Action}, nil
+		})
+
+		externalServices := database.NewMockExternalServiceStore()
+		externalServices.CreateFunc.SetDefaultReturn(nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(permissions)
+		db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).AddExternalService(ctx, &addExternalServiceArgs{
+			Input: addExternalServiceInput{
+				Kind:        extsvc.KindGitHub,
+				DisplayName: "GITHUB #1",
+				Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result == nil {
+			t.Fatal("result: want external service but got nil")
+		}
+		mockrequire.Called(t, externalServices.CreateFunc)
+	})
+
 	users := database.NewMockUserStore()
 	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
 
@@ -103,6 +143,7 @@ func TestUpdateExternalService(t *testing.T) {
 		t.Run("cannot update external services", func(t *testing.T) {
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			result, err := newSchemaResolver(db, nil, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
@@ -525,6 +566,7 @@ func TestDeleteExternalService(t *testing.T) {
 		t.Run("cannot delete external services", func(t *testing.T) {
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			result, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).DeleteExternalService(ctx, &deleteExternalServiceArgs{
@@ -587,6 +629,7 @@ func TestExternalServicesResolver(t *testing.T) {
 
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 			result, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).ExternalServices(context.Background(), &ExternalServicesArgs{})
 			if want := auth.ErrMustBeSiteAdmin; err != want {
@@ -1057,6 +1100,7 @@ func TestCancelExternalServiceSync(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		syncJobIDGraphQL := marshalExternalServiceSyncJobID(syncJobID)
@@ -1168,6 +1212,7 @@ func TestExternalServiceNamespaces(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 		mockExternalServiceNamespaces(t, []*types.ExternalServiceNamespace{&namespace}, nil)
 
@@ -1466,6 +1511,7 @@ func TestExternalServiceRepositories(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 		mockExternalServiceRepos(t, []*types.ExternalServiceRepository{repo1.ToExternalServiceRepository(), repo2.ToExternalServiceRepository()}, nil)
 
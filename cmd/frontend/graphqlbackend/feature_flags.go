@@ -33,6 +33,13 @@ func (f *FeatureFlagResolver) ToFeatureFlagRollout() (*FeatureFlagRolloutResolve
 	return nil, false
 }
 
+func (f *FeatureFlagResolver) ToFeatureFlagMultivariate() (*FeatureFlagMultivariateResolver, bool) {
+	if f.inner.Variants != nil {
+		return &FeatureFlagMultivariateResolver{f.inner}, true
+	}
+	return nil, false
+}
+
 type FeatureFlagBooleanResolver struct {
 	db database.DB
 	// Invariant: inner.Bool is non-nil
@@ -47,6 +54,9 @@ func (f *FeatureFlagBooleanResolver) CreatedAt() gqlutil.DateTime {
 func (f *FeatureFlagBooleanResolver) UpdatedAt() gqlutil.DateTime {
 	return gqlutil.DateTime{Time: f.inner.UpdatedAt}
 }
+func (f *FeatureFlagBooleanResolver) Rules() []*FeatureFlagRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
 func (f *FeatureFlagBooleanResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
 	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
 	if err != nil {
@@ -69,6 +79,9 @@ func (f *FeatureFlagRolloutResolver) CreatedAt() gqlutil.DateTime {
 func (f *FeatureFlagRolloutResolver) UpdatedAt() gqlutil.DateTime {
 	return gqlutil.DateTime{Time: f.inner.UpdatedAt}
 }
+func (f *FeatureFlagRolloutResolver) Rules() []*FeatureFlagRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
 func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
 	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
 	if err != nil {
@@ -77,6 +90,140 @@ func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureF
 	return overridesToResolvers(f.db, overrides), nil
 }
 
+type FeatureFlagMultivariateResolver struct {
+	// Invariant: inner.Variants is non-nil
+	inner *featureflag.FeatureFlag
+}
+
+func (f *FeatureFlagMultivariateResolver) Name() string { return f.inner.Name }
+func (f *FeatureFlagMultivariateResolver) Variants() []*FeatureFlagVariantResolver {
+	res := make([]*FeatureFlagVariantResolver, 0, len(f.inner.Variants.Variants))
+	for _, v := range f.inner.Variants.Variants {
+		res = append(res, &FeatureFlagVariantResolver{v})
+	}
+	return res
+}
+func (f *FeatureFlagMultivariateResolver) Rules() []*FeatureFlagRuleResolver {
+	return rulesToResolvers(f.inner.Rules)
+}
+func (f *FeatureFlagMultivariateResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: f.inner.CreatedAt}
+}
+func (f *FeatureFlagMultivariateResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: f.inner.UpdatedAt}
+}
+
+type FeatureFlagVariantResolver struct {
+	inner featureflag.Variant
+}
+
+func (v *FeatureFlagVariantResolver) Value() string            { return v.inner.Value }
+func (v *FeatureFlagVariantResolver) WeightBasisPoints() int32 { return v.inner.Weight }
+
+type FeatureFlagRuleResolver struct {
+	inner featureflag.Rule
+}
+
+func (r *FeatureFlagRuleResolver) Conditions() []*FeatureFlagConditionResolver {
+	res := make([]*FeatureFlagConditionResolver, 0, len(r.inner.Conditions))
+	for _, c := range r.inner.Conditions {
+		res = append(res, &FeatureFlagConditionResolver{c})
+	}
+	return res
+}
+
+// Value is only set for rules of boolean and rollout flags, Variant for rules of
+// multivariate flags.
+func (r *FeatureFlagRuleResolver) Value() *bool {
+	if r.inner.Variant != "" {
+		return nil
+	}
+	return &r.inner.Value
+}
+func (r *FeatureFlagRuleResolver) Variant() *string {
+	if r.inner.Variant == "" {
+		return nil
+	}
+	return &r.inner.Variant
+}
+
+func rulesToResolvers(rules []featureflag.Rule) []*FeatureFlagRuleResolver {
+	res := make([]*FeatureFlagRuleResolver, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, &FeatureFlagRuleResolver{rule})
+	}
+	return res
+}
+
+type FeatureFlagConditionResolver struct {
+	inner featureflag.Condition
+}
+
+func (c *FeatureFlagConditionResolver) Type() string { return string(c.inner.Type) }
+func (c *FeatureFlagConditionResolver) Values() []string {
+	if c.inner.Values == nil {
+		return []string{}
+	}
+	return c.inner.Values
+}
+
+type featureFlagVariantInput struct {
+	Value             string
+	WeightBasisPoints int32
+}
+
+type featureFlagRuleInput struct {
+	Conditions []featureFlagConditionInput
+	Value      *bool
+	Variant    *string
+}
+
+type featureFlagConditionInput struct {
+	Type   string
+	Values *[]string
+}
+
+// featureFlagFromArgs builds the feature flag described by the arguments of the
+// create and update mutations.
+func featureFlagFromArgs(name string, value *bool, rolloutBasisPoints *int32, variants *[]featureFlagVariantInput, rules *[]featureFlagRuleInput) (*featureflag.FeatureFlag, error) {
+	ff := &featureflag.FeatureFlag{Name: name}
+	switch {
+	case value != nil:
+		ff.Bool = &featureflag.FeatureFlagBool{Value: *value}
+	case rolloutBasisPoints != nil:
+		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *rolloutBasisPoints}
+	case variants != nil:
+		ff.Variants = &featureflag.FeatureFlagVariants{}
+		for _, v := range *variants {
+			ff.Variants.Variants = append(ff.Variants.Variants, featureflag.Variant{Value: v.Value, Weight: v.WeightBasisPoints})
+		}
+	default:
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'variants' must be set")
+	}
+
+	if rules != nil {
+		for _, r := range *rules {
+			rule := featureflag.Rule{}
+			if r.Value != nil {
+				rule.Value = *r.Value
+			}
+			if r.Variant != nil {
+				rule.Variant = *r.Variant
+			}
+			for _, c := range r.Conditions {
+				condition := featureflag.Condition{Type: featureflag.ConditionType(c.Type)}
+				if c.Values != nil {
+					condition.Values = *c.Values
+				}
+				rule.Conditions = append(rule.Conditions, condition)
+			}
+			ff.Rules = append(ff.Rules, rule)
+		}
+	}
+
+	return ff, ff.Validate()
+}
+
 func overridesToResolvers(db database.DB, input []*featureflag.Override) []*FeatureFlagOverrideResolver {
 	res := make([]*FeatureFlagOverrideResolver, 0, len(input))
 	for _, flag := range input {
@@ -141,15 +288,29 @@ func (e *EvaluatedFeatureFlagResolver) Value() bool {
 }
 
 func (r *schemaResolver) EvaluateFeatureFlag(ctx context.Context, args *struct {
-	FlagName string
+	FlagName   string
+	Repository *string
 }) *bool {
 	flagSet := featureflag.FromContext(ctx)
+	if args.Repository != nil {
+		flagSet = featureflag.ForRepo(ctx, *args.Repository)
+	}
 	if v, ok := flagSet.GetBool(args.FlagName); ok {
 		return &v
 	}
 	return nil
 }
 
+func (r *schemaResolver) EvaluateFeatureFlagVariant(ctx context.Context, args *struct {
+	FlagName string
+}) *string {
+	flagSet := featureflag.FromContext(ctx)
+	if v, ok := flagSet.GetVariant(args.FlagName); ok {
+		return &v
+	}
+	return nil
+}
+
 func (r *schemaResolver) EvaluatedFeatureFlags(ctx context.Context) []*EvaluatedFeatureFlagResolver {
 	return evaluatedFlagsToResolvers(featureflag.GetEvaluatedFlagSet(ctx))
 }
@@ -235,23 +396,19 @@ func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Variants           *[]featureFlagVariantInput
+	Rules              *[]featureFlagRuleInput
 }) (*FeatureFlagResolver, error) {
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
 	}
 
-	ff := r.db.FeatureFlags()
-
-	var res *featureflag.FeatureFlag
-	var err error
-	if args.Value != nil {
-		res, err = ff.CreateBool(ctx, args.Name, *args.Value)
-	} else if args.RolloutBasisPoints != nil {
-		res, err = ff.CreateRollout(ctx, args.Name, *args.RolloutBasisPoints)
-	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+	ff, err := featureFlagFromArgs(args.Name, args.Value, args.RolloutBasisPoints, args.Variants, args.Rules)
+	if err != nil {
+		return nil, err
 	}
 
+	res, err := r.db.FeatureFlags().CreateFeatureFlag(ctx, ff)
 	return &FeatureFlagResolver{r.db, res}, err
 }
 
@@ -268,17 +425,29 @@ func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Variants           *[]featureFlagVariantInput
+	Rules              *[]featureFlagRuleInput
 }) (*FeatureFlagResolver, error) {
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
 	}
-	ff := &featureflag.FeatureFlag{Name: args.Name}
-	if args.Value != nil {
-		ff.Bool = &featureflag.FeatureFlagBool{Value: *args.Value}
-	} else if args.RolloutBasisPoints != nil {
-		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *args.RolloutBasisPoints}
-	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+
+	ff, err := featureFlagFromArgs(args.Name, args.Value, args.RolloutBasisPoints, args.Variants, args.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Rules == nil {
+		// Updates without rules, like the ones of the site admin page, keep the
+		// rules of the flag.
+		existing, err := r.db.FeatureFlags().GetFeatureFlag(ctx, args.Name)
+		if err != nil {
+			return nil, err
+		}
+		ff.Rules = existing.Rules
+		if err := ff.Validate(); err != nil {
+			return nil, err
+		}
 	}
 
 	res, err := r.db.FeatureFlags().UpdateFeatureFlag(ctx, ff)
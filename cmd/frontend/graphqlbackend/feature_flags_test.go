@@ -242,6 +242,9 @@ func TestEvaluateFeatureFlag(t *testing.T) {
 		flags.GetUserFlagsFunc.SetDefaultHook(func(ctx context.Context, uid int32) (map[string]bool, error) {
 			return map[string]bool{"enabled-flag": true, "disabled-flag": false}, nil
 		})
+		flags.GetUserFlagsForRepoFunc.SetDefaultHook(func(ctx context.Context, uid int32, repoName string) (map[string]bool, error) {
+			return map[string]bool{"enabled-flag": true, "disabled-flag": repoName == "github.com/sourcegraph/sourcegraph"}, nil
+		})
 
 		db := database.NewMockDB()
 		db.OrgsFunc.SetDefaultReturn(orgs)
@@ -292,6 +295,197 @@ func TestEvaluateFeatureFlag(t *testing.T) {
 					}
 				`,
 			},
+			{
+				Context: ctx,
+				Schema:  mustParseGraphQLSchema(t, db),
+				Query: `
+				{
+					evaluateFeatureFlag(flagName: "disabled-flag", repository: "github.com/sourcegraph/sourcegraph")
+				}
+				`,
+				ExpectedResult: `
+					{
+						"evaluateFeatureFlag": true
+					}
+				`,
+			},
+		})
+	})
+}
+
+func TestEvaluateFeatureFlagVariant(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	flags := database.NewMockFeatureFlagStore()
+	flags.GetUserVariantsFunc.SetDefaultReturn(map[string]string{"ranking": "bm25"}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+	ctx = featureflag.WithFlags(ctx, flags)
+
+	RunTests(t, []*Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			{
+				evaluateFeatureFlagVariant(flagName: "ranking")
+			}
+			`,
+			ExpectedResult: `
+				{
+					"evaluateFeatureFlagVariant": "bm25"
+				}
+			`,
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			{
+				evaluateFeatureFlagVariant(flagName: "non-existing-flag")
+			}
+			`,
+			ExpectedResult: `
+				{
+					"evaluateFeatureFlagVariant": null
+				}
+			`,
+		},
+	})
+}
+
+func TestCreateFeatureFlagWithRules(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	flags := database.NewMockFeatureFlagStore()
+	flags.CreateFeatureFlagFunc.SetDefaultHook(func(_ context.Context, flag *featureflag.FeatureFlag) (*featureflag.FeatureFlag, error) {
+		return flag, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	RunTests(t, []*Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			mutation {
+				createFeatureFlag(
+					name: "ranking",
+					variants: [{value: "bm25", weightBasisPoints: 5000}, {value: "tf-idf", weightBasisPoints: 5000}],
+					rules: [{conditions: [{type: ORG_MEMBER, values: ["search"]}, {type: SITE_ADMIN}], variant: "bm25"}]
+				) {
+					... on FeatureFlagMultivariate {
+						name
+						variants { value weightBasisPoints }
+						rules {
+							conditions { type values }
+							value
+							variant
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+				{
+					"createFeatureFlag": {
+						"name": "ranking",
+						"variants": [
+							{"value": "bm25", "weightBasisPoints": 5000},
+							{"value": "tf-idf", "weightBasisPoints": 5000}
+						],
+						"rules": [{
+							"conditions": [
+								{"type": "ORG_MEMBER", "values": ["search"]},
+								{"type": "SITE_ADMIN", "values": []}
+							],
+							"value": null,
+							"variant": "bm25"
+						}]
+					}
+				}
+			`,
+		},
+	})
+
+	_, err := featureFlagFromArgs("ranking", nil, nil, &[]featureFlagVariantInput{{Value: "bm25", WeightBasisPoints: 5000}}, nil)
+	assert.ErrorContains(t, err, "variant weights must add up to 10000")
+}
+
+func TestUpdateFeatureFlagRules(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	rules := []featureflag.Rule{{Conditions: []featureflag.Condition{{Type: featureflag.ConditionSiteAdmin}}, Value: true}}
+	flags := database.NewMockFeatureFlagStore()
+	flags.GetFeatureFlagFunc.SetDefaultReturn(&featureflag.FeatureFlag{Name: "test-flag", Bool: &featureflag.FeatureFlagBool{Value: false}, Rules: rules}, nil)
+	flags.UpdateFeatureFlagFunc.SetDefaultHook(func(_ context.Context, flag *featureflag.FeatureFlag) (*featureflag.FeatureFlag, error) {
+		return flag, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	schema := mustParseGraphQLSchema(t, db)
+
+	t.Run("update without rules keeps rules", func(t *testing.T) {
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  schema,
+			Query: `
+			mutation {
+				updateFeatureFlag(name: "test-flag", value: true) {
+					... on FeatureFlagBoolean {
+						value
+						rules { conditions { type } value }
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+				{
+					"updateFeatureFlag": {
+						"value": true,
+						"rules": [{"conditions": [{"type": "SITE_ADMIN"}], "value": true}]
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("update with rules replaces rules", func(t *testing.T) {
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  schema,
+			Query: `
+			mutation {
+				updateFeatureFlag(name: "test-flag", value: true, rules: []) {
+					... on FeatureFlagBoolean {
+						rules { value }
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+				{
+					"updateFeatureFlag": {
+						"rules": []
+					}
+				}
+			`,
 		})
 	})
 }
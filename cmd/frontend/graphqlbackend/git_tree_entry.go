@@ -369,6 +369,25 @@ func (r *GitTreeEntryResolver) SymbolInfo(ctx context.Context, args *symbolInfoA
 	return &symbolInfoResolver{symbolInfo: result}, nil
 }
 
+func (r *GitTreeEntryResolver) SearchBasedReferences(ctx context.Context, args *searchBasedReferencesArgs) (resolverstubs.SearchBasedReferenceConnectionResolver, error) {
+	repo, err := r.commit.repoResolver.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.SearchBasedReferences(ctx, &resolverstubs.SearchBasedReferencesArgs{
+		Repo:      repo,
+		Commit:    api.CommitID(r.Commit().OID()),
+		Path:      r.Path(),
+		Line:      args.Line,
+		Character: args.Character,
+		PagedConnectionArgs: resolverstubs.PagedConnectionArgs{
+			ConnectionArgs: resolverstubs.ConnectionArgs{First: args.First},
+			After:          args.After,
+		},
+	})
+}
+
 func (r *GitTreeEntryResolver) LFS(ctx context.Context) (*lfsResolver, error) {
 	// We only care about the full content length here, so we just need content to be set.
 	content, err := r.Content(ctx, &GitTreeContentPageArgs{})
@@ -407,6 +426,13 @@ type symbolInfoArgs struct {
 	Character int32
 }
 
+type searchBasedReferencesArgs struct {
+	Line      int32
+	Character int32
+	First     *int32
+	After     *string
+}
+
 type symbolInfoResolver struct{ symbolInfo *types.SymbolInfo }
 
 func (r *symbolInfoResolver) Definition(ctx context.Context) (*symbolLocationResolver, error) {
@@ -253,6 +253,7 @@ type InsightTimeScope interface {
 type InsightIntervalTimeScope interface {
 	Unit(ctx context.Context) (string, error)
 	Value(ctx context.Context) (int32, error)
+	StartDate(ctx context.Context) (*gqlutil.DateTime, error)
 }
 
 type InsightRepositoryScopeResolver interface {
@@ -443,6 +444,7 @@ type RepositoryScopeInput struct {
 
 type TimeScopeInput struct {
 	StepInterval *TimeIntervalStepInput
+	StartDate    *gqlutil.DateTime
 }
 
 type TimeIntervalStepInput struct {
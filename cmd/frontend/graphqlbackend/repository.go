@@ -434,6 +434,10 @@ func (r *RepositoryResolver) CodeIntelSummary(ctx context.Context) (resolverstub
 	return EnterpriseResolvers.codeIntelResolver.RepositorySummary(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) CodeIntelSymbolChanges(ctx context.Context, args *resolverstubs.CodeIntelSymbolChangesArgs) (resolverstubs.CodeIntelSymbolChangeConnectionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.CodeIntelSymbolChanges(ctx, r.ID(), args)
+}
+
 func (r *RepositoryResolver) PreviewGitObjectFilter(ctx context.Context, args *resolverstubs.PreviewGitObjectFilterArgs) (resolverstubs.GitObjectFilterPreviewResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.PreviewGitObjectFilter(ctx, r.ID(), args)
 }
@@ -127,7 +127,7 @@ func (r *repositoryMirrorInfoResolver) CloneInProgress(ctx context.Context) (boo
 }
 
 func (r *repositoryMirrorInfoResolver) CloneProgress(ctx context.Context) (*string, error) {
-	if featureflag.FromContext(ctx).GetBoolOr("clone-progress-logging", false) {
+	if featureflag.ForRepo(ctx, string(r.repository.RepoName())).GetBoolOr("clone-progress-logging", false) {
 		info, err := r.computeGitserverRepo(ctx)
 		if err != nil {
 			return nil, err
@@ -3,10 +3,12 @@ package graphqlbackend
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -16,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/updatecheck"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/gitops"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	migratorshared "github.com/sourcegraph/sourcegraph/cmd/migrator/shared"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -27,9 +30,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/migration/cliutil"
 	"github.com/sourcegraph/sourcegraph/internal/database/migration/schemas"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 	"github.com/sourcegraph/sourcegraph/internal/version/upgradestore"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -90,8 +95,8 @@ func (r *siteResolver) SiteID() string { return siteid.Get() }
 
 func (r *siteResolver) Configuration(ctx context.Context) (*siteConfigurationResolver, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and users with the site config read permission may view it.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
 		return nil, err
 	}
 	return &siteConfigurationResolver{db: r.db}, nil
@@ -221,8 +226,8 @@ type siteConfigurationResolver struct {
 
 func (r *siteConfigurationResolver) ID(ctx context.Context) (int32, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and users with the site config read permission may view it.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
 		return 0, err
 	}
 	config, err := r.db.Conf().SiteGetLatest(ctx)
@@ -234,8 +239,8 @@ func (r *siteConfigurationResolver) ID(ctx context.Context) (int32, error) {
 
 func (r *siteConfigurationResolver) EffectiveContents(ctx context.Context) (JSONCString, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and users with the site config read permission may view it.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
 		return "", err
 	}
 	siteConfig, err := conf.RedactSecrets(conf.Raw())
@@ -252,8 +257,8 @@ func (r *siteConfigurationResolver) ValidationMessages(ctx context.Context) ([]s
 
 func (r *siteConfigurationResolver) History(ctx context.Context, args *graphqlutil.ConnectionResolverArgs) (*graphqlutil.ConnectionResolver[*SiteConfigurationChangeResolver], error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view the history.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and users with the site config read permission may view the history.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
 		return nil, err
 	}
 
@@ -266,6 +271,56 @@ func (r *siteConfigurationResolver) History(ctx context.Context, args *graphqlut
 	)
 }
 
+func (r *siteConfigurationResolver) GitOps(ctx context.Context) (*siteConfigurationGitOpsResolver, error) {
+	// 🚨 SECURITY: Only admins and users with the site config read permission may view
+	// where the site configuration is read from.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
+		return nil, err
+	}
+	status := gitops.CurrentStatus()
+	if status == nil {
+		return nil, nil
+	}
+	return &siteConfigurationGitOpsResolver{status: status}, nil
+}
+
+type siteConfigurationGitOpsResolver struct {
+	status *gitops.Status
+}
+
+func (r *siteConfigurationGitOpsResolver) Repository() string {
+	return string(r.status.Repository)
+}
+
+func (r *siteConfigurationGitOpsResolver) Path() string {
+	return r.status.Path
+}
+
+func (r *siteConfigurationGitOpsResolver) Revision() string {
+	return r.status.Revision
+}
+
+func (r *siteConfigurationGitOpsResolver) Commit() *string {
+	if r.status.Commit == "" {
+		return nil
+	}
+	return strptr(string(r.status.Commit))
+}
+
+func (r *siteConfigurationGitOpsResolver) SyncedAt() *gqlutil.DateTime {
+	if r.status.SyncedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.status.SyncedAt}
+}
+
+func (r *siteConfigurationGitOpsResolver) Error() *string {
+	if r.status.Error == "" {
+		return nil
+	}
+	return strptr(r.status.Error)
+}
+
 func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *struct {
 	LastID int32
 	Input  string
@@ -275,8 +330,8 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return false, err
 	}
-	if !canUpdateSiteConfiguration() {
-		return false, errors.New("updating site configuration not allowed when using SITE_CONFIG_FILE")
+	if err := checkCanUpdateSiteConfiguration(); err != nil {
+		return false, err
 	}
 	if strings.TrimSpace(args.Input) == "" {
 		return false, errors.Errorf("blank site configuration is invalid (you can clear the site configuration by entering an empty JSON object: {})")
@@ -296,9 +351,81 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 	return server.NeedServerRestart(), nil
 }
 
+func (r *schemaResolver) RestoreSiteConfiguration(ctx context.Context, args *struct {
+	ID     graphql.ID
+	LastID int32
+}) (_ bool, err error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may restore it.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return false, err
+	}
+	if err := checkCanUpdateSiteConfiguration(); err != nil {
+		return false, err
+	}
+
+	siteConfig, err := siteConfigByChangeID(ctx, r.db, args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	uid := actor.FromContext(ctx).UID
+	defer func() {
+		logSiteConfigRestoreAttempt(ctx, r.db, uid, siteConfig.ID, args.LastID, err)
+	}()
+
+	// The stored contents are unredacted, so the secrets of the restored version are
+	// restored as well.
+	prev := conf.Raw()
+	prev.Site = siteConfig.Contents
+
+	server := globals.ConfigurationServerFrontendOnly
+	if err := server.Write(ctx, prev, args.LastID, uid); err != nil {
+		return false, err
+	}
+	return server.NeedServerRestart(), nil
+}
+
+func logSiteConfigRestoreAttempt(ctx context.Context, db database.DB, uid, restoredID, lastID int32, restoreErr error) {
+	eventArgs := struct {
+		RestoredID int32  `json:"restored_id"`
+		LastID     int32  `json:"last_id"`
+		Error      string `json:"error,omitempty"`
+	}{RestoredID: restoredID, LastID: lastID}
+	if restoreErr != nil {
+		eventArgs.Error = restoreErr.Error()
+	}
+
+	// Marshalling a struct of strings and integers cannot fail.
+	args, _ := json.Marshal(eventArgs)
+
+	db.SecurityEventLogs().LogEvent(ctx, &database.SecurityEvent{
+		Name:      database.SecurityEventNameSiteConfigRestored,
+		UserID:    uint32(uid),
+		Argument:  args,
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	})
+}
+
 var siteConfigAllowEdits, _ = strconv.ParseBool(env.Get("SITE_CONFIG_ALLOW_EDITS", "false", "When SITE_CONFIG_FILE is in use, allow edits in the application to be made which will be overwritten on next process restart"))
 
+// checkCanUpdateSiteConfiguration returns an error describing why the site
+// configuration cannot be updated through the API, if it cannot.
+func checkCanUpdateSiteConfiguration() error {
+	if gitops.Enabled() {
+		return errors.New("updating site configuration not allowed when it is managed in Git (SITE_CONFIG_GIT_REPO)")
+	}
+	if !canUpdateSiteConfiguration() {
+		return errors.New("updating site configuration not allowed when using SITE_CONFIG_FILE")
+	}
+	return nil
+}
+
 func canUpdateSiteConfiguration() bool {
+	if gitops.Enabled() {
+		return false
+	}
 	return os.Getenv("SITE_CONFIG_FILE") == "" || siteConfigAllowEdits || deploy.IsApp()
 }
 
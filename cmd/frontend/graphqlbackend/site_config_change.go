@@ -8,6 +8,7 @@ import (
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/hexops/gotextdiff"
 	"github.com/hexops/gotextdiff/myers"
@@ -50,13 +51,16 @@ func (r SiteConfigurationChangeResolver) Diff() string {
 		prevRedactedContents = r.previousSiteConfig.RedactedContents
 	}
 
+	return siteConfigUnifiedDiff(prevID, prevRedactedContents, r.siteConfig.ID, r.siteConfig.RedactedContents)
+}
+
+// siteConfigUnifiedDiff returns the unified diff between two (redacted) site config versions.
+func siteConfigUnifiedDiff(beforeID int32, before string, afterID int32, after string) string {
 	prettyID := func(id int32) string { return fmt.Sprintf("ID: %d", id) }
 
 	// We're not diffing a file, so set an empty string for the URI argument.
-	edits := myers.ComputeEdits("", prevRedactedContents, r.siteConfig.RedactedContents)
-	diff := fmt.Sprint(gotextdiff.ToUnified(prettyID(prevID), prettyID(r.siteConfig.ID), prevRedactedContents, edits))
-
-	return diff
+	edits := myers.ComputeEdits("", before, after)
+	return fmt.Sprint(gotextdiff.ToUnified(prettyID(beforeID), prettyID(afterID), before, edits))
 }
 
 func (r SiteConfigurationChangeResolver) CreatedAt() gqlutil.DateTime {
@@ -71,3 +75,28 @@ func (r SiteConfigurationChangeResolver) UpdatedAt() gqlutil.DateTime {
 func marshalSiteConfigurationChangeID(id int32) graphql.ID {
 	return relay.MarshalID(siteConfigurationChangeKind, &id)
 }
+
+func unmarshalSiteConfigurationChangeID(id graphql.ID) (siteConfigID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != siteConfigurationChangeKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", siteConfigurationChangeKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &siteConfigID)
+	return
+}
+
+// siteConfigByChangeID returns the site config version with the given
+// SiteConfigurationChange ID.
+func siteConfigByChangeID(ctx context.Context, db database.DB, id graphql.ID) (*database.SiteConfig, error) {
+	siteConfigID, err := unmarshalSiteConfigurationChangeID(id)
+	if err != nil {
+		return nil, err
+	}
+	siteConfig, err := db.Conf().SiteGetByID(ctx, siteConfigID)
+	if err != nil {
+		return nil, err
+	}
+	if siteConfig == nil {
+		return nil, errors.Errorf("site configuration version %d not found", siteConfigID)
+	}
+	return siteConfig, nil
+}
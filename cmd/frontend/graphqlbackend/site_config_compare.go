@@ -0,0 +1,77 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+)
+
+func (r *siteConfigurationResolver) Compare(ctx context.Context, args *struct {
+	From graphql.ID
+	To   graphql.ID
+}) (*siteConfigurationComparisonResolver, error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins and users with the site config read permission may compare versions.
+	if err := rbac.CheckCurrentUserIsSiteAdminOrHasPermission(ctx, r.db, rbac.SiteConfigReadPermission); err != nil {
+		return nil, err
+	}
+
+	from, err := siteConfigByChangeID(ctx, r.db, args.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := siteConfigByChangeID(ctx, r.db, args.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: This should always use "RedactedContents" and never "Contents"
+	// because we do not want to leak secrets in the comparison.
+	changes, err := conf.DiffSiteConfig(from.RedactedContents, to.RedactedContents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &siteConfigurationComparisonResolver{from: from, to: to, changes: changes}, nil
+}
+
+type siteConfigurationComparisonResolver struct {
+	from, to *database.SiteConfig
+	changes  []conf.SiteConfigFieldChange
+}
+
+func (r *siteConfigurationComparisonResolver) Changes() []*siteConfigurationFieldChangeResolver {
+	resolvers := make([]*siteConfigurationFieldChangeResolver, 0, len(r.changes))
+	for _, change := range r.changes {
+		resolvers = append(resolvers, &siteConfigurationFieldChangeResolver{change: change})
+	}
+	return resolvers
+}
+
+func (r *siteConfigurationComparisonResolver) Diff() string {
+	return siteConfigUnifiedDiff(r.from.ID, r.from.RedactedContents, r.to.ID, r.to.RedactedContents)
+}
+
+type siteConfigurationFieldChangeResolver struct {
+	change conf.SiteConfigFieldChange
+}
+
+func (r *siteConfigurationFieldChangeResolver) Key() string { return r.change.Key }
+
+func (r *siteConfigurationFieldChangeResolver) Before() *JSONValue {
+	if r.change.Before == nil {
+		return nil
+	}
+	return &JSONValue{r.change.Before}
+}
+
+func (r *siteConfigurationFieldChangeResolver) After() *JSONValue {
+	if r.change.After == nil {
+		return nil
+	}
+	return &JSONValue{r.change.After}
+}
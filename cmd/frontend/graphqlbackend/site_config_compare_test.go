@@ -0,0 +1,116 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSiteConfigurationCompare(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	confStore := database.NewMockConfStore()
+	confStore.SiteGetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*database.SiteConfig, error) {
+		switch id {
+		case 1:
+			return &database.SiteConfig{
+				ID:               1,
+				Contents:         `{"externalURL": "https://a.example.com", "email.smtp": {"password": "hunter2"}}`,
+				RedactedContents: `{"externalURL": "https://a.example.com", "email.smtp": {"password": "REDACTED-1"}}`,
+			}, nil
+		case 2:
+			return &database.SiteConfig{
+				ID:               2,
+				Contents:         `{"externalURL": "https://b.example.com", "email.smtp": {"password": "hunter3"}}`,
+				RedactedContents: `{"externalURL": "https://b.example.com", "email.smtp": {"password": "REDACTED-2"}}`,
+			}, nil
+		}
+		return nil, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ConfFunc.SetDefaultReturn(confStore)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	configuration, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).Site().Configuration(ctx)
+	require.NoError(t, err)
+
+	t.Run("changed fields", func(t *testing.T) {
+		comparison, err := configuration.Compare(ctx, &struct {
+			From graphql.ID
+			To   graphql.ID
+		}{
+			From: marshalSiteConfigurationChangeID(1),
+			To:   marshalSiteConfigurationChangeID(2),
+		})
+		require.NoError(t, err)
+
+		var keys []string
+		for _, change := range comparison.Changes() {
+			keys = append(keys, change.Key())
+			for _, v := range []*JSONValue{change.Before(), change.After()} {
+				require.NotNil(t, v)
+				assert.NotContains(t, string(v.Value.(json.RawMessage)), "hunter")
+			}
+		}
+		assert.Equal(t, []string{"email.smtp", "externalURL"}, keys)
+		assert.NotContains(t, comparison.Diff(), "hunter")
+		assert.Contains(t, comparison.Diff(), "https://b.example.com")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := configuration.Compare(ctx, &struct {
+			From graphql.ID
+			To   graphql.ID
+		}{
+			From: marshalSiteConfigurationChangeID(1),
+			To:   marshalSiteConfigurationChangeID(42),
+		})
+		assert.ErrorContains(t, err, "site configuration version 42 not found")
+	})
+}
+
+func TestRestoreSiteConfiguration(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 2}, nil)
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 2})
+		_, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).RestoreSiteConfiguration(ctx, &struct {
+			ID     graphql.ID
+			LastID int32
+		}{ID: marshalSiteConfigurationChangeID(1), LastID: 2})
+		assert.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+		confStore := database.NewMockConfStore()
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.ConfFunc.SetDefaultReturn(confStore)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).RestoreSiteConfiguration(ctx, &struct {
+			ID     graphql.ID
+			LastID int32
+		}{ID: marshalSiteConfigurationChangeID(42), LastID: 2})
+		assert.ErrorContains(t, err, "site configuration version 42 not found")
+	})
+}
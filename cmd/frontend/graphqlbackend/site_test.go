@@ -24,6 +24,7 @@ func TestSiteConfiguration(t *testing.T) {
 		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{}, nil)
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(database.NewMockPermissionStore())
 
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		_, err := newSchemaResolver(db, gitserver.NewClient(), jobutil.NewUnimplementedEnterpriseJobs()).Site().Configuration(ctx)
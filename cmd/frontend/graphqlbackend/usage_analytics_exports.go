@@ -0,0 +1,136 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const usageExportDateFormat = "2006-01-02"
+
+type ExportUsageAnalyticsArgs struct {
+	From   string
+	To     string
+	Format string
+}
+
+func (r *schemaResolver) ExportUsageAnalytics(ctx context.Context, args *ExportUsageAnalyticsArgs) (*usageAnalyticsExportResolver, error) {
+	// 🚨 SECURITY: Only site admins may export usage, it includes the activity of all users.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	from, err := time.Parse(usageExportDateFormat, args.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid from date")
+	}
+	to, err := time.Parse(usageExportDateFormat, args.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to date")
+	}
+
+	export, err := adminanalytics.NewUsageExportStore(r.db).Create(
+		ctx,
+		actor.FromContext(ctx).UID,
+		adminanalytics.UsageExportFormat(strings.ToLower(args.Format)),
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &usageAnalyticsExportResolver{db: r.db, export: export}, nil
+}
+
+func (r *siteAnalyticsResolver) UsageExports(ctx context.Context, args *struct{ First int32 }) ([]*usageAnalyticsExportResolver, error) {
+	exports, err := adminanalytics.NewUsageExportStore(r.db).List(ctx, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*usageAnalyticsExportResolver, 0, len(exports))
+	for _, export := range exports {
+		resolvers = append(resolvers, &usageAnalyticsExportResolver{db: r.db, export: export})
+	}
+	return resolvers, nil
+}
+
+type usageAnalyticsExportResolver struct {
+	db     database.DB
+	export *adminanalytics.UsageExport
+}
+
+func (r *usageAnalyticsExportResolver) ID() graphql.ID {
+	return relay.MarshalID("UsageAnalyticsExport", r.export.ID)
+}
+
+func (r *usageAnalyticsExportResolver) State() string {
+	return strings.ToUpper(r.export.State)
+}
+
+func (r *usageAnalyticsExportResolver) Format() string {
+	return strings.ToUpper(string(r.export.Format))
+}
+
+func (r *usageAnalyticsExportResolver) From() string {
+	return r.export.RangeStart.Format(usageExportDateFormat)
+}
+
+func (r *usageAnalyticsExportResolver) To() string {
+	return r.export.RangeEnd.Format(usageExportDateFormat)
+}
+
+func (r *usageAnalyticsExportResolver) Initiator(ctx context.Context) (*UserResolver, error) {
+	if r.export.InitiatorID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, *r.export.InitiatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *usageAnalyticsExportResolver) QueuedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.export.QueuedAt}
+}
+
+func (r *usageAnalyticsExportResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.export.FinishedAt)
+}
+
+func (r *usageAnalyticsExportResolver) FailureMessage() *string {
+	return r.export.FailureMessage
+}
+
+func (r *usageAnalyticsExportResolver) RowCount() *int32 {
+	if r.export.RowCount == nil {
+		return nil
+	}
+	count := int32(*r.export.RowCount)
+	return &count
+}
+
+func (r *usageAnalyticsExportResolver) DownloadURL() *string {
+	if r.export.State != "completed" || r.export.ObjectKey == nil {
+		return nil
+	}
+	u := globals.ExternalURL().ResolveReference(&url.URL{
+		Path: "/.api/usage-analytics/exports/" + strconv.Itoa(r.export.ID),
+	}).String()
+	return &u
+}
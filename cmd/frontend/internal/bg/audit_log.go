@@ -0,0 +1,84 @@
+package bg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit/auditstore"
+	"github.com/sourcegraph/sourcegraph/internal/audit/sinks"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// auditLogExportInterval is how often newly stored audit log entries are streamed to
+// the configured sinks.
+const auditLogExportInterval = 10 * time.Second
+
+// DeleteExpiredAuditLogEntries deletes stored audit log entries once they are older
+// than the retention configured in `log.auditLog.storage.retentionDays`.
+func DeleteExpiredAuditLogEntries(ctx context.Context, logger log.Logger, db database.DB) {
+	logger = logger.Scoped("auditLogRetention", "deletes expired audit log entries")
+	store := auditstore.New(db)
+	for {
+		storage := auditstore.StorageConfig(conf.SiteConfig())
+		deleted, err := auditstore.DeleteExpired(ctx, store, storage, time.Now())
+		if err != nil {
+			logger.Error("deleting expired audit log entries", log.Error(err))
+		} else if deleted > 0 {
+			logger.Info("deleted expired audit log entries", log.Int("count", deleted))
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+// ExportAuditLogToSinks streams stored audit log entries to the sinks configured in
+// `log.auditLog.sinks`.
+func ExportAuditLogToSinks(ctx context.Context, logger log.Logger, db database.DB) {
+	logger = logger.Scoped("auditLogExport", "streams stored audit log entries to external sinks")
+	store := auditstore.New(db)
+	getUploadStore := auditLogUploadStore(logger)
+	for {
+		cfg := conf.SiteConfig()
+		if storage := auditstore.StorageConfig(cfg); storage != nil && storage.Enabled && cfg.Log.AuditLog.Sinks != nil {
+			var uploadStore uploadstore.Store
+			if cfg.Log.AuditLog.Sinks.File != nil {
+				var err error
+				if uploadStore, err = getUploadStore(ctx); err != nil {
+					logger.Error("creating audit log upload store, skipping the file sink", log.Error(err))
+				}
+			}
+			exporters := sinks.FromConfig(cfg.Log.AuditLog.Sinks, httpcli.ExternalDoer, uploadStore)
+			if err := auditstore.Export(ctx, store, exporters); err != nil {
+				logger.Error("exporting audit log entries", log.Error(err))
+			}
+		}
+		time.Sleep(auditLogExportInterval)
+	}
+}
+
+// auditLogUploadStore returns a function that creates the upload store of the file
+// sink on its first call, as most instances do not configure it.
+func auditLogUploadStore(logger log.Logger) func(context.Context) (uploadstore.Store, error) {
+	var (
+		once  sync.Once
+		store uploadstore.Store
+		err   error
+	)
+	return func(ctx context.Context) (uploadstore.Store, error) {
+		once.Do(func() {
+			config := sinks.UploadStoreConfigInst
+			config.Load()
+			if err = config.Validate(); err != nil {
+				return
+			}
+			store, err = sinks.NewUploadStore(ctx, observation.NewContext(logger), config)
+		})
+		return store, err
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/rbac"
-	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -43,7 +42,7 @@ func UpdatePermissions(ctx context.Context, logger log.Logger, db database.DB) {
 
 		if len(toBeAdded) > 0 {
 			// Adding new permissions to the database. This permissions will be assigned to the System roles
-			// (USER and SITE_ADMINISTRATOR).
+			// (USER and SITE_ADMINISTRATOR), or only to SITE_ADMINISTRATOR for site admin only namespaces.
 			permissions, err := permissionStore.BulkCreate(ctx, toBeAdded)
 			if err != nil {
 				return errors.Wrap(err, "creating new permissions")
@@ -52,10 +51,11 @@ func UpdatePermissions(ctx context.Context, logger log.Logger, db database.DB) {
 			for _, permission := range permissions {
 				// Assign the permission to both SITE_ADMINISTRATOR and USER roles. We do this so that we don't break the
 				// current experience and always assume that everyone has access until a site administrator revokes that
-				// access.
+				// access. Namespaces guarding areas that were restricted to site admins are only assigned to the
+				// SITE_ADMINISTRATOR role for the same reason.
 				// Context: https://sourcegraph.slack.com/archives/C044BUJET7C/p1675292124253779?thread_ts=1675280399.192819&cid=C044BUJET7C
 				if err := rolePermissionStore.BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
-					Roles:        []types.SystemRole{types.SiteAdministratorSystemRole, types.UserSystemRole},
+					Roles:        rbac.RBACSchema.SystemRolesForNamespace(permission.Namespace),
 					PermissionID: permission.ID,
 				}); err != nil {
 					return errors.Wrap(err, "assigning permission to system roles")
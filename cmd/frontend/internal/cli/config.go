@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/gitops"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/highlight"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -31,6 +32,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -162,6 +165,21 @@ func overrideSiteConfig(ctx context.Context, logger log.Logger, db database.DB)
 	return nil
 }
 
+// watchGitOpsSiteConfig starts applying the site config from a Git repository in the
+// background if SITE_CONFIG_GIT_REPO is set.
+func watchGitOpsSiteConfig(ctx context.Context, logger log.Logger, db database.DB) error {
+	if !gitops.Enabled() {
+		return nil
+	}
+	if os.Getenv("SITE_CONFIG_FILE") != "" {
+		return errors.New("SITE_CONFIG_FILE and SITE_CONFIG_GIT_REPO cannot be used together")
+	}
+
+	syncer := gitops.NewSyncer(logger, db, gitserver.NewClient())
+	go goroutine.MonitorBackgroundRoutines(ctx, syncer.NewRoutine(ctx))
+	return nil
+}
+
 func overrideGlobalSettings(ctx context.Context, logger log.Logger, db database.DB) error {
 	logger = logger.Scoped("overrideGlobalSettings", "")
 	path := os.Getenv("GLOBAL_SETTINGS_FILE")
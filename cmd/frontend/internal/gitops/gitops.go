@@ -0,0 +1,184 @@
+// Package gitops applies the site configuration from a file in a Git repository.
+//
+// When SITE_CONFIG_GIT_REPO is set, the frontend periodically reads the file at
+// SITE_CONFIG_GIT_PATH from the configured revision of that repository (via gitserver)
+// and saves it as a new site configuration version whenever its contents change. Edits
+// to the site configuration made through the application are refused while this mode
+// is enabled, so the repository remains the source of truth.
+package gitops
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	repoName     = env.Get("SITE_CONFIG_GIT_REPO", "", "The name of a repository (as known to Sourcegraph) containing the site configuration. When set, the site configuration is read from this repository and cannot be edited in the application.")
+	filePath     = env.Get("SITE_CONFIG_GIT_PATH", "site-config.json", "The path of the site configuration file in the SITE_CONFIG_GIT_REPO repository.")
+	revision     = env.Get("SITE_CONFIG_GIT_REVISION", "HEAD", "The revision of the SITE_CONFIG_GIT_REPO repository to read the site configuration from.")
+	pollInterval = env.MustGetDuration("SITE_CONFIG_GIT_POLL_INTERVAL", time.Minute, "How often to check the SITE_CONFIG_GIT_REPO repository for site configuration changes.")
+)
+
+// Enabled returns true if the site configuration is managed in a Git repository.
+func Enabled() bool {
+	return repoName != ""
+}
+
+// Status describes the most recent attempt to apply the site configuration from Git.
+type Status struct {
+	Repository api.RepoName
+	Path       string
+	Revision   string
+
+	// Commit is the commit the site configuration was last successfully read from.
+	Commit api.CommitID
+	// SyncedAt is the time of the last successful sync.
+	SyncedAt time.Time
+	// Error describes why the last sync failed. It is empty if the last sync succeeded.
+	Error string
+}
+
+var (
+	statusMu sync.RWMutex
+	status   *Status
+)
+
+// CurrentStatus returns the status of the most recent sync, or nil if the site
+// configuration is not managed in Git.
+func CurrentStatus() *Status {
+	if !Enabled() {
+		return nil
+	}
+
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+
+	if status == nil {
+		// Nothing has been synced yet.
+		return &Status{Repository: api.RepoName(repoName), Path: filePath, Revision: revision}
+	}
+	s := *status
+	return &s
+}
+
+func setStatus(s Status) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	status = &s
+}
+
+// Syncer copies the site configuration file from a Git repository into the database.
+type Syncer struct {
+	logger    log.Logger
+	db        database.DB
+	gitserver gitserver.Client
+	repo      api.RepoName
+	path      string
+	revision  string
+	now       func() time.Time
+
+	// lastCommit is the commit the site configuration was most recently found to
+	// match.
+	lastCommit api.CommitID
+}
+
+// NewSyncer returns a syncer for the repository, path and revision configured in the
+// environment.
+func NewSyncer(logger log.Logger, db database.DB, gitserverClient gitserver.Client) *Syncer {
+	return &Syncer{
+		logger:    logger.Scoped("gitops", "applies the site configuration from a Git repository"),
+		db:        db,
+		gitserver: gitserverClient,
+		repo:      api.RepoName(repoName),
+		path:      filePath,
+		revision:  revision,
+		now:       time.Now,
+	}
+}
+
+// NewRoutine returns a background routine that periodically syncs the site
+// configuration.
+func (s *Syncer) NewRoutine(ctx context.Context) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"frontend.site-config-gitops",
+		"applies the site configuration from a Git repository",
+		pollInterval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			_, err := s.Sync(ctx)
+			return err
+		}),
+	)
+}
+
+// Sync reads the site configuration file at the configured revision and saves it as
+// a new site configuration version if it differs from the latest one. It returns true
+// if a new version was saved. The file is validated the same way as edits made in the
+// application, and an invalid file leaves the current site configuration in place.
+//
+// The file is compared against the latest version on every sync rather than only when
+// the revision moves, so that changes made to the database directly are reverted.
+func (s *Syncer) Sync(ctx context.Context) (updated bool, err error) {
+	// The new site configuration version is authored by the internal actor, which is
+	// recorded as an internal process in the site configuration history.
+	ctx = actor.WithInternalActor(ctx)
+
+	defer func() {
+		st := Status{
+			Repository: s.repo,
+			Path:       s.path,
+			Revision:   s.revision,
+			Commit:     s.lastCommit,
+		}
+		statusMu.RLock()
+		if status != nil {
+			st.SyncedAt = status.SyncedAt
+		}
+		statusMu.RUnlock()
+		if err != nil {
+			st.Error = err.Error()
+		} else {
+			st.SyncedAt = s.now()
+		}
+		setStatus(st)
+	}()
+
+	commit, err := s.gitserver.ResolveRevision(ctx, s.repo, s.revision, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "resolving revision %q of %s", s.revision, s.repo)
+	}
+
+	contents, err := s.gitserver.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, s.repo, commit, s.path)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading %s at %s@%s", s.path, s.repo, commit)
+	}
+
+	latest, err := s.db.Conf().SiteGetLatest(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "ConfStore.SiteGetLatest")
+	}
+	if latest.Contents == string(contents) {
+		s.lastCommit = commit
+		return false, nil
+	}
+
+	if _, err := s.db.Conf().SiteCreateIfUpToDate(ctx, &latest.ID, actor.FromContext(ctx).UID, string(contents), false); err != nil {
+		return false, errors.Wrapf(err, "applying site configuration from %s at %s@%s", s.path, s.repo, commit)
+	}
+
+	s.lastCommit = commit
+	s.logger.Info("applied site configuration from Git", log.String("repo", string(s.repo)), log.String("commit", string(commit)))
+	return true, nil
+}
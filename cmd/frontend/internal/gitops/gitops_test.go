@@ -0,0 +1,105 @@
+package gitops
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	repoName = "github.com/acme/infra"
+	t.Cleanup(func() {
+		repoName = ""
+		status = nil
+	})
+
+	newSyncer := func(t *testing.T, file string, latest *database.SiteConfig) (*Syncer, *database.MockConfStore) {
+		t.Helper()
+		status = nil
+
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+		gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) ([]byte, error) {
+			if repo != "github.com/acme/infra" || commit != "deadbeef" || name != "sourcegraph/site.json" {
+				return nil, errors.Newf("unexpected file %s@%s:%s", repo, commit, name)
+			}
+			return []byte(file), nil
+		})
+
+		confStore := database.NewMockConfStore()
+		confStore.SiteGetLatestFunc.SetDefaultReturn(latest, nil)
+		confStore.SiteCreateIfUpToDateFunc.SetDefaultReturn(&database.SiteConfig{ID: latest.ID + 1}, nil)
+		db := database.NewMockDB()
+		db.ConfFunc.SetDefaultReturn(confStore)
+
+		return &Syncer{
+			logger:    logtest.Scoped(t),
+			db:        db,
+			gitserver: gitserverClient,
+			repo:      "github.com/acme/infra",
+			path:      "sourcegraph/site.json",
+			revision:  "main",
+			now:       func() time.Time { return now },
+		}, confStore
+	}
+
+	t.Run("applies changed file", func(t *testing.T) {
+		syncer, confStore := newSyncer(t, `{"externalURL": "https://sourcegraph.acme.com"}`, &database.SiteConfig{ID: 3, Contents: `{}`})
+
+		updated, err := syncer.Sync(ctx)
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		require.Len(t, confStore.SiteCreateIfUpToDateFunc.History(), 1)
+		call := confStore.SiteCreateIfUpToDateFunc.History()[0]
+		assert.Equal(t, int32(3), *call.Arg1)
+		assert.Equal(t, int32(0), call.Arg2, "expected the internal actor as the author")
+		assert.Equal(t, `{"externalURL": "https://sourcegraph.acme.com"}`, call.Arg3)
+		assert.False(t, call.Arg4, "expected the file to be validated like an edit in the application")
+
+		assert.Equal(t, &Status{
+			Repository: "github.com/acme/infra",
+			Path:       "sourcegraph/site.json",
+			Revision:   "main",
+			Commit:     "deadbeef",
+			SyncedAt:   now,
+		}, CurrentStatus())
+	})
+
+	t.Run("skips unchanged file", func(t *testing.T) {
+		syncer, confStore := newSyncer(t, `{}`, &database.SiteConfig{ID: 3, Contents: `{}`})
+
+		updated, err := syncer.Sync(ctx)
+		require.NoError(t, err)
+		assert.False(t, updated)
+		assert.Empty(t, confStore.SiteCreateIfUpToDateFunc.History())
+		assert.Equal(t, api.CommitID("deadbeef"), CurrentStatus().Commit)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		syncer, confStore := newSyncer(t, `{"externalURL": 1}`, &database.SiteConfig{ID: 3, Contents: `{}`})
+		confStore.SiteCreateIfUpToDateFunc.SetDefaultReturn(nil, errors.New("site configuration is invalid: externalURL: Invalid type"))
+
+		updated, err := syncer.Sync(ctx)
+		require.Error(t, err)
+		assert.False(t, updated)
+
+		st := CurrentStatus()
+		assert.Empty(t, st.Commit)
+		assert.True(t, st.SyncedAt.IsZero())
+		assert.Contains(t, st.Error, "site configuration is invalid")
+	})
+}
@@ -46,6 +46,7 @@ func newTest(t *testing.T) *httptestutil.Client {
 			BatchesAzureDevOpsWebhook:     enterpriseServices.BatchesAzureDevOpsWebhook,
 			SCIMHandler:                   enterpriseServices.SCIMHandler,
 			NewCodeIntelUploadHandler:     enterpriseServices.NewCodeIntelUploadHandler,
+			NewCodeIntelLSPHandler:        enterpriseServices.NewCodeIntelLSPHandler,
 			NewComputeStreamHandler:       enterpriseServices.NewComputeStreamHandler,
 			PermissionsGitHubWebhook:      enterpriseServices.PermissionsGitHubWebhook,
 			NewCompletionsStreamHandler:   enterpriseServices.NewCompletionsStreamHandler,
@@ -185,6 +185,16 @@ func recordAuditLog(ctx context.Context, logger sglog.Logger, data traceData) {
 			sglog.Bool("mutation", strings.Contains(data.queryParams.Query, "mutation")),
 			sglog.Bool("successful", len(data.queryErrors) == 0),
 		},
+		Data: map[string]any{
+			"request": map[string]any{
+				"name":      data.requestName,
+				"source":    data.requestSource,
+				"variables": toJson(data.queryParams.Variables),
+				"query":     data.queryParams.Query,
+			},
+			"mutation":   strings.Contains(data.queryParams.Query, "mutation"),
+			"successful": len(data.queryErrors) == 0,
+		},
 	})
 }
 
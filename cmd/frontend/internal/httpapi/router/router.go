@@ -13,6 +13,7 @@ const (
 	LSIFUpload       = "lsif.upload"
 	SCIPUpload       = "scip.upload"
 	SCIPUploadExists = "scip.upload.exists"
+	CodeIntelLSP     = "codeintel.lsp"
 
 	SearchStream      = "search.stream"
 	ComputeStream     = "compute.stream"
@@ -43,6 +44,8 @@ const (
 
 	CodeInsightsDataExport = "insights.data.export"
 
+	UsageAnalyticsExport = "usageAnalytics.export"
+
 	ExternalURL            = "internal.app-url"
 	SendEmail              = "internal.send-email"
 	GitInfoRefs            = "internal.git.info-refs"
@@ -80,12 +83,14 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
+	base.Path("/codeintel/lsp").Methods("GET").Name(CodeIntelLSP)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/usage-analytics/exports/{id}").Methods("GET").Name(UsageAnalyticsExport)
 	base.Path("/completions/stream").Methods("POST").Name(CompletionsStream)
 	base.Path("/completions/code").Methods("POST").Name(CodeCompletions)
 
@@ -31,7 +31,7 @@ import (
 // before that.
 func handleStreamBlame(logger log.Logger, db database.DB, gitserverClient gitserver.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		flags := featureflag.FromContext(r.Context())
+		flags := featureflag.ForRepo(r.Context(), mux.Vars(r)["Repo"])
 		if !flags.GetBoolOr("enable-streaming-git-blame", false) {
 			w.WriteHeader(404)
 			return
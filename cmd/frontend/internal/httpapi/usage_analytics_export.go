@@ -0,0 +1,89 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	usageExportUploadStoreOnce sync.Once
+	usageExportUploadStore     uploadstore.Store
+	usageExportUploadStoreErr  error
+)
+
+// getUsageExportUploadStore creates the upload store of usage exports on the
+// first download.
+func getUsageExportUploadStore(_ context.Context) (uploadstore.Store, error) {
+	usageExportUploadStoreOnce.Do(func() {
+		config := adminanalytics.UsageExportUploadStoreConfigInst
+		config.Load()
+		if usageExportUploadStoreErr = config.Validate(); usageExportUploadStoreErr != nil {
+			return
+		}
+		usageExportUploadStore, usageExportUploadStoreErr = adminanalytics.NewUsageExportUploadStore(
+			context.Background(), observation.NewContext(sglog.Scoped("usageExports", "usage analytics export downloads")), config,
+		)
+	})
+	return usageExportUploadStore, usageExportUploadStoreErr
+}
+
+// serveUsageAnalyticsExport streams a completed usage analytics export from
+// the upload store.
+func serveUsageAnalyticsExport(db database.DB, getUploadStore func(context.Context) (uploadstore.Store, error)) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		// 🚨 SECURITY: Only site admins may download exports, they include the activity of all users.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			return &errcode.HTTPErr{Status: http.StatusForbidden, Err: err}
+		}
+
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.Wrap(err, "invalid export ID")}
+		}
+
+		export, err := adminanalytics.NewUsageExportStore(db).GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if export.State != "completed" || export.ObjectKey == nil {
+			return &errcode.HTTPErr{Status: http.StatusNotFound, Err: errors.Newf("usage export %d is %s", id, export.State)}
+		}
+
+		store, err := getUploadStore(ctx)
+		if err != nil {
+			return errors.Wrap(err, "initialising upload store")
+		}
+		file, err := store.Get(ctx, *export.ObjectKey)
+		if err != nil {
+			return errors.Wrap(err, "reading export")
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", export.Format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf(
+			"usage-%s-%s.%s",
+			export.RangeStart.Format("2006-01-02"),
+			export.RangeEnd.Format("2006-01-02"),
+			export.Format,
+		)))
+		_, err = io.Copy(w, file)
+		return err
+	}
+}
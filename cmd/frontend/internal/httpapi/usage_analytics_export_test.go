@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+
+	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestServeUsageAnalyticsExport(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		return &types.User{ID: a.UID, SiteAdmin: a.UID == 1}, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	getUploadStore := func(context.Context) (uploadstore.Store, error) {
+		return nil, errors.New("unexpected call")
+	}
+
+	m := apirouter.New(mux.NewRouter())
+	handler := jsonMiddleware(&errorHandler{Logger: logtest.Scoped(t)})
+	m.Get(apirouter.UsageAnalyticsExport).Handler(handler(serveUsageAnalyticsExport(db, getUploadStore)))
+
+	for _, tc := range []struct {
+		name   string
+		userID int32
+		target string
+		want   int
+	}{
+		{name: "not a site admin", userID: 2, target: "/usage-analytics/exports/1", want: http.StatusForbidden},
+		{name: "invalid ID", userID: 1, target: "/usage-analytics/exports/foo", want: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.target, nil)
+			req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(tc.userID)))
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, req)
+
+			if got := w.Result().StatusCode; got != tc.want {
+				t.Fatalf("unexpected status %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
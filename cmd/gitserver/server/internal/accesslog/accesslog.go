@@ -5,7 +5,9 @@ package accesslog
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/sourcegraph/log"
 	"go.uber.org/atomic"
@@ -18,13 +20,13 @@ type contextKey struct{}
 
 type paramsContext struct {
 	repo     string
-	metadata []log.Field
+	metadata map[string]any
 }
 
 // Record updates a mutable unexported field stored in the context,
 // making it available for Middleware to log at the end of the middleware
-// chain.
-func Record(ctx context.Context, repo string, meta ...log.Field) {
+// chain. The values of meta are strings or string slices.
+func Record(ctx context.Context, repo string, meta map[string]any) {
 	pc := fromContext(ctx)
 	if pc == nil {
 		return
@@ -85,20 +87,42 @@ func (a *accessLogger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if paramsCtx != nil {
-		params := append([]log.Field{log.String("repo", paramsCtx.repo)}, paramsCtx.metadata...)
-		fields = append(fields, log.Object("params", params...))
-	} else {
-		fields = append(fields, log.String("params", "nil"))
+	params := map[string]any{"repo": paramsCtx.repo}
+	for k, v := range paramsCtx.metadata {
+		params[k] = v
 	}
+	fields = append(fields, log.Object("params", metadataFields(params)...))
 
 	audit.Log(ctx, a.logger, audit.Record{
 		Entity: "gitserver",
 		Action: "access",
 		Fields: fields,
+		Data:   map[string]any{"params": params},
 	})
 }
 
+// metadataFields returns the log fields of the metadata, sorted by key.
+func metadataFields(metadata map[string]any) []log.Field {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]log.Field, 0, len(keys))
+	for _, k := range keys {
+		switch v := metadata[k].(type) {
+		case string:
+			fields = append(fields, log.String(k, v))
+		case []string:
+			fields = append(fields, log.Strings(k, v))
+		default:
+			fields = append(fields, log.String(k, fmt.Sprint(v)))
+		}
+	}
+	return fields
+}
+
 // HTTPMiddleware will extract actor information and params collected by Record that has
 // been stored in the context, in order to log a trace of the access.
 func HTTPMiddleware(logger log.Logger, watcher conftypes.WatchableSiteConfig, next http.HandlerFunc) http.HandlerFunc {
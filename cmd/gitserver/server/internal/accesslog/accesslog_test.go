@@ -6,7 +6,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,9 +20,9 @@ func TestRecord(t *testing.T) {
 		ctx := context.Background()
 		ctx = withContext(ctx, &paramsContext{})
 
-		meta := []log.Field{log.String("cmd", "git"), log.String("args", "grep foo")}
+		meta := map[string]any{"cmd": "git", "args": []string{"grep", "foo"}}
 
-		Record(ctx, "github.com/foo/bar", meta...)
+		Record(ctx, "github.com/foo/bar", meta)
 
 		pc := fromContext(ctx)
 		require.NotNil(t, pc)
@@ -34,9 +33,9 @@ func TestRecord(t *testing.T) {
 	t.Run("OK not initialized context", func(t *testing.T) {
 		ctx := context.Background()
 
-		meta := []log.Field{log.String("cmd", "git"), log.String("args", "grep foo")}
+		meta := map[string]any{"cmd": "git", "args": []string{"grep", "foo"}}
 
-		Record(ctx, "github.com/foo/bar", meta...)
+		Record(ctx, "github.com/foo/bar", meta)
 		pc := fromContext(ctx)
 		assert.Nil(t, pc)
 	})
@@ -66,7 +65,7 @@ func TestHTTPMiddleware(t *testing.T) {
 	t.Run("OK for access log setting", func(t *testing.T) {
 		logger, exportLogs := logtest.Captured(t)
 		h := HTTPMiddleware(logger, &accessLogConf{}, func(w http.ResponseWriter, r *http.Request) {
-			Record(r.Context(), "github.com/foo/bar", log.String("cmd", "git"), log.String("args", "grep foo"))
+			Record(r.Context(), "github.com/foo/bar", map[string]any{"cmd": "git", "args": []string{"grep", "foo"}})
 		})
 
 		rec := httptest.NewRecorder()
@@ -80,7 +79,9 @@ func TestHTTPMiddleware(t *testing.T) {
 		require.Len(t, logs, 2)
 		assert.Equal(t, accessLoggingEnabledMessage, logs[0].Message)
 		assert.Contains(t, logs[1].Message, accessEventMessage)
-		assert.Equal(t, "github.com/foo/bar", logs[1].Fields["params"].(map[string]any)["repo"])
+		params := logs[1].Fields["params"].(map[string]any)
+		assert.Equal(t, "github.com/foo/bar", params["repo"])
+		assert.Equal(t, "git", params["cmd"])
 
 		auditFields := logs[1].Fields["audit"].(map[string]interface{})
 		assert.Equal(t, "gitserver", auditFields["entity"])
@@ -115,7 +116,7 @@ func TestHTTPMiddleware(t *testing.T) {
 		cfg := &accessLogConf{disabled: true}
 		var handled bool
 		h := HTTPMiddleware(logger, cfg, func(w http.ResponseWriter, r *http.Request) {
-			Record(r.Context(), "github.com/foo/bar", log.String("cmd", "git"), log.String("args", "grep foo"))
+			Record(r.Context(), "github.com/foo/bar", map[string]any{"cmd": "git", "args": []string{"grep", "foo"}})
 			handled = true
 		})
 		rec := httptest.NewRecorder()
@@ -21,6 +21,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/audit/auditstore"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -114,6 +116,11 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 	db := database.NewDB(observationCtx.Logger, sqlDB)
 
+	// Store gitserver access audit records alongside the ones of the frontend.
+	auditSink := auditstore.NewSink(logger, auditstore.New(db))
+	audit.RegisterSink(auditSink)
+	go auditSink.Start()
+
 	repoStore := db.Repos()
 	dependenciesSvc := dependencies.NewService(observationCtx, db)
 	externalServiceStore := db.ExternalServices()
@@ -230,6 +237,9 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	// shutdown they will be orphaned and continue running.
 	gitserver.Stop()
 
+	// Store the audit log entries that are still buffered.
+	auditSink.Stop()
+
 	return nil
 }
 
@@ -0,0 +1,64 @@
+package analyticsexport
+
+import (
+	"context"
+	"io/fs"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type cleanerConfig struct {
+	env.BaseConfig
+
+	retention time.Duration
+	interval  time.Duration
+}
+
+var cleanerConfigInst = &cleanerConfig{}
+
+func (c *cleanerConfig) Load() {
+	c.retention = c.GetInterval("USAGE_EXPORT_RETENTION", "168h", "How long usage analytics exports are kept after they finished.")
+	c.interval = c.GetInterval("USAGE_EXPORT_CLEANUP_INTERVAL", "1h", "The frequency at which expired usage analytics exports are deleted.")
+}
+
+// cleanupBatchSize is the maximum number of exports deleted per run.
+const cleanupBatchSize = 100
+
+// cleaner deletes the usage analytics exports that finished longer than the
+// retention period ago, along with their files in the upload store.
+type cleaner struct {
+	store       adminanalytics.UsageExportStore
+	uploadStore uploadstore.Store
+	retention   time.Duration
+	now         func() time.Time
+}
+
+var _ goroutine.Handler = &cleaner{}
+
+func (c *cleaner) Handle(ctx context.Context) error {
+	exports, err := c.store.ListFinishedBefore(ctx, c.now().Add(-c.retention), cleanupBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "listing expired usage exports")
+	}
+
+	var errs error
+	for _, e := range exports {
+		if e.ObjectKey != nil {
+			// The export is kept so that deleting its file is retried, unless
+			// the file is already gone.
+			if err := c.uploadStore.Delete(ctx, *e.ObjectKey); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = errors.Append(errs, errors.Wrapf(err, "deleting file of usage export %d", e.ID))
+				continue
+			}
+		}
+		if err := c.store.Delete(ctx, e.ID); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "deleting usage export %d", e.ID))
+		}
+	}
+	return errs
+}
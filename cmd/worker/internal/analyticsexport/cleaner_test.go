@@ -0,0 +1,82 @@
+package analyticsexport
+
+import (
+	"context"
+	"io/fs"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeExpiredExportStore struct {
+	adminanalytics.UsageExportStore
+	exports map[int]*adminanalytics.UsageExport
+	before  time.Time
+}
+
+func (s *fakeExpiredExportStore) ListFinishedBefore(_ context.Context, before time.Time, _ int) ([]*adminanalytics.UsageExport, error) {
+	s.before = before
+	var exports []*adminanalytics.UsageExport
+	for _, id := range keys(s.exports) {
+		exports = append(exports, s.exports[id])
+	}
+	return exports, nil
+}
+
+func (s *fakeExpiredExportStore) Delete(_ context.Context, id int) error {
+	delete(s.exports, id)
+	return nil
+}
+
+func TestCleaner_Handle(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	key := func(s string) *string { return &s }
+
+	store := &fakeExpiredExportStore{exports: map[int]*adminanalytics.UsageExport{
+		1: {ID: 1, ObjectKey: key("usage-export-1.csv")},
+		// The file of a failed export was never uploaded.
+		2: {ID: 2},
+		3: {ID: 3, ObjectKey: key("usage-export-3.parquet")},
+		4: {ID: 4, ObjectKey: key("usage-export-4.csv")},
+	}}
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.DeleteFunc.SetDefaultHook(func(_ context.Context, key string) error {
+		switch key {
+		case "usage-export-3.parquet":
+			return errors.New("bucket unavailable")
+		case "usage-export-4.csv":
+			return errors.Wrap(fs.ErrNotExist, "failed to delete object")
+		}
+		return nil
+	})
+
+	c := &cleaner{
+		store:       store,
+		uploadStore: uploadStore,
+		retention:   7 * 24 * time.Hour,
+		now:         func() time.Time { return now },
+	}
+
+	err := c.Handle(context.Background())
+	require.ErrorContains(t, err, "bucket unavailable")
+	assert.Equal(t, now.Add(-7*24*time.Hour), store.before)
+	// The export whose file couldn't be deleted is kept to be retried.
+	assert.Equal(t, []int{3}, keys(store.exports))
+	assert.Len(t, uploadStore.DeleteFunc.History(), 3)
+}
+
+func keys(exports map[int]*adminanalytics.UsageExport) []int {
+	var ids []int
+	for id := range exports {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,53 @@
+package analyticsexport
+
+import (
+	"context"
+	"io"
+
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type handler struct {
+	store       adminanalytics.UsageExportStore
+	uploadStore uploadstore.Store
+	// export writes the rows of an export to w and returns the number of rows.
+	export func(ctx context.Context, e *adminanalytics.UsageExport, w io.Writer) (int, error)
+}
+
+var _ workerutil.Handler[*adminanalytics.UsageExport] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, e *adminanalytics.UsageExport) error {
+	key := adminanalytics.UsageExportObjectKey(e.ID, e.Format)
+
+	// The export is streamed to the upload store, so only the rows buffered by
+	// the writer of the format, such as a Parquet row group, are held in memory.
+	pr, pw := io.Pipe()
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	var rowCount int
+	eg.Go(func() error {
+		n, err := h.export(egCtx, e, pw)
+		rowCount = n
+		// An error makes the upload fail instead of storing a partial export.
+		pw.CloseWithError(err)
+		return err
+	})
+	eg.Go(func() error {
+		_, err := h.uploadStore.Upload(egCtx, key, pr)
+		pr.CloseWithError(err)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return errors.Wrap(err, "exporting usage")
+	}
+
+	logger.Info("exported usage", log.Int("export.id", e.ID), log.Int("export.rows", rowCount))
+
+	return h.store.MarkUploaded(ctx, e.ID, key, rowCount)
+}
@@ -0,0 +1,79 @@
+package analyticsexport
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeExportStore struct {
+	adminanalytics.UsageExportStore
+	uploaded map[int]string
+	rows     map[int]int
+}
+
+func (s *fakeExportStore) MarkUploaded(_ context.Context, id int, objectKey string, rowCount int) error {
+	s.uploaded[id] = objectKey
+	s.rows[id] = rowCount
+	return nil
+}
+
+func TestHandler_Handle(t *testing.T) {
+	export := &adminanalytics.UsageExport{
+		ID:         42,
+		Format:     adminanalytics.UsageExportFormatCSV,
+		RangeStart: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		RangeEnd:   time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	newHandler := func(exportErr error) (*handler, *fakeExportStore, map[string]string) {
+		store := &fakeExportStore{uploaded: map[int]string{}, rows: map[int]int{}}
+		uploads := map[string]string{}
+		uploadStore := uploadstoremocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return 0, err
+			}
+			uploads[key] = string(data)
+			return int64(len(data)), nil
+		})
+		return &handler{
+			store:       store,
+			uploadStore: uploadStore,
+			export: func(_ context.Context, e *adminanalytics.UsageExport, w io.Writer) (int, error) {
+				if _, err := io.WriteString(w, "date,event_count\n2023-05-01,3\n"); err != nil {
+					return 0, err
+				}
+				return 1, exportErr
+			},
+		}, store, uploads
+	}
+
+	t.Run("success", func(t *testing.T) {
+		h, store, uploads := newHandler(nil)
+
+		require.NoError(t, h.Handle(context.Background(), logtest.Scoped(t), export))
+		assert.Equal(t, map[string]string{"usage-export-42.csv": "date,event_count\n2023-05-01,3\n"}, uploads)
+		assert.Equal(t, map[int]string{42: "usage-export-42.csv"}, store.uploaded)
+		assert.Equal(t, map[int]int{42: 1}, store.rows)
+	})
+
+	t.Run("export error", func(t *testing.T) {
+		h, store, uploads := newHandler(errors.New("query failed"))
+
+		err := h.Handle(context.Background(), logtest.Scoped(t), export)
+		require.ErrorContains(t, err, "query failed")
+		assert.Empty(t, uploads)
+		assert.Empty(t, store.uploaded)
+	})
+}
@@ -0,0 +1,114 @@
+package analyticsexport
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type exporter struct{}
+
+// NewExporter returns a job that writes the usage analytics exports requested
+// by site admins to the upload store.
+func NewExporter() job.Job {
+	return &exporter{}
+}
+
+func (e *exporter) Description() string {
+	return "Usage analytics exporter"
+}
+
+func (*exporter) Config() []env.Config {
+	return []env.Config{adminanalytics.UsageExportUploadStoreConfigInst, cleanerConfigInst}
+}
+
+func (e *exporter) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("exporter", "usage analytics exporter"))
+	ctx := actor.WithInternalActor(context.Background())
+
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialising database")
+	}
+
+	uploadStore, err := adminanalytics.NewUsageExportUploadStore(ctx, observationCtx, adminanalytics.UsageExportUploadStoreConfigInst)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialising upload store")
+	}
+
+	workerStore := adminanalytics.NewUsageExportWorkerStore(observationCtx, db.Handle())
+
+	return []goroutine.BackgroundRoutine{
+		makeWorker(ctx, observationCtx, workerStore, db, uploadStore),
+		makeResetter(observationCtx, workerStore),
+		makeCleaner(ctx, db, uploadStore),
+	}, nil
+}
+
+func makeWorker(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	workerStore store.Store[*adminanalytics.UsageExport],
+	db database.DB,
+	uploadStore uploadstore.Store,
+) *workerutil.Worker[*adminanalytics.UsageExport] {
+	handler := &handler{
+		store:       adminanalytics.NewUsageExportStore(db),
+		uploadStore: uploadStore,
+		export: func(ctx context.Context, e *adminanalytics.UsageExport, w io.Writer) (int, error) {
+			return adminanalytics.ExportUsage(ctx, db, e.RangeStart, e.RangeEnd, e.Format, w)
+		},
+	}
+
+	return dbworker.NewWorker[*adminanalytics.UsageExport](
+		ctx, workerStore, handler, workerutil.WorkerOptions{
+			Name:              "usage_analytics_export_worker",
+			Interval:          10 * time.Second,
+			NumHandlers:       1,
+			HeartbeatInterval: 10 * time.Second,
+			Metrics:           workerutil.NewMetrics(observationCtx, "usage_analytics_export_worker"),
+		},
+	)
+}
+
+func makeResetter(
+	observationCtx *observation.Context,
+	workerStore store.Store[*adminanalytics.UsageExport],
+) *dbworker.Resetter[*adminanalytics.UsageExport] {
+	return dbworker.NewResetter(
+		observationCtx.Logger, workerStore, dbworker.ResetterOptions{
+			Name:     "usage_analytics_export_resetter",
+			Interval: 5 * time.Minute,
+			Metrics:  dbworker.NewResetterMetrics(observationCtx, "usage_analytics_export_resetter"),
+		},
+	)
+}
+
+func makeCleaner(ctx context.Context, db database.DB, uploadStore uploadstore.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"usage_analytics_export_cleaner",
+		"deletes expired usage analytics exports",
+		cleanerConfigInst.interval,
+		&cleaner{
+			store:       adminanalytics.NewUsageExportStore(db),
+			uploadStore: uploadStore,
+			retention:   cleanerConfigInst.retention,
+			now:         time.Now,
+		},
+	)
+}
@@ -6049,6 +6049,13 @@ def go_dependencies():
         version = "v0.4.0",
     )
 
+    go_repository(
+        name = "com_github_segmentio_encoding",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/segmentio/encoding",
+        sum = "h1:UZEiaZ55nlXGDL92scoVuw00RmiRCazIEmvPSbSvt8Y=",
+        version = "v0.3.5",
+    )
     go_repository(
         name = "com_github_segmentio_fasthash",
         build_file_proto_mode = "disable_global",
@@ -6064,6 +6071,13 @@ def go_dependencies():
         version = "v1.0.4",
     )
 
+    go_repository(
+        name = "com_github_segmentio_parquet_go",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/segmentio/parquet-go",
+        sum = "h1:d09YiLivaPHjCyYDGLI5BQbl+carOqUg/U0noDQQBmo=",
+        version = "v0.0.0-20230622230624-510764ae9e80",
+    )
     go_repository(
         name = "com_github_sergi_go_diff",
         build_file_proto_mode = "disable_global",
@@ -84,6 +84,7 @@ func Post(
 			Name:        executionInput.Repository.Name,
 			Branch:      executionInput.Branch.Name,
 			FileMatches: executionInput.SearchResultPaths,
+			Metadata:    executionInput.RepositoryMetadata,
 		},
 		Outputs: outputs,
 		Steps: template.StepsContext{
@@ -130,6 +131,7 @@ func Post(
 			BaseRef:     executionInput.Branch.Name,
 			BaseRev:     executionInput.Branch.Target.OID,
 			FileMatches: executionInput.SearchResultPaths,
+			Metadata:    executionInput.RepositoryMetadata,
 		},
 		executionInput.Path,
 		os.Environ(),
@@ -168,6 +168,7 @@ func getStepContext(executionInput batcheslib.WorkspacesExecutionInput, previous
 			Name:        executionInput.Repository.Name,
 			Branch:      executionInput.Branch.Name,
 			FileMatches: executionInput.SearchResultPaths,
+			Metadata:    executionInput.RepositoryMetadata,
 		},
 		Outputs: outputs,
 		Steps: template.StepsContext{
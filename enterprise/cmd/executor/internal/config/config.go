@@ -37,6 +37,8 @@ type Config struct {
 	KeepWorkspaces                                 bool
 	DockerHostMountPath                            string
 	UseFirecracker                                 bool
+	UseSandbox                                     bool
+	SandboxRuntime                                 string
 	JobNumCPUs                                     int
 	JobMemory                                      string
 	FirecrackerDiskSpace                           string
@@ -90,6 +92,8 @@ func (c *Config) Load() {
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes()), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
+	c.UseSandbox = c.GetBool("EXECUTOR_USE_SANDBOX", "false", "Whether to isolate commands in sandboxed containers using a user-space kernel, such as gVisor. Does not require KVM. Linux hosts only. Mutually exclusive with EXECUTOR_USE_FIRECRACKER.")
+	c.SandboxRuntime = c.Get("EXECUTOR_SANDBOX_RUNTIME", DefaultSandboxRuntime, "The OCI runtime registered with the docker daemon that sandboxed containers are run with.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -194,6 +198,21 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UseSandbox {
+		if runtime.GOOS != "linux" {
+			c.AddError(errors.New("EXECUTOR_USE_SANDBOX is only supported on linux hosts."))
+		}
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_SANDBOX and EXECUTOR_USE_FIRECRACKER cannot both be enabled."))
+		}
+
+		// The sandbox workspace is a block device of the same size as a firecracker one.
+		_, err := datasize.ParseString(c.FirecrackerDiskSpace)
+		if err != nil {
+			c.AddError(errors.Wrapf(err, "invalid disk size provided for EXECUTOR_FIRECRACKER_DISK_SPACE: %q", c.FirecrackerDiskSpace))
+		}
+	}
+
 	if len(c.KubernetesNodeSelector) > 0 {
 		nodeSelectorValues := strings.Split(c.KubernetesNodeSelector, ",")
 		for _, value := range nodeSelectorValues {
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"runtime"
 	"testing"
 	"time"
 
@@ -23,6 +24,8 @@ func TestConfig_Load(t *testing.T) {
 			return "10"
 		case "EXECUTOR_USE_FIRECRACKER":
 			return "true"
+		case "EXECUTOR_USE_SANDBOX":
+			return "true"
 		case "EXECUTOR_KEEP_WORKSPACES":
 			return "true"
 		case "EXECUTOR_JOB_NUM_CPUS":
@@ -61,6 +64,8 @@ func TestConfig_Load(t *testing.T) {
 	assert.Equal(t, 10*time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 10, cfg.MaximumNumJobs)
 	assert.True(t, cfg.UseFirecracker)
+	assert.True(t, cfg.UseSandbox)
+	assert.Equal(t, "EXECUTOR_SANDBOX_RUNTIME", cfg.SandboxRuntime)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_IMAGE", cfg.FirecrackerImage)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_KERNEL_IMAGE", cfg.FirecrackerKernelImage)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", cfg.FirecrackerSandboxImage)
@@ -104,6 +109,8 @@ func TestConfig_Load_Defaults(t *testing.T) {
 	assert.Empty(t, cfg.QueueName)
 	assert.Equal(t, time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 1, cfg.MaximumNumJobs)
+	assert.False(t, cfg.UseSandbox)
+	assert.Equal(t, "runsc", cfg.SandboxRuntime)
 	assert.Equal(t, "sourcegraph/executor-vm:insiders", cfg.FirecrackerImage)
 	assert.Equal(t, "sourcegraph/ignite-kernel:5.10.135-amd64", cfg.FirecrackerKernelImage)
 	assert.Equal(t, "sourcegraph/ignite:v0.10.5", cfg.FirecrackerSandboxImage)
@@ -214,3 +221,66 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate_Sandbox(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the sandbox runtime is only supported on linux")
+	}
+
+	tests := []struct {
+		name           string
+		useFirecracker string
+		diskSpace      string
+		expectedErr    error
+	}{
+		{
+			name:           "Valid config",
+			useFirecracker: "false",
+			diskSpace:      "20G",
+		},
+		{
+			name:           "Firecracker enabled",
+			useFirecracker: "true",
+			diskSpace:      "20G",
+			expectedErr:    errors.New("EXECUTOR_USE_SANDBOX and EXECUTOR_USE_FIRECRACKER cannot both be enabled."),
+		},
+		{
+			name:           "Invalid disk space",
+			useFirecracker: "false",
+			diskSpace:      "lots",
+			expectedErr:    errors.New("invalid disk size provided for EXECUTOR_FIRECRACKER_DISK_SPACE: \"lots\": strconv.UnmarshalText: parsing \"lots\": invalid syntax"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.SetMockGetter(func(name string, defaultValue, description string) string {
+				switch name {
+				case "EXECUTOR_QUEUE_NAME":
+					return "batches"
+				case "EXECUTOR_FRONTEND_URL":
+					return "http://some-url.com"
+				case "EXECUTOR_FRONTEND_PASSWORD":
+					return "some-password"
+				case "EXECUTOR_USE_FIRECRACKER":
+					return test.useFirecracker
+				case "EXECUTOR_USE_SANDBOX":
+					return "true"
+				case "EXECUTOR_FIRECRACKER_DISK_SPACE":
+					return test.diskSpace
+				default:
+					return defaultValue
+				}
+			})
+			cfg.Load()
+
+			err := cfg.Validate()
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
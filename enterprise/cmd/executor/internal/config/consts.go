@@ -32,6 +32,9 @@ const (
 	// i8042.X: Makes boot faster, doesn't poll on the i8042 device on boot. See
 	// https://github.com/firecracker-microvm/firecracker/blob/main/docs/api_requests/actions.md#intel-and-amd-only-sendctrlaltdel.
 	FirecrackerKernelArgs = "console=ttyS0 reboot=k panic=1 pci=off ip=dhcp random.trust_cpu=on i8042.noaux i8042.nomux i8042.nopnp i8042.dumbkbd"
+	// DefaultSandboxRuntime is the OCI runtime docker uses to run step containers
+	// when the sandbox runtime is enabled. runsc is the gVisor runtime.
+	DefaultSandboxRuntime = "runsc"
 )
 
 var (
@@ -72,6 +75,9 @@ var (
 	// RequiredCLIToolsFirecracker contains all the programs that are expected to
 	// exist in PATH when running the executor with firecracker enabled.
 	RequiredCLIToolsFirecracker = []string{"dmsetup", "losetup", "mkfs.ext4", "strings"}
+	// RequiredCLIToolsSandbox contains all the programs that are expected to
+	// exist in PATH when running the executor with the sandbox runtime enabled.
+	RequiredCLIToolsSandbox = []string{"losetup", "mkfs.ext4", "mount"}
 	// CNISubnetCIDR is the CIDR range of the VMs in firecracker. This is the ignite
 	// default and chosen so that it doesn't interfere with other common applications
 	// such as docker. It also provides room for a large number of VMs.
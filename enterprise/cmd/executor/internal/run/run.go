@@ -89,6 +89,18 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 			// TODO: Validate ignite images are pulled and imported. Sadly, the
 			// output of ignite is not very parser friendly.
 		}
+
+		if cfg.UseSandbox {
+			// Validate the tools to create the workspace block devices are installed.
+			if err = util.ValidateSandboxTools(runner); err != nil {
+				return err
+			}
+
+			// Validate the OCI runtime is registered with docker.
+			if err = util.ValidateSandboxRuntimeInstalled(ctx, runner, cfg.SandboxRuntime); err != nil {
+				return err
+			}
+		}
 	}
 
 	nameSet := janitor.NewNameSet()
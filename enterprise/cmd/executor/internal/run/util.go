@@ -67,6 +67,7 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 			DockerOptions:      dockerOptions(c),
 			FirecrackerOptions: firecrackerOptions(c),
 			KubernetesOptions:  kubernetesOptions(c),
+			SandboxOptions:     sandboxOptions(c),
 		},
 		GitServicePath: "/.executors/git",
 		QueueOptions:   queueOptions(c, queueTelemetryOptions),
@@ -121,6 +122,15 @@ func firecrackerOptions(c *config.Config) runner.FirecrackerOptions {
 	}
 }
 
+func sandboxOptions(c *config.Config) runner.SandboxOptions {
+	return runner.SandboxOptions{
+		Enabled:        c.UseSandbox,
+		Runtime:        c.SandboxRuntime,
+		DockerOptions:  dockerOptions(c),
+		KeepWorkspaces: c.KeepWorkspaces,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
@@ -69,6 +69,17 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 		// output of ignite is not very parser friendly.
 	}
 
+	if conf.UseSandbox {
+		// Validate the tools to create the workspace block devices are installed.
+		if err = util.ValidateSandboxTools(runner); err != nil {
+			return err
+		}
+		// Validate the OCI runtime is registered with docker.
+		if err = util.ValidateSandboxRuntimeInstalled(cliCtx.Context, runner, conf.SandboxRuntime); err != nil {
+			return err
+		}
+	}
+
 	fmt.Print("All checks passed!\n")
 
 	return nil
@@ -2,7 +2,11 @@ package util
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // GetGitVersion returns the version of git installed on the host.
@@ -28,6 +32,24 @@ func GetDockerVersion(ctx context.Context, runner CmdRunner) (string, error) {
 	return execOutput(ctx, runner, "docker", "version", "-f", "{{.Server.Version}}")
 }
 
+// GetDockerRuntimes returns the names of the OCI runtimes registered with the docker daemon.
+func GetDockerRuntimes(ctx context.Context, runner CmdRunner) ([]string, error) {
+	out, err := execOutput(ctx, runner, "docker", "info", "-f", "{{json .Runtimes}}")
+	if err != nil {
+		return nil, err
+	}
+	var runtimes map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &runtimes); err != nil {
+		return nil, errors.Wrap(err, "failed to parse docker runtimes")
+	}
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // GetIgniteVersion returns the version of ignite installed on the host.
 func GetIgniteVersion(ctx context.Context, runner CmdRunner) (string, error) {
 	return execOutput(ctx, runner, "ignite", "version", "-o", "short")
@@ -141,6 +141,51 @@ func TestGetDockerVersion(t *testing.T) {
 	}
 }
 
+func TestGetDockerRuntimes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		exitStatus       int
+		stdout           string
+		expectedRuntimes []string
+		expectedErr      error
+	}{
+		{
+			name:             "Success",
+			stdout:           `{"runc":{"path":"runc"},"io.containerd.runc.v2":{"path":"runc"},"runsc":{"path":"/usr/local/bin/runsc"}}`,
+			expectedRuntimes: []string{"io.containerd.runc.v2", "runc", "runsc"},
+		},
+		{
+			name:        "Invalid output",
+			stdout:      "null}",
+			expectedErr: errors.New("failed to parse docker runtimes: invalid character '}' after top-level value"),
+		},
+		{
+			name:        "Error",
+			exitStatus:  1,
+			stdout:      "failed to get info",
+			expectedErr: errors.New("'docker info -f {{json .Runtimes}}': failed to get info: exit status 1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			runner.On("CombinedOutput", mock.Anything, "docker", []string{"info", "-f", "{{json .Runtimes}}"}).
+				Return(test.exitStatus, test.stdout)
+
+			runtimes, err := util.GetDockerRuntimes(context.Background(), runner)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, test.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expectedRuntimes, runtimes)
+			}
+		})
+	}
+}
+
 func TestGetIgniteVersion(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +123,41 @@ func ValidateFirecrackerTools(runner CmdRunner) error {
 	return nil
 }
 
+// ValidateSandboxTools validates that the tools required to run the sandbox runtime are installed.
+func ValidateSandboxTools(runner CmdRunner) error {
+	var missingTools []string
+	for _, tool := range config.RequiredCLIToolsSandbox {
+		if found, err := ExistsPath(runner, tool); err != nil {
+			return err
+		} else if !found {
+			missingTools = append(missingTools, tool)
+		}
+	}
+	if len(missingTools) > 0 {
+		return &ErrMissingTools{missingTools}
+	}
+	return nil
+}
+
+// ValidateSandboxRuntimeInstalled validates that the given OCI runtime is registered
+// with the docker daemon.
+func ValidateSandboxRuntimeInstalled(ctx context.Context, runner CmdRunner, runtime string) error {
+	runtimes, err := GetDockerRuntimes(ctx, runner)
+	if err != nil {
+		return errors.Wrap(err, "failed to list docker runtimes")
+	}
+	for _, r := range runtimes {
+		if r == runtime {
+			return nil
+		}
+	}
+	return errors.Newf(`Runtime %q is not registered with the docker daemon, found %v. Is it installed correctly?
+
+For gVisor, install runsc and register it with:
+  $ runsc install
+  $ systemctl reload docker`, runtime, runtimes)
+}
+
 // ValidateIgniteInstalled validates that ignite is installed to the host.
 func ValidateIgniteInstalled(ctx context.Context, runner CmdRunner) error {
 	if found, err := ExistsPath(runner, "ignite"); err != nil {
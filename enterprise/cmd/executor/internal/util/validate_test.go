@@ -428,6 +428,118 @@ func TestValidateFirecrackerTools(t *testing.T) {
 	}
 }
 
+func TestValidateSandboxTools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mockFunc    func(runner *fakeCmdRunner)
+		expectedErr error
+	}{
+		{
+			name: "Sandbox is valid",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "losetup").
+					Return("", nil)
+				runner.On("LookPath", "mkfs.ext4").
+					Return("", nil)
+				runner.On("LookPath", "mount").
+					Return("", nil)
+			},
+		},
+		{
+			name: "Losetup missing",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "losetup").
+					Return("", exec.ErrNotFound)
+				runner.On("LookPath", "mkfs.ext4").
+					Return("", nil)
+				runner.On("LookPath", "mount").
+					Return("", nil)
+			},
+			expectedErr: errors.New("losetup not found in PATH, is it installed?"),
+		},
+		{
+			name: "Losetup error",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "losetup").
+					Return("", errors.New("failed to find"))
+			},
+			expectedErr: errors.New("failed to find"),
+		},
+		{
+			name: "All missing",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "losetup").
+					Return("", exec.ErrNotFound)
+				runner.On("LookPath", "mkfs.ext4").
+					Return("", exec.ErrNotFound)
+				runner.On("LookPath", "mount").
+					Return("", exec.ErrNotFound)
+			},
+			expectedErr: errors.New("3 errors occurred:\n\t* losetup not found in PATH, is it installed?\n\t* mkfs.ext4 not found in PATH, is it installed?\n\t* mount not found in PATH, is it installed?"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			if test.mockFunc != nil {
+				test.mockFunc(runner)
+			}
+
+			err := util.ValidateSandboxTools(runner)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSandboxRuntimeInstalled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		exitStatus  int
+		stdout      string
+		expectedErr error
+	}{
+		{
+			name:   "Runtime registered",
+			stdout: `{"runc":{"path":"runc"},"runsc":{"path":"/usr/local/bin/runsc"}}`,
+		},
+		{
+			name:        "Runtime not registered",
+			stdout:      `{"runc":{"path":"runc"}}`,
+			expectedErr: errors.New("Runtime \"runsc\" is not registered with the docker daemon, found [runc]. Is it installed correctly?\n\nFor gVisor, install runsc and register it with:\n  $ runsc install\n  $ systemctl reload docker"),
+		},
+		{
+			name:        "Failed to list runtimes",
+			exitStatus:  1,
+			stdout:      "daemon not running",
+			expectedErr: errors.New("failed to list docker runtimes: 'docker info -f {{json .Runtimes}}': daemon not running: exit status 1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			runner.On("CombinedOutput", mock.Anything, "docker", []string{"info", "-f", "{{json .Runtimes}}"}).
+				Return(test.exitStatus, test.stdout)
+
+			err := util.ValidateSandboxRuntimeInstalled(context.Background(), runner, "runsc")
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateIgniteInstalled(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// NewSandboxSpec returns a spec that will run the given command in a one-shot
+// docker container using the given OCI runtime (e.g. gVisor's runsc). Like in
+// firecracker, the workspace is not bind mounted from the host file system:
+// the ext4 file system on the workspace block device is mounted into the
+// container at /data instead.
+func NewSandboxSpec(workspaceDevice string, runtime string, image string, scriptPath string, spec Spec, options DockerOptions) Spec {
+	return Spec{
+		Key:       spec.Key,
+		Command:   formatSandboxCommand(workspaceDevice, runtime, image, scriptPath, spec, options),
+		Operation: spec.Operation,
+	}
+}
+
+func formatSandboxCommand(workspaceDevice string, runtime string, image string, scriptPath string, spec Spec, options DockerOptions) []string {
+	return Flatten(
+		"docker",
+		dockerConfigFlag(options.ConfigPath),
+		"run",
+		"--rm",
+		sandboxRuntimeFlags(runtime),
+		dockerHostGatewayFlag(options.AddHostGateway),
+		dockerResourceFlags(options.Resources),
+		sandboxVolumeFlags(workspaceDevice),
+		dockerWorkingDirectoryFlags(spec.Dir),
+		dockerEnvFlags(spec.Env),
+		dockerEntrypointFlags,
+		image,
+		filepath.Join("/data", ScriptsPath, scriptPath),
+	)
+}
+
+func sandboxRuntimeFlags(runtime string) []string {
+	return []string{"--runtime", runtime}
+}
+
+// sandboxVolumeFlags mounts the file system on the given block device at /data
+// through an anonymous volume, which is removed together with the container.
+func sandboxVolumeFlags(workspaceDevice string) []string {
+	return []string{
+		"--mount",
+		fmt.Sprintf("type=volume,dst=/data,volume-driver=local,volume-opt=type=ext4,volume-opt=device=%s", workspaceDevice),
+	}
+}
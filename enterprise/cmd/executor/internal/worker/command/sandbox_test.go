@@ -0,0 +1,106 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+)
+
+func TestNewSandboxSpec(t *testing.T) {
+	tests := []struct {
+		name            string
+		workspaceDevice string
+		runtime         string
+		image           string
+		scriptPath      string
+		spec            command.Spec
+		options         command.DockerOptions
+		expectedSpec    command.Spec
+	}{
+		{
+			name:            "Converts to sandbox spec",
+			workspaceDevice: "/dev/loop1",
+			runtime:         "runsc",
+			image:           "some-image",
+			scriptPath:      "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"docker",
+					"run",
+					"--rm",
+					"--runtime",
+					"runsc",
+					"--mount",
+					"type=volume,dst=/data,volume-driver=local,volume-opt=type=ext4,volume-opt=device=/dev/loop1",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:            "Docker options",
+			workspaceDevice: "/dev/loop1",
+			runtime:         "runsc",
+			image:           "some-image",
+			scriptPath:      "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+			},
+			options: command.DockerOptions{
+				ConfigPath:     "/docker/config",
+				AddHostGateway: true,
+				Resources: command.ResourceOptions{
+					NumCPUs: 4,
+					Memory:  "12G",
+				},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"docker",
+					"--config",
+					"/docker/config",
+					"run",
+					"--rm",
+					"--runtime",
+					"runsc",
+					"--add-host=host.docker.internal:host-gateway",
+					"--cpus",
+					"4",
+					"--memory",
+					"12G",
+					"--mount",
+					"type=volume,dst=/data,volume-driver=local,volume-opt=type=ext4,volume-opt=device=/dev/loop1",
+					"-w",
+					"/data",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualSpec := command.NewSandboxSpec(test.workspaceDevice, test.runtime, test.image, test.scriptPath, test.spec, test.options)
+			assert.Equal(t, test.expectedSpec, actualSpec)
+		})
+	}
+}
@@ -97,6 +97,10 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 	// src-cli steps do not work in the new runtime environment.
 	// Remove this when native SSBC is complete.
 	if len(job.CliSteps) > 0 {
+		// src-cli runs on the host, which would defeat the purpose of the sandbox.
+		if h.options.RunnerOptions.SandboxOptions.Enabled {
+			return errors.New("src-cli steps are not supported by the sandbox runtime")
+		}
 		logger.Debug("Handling src-cli steps")
 		return h.handle(ctx, logger, commandLogger, job)
 	}
@@ -308,7 +312,7 @@ func (h *handler) prepareWorkspace(
 	job types.Job,
 	commandLogger command.Logger,
 ) (workspace.Workspace, error) {
-	if h.options.RunnerOptions.FirecrackerOptions.Enabled {
+	if h.options.RunnerOptions.FirecrackerOptions.Enabled || h.options.RunnerOptions.SandboxOptions.Enabled {
 		return workspace.NewFirecrackerWorkspace(
 			ctx,
 			h.filesStore,
@@ -201,6 +201,31 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 			},
 		},
+		{
+			name: "srcCli steps in sandbox",
+			options: Options{
+				RunnerOptions: runner.Options{
+					SandboxOptions: runner.SandboxOptions{Enabled: true, Runtime: "runsc"},
+				},
+			},
+			job: types.Job{
+				ID:             42,
+				RepositoryName: "my-repo",
+				Commit:         "cool-commit",
+				CliSteps: []types.CliStep{
+					{
+						Key:      "some-step",
+						Commands: []string{"echo", "hello"},
+					},
+				},
+			},
+			expectedErr: errors.New("src-cli steps are not supported by the sandbox runtime"),
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockFilesStore) {
+				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
+				require.Len(t, cmd.RunFunc.History(), 0)
+				require.Len(t, filesStore.GetFunc.History(), 0)
+			},
+		},
 		{
 			name:    "Success with srcCli steps default key",
 			options: Options{},
@@ -33,6 +33,16 @@ func NewDockerRunner(
 	options command.DockerOptions,
 	dockerAuthConfig types.DockerAuthConfig,
 ) Runner {
+	return newDockerRunner(cmd, logger, dir, options, dockerAuthConfig)
+}
+
+func newDockerRunner(
+	cmd command.Command,
+	logger command.Logger,
+	dir string,
+	options command.DockerOptions,
+	dockerAuthConfig types.DockerAuthConfig,
+) *dockerRunner {
 	// Use the option configuration unless the user has provided a custom configuration.
 	actualDockerAuthConfig := options.DockerAuthConfig
 	if len(dockerAuthConfig.Auths) > 0 {
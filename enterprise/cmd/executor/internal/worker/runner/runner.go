@@ -42,6 +42,7 @@ type Options struct {
 	DockerOptions      command.DockerOptions
 	FirecrackerOptions FirecrackerOptions
 	KubernetesOptions  KubernetesOptions
+	SandboxOptions     SandboxOptions
 }
 
 // NewRunner creates a new runner with the given options.
@@ -52,6 +53,10 @@ func NewRunner(cmd command.Command, dir, vmName string, logger command.Logger, o
 		return NewShellRunner(cmd, logger, dir, options.DockerOptions)
 	}
 
+	if options.SandboxOptions.Enabled {
+		return NewSandboxRunner(cmd, logger, dir, options.SandboxOptions, dockerAuthConfig)
+	}
+
 	if !options.FirecrackerOptions.Enabled {
 		return NewDockerRunner(cmd, logger, dir, options.DockerOptions, dockerAuthConfig)
 	}
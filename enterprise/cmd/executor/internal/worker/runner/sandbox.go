@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+type sandboxRunner struct {
+	cmd             command.Command
+	workspaceDevice string
+	commandLogger   command.Logger
+	options         SandboxOptions
+	// dockerRunner takes care of writing the docker auth config for the
+	// docker CLI on setup and removing it again on teardown.
+	dockerRunner *dockerRunner
+}
+
+type SandboxOptions struct {
+	// Enabled determines if commands will be run in sandboxed containers.
+	Enabled bool
+	// Runtime is the name of the OCI runtime registered with the docker daemon
+	// that containers are run with, e.g. runsc for gVisor.
+	Runtime string
+	// DockerOptions
+	DockerOptions command.DockerOptions
+	// KeepWorkspaces prevents deletion of a workspace after a job completes. Setting
+	// this value to true will continually use more and more disk, so it should only
+	// be used as a debugging mechanism.
+	KeepWorkspaces bool
+}
+
+var _ Runner = &sandboxRunner{}
+
+// NewSandboxRunner creates a runner that runs every step in a one-shot container
+// using the configured OCI runtime. The workspace is the block device created by
+// the firecracker workspace, which gets mounted into each container so that the
+// steps have no access to the host file system.
+func NewSandboxRunner(
+	cmd command.Command,
+	logger command.Logger,
+	workspaceDevice string,
+	options SandboxOptions,
+	dockerAuthConfig types.DockerAuthConfig,
+) Runner {
+	return &sandboxRunner{
+		cmd:             cmd,
+		workspaceDevice: workspaceDevice,
+		commandLogger:   logger,
+		options:         options,
+		dockerRunner:    newDockerRunner(cmd, logger, workspaceDevice, options.DockerOptions, dockerAuthConfig),
+	}
+}
+
+func (r *sandboxRunner) TempDir() string {
+	return r.dockerRunner.TempDir()
+}
+
+func (r *sandboxRunner) Setup(ctx context.Context) error {
+	return r.dockerRunner.Setup(ctx)
+}
+
+func (r *sandboxRunner) Teardown(ctx context.Context) error {
+	return r.dockerRunner.Teardown(ctx)
+}
+
+func (r *sandboxRunner) Run(ctx context.Context, spec Spec) error {
+	sandboxSpec := command.NewSandboxSpec(
+		r.workspaceDevice,
+		r.options.Runtime,
+		spec.Image,
+		spec.ScriptPath,
+		spec.CommandSpec,
+		// The docker runner sets the config path on setup.
+		r.dockerRunner.options,
+	)
+	return r.cmd.Run(ctx, r.commandLogger, sandboxSpec)
+}
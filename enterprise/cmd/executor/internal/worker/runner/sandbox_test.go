@@ -0,0 +1,106 @@
+package runner_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+func TestSandboxRunner_Setup(t *testing.T) {
+	options := runner.SandboxOptions{
+		Enabled: true,
+		Runtime: "runsc",
+	}
+	dockerAuthConfig := types.DockerAuthConfig{
+		Auths: map[string]types.DockerAuthConfigAuth{
+			"index.docker.io": {
+				Auth: []byte("foobar"),
+			},
+		},
+	}
+	sandboxRunner := runner.NewSandboxRunner(nil, nil, "/dev/loop1", options, dockerAuthConfig)
+
+	ctx := context.Background()
+	err := sandboxRunner.Setup(ctx)
+	require.NoError(t, err)
+
+	dir := sandboxRunner.TempDir()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	err = sandboxRunner.Teardown(ctx)
+	require.NoError(t, err)
+
+	_, err = os.Stat(dir)
+	require.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSandboxRunner_Run(t *testing.T) {
+	cmd := runner.NewMockCommand()
+	logger := runner.NewMockLogger()
+	options := runner.SandboxOptions{
+		Enabled: true,
+		Runtime: "runsc",
+		DockerOptions: command.DockerOptions{
+			ConfigPath: "/docker/config",
+			Resources: command.ResourceOptions{
+				NumCPUs:   10,
+				Memory:    "1G",
+				DiskSpace: "10G",
+			},
+		},
+	}
+	spec := runner.Spec{
+		CommandSpec: command.Spec{
+			Key:     "some-key",
+			Command: []string{"echo", "hello"},
+			Dir:     "/workingdir",
+			Env:     []string{"FOO=bar"},
+		},
+		Image:      "alpine",
+		ScriptPath: "/some/script",
+	}
+
+	sandboxRunner := runner.NewSandboxRunner(cmd, logger, "/dev/loop1", options, types.DockerAuthConfig{})
+
+	cmd.RunFunc.PushReturn(nil)
+
+	err := sandboxRunner.Run(context.Background(), spec)
+
+	require.NoError(t, err)
+
+	require.Len(t, cmd.RunFunc.History(), 1)
+	assert.Equal(t, "some-key", cmd.RunFunc.History()[0].Arg2.Key)
+	assert.Equal(t, []string{
+		"docker",
+		"--config",
+		"/docker/config",
+		"run",
+		"--rm",
+		"--runtime",
+		"runsc",
+		"--cpus",
+		"10",
+		"--memory",
+		"1G",
+		"--mount",
+		"type=volume,dst=/data,volume-driver=local,volume-opt=type=ext4,volume-opt=device=/dev/loop1",
+		"-w",
+		"/data/workingdir",
+		"-e",
+		"FOO=bar",
+		"--entrypoint",
+		"/bin/sh",
+		"alpine",
+		"/data/.sourcegraph-executor/some/script",
+	}, cmd.RunFunc.History()[0].Arg2.Command)
+}
@@ -87,6 +87,40 @@ func New(
 		}
 	}
 
+	if runnerOpts.SandboxOptions.Enabled {
+		// We explicitly want a sandbox runtime. So validation must pass.
+		if err := util.ValidateDockerTools(runner); err != nil {
+			var errMissingTools *util.ErrMissingTools
+			if errors.As(err, &errMissingTools) {
+				logger.Error("runtime 'sandbox' is not supported: missing required tools", log.Strings("dockerTools", errMissingTools.Tools))
+			} else {
+				logger.Error("failed to determine if docker tools are configured", log.Error(err))
+			}
+			return nil, err
+		} else if err = util.ValidateSandboxTools(runner); err != nil {
+			var errMissingTools *util.ErrMissingTools
+			if errors.As(err, &errMissingTools) {
+				logger.Error("runtime 'sandbox' is not supported: missing required tools", log.Strings("sandboxTools", errMissingTools.Tools))
+			} else {
+				logger.Error("failed to determine if sandbox tools are configured", log.Error(err))
+			}
+			return nil, err
+		} else if err = util.ValidateSandboxRuntimeInstalled(context.Background(), runner, runnerOpts.SandboxOptions.Runtime); err != nil {
+			logger.Error("runtime 'sandbox' is not supported: OCI runtime is not registered with docker", log.String("runtime", runnerOpts.SandboxOptions.Runtime), log.Error(err))
+			return nil, err
+		} else {
+			logger.Info("using runtime 'sandbox'", log.String("runtime", runnerOpts.SandboxOptions.Runtime))
+			return &sandboxRuntime{
+				cmdRunner:    runner,
+				cmd:          cmd,
+				operations:   ops,
+				filesStore:   filesStore,
+				cloneOptions: cloneOpts,
+				sandboxOpts:  runnerOpts.SandboxOptions,
+			}, nil
+		}
+	}
+
 	if runnerOpts.KubernetesOptions.Enabled {
 		configPath := runnerOpts.KubernetesOptions.ConfigPath
 		kubeConfig, err := clientcmd.BuildConfigFromFlags("", configPath)
@@ -146,6 +180,7 @@ const (
 	NameDocker      Name = "docker"
 	NameFirecracker Name = "firecracker"
 	NameKubernetes  Name = "kubernetes"
+	NameSandbox     Name = "sandbox"
 	NameShell       Name = "shell"
 )
 
@@ -155,7 +190,7 @@ func CommandKey(name Name, rawStepKey string, index int) string {
 	case NameKubernetes:
 		return kubernetesKey(rawStepKey, index)
 	default:
-		// shell, docker, and firecracker all use the same key format.
+		// shell, docker, firecracker, and sandbox all use the same key format.
 		return dockerKey(rawStepKey, index)
 	}
 }
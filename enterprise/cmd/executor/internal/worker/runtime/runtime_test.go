@@ -170,6 +170,77 @@ func TestNew(t *testing.T) {
 			},
 			expectedErr: errors.New("2 errors occurred:\n\t* Cannot find directory /opt/cni/bin. Are the CNI plugins for firecracker installed correctly?\n\t* Cannot find CNI plugins [bandwidth bridge firewall host-local isolation loopback portmap], are the CNI plugins for firecracker installed correctly?\nTo install the CNI plugins used by ignite run \"executor install cni\" or the following:\n  $ mkdir -p /opt/cni/bin\n  $ curl -sSL https://github.com/containernetworking/plugins/releases/download/v0.9.1/cni-plugins-linux-amd64-v0.9.1.tgz | tar -xz -C /opt/cni/bin\n  $ curl -sSL https://github.com/AkihiroSuda/cni-isolation/releases/download/v0.0.4/cni-isolation-amd64.tgz | tar -xz -C /opt/cni/bin"),
 		},
+		{
+			name: "Sandbox",
+			runnerOpts: runner.Options{
+				SandboxOptions: runner.SandboxOptions{
+					Enabled: true,
+					Runtime: "runsc",
+				},
+			},
+			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
+				// ValidateDockerTools + ValidateSandboxTools
+				cmdRunner.LookPathFunc.SetDefaultReturn("", nil)
+				// ValidateSandboxRuntimeInstalled (GetDockerRuntimes)
+				cmdRunner.CombinedOutputFunc.SetDefaultReturn([]byte(`{"runc":{"path":"runc"},"runsc":{"path":"/usr/local/bin/runsc"}}`), nil)
+			},
+			expectedName: runtime.NameSandbox,
+			assertMockFunc: func(t *testing.T, cmdRunner *runtime.MockCmdRunner) {
+				require.Len(t, cmdRunner.LookPathFunc.History(), 6)
+				assert.Equal(t, "docker", cmdRunner.LookPathFunc.History()[0].Arg0)
+				assert.Equal(t, "git", cmdRunner.LookPathFunc.History()[1].Arg0)
+				assert.Equal(t, "src", cmdRunner.LookPathFunc.History()[2].Arg0)
+				assert.Equal(t, "losetup", cmdRunner.LookPathFunc.History()[3].Arg0)
+				assert.Equal(t, "mkfs.ext4", cmdRunner.LookPathFunc.History()[4].Arg0)
+				assert.Equal(t, "mount", cmdRunner.LookPathFunc.History()[5].Arg0)
+
+				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 1)
+				assert.Equal(t, "docker", cmdRunner.CombinedOutputFunc.History()[0].Arg1)
+				assert.Equal(t, []string{"info", "-f", "{{json .Runtimes}}"}, cmdRunner.CombinedOutputFunc.History()[0].Arg2)
+			},
+		},
+		{
+			name: "Missing sandbox tools",
+			runnerOpts: runner.Options{
+				SandboxOptions: runner.SandboxOptions{
+					Enabled: true,
+					Runtime: "runsc",
+				},
+			},
+			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
+				// ValidateDockerTools
+				cmdRunner.LookPathFunc.PushReturn("", nil)
+				cmdRunner.LookPathFunc.PushReturn("", nil)
+				cmdRunner.LookPathFunc.PushReturn("", nil)
+				// ValidateSandboxTools
+				cmdRunner.LookPathFunc.SetDefaultReturn("", exec.ErrNotFound)
+			},
+			assertMockFunc: func(t *testing.T, cmdRunner *runtime.MockCmdRunner) {
+				require.Len(t, cmdRunner.LookPathFunc.History(), 6)
+				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
+			},
+			expectedErr: errors.New("3 errors occurred:\n\t* losetup not found in PATH, is it installed?\n\t* mkfs.ext4 not found in PATH, is it installed?\n\t* mount not found in PATH, is it installed?"),
+		},
+		{
+			name: "Sandbox runtime not registered",
+			runnerOpts: runner.Options{
+				SandboxOptions: runner.SandboxOptions{
+					Enabled: true,
+					Runtime: "runsc",
+				},
+			},
+			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
+				// ValidateDockerTools + ValidateSandboxTools
+				cmdRunner.LookPathFunc.SetDefaultReturn("", nil)
+				// ValidateSandboxRuntimeInstalled (GetDockerRuntimes)
+				cmdRunner.CombinedOutputFunc.SetDefaultReturn([]byte(`{"runc":{"path":"runc"}}`), nil)
+			},
+			assertMockFunc: func(t *testing.T, cmdRunner *runtime.MockCmdRunner) {
+				require.Len(t, cmdRunner.LookPathFunc.History(), 6)
+				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 1)
+			},
+			expectedErr: errors.New("Runtime \"runsc\" is not registered with the docker daemon, found [runc]. Is it installed correctly?\n\nFor gVisor, install runsc and register it with:\n  $ runsc install\n  $ systemctl reload docker"),
+		},
 		{
 			name: "No Runtime",
 			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
@@ -307,6 +378,13 @@ func TestCommandKey(t *testing.T) {
 			index:       1,
 			expectedKey: "step.kubernetes.1",
 		},
+		{
+			name:        "Sandbox",
+			runtimeName: runtime.NameSandbox,
+			key:         "step.1.pre",
+			index:       0,
+			expectedKey: "step.docker.step.1.pre",
+		},
 		{
 			name:        "Shell",
 			runtimeName: runtime.NameShell,
@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/util"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+type sandboxRuntime struct {
+	cmdRunner    util.CmdRunner
+	cmd          command.Command
+	operations   *command.Operations
+	filesStore   workspace.FilesStore
+	cloneOptions workspace.CloneOptions
+	sandboxOpts  runner.SandboxOptions
+}
+
+var _ Runtime = &sandboxRuntime{}
+
+func (r *sandboxRuntime) Name() Name {
+	return NameSandbox
+}
+
+func (r *sandboxRuntime) PrepareWorkspace(ctx context.Context, logger command.Logger, job types.Job) (workspace.Workspace, error) {
+	// The sandbox uses the same block device backed workspace as firecracker, so
+	// that the containers never get access to the host file system and the disk
+	// usage of a job is bounded.
+	return workspace.NewFirecrackerWorkspace(
+		ctx,
+		r.filesStore,
+		job,
+		r.sandboxOpts.DockerOptions.Resources.DiskSpace,
+		r.sandboxOpts.KeepWorkspaces,
+		r.cmdRunner,
+		r.cmd,
+		logger,
+		r.cloneOptions,
+		r.operations,
+	)
+}
+
+func (r *sandboxRuntime) NewRunner(ctx context.Context, logger command.Logger, options RunnerOptions) (runner.Runner, error) {
+	run := runner.NewSandboxRunner(r.cmd, logger, options.Path, r.sandboxOpts, options.DockerAuthConfig)
+	if err := run.Setup(ctx); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (r *sandboxRuntime) NewRunnerSpecs(ws workspace.Workspace, steps []types.DockerStep) ([]runner.Spec, error) {
+	runnerSpecs := make([]runner.Spec, len(steps))
+	for i, step := range steps {
+		runnerSpecs[i] = runner.Spec{
+			CommandSpec: command.Spec{
+				Key:       dockerKey(step.Key, i),
+				Command:   nil,
+				Dir:       step.Dir,
+				Env:       step.Env,
+				Operation: r.operations.Exec,
+			},
+			Image:      step.Image,
+			ScriptPath: ws.ScriptFilenames()[i],
+		}
+	}
+
+	return runnerSpecs, nil
+}
@@ -0,0 +1,39 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type autoMergeBlockedChangesetResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	changeset       *btypes.Changeset
+	repo            *types.Repo
+	reason          string
+	updatedAt       time.Time
+}
+
+var _ graphqlbackend.BatchChangeAutoMergeBlockedChangesetResolver = &autoMergeBlockedChangesetResolver{}
+
+func (r *autoMergeBlockedChangesetResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return NewChangesetResolver(r.store, r.gitserverClient, r.changeset, r.repo)
+}
+
+func (r *autoMergeBlockedChangesetResolver) Reason() *string {
+	// We only show the reason when the changeset is visible to the requesting user.
+	if r.repo == nil {
+		return nil
+	}
+	return &r.reason
+}
+
+func (r *autoMergeBlockedChangesetResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.updatedAt}
+}
@@ -0,0 +1,198 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type autoMergePolicyResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	policy          *btypes.AutoMergePolicy
+}
+
+var _ graphqlbackend.BatchChangeAutoMergePolicyResolver = &autoMergePolicyResolver{}
+
+func (r *autoMergePolicyResolver) MergeMethod() string {
+	return string(r.policy.MergeMethod)
+}
+
+func (r *autoMergePolicyResolver) RequiredReviewState() *string {
+	if r.policy.RequiredReviewState == "" {
+		return nil
+	}
+	state := string(r.policy.RequiredReviewState)
+	return &state
+}
+
+func (r *autoMergePolicyResolver) RequiredChecks() string {
+	return string(r.policy.RequiredChecks)
+}
+
+func (r *autoMergePolicyResolver) MergeWindowDays() []string {
+	if r.policy.MergeWindowDays == nil {
+		return []string{}
+	}
+	return r.policy.MergeWindowDays
+}
+
+func (r *autoMergePolicyResolver) MergeWindowStart() *string {
+	if r.policy.MergeWindowStart == "" {
+		return nil
+	}
+	return &r.policy.MergeWindowStart
+}
+
+func (r *autoMergePolicyResolver) MergeWindowEnd() *string {
+	if r.policy.MergeWindowEnd == "" {
+		return nil
+	}
+	return &r.policy.MergeWindowEnd
+}
+
+func (r *autoMergePolicyResolver) MaxMergesPerHour() int32 {
+	return r.policy.MaxMergesPerHour
+}
+
+func (r *autoMergePolicyResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.policy.UserID)
+}
+
+func (r *autoMergePolicyResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.policy.CreatedAt}
+}
+
+func (r *autoMergePolicyResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.policy.UpdatedAt}
+}
+
+func (r *autoMergePolicyResolver) BlockedChangesets(ctx context.Context, args *graphqlbackend.ListAutoMergeBlockedChangesetsArgs) (graphqlbackend.BatchChangeAutoMergeBlockedChangesetConnectionResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListAutoMergeBlockersOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+		BatchChangeID: r.policy.BatchChangeID,
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &autoMergeBlockedChangesetConnectionResolver{
+		store:           r.store,
+		gitserverClient: r.gitserverClient,
+		opts:            opts,
+	}, nil
+}
+
+type autoMergeBlockedChangesetConnectionResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	opts            store.ListAutoMergeBlockersOpts
+
+	// Cache results because they are used by multiple fields
+	once     sync.Once
+	blockers []*btypes.AutoMergeBlocker
+	next     int64
+	err      error
+}
+
+var _ graphqlbackend.BatchChangeAutoMergeBlockedChangesetConnectionResolver = &autoMergeBlockedChangesetConnectionResolver{}
+
+func (r *autoMergeBlockedChangesetConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountAutoMergeBlockers(ctx, r.opts.BatchChangeID)
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *autoMergeBlockedChangesetConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *autoMergeBlockedChangesetConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeAutoMergeBlockedChangesetResolver, error) {
+	blockers, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changesetIDs := make([]int64, 0, len(blockers))
+	for _, b := range blockers {
+		changesetIDs = append(changesetIDs, b.ChangesetID)
+	}
+
+	changesetsByID := map[int64]*btypes.Changeset{}
+	reposByID := map[api.RepoID]*types.Repo{}
+	if len(changesetIDs) > 0 {
+		// Load all changesets and repos at once, to avoid N+1 queries.
+		changesets, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{IDs: changesetIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range changesets {
+			changesetsByID[ch.ID] = ch
+		}
+		// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		reposByID, err = r.store.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeAutoMergeBlockedChangesetResolver, 0, len(blockers))
+	for _, b := range blockers {
+		ch, ok := changesetsByID[b.ChangesetID]
+		if !ok {
+			// The changeset has been deleted since the policy was evaluated.
+			continue
+		}
+		repo, accessible := reposByID[ch.RepoID]
+		resolver := &autoMergeBlockedChangesetResolver{
+			store:           r.store,
+			gitserverClient: r.gitserverClient,
+			changeset:       ch,
+			repo:            repo,
+			updatedAt:       b.UpdatedAt,
+		}
+		if accessible {
+			resolver.reason = b.Reason
+		}
+		resolvers = append(resolvers, resolver)
+	}
+	return resolvers, nil
+}
+
+func (r *autoMergeBlockedChangesetConnectionResolver) compute(ctx context.Context) ([]*btypes.AutoMergeBlocker, int64, error) {
+	r.once.Do(func() {
+		r.blockers, r.next, r.err = r.store.ListAutoMergeBlockers(ctx, r.opts)
+	})
+
+	return r.blockers, r.next, r.err
+}
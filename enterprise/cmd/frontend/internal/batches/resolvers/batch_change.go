@@ -354,3 +354,15 @@ func (r *batchChangeResolver) BatchSpecs(
 
 	return &batchSpecConnectionResolver{store: r.store, opts: opts}, nil
 }
+
+func (r *batchChangeResolver) AutoMergePolicy(ctx context.Context) (graphqlbackend.BatchChangeAutoMergePolicyResolver, error) {
+	policy, err := r.store.GetAutoMergePolicy(ctx, r.batchChange.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &autoMergePolicyResolver{store: r.store, gitserverClient: r.gitserverClient, policy: policy}, nil
+}
@@ -0,0 +1,175 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+type batchSpecDryRunResultResolver struct {
+	result     *btypes.BatchSpecDryRunResult
+	workspaces []graphqlbackend.BatchSpecDryRunWorkspaceResolver
+	skipped    []graphqlbackend.BatchSpecDryRunSkippedWorkspaceResolver
+}
+
+var _ graphqlbackend.BatchSpecDryRunResultResolver = &batchSpecDryRunResultResolver{}
+
+func newBatchSpecDryRunResultResolver(ctx context.Context, s *store.Store, result *btypes.BatchSpecDryRunResult) (*batchSpecDryRunResultResolver, error) {
+	repoIDs := make([]api.RepoID, 0, len(result.Workspaces)+len(result.Skipped))
+	for _, w := range result.Workspaces {
+		repoIDs = append(repoIDs, w.RepoID)
+	}
+	for _, w := range result.Skipped {
+		repoIDs = append(repoIDs, w.RepoID)
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to. The result was
+	// computed with the permissions of the user who created the batch spec, so we omit
+	// workspaces in repositories that are not visible to the viewer.
+	repos, err := s.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.DatabaseDB()
+	gitserverClient := gitserver.NewClient()
+
+	r := &batchSpecDryRunResultResolver{
+		result:     result,
+		workspaces: []graphqlbackend.BatchSpecDryRunWorkspaceResolver{},
+		skipped:    []graphqlbackend.BatchSpecDryRunSkippedWorkspaceResolver{},
+	}
+	for _, w := range result.Workspaces {
+		repo, ok := repos[w.RepoID]
+		if !ok {
+			continue
+		}
+		r.workspaces = append(r.workspaces, &batchSpecDryRunWorkspaceResolver{
+			workspace:    w,
+			repoResolver: graphqlbackend.NewRepositoryResolver(db, gitserverClient, repo),
+		})
+	}
+	for _, w := range result.Skipped {
+		repo, ok := repos[w.RepoID]
+		if !ok {
+			continue
+		}
+		r.skipped = append(r.skipped, &batchSpecDryRunSkippedWorkspaceResolver{
+			workspace:    w,
+			repoResolver: graphqlbackend.NewRepositoryResolver(db, gitserverClient, repo),
+		})
+	}
+
+	return r, nil
+}
+
+func (r *batchSpecDryRunResultResolver) Outcome() string {
+	return string(r.result.Outcome)
+}
+
+func (r *batchSpecDryRunResultResolver) Workspaces() []graphqlbackend.BatchSpecDryRunWorkspaceResolver {
+	return r.workspaces
+}
+
+func (r *batchSpecDryRunResultResolver) Skipped() []graphqlbackend.BatchSpecDryRunSkippedWorkspaceResolver {
+	return r.skipped
+}
+
+func (r *batchSpecDryRunResultResolver) MaxChangesets() int32 {
+	return int32(r.result.MaxChangesets)
+}
+
+type batchSpecDryRunWorkspaceResolver struct {
+	workspace    *btypes.BatchSpecDryRunWorkspace
+	repoResolver *graphqlbackend.RepositoryResolver
+}
+
+var _ graphqlbackend.BatchSpecDryRunWorkspaceResolver = &batchSpecDryRunWorkspaceResolver{}
+
+func (r *batchSpecDryRunWorkspaceResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repoResolver
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) Branch() string {
+	return r.workspace.Branch
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) Path() string {
+	return r.workspace.Path
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) FileMatches() []string {
+	if r.workspace.FileMatches == nil {
+		return []string{}
+	}
+	return r.workspace.FileMatches
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) Steps() []int32 {
+	steps := make([]int32, 0, len(r.workspace.Steps))
+	for _, s := range r.workspace.Steps {
+		steps = append(steps, int32(s))
+	}
+	return steps
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) Files() []graphqlbackend.BatchSpecDryRunStepFileResolver {
+	files := make([]graphqlbackend.BatchSpecDryRunStepFileResolver, 0, len(r.workspace.Files))
+	for _, f := range r.workspace.Files {
+		files = append(files, &batchSpecDryRunStepFileResolver{file: f})
+	}
+	return files
+}
+
+func (r *batchSpecDryRunWorkspaceResolver) MaxChangesets() int32 {
+	return int32(r.workspace.MaxChangesets)
+}
+
+type batchSpecDryRunStepFileResolver struct {
+	file *btypes.BatchSpecDryRunStepFile
+}
+
+var _ graphqlbackend.BatchSpecDryRunStepFileResolver = &batchSpecDryRunStepFileResolver{}
+
+func (r *batchSpecDryRunStepFileResolver) Step() int32 {
+	return int32(r.file.Step)
+}
+
+func (r *batchSpecDryRunStepFileResolver) Path() string {
+	return r.file.Path
+}
+
+func (r *batchSpecDryRunStepFileResolver) Content() *string {
+	return r.file.Content
+}
+
+type batchSpecDryRunSkippedWorkspaceResolver struct {
+	workspace    *btypes.BatchSpecDryRunSkippedWorkspace
+	repoResolver *graphqlbackend.RepositoryResolver
+}
+
+var _ graphqlbackend.BatchSpecDryRunSkippedWorkspaceResolver = &batchSpecDryRunSkippedWorkspaceResolver{}
+
+func (r *batchSpecDryRunSkippedWorkspaceResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repoResolver
+}
+
+func (r *batchSpecDryRunSkippedWorkspaceResolver) Branch() *string {
+	if r.workspace.Branch == "" {
+		return nil
+	}
+	return &r.workspace.Branch
+}
+
+func (r *batchSpecDryRunSkippedWorkspaceResolver) Path() string {
+	return r.workspace.Path
+}
+
+func (r *batchSpecDryRunSkippedWorkspaceResolver) Reason() string {
+	return string(r.workspace.Reason)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -117,13 +118,25 @@ func (r *batchSpecWorkspaceResolver) SearchResultPaths() []string {
 	return r.workspace.FileMatches
 }
 
+// templateRepository returns the repository of the workspace as it's available
+// in batch spec templates.
+func (r *batchSpecWorkspaceResolver) templateRepository() template.Repository {
+	return template.Repository{
+		Name:           r.repoResolver.Name(),
+		Branch:         r.workspace.Branch,
+		FileMatches:    r.workspace.FileMatches,
+		Metadata:       r.workspace.RepositoryMetadata,
+		MetadataLoaded: true,
+	}
+}
+
 func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.BatchSpecWorkspaceStepResolver, error) {
 	if _, ok := r.ToHiddenBatchSpecWorkspace(); ok {
 		return nil, nil
 	}
 
 	if r.execution != nil && r.execution.Version == 2 {
-		skippedSteps, err := batcheslib.SkippedStepsForRepo(r.batchSpec, r.repoResolver.Name(), r.workspace.FileMatches)
+		skippedSteps, err := batcheslib.SkippedStepsForRepo(r.batchSpec, r.templateRepository())
 		if err != nil {
 			return nil, err
 		}
@@ -217,7 +230,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 		}
 	}
 
-	skippedSteps, err := batcheslib.SkippedStepsForRepo(r.batchSpec, r.repoResolver.Name(), r.workspace.FileMatches)
+	skippedSteps, err := batcheslib.SkippedStepsForRepo(r.batchSpec, r.templateRepository())
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +62,18 @@ func (r *batchSpecWorkspaceResolutionResolver) RecentlyErrored(ctx context.Conte
 	return nil
 }
 
+func (r *batchSpecWorkspaceResolutionResolver) DryRun() bool {
+	return r.resolution.DryRun
+}
+
+func (r *batchSpecWorkspaceResolutionResolver) DryRunResult(ctx context.Context) (graphqlbackend.BatchSpecDryRunResultResolver, error) {
+	if r.resolution.DryRunResult == nil {
+		return nil, nil
+	}
+
+	return newBatchSpecDryRunResultResolver(ctx, r.store, r.resolution.DryRunResult)
+}
+
 func workspacesListArgsToDBOpts(args *graphqlbackend.ListWorkspacesArgs) (opts store.ListBatchSpecWorkspacesOpts, err error) {
 	if err := validateFirstParamDefaults(args.First); err != nil {
 		return opts, err
@@ -124,6 +124,12 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "CLOSE", nil
 	case btypes.ChangesetJobTypePublish:
 		return "PUBLISH", nil
+	case btypes.ChangesetJobTypeUpdateBranch:
+		return "UPDATE_BRANCH", nil
+	case btypes.ChangesetJobTypeSetReviewersAndLabels:
+		return "SET_REVIEWERS_AND_LABELS", nil
+	case btypes.ChangesetJobTypeRerunFailedChecks:
+		return "RERUN_FAILED_CHECKS", nil
 	default:
 		return "", errors.Errorf("invalid job type %q", t)
 	}
@@ -370,6 +370,17 @@ func (r *changesetResolver) CheckState() *string {
 	return &checkState
 }
 
+func (r *changesetResolver) Checks() []graphqlbackend.ChangesetCheckResolver {
+	resolvers := make([]graphqlbackend.ChangesetCheckResolver, 0, len(r.changeset.ExternalChecks))
+	if !r.changeset.Published() {
+		return resolvers
+	}
+	for _, c := range r.changeset.ExternalChecks {
+		resolvers = append(resolvers, &changesetCheckResolver{check: c})
+	}
+	return resolvers
+}
+
 // Error: `FailureMessage` is set by the reconciler worker if it fails when processing
 // a changeset job. However, for most reconciler operations, we automatically retry the
 // operation a number of times. When the reconciler worker picks up a failed changeset job
@@ -554,3 +565,53 @@ func (r *changesetLabelResolver) Description() *string {
 	}
 	return &r.label.Description
 }
+
+type changesetCheckResolver struct {
+	check btypes.ChangesetCheck
+}
+
+func (r *changesetCheckResolver) Name() string {
+	return r.check.Name
+}
+
+func (r *changesetCheckResolver) State() *string {
+	if r.check.State == btypes.ChangesetCheckStateUnknown || !r.check.State.Valid() {
+		return nil
+	}
+	state := string(r.check.State)
+	return &state
+}
+
+func (r *changesetCheckResolver) URL() *string {
+	if r.check.URL == "" {
+		return nil
+	}
+	return &r.check.URL
+}
+
+func (r *changesetCheckResolver) StartedAt() *gqlutil.DateTime {
+	if r.check.StartedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.check.StartedAt}
+}
+
+func (r *changesetCheckResolver) CompletedAt() *gqlutil.DateTime {
+	if r.check.CompletedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.check.CompletedAt}
+}
+
+func (r *changesetCheckResolver) Duration() *int32 {
+	d := r.check.Duration()
+	if d == 0 {
+		return nil
+	}
+	seconds := int32(d.Seconds())
+	return &seconds
+}
+
+func (r *changesetCheckResolver) Rerunnable() bool {
+	return r.check.ExternalID != ""
+}
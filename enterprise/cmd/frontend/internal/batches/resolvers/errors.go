@@ -86,6 +86,16 @@ func (e ErrApplyClosedBatchChange) Extensions() map[string]any {
 	return map[string]any{"code": "ErrApplyClosedBatchChange"}
 }
 
+type ErrApplyDryRunBatchSpec struct{}
+
+func (e ErrApplyDryRunBatchSpec) Error() string {
+	return "batch spec was resolved in a dry-run and cannot be applied"
+}
+
+func (e ErrApplyDryRunBatchSpec) Extensions() map[string]any {
+	return map[string]any{"code": "ErrApplyDryRunBatchSpec"}
+}
+
 type ErrMatchingBatchChangeExists struct{}
 
 func (e ErrMatchingBatchChangeExists) Error() string {
@@ -2693,6 +2693,120 @@ mutation($batchChange: ID!, $changesets: [ID!]!, $draft: Boolean!) {
 }
 `
 
+func TestSetBatchChangeAutoMergePolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	userID := bt.CreateTestUser(t, db, true).ID
+	// We give this user the `BATCH_CHANGES#WRITE` permission so they're authorized
+	// to configure Batch Changes.
+	assignBatchChangesWritePermissionToUser(ctx, t, db, userID)
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-auto-merge", userID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, bstore, "test-auto-merge", userID, batchSpec.ID)
+
+	r := &Resolver{store: bstore}
+	s, err := newSchema(db, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchChangeAPIID := string(bgql.MarshalBatchChangeID(batchChange.ID))
+
+	type policy struct {
+		MergeMethod         string
+		RequiredReviewState *string
+		RequiredChecks      string
+		MergeWindowDays     []string
+		MergeWindowStart    *string
+		MergeWindowEnd      *string
+		MaxMergesPerHour    int32
+	}
+
+	t.Run("set", func(t *testing.T) {
+		input := map[string]any{
+			"batchChange":         batchChangeAPIID,
+			"mergeMethod":         "SQUASH",
+			"requiredReviewState": "APPROVED",
+			"mergeWindowDays":     []string{"monday", "tuesday"},
+			"mergeWindowStart":    "09:00",
+			"mergeWindowEnd":      "17:00",
+			"maxMergesPerHour":    5,
+		}
+		var response struct {
+			SetBatchChangeAutoMergePolicy struct{ AutoMergePolicy *policy }
+		}
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationSetBatchChangeAutoMergePolicy)
+
+		approved := "APPROVED"
+		start, end := "09:00", "17:00"
+		want := &policy{
+			MergeMethod:         "SQUASH",
+			RequiredReviewState: &approved,
+			RequiredChecks:      "PASSED",
+			MergeWindowDays:     []string{"monday", "tuesday"},
+			MergeWindowStart:    &start,
+			MergeWindowEnd:      &end,
+			MaxMergesPerHour:    5,
+		}
+		if diff := cmp.Diff(want, response.SetBatchChangeAutoMergePolicy.AutoMergePolicy); diff != "" {
+			t.Fatalf("unexpected policy (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid merge window", func(t *testing.T) {
+		input := map[string]any{
+			"batchChange":      batchChangeAPIID,
+			"mergeWindowStart": "09:00",
+		}
+		var response struct{}
+		errs := apitest.Exec(actorCtx, t, s, input, &response, mutationSetBatchChangeAutoMergePolicy)
+		if len(errs) == 0 {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		input := map[string]any{"batchChange": batchChangeAPIID}
+		var response struct{}
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationDeleteBatchChangeAutoMergePolicy)
+
+		if _, err := bstore.GetAutoMergePolicy(ctx, batchChange.ID); err != store.ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, store.ErrNoResults)
+		}
+	})
+}
+
+const mutationSetBatchChangeAutoMergePolicy = `
+mutation($batchChange: ID!, $mergeMethod: BatchChangeAutoMergeMethod, $requiredReviewState: ChangesetReviewState, $requiredChecks: BatchChangeAutoMergeRequiredChecks, $mergeWindowDays: [String!], $mergeWindowStart: String, $mergeWindowEnd: String, $maxMergesPerHour: Int) {
+	setBatchChangeAutoMergePolicy(batchChange: $batchChange, mergeMethod: $mergeMethod, requiredReviewState: $requiredReviewState, requiredChecks: $requiredChecks, mergeWindowDays: $mergeWindowDays, mergeWindowStart: $mergeWindowStart, mergeWindowEnd: $mergeWindowEnd, maxMergesPerHour: $maxMergesPerHour) {
+		autoMergePolicy {
+			mergeMethod
+			requiredReviewState
+			requiredChecks
+			mergeWindowDays
+			mergeWindowStart
+			mergeWindowEnd
+			maxMergesPerHour
+		}
+	}
+}
+`
+
+const mutationDeleteBatchChangeAutoMergePolicy = `
+mutation($batchChange: ID!) {
+	deleteBatchChangeAutoMergePolicy(batchChange: $batchChange) { alwaysNil }
+}
+`
+
 func TestCheckBatchChangesCredential(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -10,7 +10,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	autoindexinggraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/transport/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/searchbased"
 	codenavgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/transport/graphql"
+	codenavlsp "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/transport/lsp"
 	policiesgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/transport/graphql"
 	sentinelgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
@@ -22,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
 )
 
 func LoadConfig() {
@@ -72,9 +75,19 @@ func Init(
 		preciseIndexResolverFactory,
 	)
 
+	searcher := newSearcher(db, enterpriseServices.EnterpriseSearchJobs)
+	searchBasedSvc := searchbased.NewService(
+		observationCtx,
+		searcher,
+		symbols.DefaultClient,
+		codeIntelServices.RankingService,
+		codeIntelServices.GitserverClient,
+	)
+
 	codenavRootResolver, err := codenavgraphql.NewRootResolver(
 		scopedContext("codenav"),
 		codeIntelServices.CodenavService,
+		searchBasedSvc,
 		codeIntelServices.AutoIndexingService,
 		codeIntelServices.GitserverClient,
 		siteAdminChecker,
@@ -90,6 +103,18 @@ func Init(
 		return err
 	}
 
+	lspHandler, err := codenavlsp.NewHandler(
+		codeIntelServices.CodenavService,
+		db,
+		codeIntelServices.GitserverClient,
+		searcher,
+		ConfigInst.MaximumIndexesPerMonikerSearch,
+		ConfigInst.HunkCacheSize,
+	)
+	if err != nil {
+		return err
+	}
+
 	policyRootResolver := policiesgraphql.NewRootResolver(
 		scopedContext("policies"),
 		codeIntelServices.PoliciesService,
@@ -125,6 +150,7 @@ func Init(
 		sentinelRootResolver,
 	))
 	enterpriseServices.NewCodeIntelUploadHandler = newUploadHandler
+	enterpriseServices.NewCodeIntelLSPHandler = func() http.Handler { return lspHandler }
 	enterpriseServices.RankingService = codeIntelServices.RankingService
 	return nil
 }
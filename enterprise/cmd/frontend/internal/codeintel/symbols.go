@@ -0,0 +1,81 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// searcher runs the searches of the code navigation LSP endpoint's workspace symbol requests
+// and of search-based references.
+type searcher struct {
+	db           database.DB
+	searchClient client.SearchClient
+}
+
+func newSearcher(db database.DB, enterpriseJobs jobutil.EnterpriseJobs) *searcher {
+	logger := log.Scoped("codenav.searcher", "")
+	return &searcher{
+		db:           db,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), enterpriseJobs),
+	}
+}
+
+func (s *searcher) SearchSymbols(ctx context.Context, query string) ([]*result.SymbolMatch, error) {
+	fileMatches, err := s.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []*result.SymbolMatch
+	for _, fileMatch := range fileMatches {
+		symbols = append(symbols, fileMatch.Symbols...)
+	}
+
+	return symbols, nil
+}
+
+func (s *searcher) Search(ctx context.Context, query string) ([]*result.FileMatch, error) {
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	patternType := "literal"
+	inputs, err := s.searchClient.Plan(
+		ctx,
+		"",
+		&patternType,
+		query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := streaming.NewAggregatingStream()
+	if _, err := s.searchClient.Execute(ctx, stream, inputs); err != nil {
+		return nil, err
+	}
+
+	var fileMatches []*result.FileMatch
+	for _, match := range stream.Results {
+		if fileMatch, ok := match.(*result.FileMatch); ok {
+			fileMatches = append(fileMatches, fileMatch)
+		}
+	}
+
+	return fileMatches, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -33,6 +34,25 @@ func insertTestUser(t *testing.T, db database.DB, name string, isAdmin bool) *ty
 	return u
 }
 
+// grantCodeMonitorsWritePermission assigns the CODE_MONITORS#WRITE permission to the
+// USER system role, mirroring what happens on startup.
+func grantCodeMonitorsWritePermission(t *testing.T, db database.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	namespace, action, err := rbac.ParsePermissionDisplayName(rbac.CodeMonitorsWritePermission)
+	require.NoError(t, err)
+
+	perm, err := db.Permissions().Create(ctx, database.CreatePermissionOpts{Namespace: namespace, Action: action})
+	require.NoError(t, err)
+
+	err = db.RolePermissions().BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
+		Roles:        []types.SystemRole{types.UserSystemRole, types.SiteAdministratorSystemRole},
+		PermissionID: perm.ID,
+	})
+	require.NoError(t, err)
+}
+
 func addUserToOrg(t *testing.T, db database.DB, userID int32, orgID int32) {
 	t.Helper()
 
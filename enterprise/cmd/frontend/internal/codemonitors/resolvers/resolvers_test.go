@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -31,6 +32,7 @@ func TestCreateCodeMonitor(t *testing.T) {
 	ctx := actor.WithInternalActor(context.Background())
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantCodeMonitorsWritePermission(t, db)
 	r := newTestResolver(t, db)
 
 	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
@@ -72,6 +74,19 @@ func TestCreateCodeMonitor(t *testing.T) {
 
 	})
 
+	t.Run("missing permission", func(t *testing.T) {
+		noPermUser := insertTestUser(t, db, "cm-user2", false)
+		err := db.UserRoles().RevokeSystemRole(ctx, database.RevokeSystemRoleOpts{UserID: noPermUser.ID, Role: types.UserSystemRole})
+		require.NoError(t, err)
+
+		noPermCtx := actor.WithActor(context.Background(), actor.FromUser(noPermUser.ID))
+		_, err = r.CreateCodeMonitor(noPermCtx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: relay.MarshalID("User", noPermUser.ID)},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+		})
+		require.ErrorAs(t, err, new(*rbac.ErrNotAuthorized))
+	})
+
 	t.Run("invalid slack webhook", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -108,6 +123,7 @@ func TestListCodeMonitors(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := actor.WithInternalActor(context.Background())
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantCodeMonitorsWritePermission(t, db)
 	r := newTestResolver(t, db)
 
 	user := insertTestUser(t, db, "cm-user1", true)
@@ -154,6 +170,7 @@ func TestListCodeMonitors(t *testing.T) {
 func TestIsAllowedToEdit(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantCodeMonitorsWritePermission(t, db)
 
 	// Setup users and org
 	owner := insertTestUser(t, db, "cm-user1", false)
@@ -285,6 +302,7 @@ func TestQueryMonitor(t *testing.T) {
 
 	ctx := actor.WithInternalActor(context.Background())
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantCodeMonitorsWritePermission(t, db)
 	r := newTestResolver(t, db)
 
 	// Create 2 test users.
@@ -664,6 +682,7 @@ func TestEditCodeMonitor(t *testing.T) {
 
 	ctx := actor.WithInternalActor(context.Background())
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantCodeMonitorsWritePermission(t, db)
 	r := newTestResolver(t, db)
 
 	// Create 2 test users.
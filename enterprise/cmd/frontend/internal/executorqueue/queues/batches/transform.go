@@ -116,6 +116,7 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		OnlyFetchWorkspace: workspace.OnlyFetchWorkspace,
 		Steps:              batchSpec.Spec.Steps,
 		SearchResultPaths:  workspace.FileMatches,
+		RepositoryMetadata: workspace.RepositoryMetadata,
 		BatchChangeAttributes: template.BatchChangeAttributes{
 			Name:        batchSpec.Spec.Name,
 			Description: batchSpec.Spec.Description,
@@ -141,7 +142,13 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		}
 	}
 
-	skipped, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), workspace.FileMatches)
+	skipped, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, template.Repository{
+		Name:           string(repo.Name),
+		Branch:         workspace.Branch,
+		FileMatches:    workspace.FileMatches,
+		Metadata:       workspace.RepositoryMetadata,
+		MetadataLoaded: true,
+	})
 	if err != nil {
 		return apiclient.Job{}, err
 	}
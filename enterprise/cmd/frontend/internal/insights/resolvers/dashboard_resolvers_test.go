@@ -1,11 +1,18 @@
 package resolvers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/graph-gophers/graphql-go/relay"
 )
@@ -116,3 +123,33 @@ func TestHasPermissionForGrants(t *testing.T) {
 		}
 	})
 }
+
+func TestInsightsDashboardWritePermission(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&itypes.User{ID: 1}, nil)
+
+	permissions := database.NewMockPermissionStore()
+	permissions.GetPermissionForUserFunc.SetDefaultReturn(nil, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	r := &Resolver{baseInsightResolver: baseInsightResolver{postgresDB: db}}
+	wantErr := &rbac.ErrNotAuthorized{Permission: rbac.CodeInsightsWritePermission}
+
+	_, err := r.CreateInsightsDashboard(ctx, &graphqlbackend.CreateInsightsDashboardArgs{})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = r.DeleteInsightsDashboard(ctx, &graphqlbackend.DeleteInsightsDashboardArgs{})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = r.DeleteInsightView(ctx, &graphqlbackend.DeleteInsightViewArgs{})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+}
@@ -19,10 +19,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/scheduler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -251,8 +254,9 @@ func (i *insightViewResolver) TimeScope(ctx context.Context) (graphqlbackend.Ins
 
 	return &insightTimeScopeUnionResolver{
 		resolver: &insightIntervalTimeScopeResolver{
-			unit:  i.view.Series[0].SampleIntervalUnit,
-			value: int32(i.view.Series[0].SampleIntervalValue),
+			unit:      i.view.Series[0].SampleIntervalUnit,
+			value:     int32(i.view.Series[0].SampleIntervalValue),
+			startDate: i.view.Series[0].BackfillStartAt,
 		},
 	}, nil
 }
@@ -327,8 +331,9 @@ func (s *searchInsightDataSeriesDefinitionResolver) RepositoryDefinition(ctx con
 
 func (s *searchInsightDataSeriesDefinitionResolver) TimeScope(ctx context.Context) (graphqlbackend.InsightTimeScope, error) {
 	intervalResolver := &insightIntervalTimeScopeResolver{
-		unit:  s.series.SampleIntervalUnit,
-		value: int32(s.series.SampleIntervalValue),
+		unit:      s.series.SampleIntervalUnit,
+		value:     int32(s.series.SampleIntervalValue),
+		startDate: s.series.BackfillStartAt,
 	}
 
 	return &insightTimeScopeUnionResolver{resolver: intervalResolver}, nil
@@ -347,8 +352,9 @@ func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 }
 
 type insightIntervalTimeScopeResolver struct {
-	unit  string
-	value int32
+	unit      string
+	value     int32
+	startDate *time.Time
 }
 
 func (i *insightIntervalTimeScopeResolver) Unit(ctx context.Context) (string, error) {
@@ -359,6 +365,10 @@ func (i *insightIntervalTimeScopeResolver) Value(ctx context.Context) (int32, er
 	return i.value, nil
 }
 
+func (i *insightIntervalTimeScopeResolver) StartDate(ctx context.Context) (*gqlutil.DateTime, error) {
+	return gqlutil.DateTimeOrNil(i.startDate), nil
+}
+
 type insightRepositoryScopeResolver struct {
 	repositories []string
 }
@@ -436,6 +446,10 @@ func (l *lineChartDataSeriesPresentationResolver) Color(ctx context.Context) (st
 }
 
 func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.CreateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	// Validation
 	// Needs at least 1 series
 	if len(args.Input.DataSeries) == 0 {
@@ -534,6 +548,10 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 }
 
 func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	if len(args.Input.DataSeries) == 0 {
 		return nil, errors.New("At least one data series is required to update an insight view")
 	}
@@ -771,10 +789,17 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 			return true
 		}
 	}
+	if !timesEqual(backfillStartAt(new.TimeScope), existing.BackfillStartAt) {
+		return true
+	}
 	return emptyIfNil(new.GroupBy) != emptyIfNil(existing.GroupBy)
 }
 
 func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend.SaveInsightAsNewViewArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	uid := actor.FromContext(ctx).UID
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
 
@@ -866,6 +891,10 @@ func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend
 }
 
 func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.CreatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	insightTx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -947,6 +976,10 @@ func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphq
 }
 
 func (r *Resolver) UpdatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	tx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -1348,6 +1381,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			StepIntervalValue:         int(series.TimeScope.StepInterval.Value),
 			GenerateFromCaptureGroups: dynamic,
 			GroupBy:                   groupBy,
+			BackfillStartAt:           backfillStartAt(series.TimeScope),
 		})
 		if err != nil {
 			return errors.Wrap(err, "FindMatchingSeries")
@@ -1370,6 +1404,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
 			RepositoryCriteria:         series.RepositoryScope.RepositoryCriteria,
+			BackfillStartAt:            backfillStartAt(series.TimeScope),
 		})
 		if err != nil {
 			return errors.Wrap(err, "CreateSeries")
@@ -1419,6 +1454,10 @@ func seriesFound(existingSeries types.InsightViewSeries, inputSeries []graphqlba
 }
 
 func (r *Resolver) DeleteInsightView(ctx context.Context, args *graphqlbackend.DeleteInsightViewArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.postgresDB, rbac.CodeInsightsWritePermission); err != nil {
+		return nil, err
+	}
+
 	var viewId string
 	err := relay.UnmarshalSpec(args.Id, &viewId)
 	if err != nil {
@@ -1610,6 +1649,53 @@ func lowercaseGroupBy(groupBy *string) *string {
 	return groupBy
 }
 
+// backfillStartAt returns the normalized time a series with the given time scope
+// should be backfilled from, or nil if it should be backfilled over the default
+// number of points.
+func backfillStartAt(timeScope *graphqlbackend.TimeScopeInput) *time.Time {
+	if timeScope == nil || timeScope.StartDate == nil {
+		return nil
+	}
+	startAt := timeScope.StartDate.Time.UTC().Truncate(time.Minute)
+	return &startAt
+}
+
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func isValidBackfillStartDate(seriesInput graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
+	startAt := backfillStartAt(seriesInput.TimeScope)
+	if startAt == nil {
+		return nil
+	}
+	if seriesInput.GroupBy != nil {
+		return errors.New("group by series do not support a start date")
+	}
+	if seriesInput.TimeScope.StepInterval == nil {
+		return errors.New("a start date requires a step interval")
+	}
+	now := time.Now()
+	if !startAt.Before(now) {
+		return errors.New("start date must be in the past")
+	}
+	interval := timeseries.TimeInterval{
+		Unit:  types.IntervalUnit(seriesInput.TimeScope.StepInterval.Unit),
+		Value: int(seriesInput.TimeScope.StepInterval.Value),
+	}
+	if !interval.IsValid() || interval.Value == 0 {
+		return errors.New("a start date requires a non-zero step interval")
+	}
+	// The first sample time is the start date unless there are too many points between it and now.
+	if sampleTimes := timeseries.BuildSampleTimesFrom(*startAt, interval, now); !sampleTimes[0].Equal(*startAt) {
+		return errors.Newf("start date and step interval would produce more than %d points, choose a later start date or a larger interval", timeseries.MaxSampleTimes)
+	}
+	return nil
+}
+
 func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
 	if seriesInput.RepositoryScope == nil {
 		return errors.New("a repository scope is required")
@@ -1625,6 +1711,9 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 	if !repoListSpecified && seriesInput.GroupBy != nil {
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
+	if err := isValidBackfillStartDate(seriesInput); err != nil {
+		return err
+	}
 
 	if repoCriteriaSpecified {
 		plan, err := querybuilder.ParseQuery(*seriesInput.RepositoryScope.RepositoryCriteria, "literal")
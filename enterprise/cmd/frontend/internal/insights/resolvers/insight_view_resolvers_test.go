@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
@@ -485,3 +486,68 @@ func TestInsightRepoScopeResolver(t *testing.T) {
 	}
 
 }
+
+func TestIsValidBackfillStartDate(t *testing.T) {
+	groupBy := "REPO"
+	makeInput := func(unit string, value int32, startDate time.Time) graphqlbackend.LineChartSearchInsightDataSeriesInput {
+		return graphqlbackend.LineChartSearchInsightDataSeriesInput{
+			TimeScope: &graphqlbackend.TimeScopeInput{
+				StepInterval: &graphqlbackend.TimeIntervalStepInput{Unit: unit, Value: value},
+				StartDate:    &gqlutil.DateTime{Time: startDate},
+			},
+		}
+	}
+	now := time.Now()
+
+	testCases := []struct {
+		name  string
+		input graphqlbackend.LineChartSearchInsightDataSeriesInput
+		want  autogold.Value
+	}{
+		{
+			name:  "no start date",
+			input: graphqlbackend.LineChartSearchInsightDataSeriesInput{TimeScope: &graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{Unit: "MONTH", Value: 1}}},
+			want:  autogold.Expect("<nil>"),
+		},
+		{
+			name:  "start date in the past",
+			input: makeInput("MONTH", 1, now.AddDate(-4, 0, 0)),
+			want:  autogold.Expect("<nil>"),
+		},
+		{
+			name:  "start date in the future",
+			input: makeInput("MONTH", 1, now.AddDate(0, 1, 0)),
+			want:  autogold.Expect("start date must be in the past"),
+		},
+		{
+			name:  "zero interval",
+			input: makeInput("DAY", 0, now.AddDate(-1, 0, 0)),
+			want:  autogold.Expect("a start date requires a non-zero step interval"),
+		},
+		{
+			name:  "too many points",
+			input: makeInput("DAY", 1, now.AddDate(-4, 0, 0)),
+			want:  autogold.Expect("start date and step interval would produce more than 1000 points, choose a later start date or a larger interval"),
+		},
+		{
+			name: "group by series",
+			input: func() graphqlbackend.LineChartSearchInsightDataSeriesInput {
+				input := makeInput("MONTH", 1, now.AddDate(-1, 0, 0))
+				input.GroupBy = &groupBy
+				return input
+			}(),
+			want: autogold.Expect("group by series do not support a start date"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidBackfillStartDate(tc.input)
+			got := "<nil>"
+			if err != nil {
+				got = err.Error()
+			}
+			tc.want.Equal(t, got)
+		})
+	}
+}
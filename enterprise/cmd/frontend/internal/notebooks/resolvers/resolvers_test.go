@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -175,6 +176,7 @@ func TestSingleNotebookCRUD(t *testing.T) {
 	logger := logtest.Scoped(t)
 	internalCtx := actor.WithInternalActor(context.Background())
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantNotebooksWritePermission(t, db)
 	u := db.Users()
 	o := db.Orgs()
 	om := db.OrgMembers()
@@ -208,6 +210,7 @@ func TestSingleNotebookCRUD(t *testing.T) {
 	testCreateNotebook(t, schema, user1, user2, org)
 	testUpdateNotebook(t, db, schema, user1, user2, org)
 	testDeleteNotebook(t, db, schema, user1, user2, org)
+	testCreateNotebookWithoutWritePermission(t, db, schema)
 }
 
 func testGetNotebook(t *testing.T, db database.DB, schema *graphql.Schema, user *types.User) {
@@ -283,6 +286,31 @@ func testCreateNotebook(t *testing.T, schema *graphql.Schema, user1 *types.User,
 	}
 }
 
+func testCreateNotebookWithoutWritePermission(t *testing.T, db database.DB, schema *graphql.Schema) {
+	internalCtx := actor.WithInternalActor(context.Background())
+	user, err := db.Users().Create(internalCtx, database.NewUser{Username: "u3", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	// Without the USER system role the user has no NOTEBOOKS#WRITE permission.
+	err = db.UserRoles().RevokeSystemRole(internalCtx, database.RevokeSystemRoleOpts{UserID: user.ID, Role: types.UserSystemRole})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	notebook := notebookFixture(user.ID, user.ID, 0, true)
+	input := map[string]any{"notebook": notebooksapitest.NotebookToAPIInput(notebook)}
+	var response struct{ CreateNotebook notebooksapitest.Notebook }
+	gotErrors := apitest.Exec(actor.WithActor(context.Background(), actor.FromUser(user.ID)), t, schema, input, &response, createNotebookMutation)
+	if len(gotErrors) == 0 {
+		t.Fatal("expected error, got none")
+	}
+	wantErr := (&rbac.ErrNotAuthorized{Permission: rbac.NotebooksWritePermission}).Error()
+	if !strings.Contains(gotErrors[0].Message, wantErr) {
+		t.Fatalf("expected error containing '%s', got '%s'", wantErr, gotErrors[0].Message)
+	}
+}
+
 func testUpdateNotebook(t *testing.T, db database.DB, schema *graphql.Schema, user1 *types.User, user2 *types.User, org *types.Org) {
 	internalCtx := actor.WithInternalActor(context.Background())
 	n := notebooks.Notebooks(db)
@@ -514,6 +542,29 @@ func testDeleteNotebook(t *testing.T, db database.DB, schema *graphql.Schema, us
 	}
 }
 
+// grantNotebooksWritePermission assigns the NOTEBOOKS#WRITE permission to the USER system
+// role, mirroring what happens on startup.
+func grantNotebooksWritePermission(t *testing.T, db database.DB) {
+	t.Helper()
+
+	ctx := actor.WithInternalActor(context.Background())
+	namespace, action, err := rbac.ParsePermissionDisplayName(rbac.NotebooksWritePermission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perm, err := db.Permissions().Create(ctx, database.CreatePermissionOpts{Namespace: namespace, Action: action})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.RolePermissions().BulkAssignPermissionsToSystemRoles(ctx, database.BulkAssignPermissionsToSystemRolesOpts{
+		Roles:        []types.SystemRole{types.UserSystemRole, types.SiteAdministratorSystemRole},
+		PermissionID: perm.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func createNotebooks(t *testing.T, db database.DB, notebooksToCreate []*notebooks.Notebook) []*notebooks.Notebook {
 	t.Helper()
 	n := notebooks.Notebooks(db)
@@ -544,6 +595,7 @@ func createNotebookStars(t *testing.T, db database.DB, notebookID int64, userIDs
 func TestListNotebooks(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantNotebooksWritePermission(t, db)
 	internalCtx := actor.WithInternalActor(context.Background())
 	u := db.Users()
 	o := db.Orgs()
@@ -705,6 +757,7 @@ func TestListNotebooks(t *testing.T) {
 func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantNotebooksWritePermission(t, db)
 	internalCtx := actor.WithInternalActor(context.Background())
 	u := db.Users()
 	n := notebooks.Notebooks(db)
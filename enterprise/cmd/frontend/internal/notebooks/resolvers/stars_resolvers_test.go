@@ -64,6 +64,7 @@ query NotebookStars($id: ID!, $first: Int!, $after: String) {
 func TestCreateAndDeleteNotebookStars(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantNotebooksWritePermission(t, db)
 	internalCtx := actor.WithInternalActor(context.Background())
 	u := db.Users()
 
@@ -140,6 +141,7 @@ func createAPINotebookStars(t *testing.T, schema *graphql.Schema, notebookID int
 func TestListNotebookStars(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	grantNotebooksWritePermission(t, db)
 	internalCtx := actor.WithInternalActor(context.Background())
 	u := db.Users()
 
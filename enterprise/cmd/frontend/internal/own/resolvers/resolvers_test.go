@@ -63,6 +63,10 @@ func (s fakeOwnService) ResolveOwnersWithType(_ context.Context, owners []*codeo
 	return resolved, nil
 }
 
+func (s fakeOwnService) InferOwners(context.Context, api.RepoID, string) ([]own.InferredOwner, error) {
+	return nil, nil
+}
+
 // fakeGitServer is a limited gitserver.Client that returns a file for every Stat call.
 type fakeGitserver struct {
 	gitserver.Client
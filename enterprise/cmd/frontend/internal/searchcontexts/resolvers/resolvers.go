@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -65,6 +66,10 @@ func (r *Resolver) SearchContextBySpec(ctx context.Context, args graphqlbackend.
 }
 
 func (r *Resolver) CreateSearchContext(ctx context.Context, args graphqlbackend.CreateSearchContextArgs) (_ graphqlbackend.SearchContextResolver, err error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.SearchContextsWritePermission); err != nil {
+		return nil, err
+	}
+
 	var namespaceUserID, namespaceOrgID int32
 	if args.SearchContext.Namespace != nil {
 		err := graphqlbackend.UnmarshalNamespaceID(*args.SearchContext.Namespace, &namespaceUserID, &namespaceOrgID)
@@ -101,6 +106,10 @@ func (r *Resolver) CreateSearchContext(ctx context.Context, args graphqlbackend.
 }
 
 func (r *Resolver) UpdateSearchContext(ctx context.Context, args graphqlbackend.UpdateSearchContextArgs) (graphqlbackend.SearchContextResolver, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.SearchContextsWritePermission); err != nil {
+		return nil, err
+	}
+
 	searchContextSpec, err := unmarshalSearchContextID(args.ID)
 	if err != nil {
 		return nil, err
@@ -170,6 +179,10 @@ func (r *Resolver) repositoryRevisionsFromInputArgs(ctx context.Context, args []
 }
 
 func (r *Resolver) DeleteSearchContext(ctx context.Context, args graphqlbackend.DeleteSearchContextArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.SearchContextsWritePermission); err != nil {
+		return nil, err
+	}
+
 	searchContextSpec, err := unmarshalSearchContextID(args.ID)
 	if err != nil {
 		return nil, err
@@ -14,7 +14,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestSearchContexts(t *testing.T) {
@@ -155,3 +157,46 @@ func TestSearchContextsStarDefaultPermissions(t *testing.T) {
 		t.Fatalf("expected no error, got %s", err)
 	}
 }
+
+func TestSearchContextsWritePermission(t *testing.T) {
+	t.Parallel()
+
+	userID := int32(1)
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: userID})
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: userID}, nil)
+
+	permissions := database.NewMockPermissionStore()
+	permissions.GetPermissionForUserFunc.SetDefaultReturn(nil, nil)
+
+	sc := database.NewMockSearchContextsStore()
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+	db.SearchContextsFunc.SetDefaultReturn(sc)
+
+	graphqlSearchContextID := marshalSearchContextID("test")
+	wantErr := &rbac.ErrNotAuthorized{Permission: rbac.SearchContextsWritePermission}
+
+	_, err := (&Resolver{db: db}).CreateSearchContext(ctx, graphqlbackend.CreateSearchContextArgs{
+		SearchContext: graphqlbackend.SearchContextInputArgs{Name: "test"},
+	})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = (&Resolver{db: db}).UpdateSearchContext(ctx, graphqlbackend.UpdateSearchContextArgs{
+		ID:            graphqlSearchContextID,
+		SearchContext: graphqlbackend.SearchContextEditInputArgs{Name: "test"},
+	})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	_, err = (&Resolver{db: db}).DeleteSearchContext(ctx, graphqlbackend.DeleteSearchContextArgs{ID: graphqlSearchContextID})
+	if !errors.HasType(err, wantErr) {
+		t.Fatalf("expected error %s, got %v", wantErr, err)
+	}
+	mockrequire.NotCalled(t, sc.CreateSearchContextWithRepositoryRevisionsFunc)
+	mockrequire.NotCalled(t, sc.DeleteSearchContextFunc)
+}
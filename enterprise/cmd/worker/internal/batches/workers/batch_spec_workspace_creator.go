@@ -78,6 +78,12 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
+	// Dry-runs only report the resolved workspaces and don't need the secrets
+	// required to compute cache keys.
+	if job.DryRun {
+		return r.processDryRun(ctx, newResolver(r.store), job, spec, evaluatableSpec)
+	}
+
 	// Next, we fetch all secrets that are requested by the spec.
 	rk := spec.Spec.RequiredEnvVars()
 	var secrets []*database.ExecutorSecret
@@ -126,6 +132,8 @@ func (r *batchSpecWorkspaceCreator) process(
 
 	// Build workspaces DB objects.
 	for _, w := range workspaces {
+		templateRepo := w.TemplateRepository()
+
 		workspace := &btypes.BatchSpecWorkspace{
 			BatchSpecID:      spec.ID,
 			ChangesetSpecIDs: []int64{},
@@ -136,6 +144,7 @@ func (r *batchSpecWorkspaceCreator) process(
 			Path:               w.Path,
 			FileMatches:        w.FileMatches,
 			OnlyFetchWorkspace: w.OnlyFetchWorkspace,
+			RepositoryMetadata: templateRepo.Metadata,
 
 			Unsupported: w.Unsupported,
 			Ignored:     w.Ignored,
@@ -156,9 +165,10 @@ func (r *batchSpecWorkspaceCreator) process(
 			BaseRef:     w.Branch,
 			BaseRev:     string(w.Commit),
 			FileMatches: w.FileMatches,
+			Metadata:    templateRepo.Metadata,
 		}
 
-		skippedSteps, err := batcheslib.SkippedStepsForRepo(spec.Spec, string(w.Repo.Name), w.FileMatches)
+		skippedSteps, err := batcheslib.SkippedStepsForRepo(spec.Spec, templateRepo)
 		if err != nil {
 			return err
 		}
@@ -336,6 +346,34 @@ func (r *batchSpecWorkspaceCreator) process(
 	return tx.CreateBatchSpecWorkspace(ctx, ws...)
 }
 
+// processDryRun resolves the workspaces for the given dry-run job and records
+// the outcome on the job, without creating any workspaces or changeset specs.
+func (r *batchSpecWorkspaceCreator) processDryRun(
+	ctx context.Context,
+	resolver service.WorkspaceResolver,
+	job *btypes.BatchSpecResolutionJob,
+	spec *btypes.BatchSpec,
+	evaluatableSpec *batcheslib.BatchSpec,
+) error {
+	result, err := resolver.DryRunWorkspacesForBatchSpec(ctx, evaluatableSpec, service.DryRunOpts{
+		AllowIgnored:     spec.AllowIgnored,
+		AllowUnsupported: spec.AllowUnsupported,
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info("dry-run resolved workspaces for batch spec",
+		log.Int64("job", job.ID),
+		log.Int64("spec", spec.ID),
+		log.String("outcome", string(result.Outcome)),
+		log.Int("workspaces", len(result.Workspaces)),
+		log.Int("skipped", len(result.Skipped)),
+		log.Int("maxChangesets", result.MaxChangesets))
+
+	return r.store.SetBatchSpecResolutionJobDryRunResult(ctx, job.ID, result)
+}
+
 func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64) ([]*btypes.BatchSpecWorkspaceFile, error) {
 	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
 	if err != nil {
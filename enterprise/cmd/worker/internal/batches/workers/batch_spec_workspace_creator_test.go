@@ -915,6 +915,79 @@ importChangesets:
 	}
 }
 
+func TestBatchSpecWorkspaceCreatorProcess_DryRun(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	repos, _ := bt.CreateTestRepos(t, context.Background(), db, 2)
+
+	user := bt.CreateTestUser(t, db, true)
+
+	s := store.New(db, &observation.TestContext, nil)
+
+	batchSpec, err := btypes.NewBatchSpecFromRaw(bt.TestRawBatchSpecYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchSpec.UserID = user.ID
+	batchSpec.NamespaceUserID = user.ID
+	if err := s.CreateBatchSpec(context.Background(), batchSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID, InitiatorID: user.ID, DryRun: true}
+	if err := s.CreateBatchSpecResolutionJob(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := &dummyWorkspaceResolver{
+		dryRunResult: &btypes.BatchSpecDryRunResult{
+			Outcome: btypes.BatchSpecDryRunOutcomeWorkspaces,
+			Workspaces: []*btypes.BatchSpecDryRunWorkspace{
+				{
+					RepoID:        repos[0].ID,
+					RepoName:      repos[0].Name,
+					Branch:        "refs/heads/main",
+					Commit:        "d34db33f",
+					FileMatches:   []string{"a/b/c.go"},
+					Steps:         []int{0},
+					MaxChangesets: 1,
+				},
+			},
+			Skipped: []*btypes.BatchSpecDryRunSkippedWorkspace{
+				{
+					RepoID:   repos[1].ID,
+					RepoName: repos[1].Name,
+					Branch:   "refs/heads/main",
+					Reason:   btypes.BatchSpecDryRunSkipReasonIgnored,
+				},
+			},
+			MaxChangesets: 1,
+		},
+	}
+
+	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
+		t.Fatalf("proces failed: %s", err)
+	}
+
+	workspaces, _, err := s.ListBatchSpecWorkspaces(context.Background(), store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		t.Fatalf("listing workspaces failed: %s", err)
+	}
+	if len(workspaces) != 0 {
+		t.Fatalf("dry-run created %d workspaces", len(workspaces))
+	}
+
+	have, err := s.GetBatchSpecResolutionJob(context.Background(), store.GetBatchSpecResolutionJobOpts{ID: job.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(resolver.dryRunResult, have.DryRunResult); diff != "" {
+		t.Fatalf("wrong dry-run result (-want +got):\n%s", diff)
+	}
+}
+
 func TestBatchSpecWorkspaceCreatorProcess_Secrets(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -998,8 +1071,9 @@ func TestBatchSpecWorkspaceCreatorProcess_Secrets(t *testing.T) {
 }
 
 type dummyWorkspaceResolver struct {
-	workspaces []*service.RepoWorkspace
-	err        error
+	workspaces   []*service.RepoWorkspace
+	dryRunResult *btypes.BatchSpecDryRunResult
+	err          error
 }
 
 // DummyBuilder is a simple implementation of the service.WorkspaceResolverBuilder
@@ -1011,6 +1085,10 @@ func (d *dummyWorkspaceResolver) ResolveWorkspacesForBatchSpec(context.Context,
 	return d.workspaces, d.err
 }
 
+func (d *dummyWorkspaceResolver) DryRunWorkspacesForBatchSpec(context.Context, *batcheslib.BatchSpec, service.DryRunOpts) (*btypes.BatchSpecDryRunResult, error) {
+	return d.dryRunResult, d.err
+}
+
 var testDiff = []byte(`diff README.md README.md
 index 671e50a..851b23a 100644
 --- README.md
@@ -40,6 +40,7 @@ var additionalJobs = map[string]job.Job{
 	"batches-reconciler":            batches.NewReconcilerJob(),
 	"batches-bulk-processor":        batches.NewBulkOperationProcessorJob(),
 	"batches-workspace-resolver":    batches.NewWorkspaceResolverJob(),
+	"batches-auto-merger":           batches.NewAutoMergeJob(),
 	"executors-janitor":             executors.NewJanitorJob(),
 	"executors-metricsserver":       executors.NewMetricsServerJob(),
 	"codemonitors-job":              codemonitors.NewCodeMonitorJob(),
@@ -0,0 +1,245 @@
+// Package automerge merges the changesets of batch changes that have an
+// auto-merge policy, once they satisfy the policy.
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const mergeInterval = 1 * time.Minute
+
+// NewMerger returns a background routine that periodically evaluates all
+// auto-merge policies.
+func NewMerger(ctx context.Context, logger log.Logger, bstore *store.Store) goroutine.BackgroundRoutine {
+	m := &merger{store: bstore, logger: logger, now: bstore.Clock()}
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.auto-merger", "merges changesets that satisfy the auto-merge policy of their batch change",
+		mergeInterval,
+		goroutine.HandlerFunc(m.run),
+	)
+}
+
+type merger struct {
+	store  *store.Store
+	logger log.Logger
+	now    func() time.Time
+}
+
+func (m *merger) run(ctx context.Context) error {
+	policies, err := m.store.ListAutoMergePolicies(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing auto-merge policies")
+	}
+
+	var errs error
+	for _, policy := range policies {
+		if err := m.apply(ctx, policy); err != nil {
+			m.logger.Warn("applying auto-merge policy failed", log.Int64("batchChangeID", policy.BatchChangeID), log.Error(err))
+			errs = errors.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// apply enqueues merge jobs for the eligible changesets of the policy's batch
+// change and records why the other open changesets weren't merged.
+func (m *merger) apply(ctx context.Context, policy *btypes.AutoMergePolicy) (err error) {
+	now := m.now()
+
+	mergeWindow, err := policy.MergeWindow()
+	if err != nil {
+		return errors.Wrap(err, "parsing merge window")
+	}
+
+	cs, _, err := m.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:  policy.BatchChangeID,
+		ExternalStates: []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	pendingJobs, err := m.store.ListChangesetJobs(ctx, store.ListChangesetJobsOpts{
+		BatchChangeID: policy.BatchChangeID,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		States: []btypes.ChangesetJobState{
+			btypes.ChangesetJobStateQueued,
+			btypes.ChangesetJobStateProcessing,
+			btypes.ChangesetJobStateErrored,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing pending merge jobs")
+	}
+	pending := make(map[int64]struct{}, len(pendingJobs))
+	for _, job := range pendingJobs {
+		pending[job.ChangesetID] = struct{}{}
+	}
+
+	failedJobs, err := m.store.ListChangesetJobs(ctx, store.ListChangesetJobsOpts{
+		BatchChangeID: policy.BatchChangeID,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		States:        []btypes.ChangesetJobState{btypes.ChangesetJobStateFailed},
+		AutoMerge:     true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing failed merge jobs")
+	}
+	// Jobs are listed in the order they were created, so this keeps the most
+	// recent failure of each changeset.
+	failed := make(map[int64]*btypes.ChangesetJob, len(failedJobs))
+	for _, job := range failedJobs {
+		failed[job.ChangesetID] = job
+	}
+
+	budget := -1
+	if policy.MaxMergesPerHour > 0 {
+		// Only merges done by the policy count towards its limit, failed
+		// attempts and manual merges don't. Merge jobs the policy enqueued
+		// that didn't run yet count as well, since they would otherwise be
+		// enqueued on top of the limit on every run until they complete.
+		merges, err := m.store.CountChangesetJobs(ctx, store.ListChangesetJobsOpts{
+			BatchChangeID: policy.BatchChangeID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			States: []btypes.ChangesetJobState{
+				btypes.ChangesetJobStateQueued,
+				btypes.ChangesetJobStateProcessing,
+				btypes.ChangesetJobStateErrored,
+				btypes.ChangesetJobStateCompleted,
+			},
+			CreatedAfter: now.Add(-1 * time.Hour),
+			AutoMerge:    true,
+		})
+		if err != nil {
+			return errors.Wrap(err, "counting recent merge jobs")
+		}
+		budget = int(policy.MaxMergesPerHour) - merges
+		if budget < 0 {
+			budget = 0
+		}
+	}
+
+	eligible, blockers := evaluate(policy, cs, pending, failed, mergeWindow, now, budget)
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if len(eligible) > 0 {
+		bulkGroupID, err := store.RandomID()
+		if err != nil {
+			return errors.Wrap(err, "creating bulk group ID")
+		}
+
+		jobs := make([]*btypes.ChangesetJob, 0, len(eligible))
+		for _, ch := range eligible {
+			jobs = append(jobs, &btypes.ChangesetJob{
+				BulkGroup:     bulkGroupID,
+				ChangesetID:   ch.ID,
+				BatchChangeID: policy.BatchChangeID,
+				UserID:        policy.UserID,
+				State:         btypes.ChangesetJobStateQueued,
+				JobType:       btypes.ChangesetJobTypeMerge,
+				Payload:       &btypes.ChangesetJobMergePayload{Squash: policy.Squash(), AutoMerge: true},
+			})
+		}
+		if err := tx.CreateChangesetJob(ctx, jobs...); err != nil {
+			return errors.Wrap(err, "creating merge jobs")
+		}
+	}
+
+	return tx.ReplaceAutoMergeBlockers(ctx, policy.BatchChangeID, blockers)
+}
+
+// evaluate splits the given changesets into those that should be merged now and
+// blockers for those that can't be merged. Changesets with a pending merge job
+// are neither merged again nor reported as blocked. Changesets whose last merge
+// job failed are only merged again once they changed on the code host. A
+// negative budget means that any number of changesets may be merged.
+func evaluate(policy *btypes.AutoMergePolicy, cs btypes.Changesets, pending map[int64]struct{}, failed map[int64]*btypes.ChangesetJob, mergeWindow *window.Window, now time.Time, budget int) (eligible btypes.Changesets, blockers []*btypes.AutoMergeBlocker) {
+	for _, ch := range cs {
+		if _, ok := pending[ch.ID]; ok {
+			continue
+		}
+
+		reason := blockedReason(policy, ch, mergeWindow, now)
+		if job, ok := failed[ch.ID]; ok && reason == "" && !ch.ExternalUpdatedAt.After(job.FinishedAt) {
+			reason = failedMergeReason(job)
+		}
+		if reason == "" && budget == 0 {
+			reason = fmt.Sprintf("the limit of %d merges per hour has been reached", policy.MaxMergesPerHour)
+		}
+		if reason != "" {
+			blockers = append(blockers, &btypes.AutoMergeBlocker{
+				BatchChangeID: policy.BatchChangeID,
+				ChangesetID:   ch.ID,
+				Reason:        reason,
+			})
+			continue
+		}
+
+		eligible = append(eligible, ch)
+		if budget > 0 {
+			budget--
+		}
+	}
+	return eligible, blockers
+}
+
+// failedMergeReason returns the reason a changeset whose merge job failed is
+// blocked.
+func failedMergeReason(job *btypes.ChangesetJob) string {
+	reason := "merging failed"
+	if job.FailureMessage != nil && *job.FailureMessage != "" {
+		reason += ": " + *job.FailureMessage
+	}
+	return reason + ". It is retried once the changeset changes on the code host"
+}
+
+// blockedReason returns a human readable reason why the changeset can't be
+// merged under the given policy, or an empty string if it can be merged.
+func blockedReason(policy *btypes.AutoMergePolicy, ch *btypes.Changeset, mergeWindow *window.Window, now time.Time) string {
+	if ch.ArchivedIn(policy.BatchChangeID) {
+		return "changeset is archived"
+	}
+	if !ch.Published() || ch.ExternalState != btypes.ChangesetExternalStateOpen {
+		return "changeset is not open on the code host"
+	}
+	if ch.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return "changeset is still being processed"
+	}
+
+	if policy.RequiredReviewState != "" && ch.ExternalReviewState != policy.RequiredReviewState {
+		return fmt.Sprintf("review state is %s, but %s is required", ch.ExternalReviewState, policy.RequiredReviewState)
+	}
+
+	switch policy.RequiredChecks {
+	case btypes.AutoMergeRequiredChecksPassed:
+		if ch.ExternalCheckState != btypes.ChangesetCheckStatePassed {
+			return fmt.Sprintf("checks are %s, but must have passed", ch.ExternalCheckState)
+		}
+	case btypes.AutoMergeRequiredChecksPassedOrNone:
+		if ch.ExternalCheckState != btypes.ChangesetCheckStatePassed && ch.ExternalCheckState != btypes.ChangesetCheckStateUnknown {
+			return fmt.Sprintf("checks are %s, but must have passed", ch.ExternalCheckState)
+		}
+	}
+
+	if mergeWindow != nil && !mergeWindow.IsOpen(now) {
+		return "merge window is closed"
+	}
+
+	return ""
+}
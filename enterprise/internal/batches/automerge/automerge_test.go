@@ -0,0 +1,293 @@
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestBlockedReason(t *testing.T) {
+	// A Monday.
+	now := time.Date(2023, 5, 22, 10, 0, 0, 0, time.UTC)
+
+	openChangeset := func(mods ...func(*btypes.Changeset)) *btypes.Changeset {
+		ch := &btypes.Changeset{
+			ID:                  1,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: 1}},
+		}
+		for _, mod := range mods {
+			mod(ch)
+		}
+		return ch
+	}
+
+	for name, tc := range map[string]struct {
+		policy    btypes.AutoMergePolicy
+		changeset *btypes.Changeset
+		want      string
+	}{
+		"eligible": {
+			policy:    btypes.AutoMergePolicy{RequiredReviewState: btypes.ChangesetReviewStateApproved, RequiredChecks: btypes.AutoMergeRequiredChecksPassed},
+			changeset: openChangeset(),
+		},
+		"archived": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.BatchChanges[0].IsArchived = true
+			}),
+			want: "changeset is archived",
+		},
+		"unpublished": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.PublicationState = btypes.ChangesetPublicationStateUnpublished
+			}),
+			want: "changeset is not open on the code host",
+		},
+		"processing": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ReconcilerState = btypes.ReconcilerStateQueued
+			}),
+			want: "changeset is still being processed",
+		},
+		"review not approved": {
+			policy: btypes.AutoMergePolicy{RequiredReviewState: btypes.ChangesetReviewStateApproved, RequiredChecks: btypes.AutoMergeRequiredChecksNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalReviewState = btypes.ChangesetReviewStatePending
+			}),
+			want: "review state is PENDING, but APPROVED is required",
+		},
+		"review state ignored": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested
+			}),
+		},
+		"checks failed": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksPassed},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalCheckState = btypes.ChangesetCheckStateFailed
+			}),
+			want: "checks are FAILED, but must have passed",
+		},
+		"no checks required to pass": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksPassed},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalCheckState = btypes.ChangesetCheckStateUnknown
+			}),
+			want: "checks are UNKNOWN, but must have passed",
+		},
+		"no checks allowed": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksPassedOrNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalCheckState = btypes.ChangesetCheckStateUnknown
+			}),
+		},
+		"pending checks with passed or none": {
+			policy: btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksPassedOrNone},
+			changeset: openChangeset(func(ch *btypes.Changeset) {
+				ch.ExternalCheckState = btypes.ChangesetCheckStatePending
+			}),
+			want: "checks are PENDING, but must have passed",
+		},
+		"merge window open": {
+			policy:    btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone, MergeWindowDays: []string{"monday"}, MergeWindowStart: "09:00", MergeWindowEnd: "17:00"},
+			changeset: openChangeset(),
+		},
+		"merge window closed": {
+			policy:    btypes.AutoMergePolicy{RequiredChecks: btypes.AutoMergeRequiredChecksNone, MergeWindowDays: []string{"saturday", "sunday"}},
+			changeset: openChangeset(),
+			want:      "merge window is closed",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.policy.BatchChangeID = 1
+			w, err := tc.policy.MergeWindow()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have := blockedReason(&tc.policy, tc.changeset, w, now); have != tc.want {
+				t.Errorf("unexpected reason: have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2023, 5, 22, 10, 0, 0, 0, time.UTC)
+	policy := &btypes.AutoMergePolicy{
+		BatchChangeID:    1,
+		RequiredChecks:   btypes.AutoMergeRequiredChecksPassed,
+		MaxMergesPerHour: 2,
+	}
+
+	cs := make(btypes.Changesets, 0, 5)
+	for i := 1; i <= cap(cs); i++ {
+		cs = append(cs, &btypes.Changeset{
+			ID:                 int64(i),
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			ExternalCheckState: btypes.ChangesetCheckStatePassed,
+		})
+	}
+	cs[1].ExternalCheckState = btypes.ChangesetCheckStatePending
+	pending := map[int64]struct{}{cs[2].ID: {}}
+
+	t.Run("with budget", func(t *testing.T) {
+		eligible, blockers := evaluate(policy, cs, pending, nil, nil, now, 2)
+
+		if diff := cmp.Diff(eligible, btypes.Changesets{cs[0], cs[3]}); diff != "" {
+			t.Errorf("unexpected eligible changesets (-have +want):\n%s", diff)
+		}
+		want := []*btypes.AutoMergeBlocker{
+			{BatchChangeID: 1, ChangesetID: 2, Reason: "checks are PENDING, but must have passed"},
+			{BatchChangeID: 1, ChangesetID: 5, Reason: "the limit of 2 merges per hour has been reached"},
+		}
+		if diff := cmp.Diff(blockers, want); diff != "" {
+			t.Errorf("unexpected blockers (-have +want):\n%s", diff)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		eligible, blockers := evaluate(policy, cs, pending, nil, nil, now, -1)
+
+		if diff := cmp.Diff(eligible, btypes.Changesets{cs[0], cs[3], cs[4]}); diff != "" {
+			t.Errorf("unexpected eligible changesets (-have +want):\n%s", diff)
+		}
+		if len(blockers) != 1 {
+			t.Errorf("unexpected number of blockers: %d", len(blockers))
+		}
+	})
+	t.Run("failed merge", func(t *testing.T) {
+		failure := "changeset is not mergeable"
+		job := &btypes.ChangesetJob{ChangesetID: cs[0].ID, FailureMessage: &failure, FinishedAt: now.Add(-1 * time.Minute)}
+		failed := map[int64]*btypes.ChangesetJob{cs[0].ID: job}
+
+		cs[0].ExternalUpdatedAt = now.Add(-2 * time.Minute)
+		eligible, blockers := evaluate(policy, cs, pending, failed, nil, now, -1)
+		if diff := cmp.Diff(eligible, btypes.Changesets{cs[3], cs[4]}); diff != "" {
+			t.Errorf("unexpected eligible changesets (-have +want):\n%s", diff)
+		}
+		want := []*btypes.AutoMergeBlocker{
+			{BatchChangeID: 1, ChangesetID: 1, Reason: "merging failed: changeset is not mergeable. It is retried once the changeset changes on the code host"},
+			{BatchChangeID: 1, ChangesetID: 2, Reason: "checks are PENDING, but must have passed"},
+		}
+		if diff := cmp.Diff(blockers, want); diff != "" {
+			t.Errorf("unexpected blockers (-have +want):\n%s", diff)
+		}
+
+		// Once the changeset changed on the code host, merging is retried.
+		cs[0].ExternalUpdatedAt = now
+		eligible, _ = evaluate(policy, cs, pending, failed, nil, now, -1)
+		if diff := cmp.Diff(eligible, btypes.Changesets{cs[0], cs[3], cs[4]}); diff != "" {
+			t.Errorf("unexpected eligible changesets (-have +want):\n%s", diff)
+		}
+	})
+}
+
+func TestMergerApplyBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	now := timeutil.Now()
+	s := store.NewWithClock(db, &observation.TestContext, nil, func() time.Time { return now })
+
+	user := bt.CreateTestUser(t, db, false)
+	spec := bt.CreateBatchSpec(t, ctx, s, "auto-merge", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-merge", user.ID, spec.ID)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	cs := make([]*btypes.Changeset, 0, 4)
+	for i := 1; i <= cap(cs); i++ {
+		cs = append(cs, bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChange:         batchChange.ID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalID:          fmt.Sprint(i),
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+		}))
+	}
+
+	policy := &btypes.AutoMergePolicy{
+		BatchChangeID:    batchChange.ID,
+		UserID:           user.ID,
+		MergeMethod:      btypes.AutoMergeMethodSquash,
+		RequiredChecks:   btypes.AutoMergeRequiredChecksPassed,
+		MaxMergesPerHour: 2,
+	}
+	if err := s.UpsertAutoMergePolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// A merge job the policy enqueued on a previous run that didn't run yet.
+	if err := s.CreateChangesetJob(ctx, &btypes.ChangesetJob{
+		ChangesetID:   cs[0].ID,
+		BatchChangeID: batchChange.ID,
+		UserID:        user.ID,
+		State:         btypes.ChangesetJobStateQueued,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		Payload:       &btypes.ChangesetJobMergePayload{Squash: true, AutoMerge: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &merger{store: s, logger: logger, now: s.Clock()}
+	if err := m.apply(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// The pending job uses up one merge of the budget, so only one more
+	// changeset is merged.
+	queued, err := s.ListChangesetJobs(ctx, store.ListChangesetJobsOpts{
+		BatchChangeID: batchChange.ID,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		States:        []btypes.ChangesetJobState{btypes.ChangesetJobStateQueued},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 {
+		t.Fatalf("unexpected number of queued merge jobs: have %d, want %d", len(queued), 2)
+	}
+
+	blockers, _, err := s.ListAutoMergeBlockers(ctx, store.ListAutoMergeBlockersOpts{BatchChangeID: batchChange.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blockers) != 2 {
+		t.Fatalf("unexpected number of blockers: have %d, want %d", len(blockers), 2)
+	}
+	for _, b := range blockers {
+		if want := "the limit of 2 merges per hour has been reached"; b.Reason != want {
+			t.Errorf("unexpected blocker reason: have %q, want %q", b.Reason, want)
+		}
+	}
+}
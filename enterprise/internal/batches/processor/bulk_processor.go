@@ -3,11 +3,13 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	bgql "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
@@ -17,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -102,6 +105,12 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
 		return nil, b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeUpdateBranch:
+		return b.updateChangesetBranch(ctx)
+	case btypes.ChangesetJobTypeSetReviewersAndLabels:
+		return b.setReviewersAndLabels(ctx, job)
+	case btypes.ChangesetJobTypeRerunFailedChecks:
+		return b.rerunFailedChecks(ctx)
 
 	default:
 		return nil, &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -289,6 +298,184 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 	return nil
 }
 
+func (b *bulkProcessor) updateChangesetBranch(ctx context.Context) (afterDone func(*store.Store), err error) {
+	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+		RemoteRepo: remoteRepo,
+	}
+
+	if bucss, ok := b.css.(sources.BranchUpdatableChangesetSource); ok {
+		// The code host can bring the branch up to date itself.
+		if err := bucss.UpdateChangesetBranch(ctx, cs); err != nil {
+			return nil, err
+		}
+	} else {
+		// Otherwise, we re-create the commit from the cached diff on top of the
+		// current base revision and push it to the changeset's branch.
+		if err := b.recreateChangesetCommit(ctx, cs); err != nil {
+			return nil, err
+		}
+		if err := b.css.LoadChangeset(ctx, cs); err != nil {
+			return nil, errors.Wrap(err, "reloading changeset")
+		}
+	}
+
+	if err := b.updateCodeHostState(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetUpdate) }
+	return afterDone, nil
+}
+
+// recreateChangesetCommit applies the diff of the changeset's current spec on
+// top of the latest revision of the base branch and force-pushes the result to
+// the changeset's branch.
+func (b *bulkProcessor) recreateChangesetCommit(ctx context.Context, cs *sources.Changeset) error {
+	// Imported changesets don't have a diff we could re-apply.
+	if b.ch.CurrentSpecID == 0 {
+		return errcode.MakeNonRetryable(errors.New("cannot update the branch of an imported changeset"))
+	}
+
+	spec, err := b.tx.GetChangesetSpecByID(ctx, b.ch.CurrentSpecID)
+	if err != nil {
+		b.logger.Error("GetChangesetBySpecID", log.Error(err))
+		return errcode.MakeNonRetryable(errors.Wrapf(err, "getting changeset spec for changeset %d", b.ch.ID))
+	} else if spec == nil {
+		return errcode.MakeNonRetryable(errors.Newf("no changeset spec for changeset %d", b.ch.ID))
+	}
+
+	if cs.RemoteRepo.Archived {
+		return errcode.MakeNonRetryable(errors.New("cannot update the branch of a changeset in an archived repository"))
+	}
+
+	gitserverClient := gitserver.NewClient()
+	baseRev, err := gitserverClient.ResolveRevision(ctx, b.repo.Name, spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "resolving base ref %q", spec.BaseRef)
+	}
+
+	pushConf, err := b.css.GitserverPushConfig(cs.RemoteRepo)
+	if err != nil {
+		return err
+	}
+
+	// We copy the spec, so that we don't modify the cached base revision.
+	updatedSpec := *spec
+	updatedSpec.BaseRev = string(baseRev)
+
+	_, err = gitserverClient.CreateCommitFromPatch(ctx, reconciler.BuildCommitOpts(b.repo, &updatedSpec, pushConf))
+	if err != nil {
+		var e *protocol.CreateCommitFromPatchError
+		if errors.As(err, &e) && strings.Contains(e.CombinedOutput, "patch does not apply") {
+			// The diff conflicts with the current base branch; retrying won't help.
+			return errcode.MakeNonRetryable(errors.Wrap(err, "applying diff to current base revision"))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (b *bulkProcessor) setReviewersAndLabels(ctx context.Context, job *btypes.ChangesetJob) (afterDone func(*store.Store), err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobSetReviewersAndLabelsPayload)
+	if !ok {
+		return nil, errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobSetReviewersAndLabelsPayload{}, job.Payload)
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+		RemoteRepo: remoteRepo,
+	}
+	opts := sources.ReviewersAndLabels{
+		Reviewers: typedPayload.Reviewers,
+		Assignees: typedPayload.Assignees,
+		Labels:    typedPayload.Labels,
+	}
+	if err := b.css.SetReviewersAndLabels(ctx, cs, opts); err != nil {
+		return nil, err
+	}
+
+	if err := b.updateCodeHostState(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetUpdate) }
+	return afterDone, nil
+}
+
+func (b *bulkProcessor) rerunFailedChecks(ctx context.Context) (afterDone func(*store.Store), err error) {
+	crcss, ok := b.css.(sources.CheckRerunnableChangesetSource)
+	if !ok {
+		return nil, errcode.MakeNonRetryable(errors.New("code host does not support re-running checks"))
+	}
+	// There's nothing to do if the checks have been re-run or succeeded since
+	// the bulk operation was created.
+	if len(b.ch.RerunnableFailedChecks()) == 0 {
+		return nil, nil
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := crcss.RerunFailedChecks(ctx, cs); err != nil {
+		return nil, err
+	}
+	// Reload the changeset so the re-run checks show up as pending.
+	if err := b.css.LoadChangeset(ctx, cs); err != nil {
+		return nil, errors.Wrap(err, "reloading changeset")
+	}
+
+	if err := b.updateCodeHostState(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetUpdate) }
+	return afterDone, nil
+}
+
+// updateCodeHostState persists the events and code host state of a changeset
+// after it has been modified on the code host.
+func (b *bulkProcessor) updateCodeHostState(ctx context.Context, cs *sources.Changeset) error {
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		b.logger.Error("Events", log.Error(err))
+		return errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient(), cs.Changeset, events)
+
+	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		b.logger.Error("UpsertChangesetEvents", log.Error(err))
+		return errcode.MakeNonRetryable(err)
+	}
+
+	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		b.logger.Error("UpdateChangeset", log.Error(err))
+		return errcode.MakeNonRetryable(err)
+	}
+
+	return nil
+}
+
 func (b *bulkProcessor) enqueueWebhook(ctx context.Context, store *store.Store, eventType string) {
 	webhooks.EnqueueChangeset(ctx, b.logger, store, eventType, bgql.MarshalChangesetID(b.ch.ID))
 }
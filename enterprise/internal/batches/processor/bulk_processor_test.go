@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
@@ -310,6 +312,154 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Set reviewers and labels job", func(t *testing.T) {
+		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeSetReviewersAndLabels,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload: &btypes.ChangesetJobSetReviewersAndLabelsPayload{
+				Reviewers: []string{"alice"},
+				Labels:    []string{"batch-change"},
+			},
+		}
+		afterDone, err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.SetReviewersAndLabelsCalled {
+			t.Fatal("expected SetReviewersAndLabels to be called but wasn't")
+		}
+		want := []sources.ReviewersAndLabels{{Reviewers: []string{"alice"}, Labels: []string{"batch-change"}}}
+		if diff := cmp.Diff(want, fake.ReviewersAndLabels); diff != "" {
+			t.Fatalf("wrong reviewers and labels (-want +got):\n%s", diff)
+		}
+		if afterDone == nil {
+			t.Fatal("unexpected nil afterDone")
+		}
+
+		// Ensure that the appropriate webhook job will be created
+		afterDone(bstore)
+		webhook, err := wstore.GetLast(ctx)
+
+		if err != nil {
+			t.Fatalf("could not get latest webhook job: %s", err)
+		}
+		if webhook == nil {
+			t.Fatalf("expected webhook job to be created")
+		}
+		if webhook.EventType != webhooks.ChangesetUpdate {
+			t.Fatalf("wrong webhook job type. want=%s, have=%s", webhooks.ChangesetUpdate, webhook.EventType)
+		}
+	})
+
+	t.Run("Set reviewers and labels job with invalid payload", func(t *testing.T) {
+		fake := &stesting.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeSetReviewersAndLabels,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobCommentPayload{},
+		}
+		if _, err := bp.Process(ctx, job); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+		if fake.SetReviewersAndLabelsCalled {
+			t.Fatal("expected SetReviewersAndLabels not to be called")
+		}
+	})
+
+	t.Run("Rerun failed checks job", func(t *testing.T) {
+		failingChangeset := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			CurrentSpec:         changesetSpec.ID,
+		})
+		failingChangeset.ExternalChecks = []btypes.ChangesetCheck{
+			{ExternalID: "suite-1", Name: "build", State: btypes.ChangesetCheckStateFailed},
+		}
+		if err := bstore.UpdateChangesetCodeHostState(ctx, failingChangeset); err != nil {
+			t.Fatal(err)
+		}
+
+		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeRerunFailedChecks,
+			ChangesetID: failingChangeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobRerunFailedChecksPayload{},
+		}
+		afterDone, err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.RerunFailedChecksCalled {
+			t.Fatal("expected RerunFailedChecks to be called but wasn't")
+		}
+		if !fake.LoadChangesetCalled {
+			t.Fatal("expected LoadChangeset to be called but wasn't")
+		}
+		if afterDone == nil {
+			t.Fatal("unexpected nil afterDone")
+		}
+
+		// Ensure that the appropriate webhook job will be created
+		afterDone(bstore)
+		webhook, err := wstore.GetLast(ctx)
+
+		if err != nil {
+			t.Fatalf("could not get latest webhook job: %s", err)
+		}
+		if webhook == nil {
+			t.Fatalf("expected webhook job to be created")
+		}
+		if webhook.EventType != webhooks.ChangesetUpdate {
+			t.Fatalf("wrong webhook job type. want=%s, have=%s", webhooks.ChangesetUpdate, webhook.EventType)
+		}
+	})
+
+	t.Run("Rerun failed checks job without failed checks", func(t *testing.T) {
+		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeRerunFailedChecks,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobRerunFailedChecksPayload{},
+		}
+		afterDone, err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fake.RerunFailedChecksCalled {
+			t.Fatal("expected RerunFailedChecks not to be called")
+		}
+		if afterDone != nil {
+			t.Fatal("unexpected non-nil afterDone")
+		}
+	})
+
 	t.Run("Publish job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
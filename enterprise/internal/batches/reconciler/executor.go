@@ -201,7 +201,7 @@ func (e *executor) pushChangesetPatch(ctx context.Context, triggerUpdateWebhook
 	if err != nil {
 		return afterDone, err
 	}
-	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	opts := BuildCommitOpts(e.targetRepo, e.spec, pushConf)
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -670,7 +670,9 @@ func (e *executor) enqueueWebhook(ctx context.Context, store *store.Store, event
 	webhooks.EnqueueChangeset(ctx, e.logger, store, eventType, bgql.MarshalChangesetID(e.ch.ID))
 }
 
-func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+// BuildCommitOpts returns the request to create a commit from the diff in the
+// given changeset spec on top of its base revision.
+func BuildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
 	// IMPORTANT: We add a trailing newline here, otherwise `git apply`
 	// will fail with "corrupt patch at line <N>" where N is the last line.
 	patch := append([]byte{}, spec.Diff...)
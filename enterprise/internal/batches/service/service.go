@@ -90,6 +90,8 @@ type operations struct {
 	fetchUsernameForBitbucketServerToken *observation.Operation
 	validateAuthenticator                *observation.Operation
 	createChangesetJobs                  *observation.Operation
+	setAutoMergePolicy                   *observation.Operation
+	deleteAutoMergePolicy                *observation.Operation
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
@@ -140,6 +142,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 			fetchUsernameForBitbucketServerToken: op("FetchUsernameForBitbucketServerToken"),
 			validateAuthenticator:                op("ValidateAuthenticator"),
 			createChangesetJobs:                  op("CreateChangesetJobs"),
+			setAutoMergePolicy:                   op("SetAutoMergePolicy"),
+			deleteAutoMergePolicy:                op("DeleteAutoMergePolicy"),
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
@@ -408,6 +412,11 @@ type CreateBatchSpecFromRawOpts struct {
 	AllowUnsupported bool
 	NoCache          bool
 
+	// DryRun only resolves the workspaces of the batch spec and records a
+	// preview of them on the resolution job. Dry-run batch specs can't be
+	// executed.
+	DryRun bool
+
 	BatchChange int64
 }
 
@@ -466,6 +475,7 @@ func (s *Service) CreateBatchSpecFromRaw(ctx context.Context, opts CreateBatchSp
 		allowIgnored:     opts.AllowIgnored,
 		allowUnsupported: opts.AllowUnsupported,
 		noCache:          opts.NoCache,
+		dryRun:           opts.DryRun,
 	})
 }
 
@@ -474,6 +484,7 @@ type createBatchSpecForExecutionOpts struct {
 	allowUnsupported bool
 	allowIgnored     bool
 	noCache          bool
+	dryRun           bool
 }
 
 // createBatchSpecForExecution persists the given BatchSpec in the given
@@ -494,6 +505,7 @@ func (s *Service) createBatchSpecForExecution(ctx context.Context, tx *store.Sto
 		State:       btypes.BatchSpecResolutionJobStateQueued,
 		BatchSpecID: opts.spec.ID,
 		InitiatorID: opts.spec.UserID,
+		DryRun:      opts.dryRun,
 	})
 }
 
@@ -510,6 +522,8 @@ func (e ErrBatchSpecResolutionErrored) Error() string {
 
 var ErrBatchSpecResolutionIncomplete = errors.New("cannot execute batch spec, workspaces still being resolved")
 
+var ErrBatchSpecDryRun = errors.New("cannot execute batch spec, workspaces were resolved in a dry-run")
+
 type ExecuteBatchSpecOpts struct {
 	BatchSpecRandID string
 	NoCache         *bool
@@ -553,6 +567,10 @@ func (s *Service) ExecuteBatchSpec(ctx context.Context, opts ExecuteBatchSpecOpt
 		return nil, err
 	}
 
+	if resolutionJob.DryRun {
+		return nil, ErrBatchSpecDryRun
+	}
+
 	switch resolutionJob.State {
 	case btypes.BatchSpecResolutionJobStateErrored, btypes.BatchSpecResolutionJobStateFailed:
 		return nil, ErrBatchSpecResolutionErrored{resolutionJob.FailureMessage}
@@ -761,6 +779,7 @@ func (s *Service) UpsertBatchSpecInput(ctx context.Context, opts UpsertBatchSpec
 		allowIgnored:     opts.AllowIgnored,
 		allowUnsupported: opts.AllowUnsupported,
 		noCache:          opts.NoCache,
+		dryRun:           opts.DryRun,
 	})
 }
 
@@ -1332,6 +1351,56 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	return bulkGroupID, nil
 }
 
+// ErrBatchChangeClosed is returned when an auto-merge policy is set on a closed
+// batch change.
+var ErrBatchChangeClosed = errors.New("batch change is closed")
+
+// SetAutoMergePolicy creates or replaces the auto-merge policy of a batch
+// change. Merges are performed on behalf of the user in the context.
+func (s *Service) SetAutoMergePolicy(ctx context.Context, policy *btypes.AutoMergePolicy) (err error) {
+	ctx, _, endObservation := s.operations.setAutoMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: policy.BatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can configure merges.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	if batchChange.Closed() {
+		return ErrBatchChangeClosed
+	}
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	policy.UserID = sgactor.FromContext(ctx).UID
+	return s.store.UpsertAutoMergePolicy(ctx, policy)
+}
+
+// DeleteAutoMergePolicy removes the auto-merge policy of a batch change.
+func (s *Service) DeleteAutoMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteAutoMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can configure merges.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteAutoMergePolicy(ctx, batchChangeID)
+}
+
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
 // that would publish to the same branch in the same repository.
 // If the return value is nil, then the BatchSpec is valid.
@@ -1638,6 +1707,10 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		btypes.ChangesetJobTypeMerge:     0,
 		btypes.ChangesetJobTypePublish:   0,
 		btypes.ChangesetJobTypeReenqueue: 0,
+
+		btypes.ChangesetJobTypeUpdateBranch:          0,
+		btypes.ChangesetJobTypeSetReviewersAndLabels: 0,
+		btypes.ChangesetJobTypeRerunFailedChecks:     0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1698,6 +1771,17 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
 		}
+
+		// UPDATE_BRANCH and SET_REVIEWERS_AND_LABELS
+		if !isChangesetArchived && !isChangesetJobFailed && (isChangesetOpen || isChangesetDraft) {
+			bulkOperationsCounter[btypes.ChangesetJobTypeUpdateBranch] += 1
+			bulkOperationsCounter[btypes.ChangesetJobTypeSetReviewersAndLabels] += 1
+		}
+
+		// RERUN_FAILED_CHECKS
+		if !isChangesetArchived && (isChangesetOpen || isChangesetDraft) && len(changeset.RerunnableFailedChecks()) > 0 {
+			bulkOperationsCounter[btypes.ChangesetJobTypeRerunFailedChecks] += 1
+		}
 	}
 
 	noOfChangesets := len(opts.Changesets)
@@ -29,6 +29,12 @@ var ErrMatchingBatchChangeExists = errors.New("a batch change matching the given
 // batchSpec exists in the given namespace but has a different ID.
 var ErrEnsureBatchChangeFailed = errors.New("a batch change in the given namespace and with the given name exists but does not match the given ID")
 
+// ErrApplyDryRunBatchSpec is returned by ApplyBatchChange when the workspaces
+// of the batch spec were resolved in a dry-run. Dry-run batch specs don't have
+// any changeset specs, so applying them would detach or close every changeset
+// of the matching batch change.
+var ErrApplyDryRunBatchSpec = errors.New("batch spec was resolved in a dry-run and cannot be applied")
+
 type ApplyBatchChangeOpts struct {
 	BatchSpecRandID     string
 	EnsureBatchChangeID int64
@@ -70,6 +76,16 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
+	if batchSpec.CreatedFromRaw {
+		resolutionJob, err := s.store.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: batchSpec.ID})
+		if err != nil && err != store.ErrNoResults {
+			return nil, err
+		}
+		if resolutionJob != nil && resolutionJob.DryRun {
+			return nil, ErrApplyDryRunBatchSpec
+		}
+	}
+
 	// Validate ChangesetSpecs and return error if they're invalid and the
 	// BatchSpec can't be applied safely.
 	if err := s.ValidateChangesetSpecs(ctx, batchSpec.ID); err != nil {
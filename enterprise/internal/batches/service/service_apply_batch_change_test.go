@@ -1182,6 +1182,38 @@ func TestServiceApplyBatchChange(t *testing.T) {
 		})
 	})
 
+	t.Run("applying dry-run batch spec", func(t *testing.T) {
+		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs", "batch_spec_resolution_jobs")
+		batchSpec := bt.CreateBatchSpec(t, ctx, store, "dry-run-batch-change", admin.ID, 0)
+		bt.CreateBatchChange(t, ctx, store, "dry-run-batch-change", admin.ID, batchSpec.ID)
+
+		dryRunSpec := &btypes.BatchSpec{
+			UserID:          admin.ID,
+			NamespaceUserID: admin.ID,
+			Spec:            batchSpec.Spec,
+			RawSpec:         batchSpec.RawSpec,
+			CreatedFromRaw:  true,
+		}
+		if err := store.CreateBatchSpec(ctx, dryRunSpec); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.CreateBatchSpecResolutionJob(ctx, &btypes.BatchSpecResolutionJob{
+			State:       btypes.BatchSpecResolutionJobStateCompleted,
+			BatchSpecID: dryRunSpec.ID,
+			InitiatorID: admin.ID,
+			DryRun:      true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := svc.ApplyBatchChange(adminCtx, ApplyBatchChangeOpts{
+			BatchSpecRandID: dryRunSpec.RandID,
+		})
+		if err != ErrApplyDryRunBatchSpec {
+			t.Fatalf("ApplyBatchChange returned unexpected error: %s", err)
+		}
+	})
+
 	t.Run("applying to closed batch change", func(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 		batchSpec := bt.CreateBatchSpec(t, ctx, store, "closed-batch-change", admin.ID, 0)
@@ -154,6 +154,20 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("SetAutoMergePolicy", func(t *testing.T) {
+				err := svc.SetAutoMergePolicy(currentUserCtx, &btypes.AutoMergePolicy{
+					BatchChangeID:  batchChange.ID,
+					MergeMethod:    btypes.AutoMergeMethodMerge,
+					RequiredChecks: btypes.AutoMergeRequiredChecksPassed,
+				})
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("DeleteAutoMergePolicy", func(t *testing.T) {
+				err := svc.DeleteAutoMergePolicy(currentUserCtx, batchChange.ID)
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("ExecuteBatchSpec", func(t *testing.T) {
 				_, err := svc.ExecuteBatchSpec(currentUserCtx, ExecuteBatchSpecOpts{
 					BatchSpecRandID: batchSpec.RandID,
@@ -245,6 +259,74 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("SetAutoMergePolicy", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("invalid policy", func(t *testing.T) {
+			err := svc.SetAutoMergePolicy(adminCtx, &btypes.AutoMergePolicy{
+				BatchChangeID:  batchChange.ID,
+				MergeMethod:    "REBASE",
+				RequiredChecks: btypes.AutoMergeRequiredChecksPassed,
+			})
+			if err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+
+		t.Run("valid policy", func(t *testing.T) {
+			err := svc.SetAutoMergePolicy(adminCtx, &btypes.AutoMergePolicy{
+				BatchChangeID:    batchChange.ID,
+				MergeMethod:      btypes.AutoMergeMethodSquash,
+				RequiredChecks:   btypes.AutoMergeRequiredChecksPassedOrNone,
+				MaxMergesPerHour: 3,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := s.GetAutoMergePolicy(ctx, batchChange.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.UserID != admin.ID {
+				t.Fatalf("policy has wrong user: have %d, want %d", have.UserID, admin.ID)
+			}
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			if err := svc.DeleteAutoMergePolicy(adminCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetAutoMergePolicy(ctx, batchChange.ID); err != store.ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, store.ErrNoResults)
+			}
+		})
+
+		t.Run("closed batch change", func(t *testing.T) {
+			batchChange.ClosedAt = now
+			if err := s.UpdateBatchChange(ctx, batchChange); err != nil {
+				t.Fatal(err)
+			}
+
+			err := svc.SetAutoMergePolicy(adminCtx, &btypes.AutoMergePolicy{
+				BatchChangeID:  batchChange.ID,
+				MergeMethod:    btypes.AutoMergeMethodMerge,
+				RequiredChecks: btypes.AutoMergeRequiredChecksPassed,
+			})
+			if err != ErrBatchChangeClosed {
+				t.Fatalf("have err %v, want %v", err, ErrBatchChangeClosed)
+			}
+		})
+	})
+
 	t.Run("CloseBatchChange", func(t *testing.T) {
 		createBatchChange := func(t *testing.T) *btypes.BatchChange {
 			t.Helper()
@@ -2716,7 +2798,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH", "UPDATE_BRANCH", "SET_REVIEWERS_AND_LABELS"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2741,7 +2823,38 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH", "UPDATE_BRANCH", "SET_REVIEWERS_AND_LABELS"}
+			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
+				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
+			}
+		})
+
+		t.Run("open changesets with failed checks", func(t *testing.T) {
+			changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+				Repo:               rs[0].ID,
+				PublicationState:   btypes.ChangesetPublicationStatePublished,
+				BatchChange:        batchChange.ID,
+				OwnedByBatchChange: batchChange.ID,
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+			})
+			changeset.ExternalChecks = []btypes.ChangesetCheck{
+				{ExternalID: "suite-1", Name: "build", State: btypes.ChangesetCheckStateFailed},
+			}
+			if err := s.UpdateChangesetCodeHostState(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			bulkOperations, err := svc.GetAvailableBulkOperations(ctx, GetAvailableBulkOperationsOpts{
+				Changesets: []int64{
+					changeset.ID,
+				},
+				BatchChange: batchChange.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH", "UPDATE_BRANCH", "SET_REVIEWERS_AND_LABELS", "RERUN_FAILED_CHECKS"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2833,7 +2946,7 @@ changesetTemplate:
 			})
 
 			assert.NoError(t, err)
-			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE"}
+			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE", "UPDATE_BRANCH", "SET_REVIEWERS_AND_LABELS"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"sort"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DryRunOpts configures which workspaces are considered to be executed in a
+// dry-run of a batch spec.
+type DryRunOpts struct {
+	// AllowIgnored includes workspaces in repositories with a .batchignore file.
+	AllowIgnored bool
+	// AllowUnsupported includes workspaces on unsupported code hosts.
+	AllowUnsupported bool
+}
+
+// skipRecorder is called for every repository or workspace that matches the
+// `on` definitions of a batch spec but is dropped while resolving workspaces.
+type skipRecorder func(ws *btypes.BatchSpecDryRunSkippedWorkspace)
+
+func (r skipRecorder) record(rev *RepoRevision, path string, reason btypes.BatchSpecDryRunSkipReason) {
+	if r == nil {
+		return
+	}
+
+	r(&btypes.BatchSpecDryRunSkippedWorkspace{
+		RepoID:   rev.Repo.ID,
+		RepoName: rev.Repo.Name,
+		Branch:   rev.Branch,
+		Path:     path,
+		Reason:   reason,
+	})
+}
+
+// newBatchSpecDryRunResult builds the result of a dry-run from the resolved
+// workspaces and the workspaces skipped during resolution.
+func newBatchSpecDryRunResult(
+	spec *batcheslib.BatchSpec,
+	workspaces []*RepoWorkspace,
+	skipped []*btypes.BatchSpecDryRunSkippedWorkspace,
+	opts DryRunOpts,
+) (*btypes.BatchSpecDryRunResult, error) {
+	result := &btypes.BatchSpecDryRunResult{
+		Workspaces: []*btypes.BatchSpecDryRunWorkspace{},
+		Skipped:    []*btypes.BatchSpecDryRunSkippedWorkspace{},
+	}
+
+	// Repositories without a branch are reported once for every `on` entry
+	// they're matched by, so we deduplicate them here.
+	type skipKey struct {
+		repoID int32
+		path   string
+		reason btypes.BatchSpecDryRunSkipReason
+	}
+	seen := make(map[skipKey]struct{}, len(skipped))
+	for _, ws := range skipped {
+		key := skipKey{repoID: int32(ws.RepoID), path: ws.Path, reason: ws.Reason}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result.Skipped = append(result.Skipped, ws)
+	}
+
+	for _, ws := range workspaces {
+		var reason btypes.BatchSpecDryRunSkipReason
+		if ws.Ignored && !opts.AllowIgnored {
+			reason = btypes.BatchSpecDryRunSkipReasonIgnored
+		} else if ws.Unsupported && !opts.AllowUnsupported {
+			reason = btypes.BatchSpecDryRunSkipReasonUnsupported
+		}
+		if reason != "" {
+			result.Skipped = append(result.Skipped, &btypes.BatchSpecDryRunSkippedWorkspace{
+				RepoID:   ws.Repo.ID,
+				RepoName: ws.Repo.Name,
+				Branch:   ws.Branch,
+				Path:     ws.Path,
+				Reason:   reason,
+			})
+			continue
+		}
+
+		skippedSteps, err := batcheslib.SkippedStepsForRepo(spec, ws.TemplateRepository())
+		if err != nil {
+			return nil, err
+		}
+		steps := []int{}
+		for i := range spec.Steps {
+			if _, ok := skippedSteps[i]; !ok {
+				steps = append(steps, i)
+			}
+		}
+
+		files, err := dryRunStepFiles(spec, ws.TemplateRepository(), steps)
+		if err != nil {
+			return nil, err
+		}
+
+		changesets := maxChangesets(spec, string(ws.Repo.Name), steps)
+		result.Workspaces = append(result.Workspaces, &btypes.BatchSpecDryRunWorkspace{
+			RepoID:        ws.Repo.ID,
+			RepoName:      ws.Repo.Name,
+			Branch:        ws.Branch,
+			Commit:        string(ws.Commit),
+			Path:          ws.Path,
+			FileMatches:   ws.FileMatches,
+			Steps:         steps,
+			Files:         files,
+			MaxChangesets: changesets,
+		})
+		result.MaxChangesets += changesets
+	}
+
+	sort.Slice(result.Skipped, func(i, j int) bool {
+		if result.Skipped[i].RepoName != result.Skipped[j].RepoName {
+			return result.Skipped[i].RepoName < result.Skipped[j].RepoName
+		}
+		return result.Skipped[i].Path < result.Skipped[j].Path
+	})
+
+	switch {
+	case len(result.Workspaces) > 0:
+		result.Outcome = btypes.BatchSpecDryRunOutcomeWorkspaces
+	case len(result.Skipped) > 0:
+		result.Outcome = btypes.BatchSpecDryRunOutcomeAllSkipped
+	default:
+		result.Outcome = btypes.BatchSpecDryRunOutcomeNoMatches
+	}
+
+	return result, nil
+}
+
+// dryRunStepFiles renders the `files:` of the given steps with the information
+// that is available before the steps are executed. Files whose content depends
+// on values that are only known at execution time are reported without
+// content.
+func dryRunStepFiles(spec *batcheslib.BatchSpec, repo template.Repository, steps []int) ([]*btypes.BatchSpecDryRunStepFile, error) {
+	stepCtx := &template.StepContext{
+		Repository: repo,
+		BatchChange: template.BatchChangeAttributes{
+			Name:        spec.Name,
+			Description: spec.Description,
+		},
+	}
+
+	files := []*btypes.BatchSpecDryRunStepFile{}
+	for _, idx := range steps {
+		paths := make([]string, 0, len(spec.Steps[idx].Files))
+		for path := range spec.Steps[idx].Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			static, content, err := template.IsStaticString(spec.Steps[idx].Files[path], stepCtx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "evaluating file %q of step %d", path, idx)
+			}
+
+			file := &btypes.BatchSpecDryRunStepFile{Step: idx, Path: path}
+			if static {
+				file.Content = &content
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// maxChangesets returns the maximum number of changesets a workspace in the
+// given repository that runs the given steps can produce. The diff of a
+// workspace isn't known before its steps are executed, so every branch it
+// could be split into by the transformChanges groups of the repository is
+// counted. Workspaces that don't run any steps produce no changesets.
+func maxChangesets(spec *batcheslib.BatchSpec, repoName string, steps []int) int {
+	if spec.ChangesetTemplate == nil || len(steps) == 0 {
+		return 0
+	}
+
+	branches := map[string]struct{}{spec.ChangesetTemplate.Branch: {}}
+	if spec.TransformChanges != nil {
+		for _, g := range spec.TransformChanges.Group {
+			if g.Repository == "" || g.Repository == repoName {
+				branches[g.Branch] = struct{}{}
+			}
+		}
+	}
+
+	return len(branches)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestNewBatchSpecDryRunResult(t *testing.T) {
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	ignoredRepo := &types.Repo{ID: 2, Name: "github.com/sourcegraph/ignored"}
+	unsupportedRepo := &types.Repo{ID: 3, Name: "git-codecommit.us-est-1.amazonaws.com/unsupported"}
+	emptyRepo := &types.Repo{ID: 4, Name: "github.com/sourcegraph/empty"}
+
+	spec := &batcheslib.BatchSpec{
+		Steps: []batcheslib.Step{
+			{Run: "echo 1"},
+			{Run: "echo 2", If: `${{ eq repository.name "github.com/sourcegraph/ignored" }}`},
+			{Run: "echo 3", If: `${{ outputs.foo }}`, Files: map[string]string{
+				"/tmp/repo.txt":   "${{ repository.name }}",
+				"/tmp/output.txt": "${{ outputs.foo }}",
+			}},
+		},
+		ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch"},
+	}
+	workspaces := []*RepoWorkspace{
+		{RepoRevision: &RepoRevision{Repo: repo, Branch: "main", Commit: "d34db33f", FileMatches: []string{"README.md"}}, Path: ""},
+		{RepoRevision: &RepoRevision{Repo: ignoredRepo, Branch: "main", Commit: "c0ff33"}, Path: "", Ignored: true},
+		{RepoRevision: &RepoRevision{Repo: unsupportedRepo, Branch: "main", Commit: "f00b4r"}, Path: "", Unsupported: true},
+	}
+	skipped := []*btypes.BatchSpecDryRunSkippedWorkspace{
+		{RepoID: emptyRepo.ID, RepoName: emptyRepo.Name, Reason: btypes.BatchSpecDryRunSkipReasonNoBranch},
+		// Reported a second time for another `on` entry.
+		{RepoID: emptyRepo.ID, RepoName: emptyRepo.Name, Reason: btypes.BatchSpecDryRunSkipReasonNoBranch},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		have, err := newBatchSpecDryRunResult(spec, workspaces, skipped, DryRunOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		repoName := string(repo.Name)
+		want := &btypes.BatchSpecDryRunResult{
+			Outcome: btypes.BatchSpecDryRunOutcomeWorkspaces,
+			Workspaces: []*btypes.BatchSpecDryRunWorkspace{
+				{
+					RepoID:      1,
+					RepoName:    repo.Name,
+					Branch:      "main",
+					Commit:      "d34db33f",
+					FileMatches: []string{"README.md"},
+					Steps:       []int{0, 2},
+					Files: []*btypes.BatchSpecDryRunStepFile{
+						{Step: 2, Path: "/tmp/output.txt"},
+						{Step: 2, Path: "/tmp/repo.txt", Content: &repoName},
+					},
+					MaxChangesets: 1,
+				},
+			},
+			Skipped: []*btypes.BatchSpecDryRunSkippedWorkspace{
+				{RepoID: 3, RepoName: unsupportedRepo.Name, Branch: "main", Reason: btypes.BatchSpecDryRunSkipReasonUnsupported},
+				{RepoID: 4, RepoName: emptyRepo.Name, Reason: btypes.BatchSpecDryRunSkipReasonNoBranch},
+				{RepoID: 2, RepoName: ignoredRepo.Name, Branch: "main", Reason: btypes.BatchSpecDryRunSkipReasonIgnored},
+			},
+			MaxChangesets: 1,
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected result (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("allow ignored and unsupported", func(t *testing.T) {
+		have, err := newBatchSpecDryRunResult(spec, workspaces, nil, DryRunOpts{AllowIgnored: true, AllowUnsupported: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(have.Workspaces) != 3 {
+			t.Errorf("unexpected number of workspaces. want=%d have=%d", 3, len(have.Workspaces))
+		}
+		if len(have.Skipped) != 0 {
+			t.Errorf("unexpected number of skipped workspaces. want=%d have=%d", 0, len(have.Skipped))
+		}
+		if have.MaxChangesets != 3 {
+			t.Errorf("unexpected estimated changesets. want=%d have=%d", 3, have.MaxChangesets)
+		}
+		if diff := cmp.Diff([]int{0, 1, 2}, have.Workspaces[1].Steps); diff != "" {
+			t.Errorf("unexpected steps for ignored repo (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("all skipped", func(t *testing.T) {
+		have, err := newBatchSpecDryRunResult(spec, workspaces[1:], skipped, DryRunOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(have.Workspaces) != 0 {
+			t.Errorf("unexpected number of workspaces. want=%d have=%d", 0, len(have.Workspaces))
+		}
+		if have.Outcome != btypes.BatchSpecDryRunOutcomeAllSkipped {
+			t.Errorf("unexpected outcome. want=%s have=%s", btypes.BatchSpecDryRunOutcomeAllSkipped, have.Outcome)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		have, err := newBatchSpecDryRunResult(spec, nil, nil, DryRunOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have.Outcome != btypes.BatchSpecDryRunOutcomeNoMatches {
+			t.Errorf("unexpected outcome. want=%s have=%s", btypes.BatchSpecDryRunOutcomeNoMatches, have.Outcome)
+		}
+	})
+}
+
+func TestMaxChangesets(t *testing.T) {
+	repoName := "github.com/sourcegraph/sourcegraph"
+	transform := &batcheslib.TransformChanges{
+		Group: []batcheslib.Group{
+			{Directory: "client", Branch: "my-branch-client"},
+			{Directory: "cmd", Branch: "my-branch-cmd"},
+			{Directory: "enterprise/cmd", Branch: "my-branch-cmd"},
+			{Directory: "docs", Branch: "my-branch-docs", Repository: "github.com/sourcegraph/other"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		spec  *batcheslib.BatchSpec
+		steps []int
+		want  int
+	}{
+		"no changeset template": {
+			spec:  &batcheslib.BatchSpec{},
+			steps: []int{0},
+			want:  0,
+		},
+		"no steps": {
+			spec: &batcheslib.BatchSpec{ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch"}},
+			want: 0,
+		},
+		"no transformChanges": {
+			spec:  &batcheslib.BatchSpec{ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch"}},
+			steps: []int{0},
+			want:  1,
+		},
+		"transformChanges": {
+			spec:  &batcheslib.BatchSpec{ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch"}, TransformChanges: transform},
+			steps: []int{0},
+			want:  3,
+		},
+		"transformChanges group on the template branch": {
+			spec:  &batcheslib.BatchSpec{ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch-client"}, TransformChanges: transform},
+			steps: []int{0},
+			want:  2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := maxChangesets(tc.spec, repoName, tc.steps); have != tc.want {
+				t.Errorf("unexpected number of changesets. want=%d have=%d", tc.want, have)
+			}
+		})
+	}
+}
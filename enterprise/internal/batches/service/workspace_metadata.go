@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// repoMetadataLoader loads the repository metadata that is available in batch
+// spec templates. Since a repository can be matched by multiple `on` entries,
+// the metadata is loaded at most once per repository revision.
+type repoMetadataLoader struct {
+	logger log.Logger
+	db     database.DB
+	// owners is nil if the batch spec doesn't reference code owners, so we
+	// don't look up CODEOWNERS files that are never used.
+	owners own.Service
+
+	metadata map[api.RepoID]*template.RepositoryMetadata
+	rulesets map[repoRevKey]*codeowners.Ruleset
+}
+
+func newRepoMetadataLoader(logger log.Logger, db database.DB, gitserverClient gitserver.Client, spec *batcheslib.BatchSpec) (*repoMetadataLoader, error) {
+	l := &repoMetadataLoader{
+		logger:   logger,
+		db:       db,
+		metadata: make(map[api.RepoID]*template.RepositoryMetadata),
+		rulesets: make(map[repoRevKey]*codeowners.Ruleset),
+	}
+
+	referencesCodeOwners, err := specReferences(spec, "repository", "code_owners")
+	if err != nil {
+		return nil, err
+	}
+	if referencesCodeOwners {
+		l.owners = own.NewService(gitserverClient, db)
+	}
+
+	return l, nil
+}
+
+// load sets the metadata of the given repository revision. The metadata is left
+// nil if the repository has no key-value pairs and topics.
+func (l *repoMetadataLoader) load(ctx context.Context, rev *RepoRevision) error {
+	md, ok := l.metadata[rev.Repo.ID]
+	if !ok {
+		kvps, err := l.db.RepoKVPs().List(ctx, rev.Repo.ID)
+		if err != nil {
+			return errors.Wrap(err, "listing repository key-value pairs")
+		}
+
+		topics := repoTopics(rev.Repo)
+		if len(kvps) > 0 || len(topics) > 0 {
+			md = &template.RepositoryMetadata{
+				KeyValuePairs: make(map[string]string, len(kvps)),
+				Topics:        topics,
+			}
+		}
+		for _, kvp := range kvps {
+			// Tags are key-value pairs without a value.
+			var value string
+			if kvp.Value != nil {
+				value = *kvp.Value
+			}
+			md.KeyValuePairs[kvp.Key] = value
+		}
+		l.metadata[rev.Repo.ID] = md
+	}
+	rev.Metadata = md
+	rev.MetadataLoaded = true
+
+	if l.owners == nil {
+		return nil
+	}
+
+	rs, ok := l.rulesets[rev.Key()]
+	if !ok {
+		var err error
+		rs, err = l.owners.RulesetForRepo(ctx, rev.Repo.Name, rev.Repo.ID, rev.Commit)
+		if err != nil {
+			// A broken CODEOWNERS file shouldn't fail the whole batch spec,
+			// the repository is treated as having no code owners instead.
+			l.logger.Warn("loading CODEOWNERS ruleset",
+				log.String("repo", string(rev.Repo.Name)),
+				log.String("commit", string(rev.Commit)),
+				log.Error(err))
+			rs = nil
+		}
+		l.rulesets[rev.Key()] = rs
+	}
+	rev.CodeOwners = rs
+
+	return nil
+}
+
+// specReferences returns true if the templates of the given batch spec
+// reference the given field of the given templating variable, e.g.
+// `repository.code_owners`. The spec is searched as a whole, which is good
+// enough to find out whether the field might be used.
+func specReferences(spec *batcheslib.BatchSpec, variable, field string) (bool, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return false, err
+	}
+	re, err := regexp.Compile(`\b` + regexp.QuoteMeta(variable) + `\.` + regexp.QuoteMeta(field) + `\b`)
+	if err != nil {
+		return false, err
+	}
+	return re.Match(raw), nil
+}
+
+// repoTopics returns the topics of the repository on the code host. Only
+// GitHub repositories have topics.
+func repoTopics(repo *types.Repo) []string {
+	ghRepo, ok := repo.Metadata.(*github.Repository)
+	if !ok {
+		return []string{}
+	}
+
+	topics := make([]string, 0, len(ghRepo.RepositoryTopics.Nodes))
+	for _, node := range ghRepo.RepositoryTopics.Nodes {
+		topics = append(topics, node.Topic.Name)
+	}
+	return topics
+}
+
+// codeOwnersForPaths returns the owners of the given paths according to the
+// ruleset. If no paths are given, the default owners of the repository are
+// returned, which are the owners of the last catch-all rule.
+func codeOwnersForPaths(rs *codeowners.Ruleset, paths []string) []string {
+	if rs == nil {
+		return []string{}
+	}
+
+	var rules []*codeownerspb.Rule
+	if len(paths) == 0 {
+		for _, rule := range rs.GetFile().GetRule() {
+			switch rule.GetPattern() {
+			case "*", "**", "/**":
+				rules = []*codeownerspb.Rule{rule}
+			}
+		}
+	}
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if rule := rs.Match(p); rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	owners := []string{}
+	for _, rule := range rules {
+		for _, o := range rule.GetOwner() {
+			owner := o.GetEmail()
+			if handle := o.GetHandle(); handle != "" {
+				owner = "@" + handle
+			}
+			if _, ok := seen[owner]; ok || owner == "" {
+				continue
+			}
+			seen[owner] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+
+	return owners
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+func TestCodeOwnersForPaths(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader(`
+* @sourcegraph/everyone
+/docs/ @sourcegraph/docs alice@sourcegraph.com
+*.go @sourcegraph/backend
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := codeowners.NewRuleset(codeowners.GitRulesetSource{}, file)
+
+	tests := map[string]struct {
+		rs    *codeowners.Ruleset
+		paths []string
+		want  []string
+	}{
+		"no ruleset": {
+			paths: []string{"main.go"},
+			want:  []string{},
+		},
+		"no paths": {
+			rs:   rs,
+			want: []string{"@sourcegraph/everyone"},
+		},
+		"single path": {
+			rs:    rs,
+			paths: []string{"cmd/main.go"},
+			want:  []string{"@sourcegraph/backend"},
+		},
+		"multiple paths": {
+			rs:    rs,
+			paths: []string{"docs/index.md", "main.go", "docs/admin.md", "README.md"},
+			want:  []string{"@sourcegraph/backend", "@sourcegraph/docs", "@sourcegraph/everyone", "alice@sourcegraph.com"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := codeOwnersForPaths(tt.rs, tt.paths)
+			if diff := cmp.Diff(tt.want, have); diff != "" {
+				t.Fatalf("wrong owners (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRepoTopics(t *testing.T) {
+	ghRepo := &types.Repo{Metadata: &github.Repository{
+		RepositoryTopics: github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+			{Topic: github.Topic{Name: "go"}},
+			{Topic: github.Topic{Name: "cli"}},
+		}},
+	}}
+	if diff := cmp.Diff([]string{"go", "cli"}, repoTopics(ghRepo)); diff != "" {
+		t.Fatalf("wrong topics (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{}, repoTopics(&types.Repo{})); diff != "" {
+		t.Fatalf("wrong topics (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpecReferences(t *testing.T) {
+	tests := map[string]struct {
+		spec *batcheslib.BatchSpec
+		want bool
+	}{
+		"template variable": {
+			spec: &batcheslib.BatchSpec{Steps: []batcheslib.Step{{Run: "echo ${{ join repository.code_owners \" \" }}"}}},
+			want: true,
+		},
+		"other text": {
+			spec: &batcheslib.BatchSpec{Steps: []batcheslib.Step{{Run: "cat code_owners.txt"}}},
+			want: false,
+		},
+		"other variable": {
+			spec: &batcheslib.BatchSpec{Steps: []batcheslib.Step{{Run: "echo ${{ myrepository.code_owners }}"}}},
+			want: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := specReferences(tt.spec, "repository", "code_owners")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tt.want {
+				t.Fatalf("wrong result: want=%t, have=%t", tt.want, have)
+			}
+		})
+	}
+}
+
+func TestTemplateRepositoryMetadata(t *testing.T) {
+	rev := &RepoRevision{Repo: &types.Repo{Name: "github.com/sourcegraph/sourcegraph"}, MetadataLoaded: true}
+	if md := rev.TemplateRepository().Metadata; md != nil {
+		t.Fatalf("unexpected metadata for repository without metadata: %+v", md)
+	}
+
+	file, err := codeowners.Parse(strings.NewReader("* @sourcegraph/everyone\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev.CodeOwners = codeowners.NewRuleset(codeowners.GitRulesetSource{}, file)
+	want := &template.RepositoryMetadata{CodeOwners: []string{"@sourcegraph/everyone"}}
+	if diff := cmp.Diff(want, rev.TemplateRepository().Metadata); diff != "" {
+		t.Fatalf("wrong metadata (-want +got):\n%s", diff)
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
@@ -44,12 +45,46 @@ type RepoRevision struct {
 	Branch      string
 	Commit      api.CommitID
 	FileMatches []string
+	// Metadata is the repository metadata that's available in templates,
+	// without code owners. It's nil if it hasn't been loaded or the
+	// repository has no key-value pairs and topics.
+	Metadata *template.RepositoryMetadata
+	// MetadataLoaded is true once Metadata and CodeOwners have been loaded.
+	MetadataLoaded bool
+
+	// CodeOwners is the CODEOWNERS ruleset at the revision. It's nil if it
+	// hasn't been loaded or the repository has no CODEOWNERS file.
+	CodeOwners *codeowners.Ruleset
 }
 
 func (r *RepoRevision) HasBranch() bool {
 	return r.Branch != ""
 }
 
+// TemplateRepository returns the repository as it's available in templates,
+// including the code owners of the file matches.
+func (r *RepoRevision) TemplateRepository() template.Repository {
+	repo := template.Repository{
+		Name:           string(r.Repo.Name),
+		Branch:         r.Branch,
+		FileMatches:    r.FileMatches,
+		MetadataLoaded: r.MetadataLoaded,
+	}
+
+	// The metadata is left nil if it's empty, so that it's omitted from the
+	// cache keys of repositories without metadata.
+	owners := codeOwnersForPaths(r.CodeOwners, r.FileMatches)
+	if r.Metadata != nil || len(owners) > 0 {
+		var md template.RepositoryMetadata
+		if r.Metadata != nil {
+			md = *r.Metadata
+		}
+		md.CodeOwners = owners
+		repo.Metadata = &md
+	}
+	return repo
+}
+
 type RepoWorkspace struct {
 	*RepoRevision
 	Path string
@@ -68,6 +103,19 @@ type WorkspaceResolver interface {
 		workspaces []*RepoWorkspace,
 		err error,
 	)
+
+	// DryRunWorkspacesForBatchSpec resolves the workspaces of the given batch
+	// spec without executing any steps and reports which workspaces would be
+	// executed, which would be skipped and why, and how many changesets the
+	// batch spec is expected to produce.
+	DryRunWorkspacesForBatchSpec(
+		ctx context.Context,
+		batchSpec *batcheslib.BatchSpec,
+		opts DryRunOpts,
+	) (
+		result *btypes.BatchSpecDryRunResult,
+		err error,
+	)
 }
 
 type WorkspaceResolverBuilder func(tx *store.Store) WorkspaceResolver
@@ -95,9 +143,31 @@ func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context,
 		tr.Finish()
 	}()
 
+	return wr.resolveWorkspaces(ctx, batchSpec, nil)
+}
+
+func (wr *workspaceResolver) DryRunWorkspacesForBatchSpec(ctx context.Context, batchSpec *batcheslib.BatchSpec, opts DryRunOpts) (result *btypes.BatchSpecDryRunResult, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.DryRunWorkspacesForBatchSpec", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var skipped []*btypes.BatchSpecDryRunSkippedWorkspace
+	workspaces, err := wr.resolveWorkspaces(ctx, batchSpec, func(ws *btypes.BatchSpecDryRunSkippedWorkspace) {
+		skipped = append(skipped, ws)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newBatchSpecDryRunResult(batchSpec, workspaces, skipped, opts)
+}
+
+func (wr *workspaceResolver) resolveWorkspaces(ctx context.Context, batchSpec *batcheslib.BatchSpec, onSkip skipRecorder) ([]*RepoWorkspace, error) {
 	// First, find all repositories that match the batch spec `on` definitions.
 	// This list is filtered by permissions using database.Repos.List.
-	repos, err := wr.determineRepositories(ctx, batchSpec)
+	repos, err := wr.determineRepositories(ctx, batchSpec, onSkip)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +179,7 @@ func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context,
 	}
 
 	// Now build the workspaces for the list of repos.
-	workspaces, err = findWorkspaces(ctx, batchSpec, wr, repos)
+	workspaces, err := findWorkspaces(ctx, batchSpec, wr, repos, onSkip)
 	if err != nil {
 		return nil, err
 	}
@@ -141,9 +211,14 @@ func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context,
 	return workspaces, nil
 }
 
-func (wr *workspaceResolver) determineRepositories(ctx context.Context, batchSpec *batcheslib.BatchSpec) ([]*RepoRevision, error) {
+func (wr *workspaceResolver) determineRepositories(ctx context.Context, batchSpec *batcheslib.BatchSpec, onSkip skipRecorder) ([]*RepoRevision, error) {
 	agg := onlib.NewRepoRevisionAggregator()
 
+	metadata, err := newRepoMetadataLoader(wr.logger, wr.store.DatabaseDB(), wr.gitserverClient, batchSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	var errs error
 	// TODO: this could be trivially parallelised in the future.
 	for _, on := range batchSpec.On {
@@ -157,6 +232,22 @@ func (wr *workspaceResolver) determineRepositories(ctx context.Context, batchSpe
 		for _, rev := range revs {
 			// Skip repos where no branch exists.
 			if !rev.HasBranch() {
+				onSkip.record(rev, "", btypes.BatchSpecDryRunSkipReasonNoBranch)
+				continue
+			}
+
+			if err := metadata.load(ctx, rev); err != nil {
+				return nil, errors.Wrapf(err, "loading metadata of %q", rev.Repo.Name)
+			}
+
+			// Skip repos that don't fulfill the condition of the `on` entry.
+			include, err := evalOnCondition(batchSpec, &on, rev)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				break
+			}
+			if !include {
+				onSkip.record(rev, "", btypes.BatchSpecDryRunSkipReasonOnCondition)
 				continue
 			}
 
@@ -171,6 +262,28 @@ func (wr *workspaceResolver) determineRepositories(ctx context.Context, batchSpe
 	return repoRevs, errs
 }
 
+// evalOnCondition evaluates the `if:` condition of the given `on` entry for
+// the given repository revision.
+func evalOnCondition(batchSpec *batcheslib.BatchSpec, on *batcheslib.OnQueryOrRepository, rev *RepoRevision) (bool, error) {
+	cond := on.IfCondition()
+	if cond == "" {
+		return true, nil
+	}
+
+	stepCtx := &template.StepContext{
+		Repository: rev.TemplateRepository(),
+		BatchChange: template.BatchChangeAttributes{
+			Name:        batchSpec.Name,
+			Description: batchSpec.Description,
+		},
+	}
+	include, err := template.EvalStepCondition(cond, stepCtx)
+	if err != nil {
+		return false, batcheslib.NewValidationError(errors.Wrapf(err, "evaluating if condition of %q", on.String()))
+	}
+	return include, nil
+}
+
 // ignoredWorkspaceResolverConcurrency defines the maximum concurrency level at that
 // findIgnoredRepositories will hit gitserver for file info.
 const ignoredWorkspaceResolverConcurrency = 5
@@ -585,6 +698,7 @@ func findWorkspaces(
 	spec *batcheslib.BatchSpec,
 	finder directoryFinder,
 	repoRevs []*RepoRevision,
+	onSkip skipRecorder,
 ) ([]*RepoWorkspace, error) {
 	// Pre-compile all globs.
 	workspaceMatchers := make(map[batcheslib.WorkspaceConfiguration]glob.Glob)
@@ -703,17 +817,14 @@ func findWorkspaces(
 			repoRevision := *workspace.RepoRevision
 			repoRevision.FileMatches = paths
 
-			steps, err := stepsForRepo(spec, template.Repository{
-				Name:        string(repoRevision.Repo.Name),
-				Branch:      repoRevision.Branch,
-				FileMatches: repoRevision.FileMatches,
-			})
+			steps, err := stepsForRepo(spec, repoRevision.TemplateRepository())
 			if err != nil {
 				return nil, err
 			}
 
 			// If the workspace doesn't have any steps we don't need to include it.
 			if len(steps) == 0 {
+				onSkip.record(&repoRevision, path, btypes.BatchSpecDryRunSkipReasonNoSteps)
 				continue
 			}
 
@@ -18,6 +18,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -31,6 +32,7 @@ import (
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -91,10 +93,11 @@ func TestService_ResolveWorkspacesForBatchSpec(t *testing.T) {
 		}
 		return &RepoWorkspace{
 			RepoRevision: &RepoRevision{
-				Repo:        repo,
-				Branch:      branch,
-				Commit:      api.CommitID(commit),
-				FileMatches: fileMatches,
+				Repo:           repo,
+				Branch:         branch,
+				Commit:         api.CommitID(commit),
+				FileMatches:    fileMatches,
+				MetadataLoaded: true,
 			},
 			Path:               "",
 			OnlyFetchWorkspace: false,
@@ -411,6 +414,132 @@ func TestService_ResolveWorkspacesForBatchSpec(t *testing.T) {
 		want := []*RepoWorkspace{ws1}
 		resolveWorkspacesAndCompare(t, s, gs, u, map[string][]streamhttp.EventMatch{}, batchSpec, want)
 	})
+
+	t.Run("on condition with repository metadata", func(t *testing.T) {
+		if err := db.RepoKVPs().Create(ctx, rs[0].ID, database.KeyValuePair{Key: "frozen"}); err != nil {
+			t.Fatal(err)
+		}
+		owner := "batches"
+		if err := db.RepoKVPs().Create(ctx, rs[1].ID, database.KeyValuePair{Key: "owner", Value: &owner}); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := db.RepoKVPs().Delete(ctx, rs[0].ID, "frozen"); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.RepoKVPs().Delete(ctx, rs[1].ID, "owner"); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		batchSpec := &batcheslib.BatchSpec{
+			On: []batcheslib.OnQueryOrRepository{
+				{Repository: string(rs[0].Name), If: `${{ not (has_key repository.key_value_pairs "frozen") }}`},
+				{Repository: string(rs[1].Name), If: `${{ not (has_key repository.key_value_pairs "frozen") }}`},
+			},
+			Steps: []batcheslib.Step{
+				{Run: "echo 1", If: `${{ eq (index repository.key_value_pairs "owner") "batches" }}`},
+			},
+		}
+
+		gs := newGitserverClient(
+			map[api.CommitID]bool{
+				defaultBranches[rs[0].Name].commit: false,
+				defaultBranches[rs[1].Name].commit: false,
+			},
+			map[string]api.CommitID{
+				defaultBranches[rs[0].Name].branch: defaultBranches[rs[0].Name].commit,
+				defaultBranches[rs[1].Name].branch: defaultBranches[rs[1].Name].commit,
+			},
+		)
+
+		ws1 := buildRepoWorkspace(rs[1], "", "", []string{})
+		ws1.Metadata = &template.RepositoryMetadata{
+			KeyValuePairs: map[string]string{"owner": "batches"},
+			Topics:        []string{},
+		}
+
+		want := []*RepoWorkspace{ws1}
+		resolveWorkspacesAndCompare(t, s, gs, u, map[string][]streamhttp.EventMatch{}, batchSpec, want)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		batchSpec := &batcheslib.BatchSpec{
+			On: []batcheslib.OnQueryOrRepository{
+				{Repository: string(rs[0].Name)},
+				{Repository: string(rs[1].Name)},
+				{Repository: string(rs[3].Name)},
+				{Repository: string(unsupported[0].Name)},
+			},
+			Steps: []batcheslib.Step{
+				// Step should not execute in rs[0]
+				{Run: "echo 1", If: fmt.Sprintf(`${{ ne repository.name %q }}`, rs[0].Name)},
+			},
+			ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "my-branch"},
+		}
+
+		gs := newGitserverClient(
+			map[api.CommitID]bool{
+				defaultBranches[rs[0].Name].commit:          false,
+				defaultBranches[rs[1].Name].commit:          false,
+				defaultBranches[rs[3].Name].commit:          true,
+				defaultBranches[unsupported[0].Name].commit: false,
+			},
+			map[string]api.CommitID{
+				defaultBranches[rs[0].Name].branch:          defaultBranches[rs[0].Name].commit,
+				defaultBranches[rs[1].Name].branch:          defaultBranches[rs[1].Name].commit,
+				defaultBranches[rs[3].Name].branch:          defaultBranches[rs[3].Name].commit,
+				defaultBranches[unsupported[0].Name].branch: defaultBranches[unsupported[0].Name].commit,
+			},
+		)
+
+		wr := &workspaceResolver{
+			store:               s,
+			gitserverClient:     gs,
+			frontendInternalURL: newStreamSearchTestServer(t, map[string][]streamhttp.EventMatch{}),
+		}
+		ctx := actor.WithActor(context.Background(), actor.FromUser(u.ID))
+		have, err := wr.DryRunWorkspacesForBatchSpec(ctx, batchSpec, DryRunOpts{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		skipped := func(repo *types.Repo, reason btypes.BatchSpecDryRunSkipReason) *btypes.BatchSpecDryRunSkippedWorkspace {
+			return &btypes.BatchSpecDryRunSkippedWorkspace{
+				RepoID:   repo.ID,
+				RepoName: repo.Name,
+				Branch:   defaultBranches[repo.Name].branch,
+				Reason:   reason,
+			}
+		}
+		want := &btypes.BatchSpecDryRunResult{
+			Outcome: btypes.BatchSpecDryRunOutcomeWorkspaces,
+			Workspaces: []*btypes.BatchSpecDryRunWorkspace{
+				{
+					RepoID:        rs[1].ID,
+					RepoName:      rs[1].Name,
+					Branch:        defaultBranches[rs[1].Name].branch,
+					Commit:        string(defaultBranches[rs[1].Name].commit),
+					FileMatches:   []string{},
+					Steps:         []int{0},
+					Files:         []*btypes.BatchSpecDryRunStepFile{},
+					MaxChangesets: 1,
+				},
+			},
+			Skipped: []*btypes.BatchSpecDryRunSkippedWorkspace{
+				skipped(rs[0], btypes.BatchSpecDryRunSkipReasonNoSteps),
+				skipped(rs[3], btypes.BatchSpecDryRunSkipReasonIgnored),
+				skipped(unsupported[0], btypes.BatchSpecDryRunSkipReasonUnsupported),
+			},
+			MaxChangesets: 1,
+		}
+		// The skipped workspaces are sorted by repository name.
+		sort.Slice(want.Skipped, func(i, j int) bool { return want.Skipped[i].RepoName < want.Skipped[j].RepoName })
+
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("returned dry-run result wrong. (-want +got):\n%s", diff)
+		}
+	})
 }
 
 func resolveWorkspacesAndCompare(t *testing.T, s *store.Store, gs gitserver.Client, u *types.User, matches map[string][]streamhttp.EventMatch, spec *batcheslib.BatchSpec, want []*RepoWorkspace) {
@@ -666,7 +795,7 @@ func TestFindWorkspaces(t *testing.T) {
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			finder := &mockDirectoryFinder{results: tt.finderResults}
-			workspaces, err := findWorkspaces(context.Background(), tt.spec, finder, repoRevs)
+			workspaces, err := findWorkspaces(context.Background(), tt.spec, finder, repoRevs, nil)
 			if err != nil {
 				if tt.wantErr != nil {
 					require.Exactly(t, tt.wantErr.Error(), err.Error(), "wrong error returned")
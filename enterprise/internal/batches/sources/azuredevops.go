@@ -24,7 +24,10 @@ type AzureDevOpsSource struct {
 	client azuredevops.Client
 }
 
-var _ ForkableChangesetSource = AzureDevOpsSource{}
+var (
+	_ ForkableChangesetSource        = AzureDevOpsSource{}
+	_ CheckRerunnableChangesetSource = AzureDevOpsSource{}
+)
 
 func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -280,6 +283,56 @@ func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, cs *Changeset, sq
 	return errors.Wrap(s.setChangesetMetadata(ctx, repo, &updated, cs), "setting Azure DevOps changeset metadata")
 }
 
+// SetReviewersAndLabels adds reviewers and labels to the pull request.
+// Reviewers are Azure DevOps identity IDs. Azure DevOps has no concept of
+// assignees on pull requests.
+func (s AzureDevOpsSource) SetReviewersAndLabels(ctx context.Context, cs *Changeset, opts ReviewersAndLabels) error {
+	if len(opts.Assignees) > 0 {
+		return UnsupportedChangesetAttributeError{Attribute: "assignees", CodeHost: "Azure DevOps"}
+	}
+
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	args, err := s.createCommonPullRequestArgs(*repo, *cs)
+	if err != nil {
+		return err
+	}
+
+	for _, reviewer := range opts.Reviewers {
+		if _, err := s.client.AddPullRequestReviewer(ctx, args, reviewer); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", reviewer)
+		}
+	}
+	for _, label := range opts.Labels {
+		if _, err := s.client.AddPullRequestLabel(ctx, args, label); err != nil {
+			return errors.Wrapf(err, "adding label %q", label)
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// RerunFailedChecks retries the failed Azure Pipelines builds of the pull
+// request. Statuses that weren't posted by a build can't be re-run.
+func (s AzureDevOpsSource) RerunFailedChecks(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	org, err := repo.GetOrganization()
+	if err != nil {
+		return errors.Wrap(err, "getting Azure DevOps organization from project")
+	}
+
+	for _, buildID := range cs.Changeset.RerunnableFailedChecks() {
+		id, err := strconv.Atoi(buildID)
+		if err != nil {
+			return errors.Wrapf(err, "parsing build ID %q", buildID)
+		}
+		if _, err := s.client.RetryBuild(ctx, org, repo.Project.Name, id); err != nil {
+			return errors.Wrapf(err, "retrying build %d", id)
+		}
+	}
+
+	return nil
+}
+
 // GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
 // exists and creating it if it doesn't. If namespace is not provided, the original namespace is used.
 // If name is not provided, the fork will be named with the default Sourcegraph convention:
@@ -660,6 +660,45 @@ func TestAzureDevOpsSource_MergeChangeset(t *testing.T) {
 	})
 }
 
+func TestAzureDevOpsSource_RerunFailedChecks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error from RetryBuild", func(t *testing.T) {
+		cs, _ := mockAzureDevOpsChangeset()
+		cs.ExternalChecks = []btypes.ChangesetCheck{
+			{ExternalID: "7", Name: "build", State: btypes.ChangesetCheckStateFailed},
+		}
+		s, client := mockAzureDevOpsSource()
+
+		want := errors.New("error")
+		client.RetryBuildFunc.SetDefaultReturn(azuredevops.Build{}, want)
+
+		err := s.RerunFailedChecks(ctx, cs)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		cs, _ := mockAzureDevOpsChangeset()
+		cs.ExternalChecks = []btypes.ChangesetCheck{
+			{ExternalID: "7", Name: "build", State: btypes.ChangesetCheckStateFailed},
+			{ExternalID: "8", Name: "test", State: btypes.ChangesetCheckStatePassed},
+			{Name: "external", State: btypes.ChangesetCheckStateFailed},
+		}
+		s, client := mockAzureDevOpsSource()
+
+		client.RetryBuildFunc.SetDefaultHook(func(ctx context.Context, org, project string, buildID int) (azuredevops.Build, error) {
+			assert.Equal(t, testOrgName, org)
+			assert.Equal(t, testProjectName, project)
+			assert.Equal(t, 7, buildID)
+			return azuredevops.Build{ID: buildID}, nil
+		})
+
+		err := s.RerunFailedChecks(ctx, cs)
+		assert.Nil(t, err)
+		assert.Len(t, client.RetryBuildFunc.History(), 1)
+	})
+}
+
 func TestAzureDevOpsSource_GetFork(t *testing.T) {
 	ctx := context.Background()
 
@@ -215,6 +215,57 @@ func (s BitbucketCloudSource) MergeChangeset(ctx context.Context, cs *Changeset,
 	return s.setChangesetMetadata(ctx, repo, updated, cs)
 }
 
+// SetReviewersAndLabels adds reviewers to the pull request. Reviewers are
+// Bitbucket Cloud account UUIDs. Bitbucket Cloud has no concept of assignees or
+// labels on pull requests.
+func (s BitbucketCloudSource) SetReviewersAndLabels(ctx context.Context, cs *Changeset, opts ReviewersAndLabels) error {
+	if len(opts.Assignees) > 0 {
+		return UnsupportedChangesetAttributeError{Attribute: "assignees", CodeHost: "Bitbucket Cloud"}
+	}
+	if len(opts.Labels) > 0 {
+		return UnsupportedChangesetAttributeError{Attribute: "labels", CodeHost: "Bitbucket Cloud"}
+	}
+
+	repo := cs.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr := cs.Metadata.(*bbcs.AnnotatedPullRequest)
+
+	reviewers := append([]bitbucketcloud.Account{}, pr.Reviewers...)
+	for _, uuid := range opts.Reviewers {
+		if !hasBitbucketCloudReviewer(reviewers, uuid) {
+			reviewers = append(reviewers, bitbucketcloud.Account{UUID: uuid})
+		}
+	}
+
+	// The endpoint for updating a pull request is a PUT endpoint, so we have to
+	// provide the current values of all other fields.
+	input := bitbucketcloud.PullRequestInput{
+		Title:             pr.Title,
+		Description:       pr.Summary.Raw,
+		SourceBranch:      pr.Source.Branch.Name,
+		DestinationBranch: &pr.Destination.Branch.Name,
+		Reviewers:         reviewers,
+	}
+	if pr.Source.Repo.FullName != repo.FullName {
+		input.SourceRepo = &pr.Source.Repo
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, input)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+func hasBitbucketCloudReviewer(reviewers []bitbucketcloud.Account, uuid string) bool {
+	for _, r := range reviewers {
+		if r.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
 // GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
 // exists and creating it if it doesn't. If namespace is not provided, the fork will be in
 // the currently authenticated user's namespace. If name is not provided, the fork will be
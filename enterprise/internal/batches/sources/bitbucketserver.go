@@ -280,6 +280,54 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	return c.Changeset.SetMetadata(merged)
 }
 
+// SetReviewersAndLabels adds reviewers to the pull request. Reviewers are
+// Bitbucket Server usernames. Bitbucket Server has no concept of assignees or
+// labels on pull requests.
+func (s BitbucketServerSource) SetReviewersAndLabels(ctx context.Context, c *Changeset, opts ReviewersAndLabels) error {
+	if len(opts.Assignees) > 0 {
+		return UnsupportedChangesetAttributeError{Attribute: "assignees", CodeHost: "Bitbucket Server"}
+	}
+	if len(opts.Labels) > 0 {
+		return UnsupportedChangesetAttributeError{Attribute: "labels", CodeHost: "Bitbucket Server"}
+	}
+
+	var updated *bitbucketserver.PullRequest
+	_, err := s.callAndRetryIfOutdated(ctx, c, func(ctx context.Context, pr *bitbucketserver.PullRequest) (err error) {
+		reviewers := append([]bitbucketserver.Reviewer{}, pr.Reviewers...)
+		for _, name := range opts.Reviewers {
+			if !hasBitbucketServerReviewer(reviewers, name) {
+				reviewers = append(reviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+			}
+		}
+
+		// The endpoint for updating a pull request is a PUT endpoint, so we
+		// have to provide the current values of all other fields.
+		updated, err = s.client.UpdatePullRequest(ctx, &bitbucketserver.UpdatePullRequestInput{
+			PullRequestID: strconv.Itoa(pr.ID),
+			Version:       pr.Version,
+			Title:         pr.Title,
+			Description:   pr.Description,
+			ToRef:         pr.ToRef,
+			Reviewers:     reviewers,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+func hasBitbucketServerReviewer(reviewers []bitbucketserver.Reviewer, name string) bool {
+	for _, r := range reviewers {
+		if r.User != nil && r.User.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type bitbucketClientFunc func(context.Context, *bitbucketserver.PullRequest) error
 
 func (s BitbucketServerSource) callAndRetryIfOutdated(ctx context.Context, c *Changeset, fn bitbucketClientFunc) (*bitbucketserver.PullRequest, error) {
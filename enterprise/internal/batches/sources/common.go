@@ -48,6 +48,28 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A BranchUpdatableChangesetSource can bring the head branch of a changeset up
+// to date with its base branch using the code host's own update mechanism.
+type BranchUpdatableChangesetSource interface {
+	ChangesetSource
+
+	// UpdateChangesetBranch updates the head branch of the Changeset on the
+	// code host with the latest changes of its base branch.
+	UpdateChangesetBranch(context.Context, *Changeset) error
+}
+
+// A CheckRerunnableChangesetSource can re-run the failed CI checks of a
+// changeset through the code host's API.
+type CheckRerunnableChangesetSource interface {
+	ChangesetSource
+
+	// RerunFailedChecks re-runs the failed checks of the Changeset, as
+	// returned by Changeset.RerunnableFailedChecks. The checks are re-run
+	// asynchronously, so the Changeset reflects their new state once it is
+	// synced again.
+	RerunFailedChecks(context.Context, *Changeset) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -96,8 +118,37 @@ type ChangesetSource interface {
 	// merge. If the changeset cannot be merged, because it is in an unmergeable
 	// state, ChangesetNotMergeableError must be returned.
 	MergeChangeset(ctx context.Context, ch *Changeset, squash bool) error
+	// SetReviewersAndLabels requests reviews from the given reviewers, assigns
+	// the given assignees and adds the given labels to the Changeset. Existing
+	// reviewers, assignees and labels are retained. If the code host has no
+	// concept of one of the given attributes, UnsupportedChangesetAttributeError
+	// must be returned.
+	SetReviewersAndLabels(ctx context.Context, ch *Changeset, opts ReviewersAndLabels) error
 }
 
+// ReviewersAndLabels describes the reviewers, assignees and labels to add to a
+// changeset. Users are identified in the format the code host expects, such as
+// usernames on GitHub or account UUIDs on Bitbucket Cloud.
+type ReviewersAndLabels struct {
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+}
+
+// UnsupportedChangesetAttributeError is returned by SetReviewersAndLabels if
+// the code host has no concept of the given attribute, such as labels on
+// Bitbucket Server.
+type UnsupportedChangesetAttributeError struct {
+	Attribute string
+	CodeHost  string
+}
+
+func (e UnsupportedChangesetAttributeError) Error() string {
+	return fmt.Sprintf("%s does not support %s on changesets", e.CodeHost, e.Attribute)
+}
+
+func (e UnsupportedChangesetAttributeError) NonRetryable() bool { return true }
+
 // ChangesetNotMergeableError is returned by MergeChangeset if the changeset
 // could not be merged on the codehost, because some precondition is not met. This
 // is only returned, if the changeset is not mergeable. Other errors, such as
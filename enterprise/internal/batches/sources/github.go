@@ -25,7 +25,11 @@ type GitHubSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = GitHubSource{}
+var (
+	_ ForkableChangesetSource        = GitHubSource{}
+	_ BranchUpdatableChangesetSource = GitHubSource{}
+	_ CheckRerunnableChangesetSource = GitHubSource{}
+)
 
 func NewGitHubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitHubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -284,6 +288,67 @@ func (s GitHubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// UpdateChangesetBranch merges the latest changes of the base branch into the
+// head branch of the pull request.
+func (s GitHubSource) UpdateChangesetBranch(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.UpdatePullRequestBranch(ctx, pr); err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
+// SetReviewersAndLabels requests reviews, assigns users and adds labels to the
+// pull request. Reviewers and assignees are GitHub logins.
+func (s GitHubSource) SetReviewersAndLabels(ctx context.Context, c *Changeset, opts ReviewersAndLabels) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+	pr.RepoWithOwner = c.TargetRepo.Metadata.(*github.Repository).NameWithOwner
+
+	if len(opts.Reviewers) > 0 {
+		if err := s.client.RequestPullRequestReviews(ctx, pr, opts.Reviewers); err != nil {
+			return errors.Wrap(err, "requesting reviews")
+		}
+	}
+	if len(opts.Assignees) > 0 {
+		if err := s.client.AddPullRequestAssignees(ctx, pr, opts.Assignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+	if len(opts.Labels) > 0 {
+		if err := s.client.AddPullRequestLabels(ctx, pr, opts.Labels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
+
+// RerunFailedChecks re-requests the check suites of the failed check runs of
+// the pull request. Failed commit statuses are posted by external CI systems
+// and can't be re-run through GitHub.
+func (s GitHubSource) RerunFailedChecks(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	for _, checkSuiteID := range c.Changeset.RerunnableFailedChecks() {
+		if err := s.client.RerequestCheckSuite(ctx, pr.BaseRepository.ID, checkSuiteID); err != nil {
+			return errors.Wrapf(err, "re-requesting check suite %s", checkSuiteID)
+		}
+	}
+
+	return nil
+}
+
 func (GitHubSource) IsPushResponseArchived(s string) bool {
 	return strings.Contains(s, "This repository was archived so it is read-only.")
 }
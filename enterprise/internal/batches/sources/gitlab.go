@@ -29,6 +29,8 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ BranchUpdatableChangesetSource = &GitLabSource{}
+var _ CheckRerunnableChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -550,6 +552,111 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// UpdateChangesetBranch rebases the source branch of the merge request onto
+// its target branch. GitLab performs the rebase asynchronously, so the changeset
+// reflects the new head commit once it is synced again.
+func (s *GitLabSource) UpdateChangesetBranch(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	if err := s.client.RebaseMergeRequest(ctx, project, mr); err != nil {
+		return errors.Wrap(err, "rebasing GitLab merge request")
+	}
+
+	return nil
+}
+
+// SetReviewersAndLabels adds reviewers, assignees and labels to the merge
+// request. Reviewers and assignees are GitLab usernames.
+func (s *GitLabSource) SetReviewersAndLabels(ctx context.Context, c *Changeset, opts ReviewersAndLabels) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	// GitLab replaces the reviewers and assignees of a merge request on
+	// update, so we have to include the existing ones.
+	var update gitlab.UpdateMergeRequestOpts
+	if len(opts.Reviewers) > 0 {
+		ids, err := s.userIDs(ctx, mr.Reviewers, opts.Reviewers)
+		if err != nil {
+			return errors.Wrap(err, "resolving reviewers")
+		}
+		update.ReviewerIDs = ids
+	}
+	if len(opts.Assignees) > 0 {
+		ids, err := s.userIDs(ctx, mr.Assignees, opts.Assignees)
+		if err != nil {
+			return errors.Wrap(err, "resolving assignees")
+		}
+		update.AssigneeIDs = ids
+	}
+	update.AddLabels = strings.Join(opts.Labels, ",")
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, update)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// RerunFailedChecks retries the failed jobs of the latest pipeline of the
+// merge request.
+func (s *GitLabSource) RerunFailedChecks(ctx context.Context, c *Changeset) error {
+	if _, ok := c.Changeset.Metadata.(*gitlab.MergeRequest); !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	for _, pipelineID := range c.Changeset.RerunnableFailedChecks() {
+		id, err := strconv.Atoi(pipelineID)
+		if err != nil {
+			return errors.Wrapf(err, "parsing pipeline ID %q", pipelineID)
+		}
+		if _, err := s.client.RetryPipeline(ctx, project, gitlab.ID(id)); err != nil {
+			return errors.Wrapf(err, "retrying pipeline %d", id)
+		}
+	}
+
+	return nil
+}
+
+// userIDs returns the IDs of the existing users followed by the IDs of the
+// users with the given usernames.
+func (s *GitLabSource) userIDs(ctx context.Context, existing []gitlab.User, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(existing)+len(usernames))
+	seen := make(map[string]struct{}, len(existing))
+	for _, user := range existing {
+		ids = append(ids, user.ID)
+		seen[user.Username] = struct{}{}
+	}
+
+	for _, username := range usernames {
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		seen[username] = struct{}{}
+
+		user, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, user.ID)
+	}
+
+	return ids, nil
+}
+
 func (*GitLabSource) IsPushResponseArchived(s string) bool {
 	return strings.Contains(s, "ERROR: You are not allowed to push code to this project")
 }
@@ -47,6 +47,9 @@ type MockChangesetSource struct {
 	// ReopenChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method ReopenChangeset.
 	ReopenChangesetFunc *ChangesetSourceReopenChangesetFunc
+	// SetReviewersAndLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method SetReviewersAndLabels.
+	SetReviewersAndLabelsFunc *ChangesetSourceSetReviewersAndLabelsFunc
 	// UpdateChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateChangeset.
 	UpdateChangesetFunc *ChangesetSourceUpdateChangesetFunc
@@ -98,6 +101,11 @@ func NewMockChangesetSource() *MockChangesetSource {
 				return
 			},
 		},
+		SetReviewersAndLabelsFunc: &ChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: func(context.Context, *Changeset, ReviewersAndLabels) (r0 error) {
+				return
+			},
+		},
 		UpdateChangesetFunc: &ChangesetSourceUpdateChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) (r0 error) {
 				return
@@ -155,6 +163,11 @@ func NewStrictMockChangesetSource() *MockChangesetSource {
 				panic("unexpected invocation of MockChangesetSource.ReopenChangeset")
 			},
 		},
+		SetReviewersAndLabelsFunc: &ChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: func(context.Context, *Changeset, ReviewersAndLabels) error {
+				panic("unexpected invocation of MockChangesetSource.SetReviewersAndLabels")
+			},
+		},
 		UpdateChangesetFunc: &ChangesetSourceUpdateChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) error {
 				panic("unexpected invocation of MockChangesetSource.UpdateChangeset")
@@ -199,6 +212,9 @@ func NewMockChangesetSourceFrom(i ChangesetSource) *MockChangesetSource {
 		ReopenChangesetFunc: &ChangesetSourceReopenChangesetFunc{
 			defaultHook: i.ReopenChangeset,
 		},
+		SetReviewersAndLabelsFunc: &ChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: i.SetReviewersAndLabels,
+		},
 		UpdateChangesetFunc: &ChangesetSourceUpdateChangesetFunc{
 			defaultHook: i.UpdateChangeset,
 		},
@@ -968,6 +984,118 @@ func (c ChangesetSourceReopenChangesetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ChangesetSourceSetReviewersAndLabelsFunc describes the behavior when the
+// SetReviewersAndLabels method of the parent MockChangesetSource instance
+// is invoked.
+type ChangesetSourceSetReviewersAndLabelsFunc struct {
+	defaultHook func(context.Context, *Changeset, ReviewersAndLabels) error
+	hooks       []func(context.Context, *Changeset, ReviewersAndLabels) error
+	history     []ChangesetSourceSetReviewersAndLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetReviewersAndLabels delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockChangesetSource) SetReviewersAndLabels(v0 context.Context, v1 *Changeset, v2 ReviewersAndLabels) error {
+	r0 := m.SetReviewersAndLabelsFunc.nextHook()(v0, v1, v2)
+	m.SetReviewersAndLabelsFunc.appendCall(ChangesetSourceSetReviewersAndLabelsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetReviewersAndLabels method of the parent MockChangesetSource instance
+// is invoked and the hook queue is empty.
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) SetDefaultHook(hook func(context.Context, *Changeset, ReviewersAndLabels) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetReviewersAndLabels method of the parent MockChangesetSource instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) PushHook(hook func(context.Context, *Changeset, ReviewersAndLabels) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, ReviewersAndLabels) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, ReviewersAndLabels) error {
+		return r0
+	})
+}
+
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) nextHook() func(context.Context, *Changeset, ReviewersAndLabels) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) appendCall(r0 ChangesetSourceSetReviewersAndLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ChangesetSourceSetReviewersAndLabelsFuncCall objects describing the
+// invocations of this function.
+func (f *ChangesetSourceSetReviewersAndLabelsFunc) History() []ChangesetSourceSetReviewersAndLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]ChangesetSourceSetReviewersAndLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ChangesetSourceSetReviewersAndLabelsFuncCall is an object that describes
+// an invocation of method SetReviewersAndLabels on an instance of
+// MockChangesetSource.
+type ChangesetSourceSetReviewersAndLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 ReviewersAndLabels
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ChangesetSourceSetReviewersAndLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ChangesetSourceSetReviewersAndLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ChangesetSourceUpdateChangesetFunc describes the behavior when the
 // UpdateChangeset method of the parent MockChangesetSource instance is
 // invoked.
@@ -1318,6 +1446,9 @@ type MockForkableChangesetSource struct {
 	// ReopenChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method ReopenChangeset.
 	ReopenChangesetFunc *ForkableChangesetSourceReopenChangesetFunc
+	// SetReviewersAndLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method SetReviewersAndLabels.
+	SetReviewersAndLabelsFunc *ForkableChangesetSourceSetReviewersAndLabelsFunc
 	// UpdateChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateChangeset.
 	UpdateChangesetFunc *ForkableChangesetSourceUpdateChangesetFunc
@@ -1374,6 +1505,11 @@ func NewMockForkableChangesetSource() *MockForkableChangesetSource {
 				return
 			},
 		},
+		SetReviewersAndLabelsFunc: &ForkableChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: func(context.Context, *Changeset, ReviewersAndLabels) (r0 error) {
+				return
+			},
+		},
 		UpdateChangesetFunc: &ForkableChangesetSourceUpdateChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) (r0 error) {
 				return
@@ -1437,6 +1573,11 @@ func NewStrictMockForkableChangesetSource() *MockForkableChangesetSource {
 				panic("unexpected invocation of MockForkableChangesetSource.ReopenChangeset")
 			},
 		},
+		SetReviewersAndLabelsFunc: &ForkableChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: func(context.Context, *Changeset, ReviewersAndLabels) error {
+				panic("unexpected invocation of MockForkableChangesetSource.SetReviewersAndLabels")
+			},
+		},
 		UpdateChangesetFunc: &ForkableChangesetSourceUpdateChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) error {
 				panic("unexpected invocation of MockForkableChangesetSource.UpdateChangeset")
@@ -1484,6 +1625,9 @@ func NewMockForkableChangesetSourceFrom(i ForkableChangesetSource) *MockForkable
 		ReopenChangesetFunc: &ForkableChangesetSourceReopenChangesetFunc{
 			defaultHook: i.ReopenChangeset,
 		},
+		SetReviewersAndLabelsFunc: &ForkableChangesetSourceSetReviewersAndLabelsFunc{
+			defaultHook: i.SetReviewersAndLabels,
+		},
 		UpdateChangesetFunc: &ForkableChangesetSourceUpdateChangesetFunc{
 			defaultHook: i.UpdateChangeset,
 		},
@@ -2384,6 +2528,118 @@ func (c ForkableChangesetSourceReopenChangesetFuncCall) Results() []interface{}
 	return []interface{}{c.Result0}
 }
 
+// ForkableChangesetSourceSetReviewersAndLabelsFunc describes the behavior
+// when the SetReviewersAndLabels method of the parent
+// MockForkableChangesetSource instance is invoked.
+type ForkableChangesetSourceSetReviewersAndLabelsFunc struct {
+	defaultHook func(context.Context, *Changeset, ReviewersAndLabels) error
+	hooks       []func(context.Context, *Changeset, ReviewersAndLabels) error
+	history     []ForkableChangesetSourceSetReviewersAndLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetReviewersAndLabels delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockForkableChangesetSource) SetReviewersAndLabels(v0 context.Context, v1 *Changeset, v2 ReviewersAndLabels) error {
+	r0 := m.SetReviewersAndLabelsFunc.nextHook()(v0, v1, v2)
+	m.SetReviewersAndLabelsFunc.appendCall(ForkableChangesetSourceSetReviewersAndLabelsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetReviewersAndLabels method of the parent MockForkableChangesetSource
+// instance is invoked and the hook queue is empty.
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) SetDefaultHook(hook func(context.Context, *Changeset, ReviewersAndLabels) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetReviewersAndLabels method of the parent MockForkableChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) PushHook(hook func(context.Context, *Changeset, ReviewersAndLabels) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, ReviewersAndLabels) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, ReviewersAndLabels) error {
+		return r0
+	})
+}
+
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) nextHook() func(context.Context, *Changeset, ReviewersAndLabels) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) appendCall(r0 ForkableChangesetSourceSetReviewersAndLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ForkableChangesetSourceSetReviewersAndLabelsFuncCall objects describing
+// the invocations of this function.
+func (f *ForkableChangesetSourceSetReviewersAndLabelsFunc) History() []ForkableChangesetSourceSetReviewersAndLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]ForkableChangesetSourceSetReviewersAndLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ForkableChangesetSourceSetReviewersAndLabelsFuncCall is an object that
+// describes an invocation of method SetReviewersAndLabels on an instance of
+// MockForkableChangesetSource.
+type ForkableChangesetSourceSetReviewersAndLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 ReviewersAndLabels
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ForkableChangesetSourceSetReviewersAndLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ForkableChangesetSourceSetReviewersAndLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ForkableChangesetSourceUpdateChangesetFunc describes the behavior when
 // the UpdateChangeset method of the parent MockForkableChangesetSource
 // instance is invoked.
@@ -5688,6 +5944,12 @@ type MockAzureDevOpsClient struct {
 	// AbandonPullRequestFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonPullRequest.
 	AbandonPullRequestFunc *AzureDevOpsClientAbandonPullRequestFunc
+	// AddPullRequestLabelFunc is an instance of a mock function object
+	// controlling the behavior of the method AddPullRequestLabel.
+	AddPullRequestLabelFunc *AzureDevOpsClientAddPullRequestLabelFunc
+	// AddPullRequestReviewerFunc is an instance of a mock function object
+	// controlling the behavior of the method AddPullRequestReviewer.
+	AddPullRequestReviewerFunc *AzureDevOpsClientAddPullRequestReviewerFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *AzureDevOpsClientAuthenticatorFunc
@@ -5736,6 +5998,9 @@ type MockAzureDevOpsClient struct {
 	// object controlling the behavior of the method
 	// ListRepositoriesByProjectOrOrg.
 	ListRepositoriesByProjectOrOrgFunc *AzureDevOpsClientListRepositoriesByProjectOrOrgFunc
+	// RetryBuildFunc is an instance of a mock function object controlling
+	// the behavior of the method RetryBuild.
+	RetryBuildFunc *AzureDevOpsClientRetryBuildFunc
 	// SetWaitForRateLimitFunc is an instance of a mock function object
 	// controlling the behavior of the method SetWaitForRateLimit.
 	SetWaitForRateLimitFunc *AzureDevOpsClientSetWaitForRateLimitFunc
@@ -5756,6 +6021,16 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (r0 azuredevops.PullRequestLabel, r1 error) {
+				return
+			},
+		},
+		AddPullRequestReviewerFunc: &AzureDevOpsClientAddPullRequestReviewerFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (r0 azuredevops.Reviewer, r1 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -5831,6 +6106,11 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		RetryBuildFunc: &AzureDevOpsClientRetryBuildFunc{
+			defaultHook: func(context.Context, string, string, int) (r0 azuredevops.Build, r1 error) {
+				return
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				return
@@ -5858,6 +6138,16 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.AbandonPullRequest")
 			},
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.AddPullRequestLabel")
+			},
+		},
+		AddPullRequestReviewerFunc: &AzureDevOpsClientAddPullRequestReviewerFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.AddPullRequestReviewer")
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockAzureDevOpsClient.Authenticator")
@@ -5933,6 +6223,11 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.ListRepositoriesByProjectOrOrg")
 			},
 		},
+		RetryBuildFunc: &AzureDevOpsClientRetryBuildFunc{
+			defaultHook: func(context.Context, string, string, int) (azuredevops.Build, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.RetryBuild")
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				panic("unexpected invocation of MockAzureDevOpsClient.SetWaitForRateLimit")
@@ -5959,6 +6254,12 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		AbandonPullRequestFunc: &AzureDevOpsClientAbandonPullRequestFunc{
 			defaultHook: i.AbandonPullRequest,
 		},
+		AddPullRequestLabelFunc: &AzureDevOpsClientAddPullRequestLabelFunc{
+			defaultHook: i.AddPullRequestLabel,
+		},
+		AddPullRequestReviewerFunc: &AzureDevOpsClientAddPullRequestReviewerFunc{
+			defaultHook: i.AddPullRequestReviewer,
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -6004,6 +6305,9 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: i.ListRepositoriesByProjectOrOrg,
 		},
+		RetryBuildFunc: &AzureDevOpsClientRetryBuildFunc{
+			defaultHook: i.RetryBuild,
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: i.SetWaitForRateLimit,
 		},
@@ -6127,6 +6431,236 @@ func (c AzureDevOpsClientAbandonPullRequestFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientAddPullRequestLabelFunc describes the behavior when the
+// AddPullRequestLabel method of the parent MockAzureDevOpsClient instance
+// is invoked.
+type AzureDevOpsClientAddPullRequestLabelFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error)
+	history     []AzureDevOpsClientAddPullRequestLabelFuncCall
+	mutex       sync.Mutex
+}
+
+// AddPullRequestLabel delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) AddPullRequestLabel(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 string) (azuredevops.PullRequestLabel, error) {
+	r0, r1 := m.AddPullRequestLabelFunc.nextHook()(v0, v1, v2)
+	m.AddPullRequestLabelFunc.appendCall(AzureDevOpsClientAddPullRequestLabelFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddPullRequestLabel
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddPullRequestLabel method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) SetDefaultReturn(r0 azuredevops.PullRequestLabel, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) PushReturn(r0 azuredevops.PullRequestLabel, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.PullRequestLabel, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) appendCall(r0 AzureDevOpsClientAddPullRequestLabelFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientAddPullRequestLabelFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientAddPullRequestLabelFunc) History() []AzureDevOpsClientAddPullRequestLabelFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAddPullRequestLabelFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAddPullRequestLabelFuncCall is an object that describes
+// an invocation of method AddPullRequestLabel on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientAddPullRequestLabelFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.PullRequestLabel
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestLabelFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestLabelFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientAddPullRequestReviewerFunc describes the behavior when
+// the AddPullRequestReviewer method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientAddPullRequestReviewerFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error)
+	history     []AzureDevOpsClientAddPullRequestReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// AddPullRequestReviewer delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) AddPullRequestReviewer(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 string) (azuredevops.Reviewer, error) {
+	r0, r1 := m.AddPullRequestReviewerFunc.nextHook()(v0, v1, v2)
+	m.AddPullRequestReviewerFunc.appendCall(AzureDevOpsClientAddPullRequestReviewerFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// AddPullRequestReviewer method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddPullRequestReviewer method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) SetDefaultReturn(r0 azuredevops.Reviewer, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) PushReturn(r0 azuredevops.Reviewer, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, string) (azuredevops.Reviewer, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) appendCall(r0 AzureDevOpsClientAddPullRequestReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientAddPullRequestReviewerFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientAddPullRequestReviewerFunc) History() []AzureDevOpsClientAddPullRequestReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAddPullRequestReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAddPullRequestReviewerFuncCall is an object that
+// describes an invocation of method AddPullRequestReviewer on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientAddPullRequestReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.Reviewer
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientAuthenticatorFunc describes the behavior when the
 // Authenticator method of the parent MockAzureDevOpsClient instance is
 // invoked.
@@ -7779,6 +8313,121 @@ func (c AzureDevOpsClientListRepositoriesByProjectOrOrgFuncCall) Results() []int
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientRetryBuildFunc describes the behavior when the
+// RetryBuild method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientRetryBuildFunc struct {
+	defaultHook func(context.Context, string, string, int) (azuredevops.Build, error)
+	hooks       []func(context.Context, string, string, int) (azuredevops.Build, error)
+	history     []AzureDevOpsClientRetryBuildFuncCall
+	mutex       sync.Mutex
+}
+
+// RetryBuild delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) RetryBuild(v0 context.Context, v1 string, v2 string, v3 int) (azuredevops.Build, error) {
+	r0, r1 := m.RetryBuildFunc.nextHook()(v0, v1, v2, v3)
+	m.RetryBuildFunc.appendCall(AzureDevOpsClientRetryBuildFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RetryBuild method of
+// the parent MockAzureDevOpsClient instance is invoked and the hook queue
+// is empty.
+func (f *AzureDevOpsClientRetryBuildFunc) SetDefaultHook(hook func(context.Context, string, string, int) (azuredevops.Build, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RetryBuild method of the parent MockAzureDevOpsClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AzureDevOpsClientRetryBuildFunc) PushHook(hook func(context.Context, string, string, int) (azuredevops.Build, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientRetryBuildFunc) SetDefaultReturn(r0 azuredevops.Build, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int) (azuredevops.Build, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientRetryBuildFunc) PushReturn(r0 azuredevops.Build, r1 error) {
+	f.PushHook(func(context.Context, string, string, int) (azuredevops.Build, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientRetryBuildFunc) nextHook() func(context.Context, string, string, int) (azuredevops.Build, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientRetryBuildFunc) appendCall(r0 AzureDevOpsClientRetryBuildFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientRetryBuildFuncCall objects
+// describing the invocations of this function.
+func (f *AzureDevOpsClientRetryBuildFunc) History() []AzureDevOpsClientRetryBuildFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientRetryBuildFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientRetryBuildFuncCall is an object that describes an
+// invocation of method RetryBuild on an instance of MockAzureDevOpsClient.
+type AzureDevOpsClientRetryBuildFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.Build
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientRetryBuildFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientRetryBuildFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientSetWaitForRateLimitFunc describes the behavior when the
 // SetWaitForRateLimit method of the parent MockAzureDevOpsClient instance
 // is invoked.
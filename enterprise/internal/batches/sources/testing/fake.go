@@ -58,6 +58,8 @@ type FakeChangesetSource struct {
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
 	IsArchivedPushErrorCalled   bool
+	SetReviewersAndLabelsCalled bool
+	RerunFailedChecksCalled     bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -98,6 +100,10 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*sources.Changeset
 
+	// ReviewersAndLabels contains the options that were passed to
+	// SetReviewersAndLabels
+	ReviewersAndLabels []sources.ReviewersAndLabels
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 
@@ -318,3 +324,18 @@ func (s *FakeChangesetSource) IsArchivedPushError(output string) bool {
 	s.IsArchivedPushErrorCalled = true
 	return s.IsArchivedPushErrorTrue
 }
+
+func (s *FakeChangesetSource) SetReviewersAndLabels(ctx context.Context, c *sources.Changeset, opts sources.ReviewersAndLabels) error {
+	s.SetReviewersAndLabelsCalled = true
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.ReviewersAndLabels = append(s.ReviewersAndLabels, opts)
+	return nil
+}
+
+func (s *FakeChangesetSource) RerunFailedChecks(ctx context.Context, c *sources.Changeset) error {
+	s.RerunFailedChecksCalled = true
+	return s.Err
+}
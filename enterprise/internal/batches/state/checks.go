@@ -0,0 +1,207 @@
+package state
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	adobatches "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// computeChecks computes the individual checks of the changeset based on the
+// current synced checks and any webhook events that have arrived after the
+// most recent sync. It follows the same rules as computeCheckState, so the
+// checks add up to the overall check state.
+//
+// Only GitHub, GitLab and Azure DevOps report the details needed to list and
+// re-run individual checks. For other code hosts, nil is returned.
+func computeChecks(c *btypes.Changeset, events ChangesetEvents) []btypes.ChangesetCheck {
+	var checks []btypes.ChangesetCheck
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		checks = computeGitHubChecks(c.UpdatedAt, m, events)
+	case *gitlab.MergeRequest:
+		checks = computeGitLabChecks(c.UpdatedAt, m, events)
+	case *azuredevops.AnnotatedPullRequest:
+		checks = computeAzureDevOpsChecks(m)
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Name != checks[j].Name {
+			return checks[i].Name < checks[j].Name
+		}
+		return checks[i].URL < checks[j].URL
+	})
+	return checks
+}
+
+func computeGitHubChecks(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	var latestCommitTime time.Time
+	var latestOID string
+	checkPerContext := make(map[string]btypes.ChangesetCheck)
+	checkPerCheckRun := make(map[string]btypes.ChangesetCheck)
+
+	if len(pr.Commits.Nodes) > 0 {
+		// We only request the most recent commit
+		commit := pr.Commits.Nodes[0]
+		latestCommitTime = commit.Commit.CommittedDate
+		latestOID = commit.Commit.OID
+		for _, c := range commit.Commit.Status.Contexts {
+			// Commit statuses are posted by external CI systems, so there's
+			// nothing we can re-run.
+			checkPerContext[c.Context] = btypes.ChangesetCheck{
+				Name:  c.Context,
+				State: parseGithubCheckState(c.State),
+				URL:   c.TargetURL,
+			}
+		}
+		for _, suite := range commit.Commit.CheckSuites.Nodes {
+			for _, r := range suite.CheckRuns.Nodes {
+				checkPerCheckRun[r.ID] = newGitHubCheckRunCheck(suite.ID, r)
+			}
+		}
+	}
+
+	var statuses []*github.CommitStatus
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.CommitStatus:
+			if m.ReceivedAt.After(lastSynced) {
+				statuses = append(statuses, m)
+			}
+		case *github.PullRequestCommit:
+			if m.Commit.CommittedDate.After(latestCommitTime) {
+				latestCommitTime = m.Commit.CommittedDate
+				latestOID = m.Commit.OID
+				// checkPerContext is now out of date, reset it
+				for k := range checkPerContext {
+					delete(checkPerContext, k)
+				}
+			}
+		case *github.CheckRun:
+			if !m.ReceivedAt.After(lastSynced) {
+				continue
+			}
+			check := newGitHubCheckRunCheck(m.CheckSuiteID, *m)
+			// Events received before we stored the details of check runs
+			// only carry the state.
+			if existing, ok := checkPerCheckRun[m.ID]; ok && m.Name == "" {
+				existing.State = check.State
+				check = existing
+			}
+			checkPerCheckRun[m.ID] = check
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ReceivedAt.Before(statuses[j].ReceivedAt)
+	})
+	for _, s := range statuses {
+		if s.SHA != latestOID {
+			continue
+		}
+		checkPerContext[s.Context] = btypes.ChangesetCheck{
+			Name:  s.Context,
+			State: parseGithubCheckState(s.State),
+			URL:   s.TargetURL,
+		}
+	}
+
+	checks := make([]btypes.ChangesetCheck, 0, len(checkPerContext)+len(checkPerCheckRun))
+	for _, check := range checkPerContext {
+		checks = append(checks, check)
+	}
+	for _, check := range checkPerCheckRun {
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// newGitHubCheckRunCheck returns the check of a check run. Check runs are
+// re-run by re-requesting the check suite they belong to.
+func newGitHubCheckRunCheck(checkSuiteID string, r github.CheckRun) btypes.ChangesetCheck {
+	return btypes.ChangesetCheck{
+		ExternalID:  checkSuiteID,
+		Name:        r.Name,
+		State:       parseGithubCheckSuiteState(r.Status, r.Conclusion),
+		URL:         r.DetailsURL,
+		StartedAt:   r.StartedAt,
+		CompletedAt: r.CompletedAt,
+	}
+}
+
+func computeGitLabChecks(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	// GitLab combines the jobs of a merge request into a pipeline, which is
+	// also what's retried, so the latest pipeline is the only check.
+	pipeline := latestGitLabPipeline(lastSynced, mr, events)
+	if pipeline == nil {
+		return nil
+	}
+
+	check := btypes.ChangesetCheck{
+		ExternalID: strconv.Itoa(int(pipeline.ID)),
+		Name:       fmt.Sprintf("Pipeline #%d", pipeline.ID),
+		State:      parseGitLabPipelineStatus(pipeline.Status),
+		URL:        pipeline.WebURL,
+		StartedAt:  pipeline.CreatedAt.Time,
+	}
+	// GitLab doesn't tell us when a pipeline finished, but a finished pipeline
+	// isn't updated anymore.
+	if check.State == btypes.ChangesetCheckStatePassed || check.State == btypes.ChangesetCheckStateFailed {
+		check.CompletedAt = pipeline.UpdatedAt.Time
+	}
+	return []btypes.ChangesetCheck{check}
+}
+
+func computeAzureDevOpsChecks(apr *azuredevops.AnnotatedPullRequest) []btypes.ChangesetCheck {
+	// A status is posted for every iteration of the pull request, so we only
+	// keep the latest status of every context.
+	latest := make(map[string]*adobatches.PullRequestBuildStatus)
+	for _, status := range apr.Statuses {
+		key := status.Context.Genre + "/" + status.Context.Name
+		if l, ok := latest[key]; !ok || l.ID < status.ID {
+			latest[key] = status
+		}
+	}
+
+	checks := make([]btypes.ChangesetCheck, 0, len(latest))
+	for _, status := range latest {
+		check := btypes.ChangesetCheck{
+			ExternalID: azureDevOpsBuildID(status.TargetURL),
+			Name:       status.Context.Name,
+			State:      parseAzureDevOpsBuildState(status.State),
+			URL:        status.TargetURL,
+			StartedAt:  status.CreationDate,
+		}
+		if check.Name == "" {
+			check.Name = status.Description
+		}
+		if check.State == btypes.ChangesetCheckStatePassed || check.State == btypes.ChangesetCheckStateFailed {
+			check.CompletedAt = status.UpdateDate
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// azureDevOpsBuildID returns the ID of the Azure Pipelines build that posted a
+// status, or an empty string if the status wasn't posted by a build. Builds
+// link to their results page, such as
+// https://dev.azure.com/org/project/_build/results?buildId=42.
+func azureDevOpsBuildID(targetURL string) string {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return ""
+	}
+	id := u.Query().Get("buildId")
+	if _, err := strconv.Atoi(id); err != nil {
+		return ""
+	}
+	return id
+}
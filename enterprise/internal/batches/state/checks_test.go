@@ -0,0 +1,200 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	azuredevops2 "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+func TestComputeGitHubChecks(t *testing.T) {
+	t.Parallel()
+
+	lastSynced := time.Unix(100, 0)
+	started := time.Unix(10, 0)
+	completed := time.Unix(70, 0)
+
+	pr := &github.PullRequest{}
+	commit := github.CommitWithChecks{}
+	commit.Commit.OID = "deadbeef"
+	commit.Commit.CommittedDate = time.Unix(5, 0)
+	commit.Commit.Status.Contexts = []github.Context{
+		{Context: "ci/jenkins", State: "SUCCESS", TargetURL: "https://jenkins.example.com/1"},
+	}
+	suite := github.CheckSuite{ID: "suite-1", Status: "COMPLETED", Conclusion: "FAILURE"}
+	suite.CheckRuns.Nodes = []github.CheckRun{
+		{ID: "run-1", Name: "build", Status: "COMPLETED", Conclusion: "SUCCESS", DetailsURL: "https://github.com/run-1", StartedAt: started, CompletedAt: completed},
+		{ID: "run-2", Name: "test", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://github.com/run-2", StartedAt: started, CompletedAt: completed},
+	}
+	commit.Commit.CheckSuites.Nodes = []github.CheckSuite{suite}
+	pr.Commits.Nodes = []github.CommitWithChecks{commit}
+
+	t.Run("synced", func(t *testing.T) {
+		have := computeChecks(&btypes.Changeset{UpdatedAt: lastSynced, Metadata: pr}, nil)
+		want := []btypes.ChangesetCheck{
+			{ExternalID: "suite-1", Name: "build", State: btypes.ChangesetCheckStatePassed, URL: "https://github.com/run-1", StartedAt: started, CompletedAt: completed},
+			{Name: "ci/jenkins", State: btypes.ChangesetCheckStatePassed, URL: "https://jenkins.example.com/1"},
+			{ExternalID: "suite-1", Name: "test", State: btypes.ChangesetCheckStateFailed, URL: "https://github.com/run-2", StartedAt: started, CompletedAt: completed},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected checks (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("with events", func(t *testing.T) {
+		events := []*btypes.ChangesetEvent{
+			{
+				Kind: btypes.ChangesetEventKindCheckRun,
+				Metadata: &github.CheckRun{
+					ID:           "run-2",
+					Name:         "test",
+					Status:       "IN_PROGRESS",
+					DetailsURL:   "https://github.com/run-2",
+					StartedAt:    time.Unix(110, 0),
+					CheckSuiteID: "suite-1",
+					ReceivedAt:   time.Unix(110, 0),
+				},
+			},
+			{
+				// Events from before the last sync are ignored.
+				Kind: btypes.ChangesetEventKindCheckRun,
+				Metadata: &github.CheckRun{
+					ID:         "run-1",
+					Name:       "build",
+					Status:     "IN_PROGRESS",
+					ReceivedAt: time.Unix(90, 0),
+				},
+			},
+			{
+				Kind: btypes.ChangesetEventKindCommitStatus,
+				Metadata: &github.CommitStatus{
+					SHA:        "deadbeef",
+					Context:    "ci/jenkins",
+					State:      "FAILURE",
+					TargetURL:  "https://jenkins.example.com/2",
+					ReceivedAt: time.Unix(120, 0),
+				},
+			},
+		}
+
+		have := computeChecks(&btypes.Changeset{UpdatedAt: lastSynced, Metadata: pr}, events)
+		want := []btypes.ChangesetCheck{
+			{ExternalID: "suite-1", Name: "build", State: btypes.ChangesetCheckStatePassed, URL: "https://github.com/run-1", StartedAt: started, CompletedAt: completed},
+			{Name: "ci/jenkins", State: btypes.ChangesetCheckStateFailed, URL: "https://jenkins.example.com/2"},
+			{ExternalID: "suite-1", Name: "test", State: btypes.ChangesetCheckStatePending, URL: "https://github.com/run-2", StartedAt: time.Unix(110, 0)},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected checks (-want +have):\n%s", diff)
+		}
+	})
+}
+
+func TestComputeGitLabChecks(t *testing.T) {
+	t.Parallel()
+
+	mr := &gitlab.MergeRequest{
+		Pipelines: []*gitlab.Pipeline{
+			{
+				ID:        1,
+				Status:    gitlab.PipelineStatusSuccess,
+				WebURL:    "https://gitlab.com/pipelines/1",
+				CreatedAt: gitlab.Time{Time: time.Unix(5, 0)},
+				UpdatedAt: gitlab.Time{Time: time.Unix(8, 0)},
+			},
+			{
+				ID:        2,
+				Status:    gitlab.PipelineStatusFailed,
+				WebURL:    "https://gitlab.com/pipelines/2",
+				CreatedAt: gitlab.Time{Time: time.Unix(10, 0)},
+				UpdatedAt: gitlab.Time{Time: time.Unix(40, 0)},
+			},
+		},
+	}
+
+	t.Run("synced", func(t *testing.T) {
+		have := computeChecks(&btypes.Changeset{UpdatedAt: time.Unix(50, 0), Metadata: mr}, nil)
+		want := []btypes.ChangesetCheck{{
+			ExternalID:  "2",
+			Name:        "Pipeline #2",
+			State:       btypes.ChangesetCheckStateFailed,
+			URL:         "https://gitlab.com/pipelines/2",
+			StartedAt:   time.Unix(10, 0),
+			CompletedAt: time.Unix(40, 0),
+		}}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected checks (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("running pipeline from event", func(t *testing.T) {
+		events := []*btypes.ChangesetEvent{{
+			Kind: btypes.ChangesetEventKindGitLabPipeline,
+			Metadata: &gitlab.Pipeline{
+				ID:        3,
+				Status:    gitlab.PipelineStatusRunning,
+				WebURL:    "https://gitlab.com/pipelines/3",
+				CreatedAt: gitlab.Time{Time: time.Unix(60, 0)},
+				UpdatedAt: gitlab.Time{Time: time.Unix(65, 0)},
+			},
+		}}
+		have := computeChecks(&btypes.Changeset{UpdatedAt: time.Unix(50, 0), Metadata: mr}, events)
+		want := []btypes.ChangesetCheck{{
+			ExternalID: "3",
+			Name:       "Pipeline #3",
+			State:      btypes.ChangesetCheckStatePending,
+			URL:        "https://gitlab.com/pipelines/3",
+			StartedAt:  time.Unix(60, 0),
+		}}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected checks (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("no pipelines", func(t *testing.T) {
+		if have := computeChecks(&btypes.Changeset{Metadata: &gitlab.MergeRequest{}}, nil); len(have) != 0 {
+			t.Errorf("unexpected checks: %+v", have)
+		}
+	})
+}
+
+func TestComputeAzureDevOpsChecks(t *testing.T) {
+	t.Parallel()
+
+	created := time.Unix(10, 0)
+	updated := time.Unix(20, 0)
+	status := func(id int, name string, state azuredevops.PullRequestStatusState, targetURL string) *azuredevops.PullRequestBuildStatus {
+		return &azuredevops.PullRequestBuildStatus{
+			ID:           id,
+			State:        state,
+			Context:      azuredevops.PullRequestStatusContext{Name: name, Genre: "continuous-integration"},
+			TargetURL:    targetURL,
+			CreationDate: created,
+			UpdateDate:   updated,
+		}
+	}
+
+	apr := &azuredevops2.AnnotatedPullRequest{
+		PullRequest: &azuredevops.PullRequest{},
+		Statuses: []*azuredevops.PullRequestBuildStatus{
+			status(1, "build", azuredevops.PullRequestBuildStatusStateFailed, "https://dev.azure.com/org/project/_build/results?buildId=41"),
+			// The status of a newer iteration replaces the previous one.
+			status(2, "build", azuredevops.PullRequestBuildStatusStateFailed, "https://dev.azure.com/org/project/_build/results?buildId=42"),
+			status(3, "lint", azuredevops.PullRequestBuildStatusStatePending, "https://lint.example.com"),
+		},
+	}
+
+	have := computeChecks(&btypes.Changeset{Metadata: apr}, nil)
+	want := []btypes.ChangesetCheck{
+		{ExternalID: "42", Name: "build", State: btypes.ChangesetCheckStateFailed, URL: "https://dev.azure.com/org/project/_build/results?buildId=42", StartedAt: created, CompletedAt: updated},
+		{Name: "lint", State: btypes.ChangesetCheckStatePending, URL: "https://lint.example.com", StartedAt: created},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected checks (-want +have):\n%s", diff)
+	}
+}
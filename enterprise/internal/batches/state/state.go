@@ -51,6 +51,7 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client g
 	}
 
 	c.ExternalCheckState = computeCheckState(c, events)
+	c.ExternalChecks = computeChecks(c, events)
 
 	history, err := computeHistory(c, events)
 	if err != nil {
@@ -444,12 +445,21 @@ func parseGithubCheckSuiteState(status, conclusion string) btypes.ChangesetCheck
 func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// GitLab pipelines aren't tied to commits in the same way that GitHub
 	// checks are. We're simply looking for the most recent pipeline run that
-	// was associated with the merge request, which may live in a changeset
-	// event (via webhook) or on the Pipelines field of the merge request
-	// itself. We don't need to implement the same combinatorial logic that
-	// exists for other code hosts because that's essentially what the pipeline
-	// is, except GitLab handles the details of combining the job states.
+	// was associated with the merge request. We don't need to implement the
+	// same combinatorial logic that exists for other code hosts because that's
+	// essentially what the pipeline is, except GitLab handles the details of
+	// combining the job states.
+	if pipeline := latestGitLabPipeline(lastSynced, mr, events); pipeline != nil {
+		return parseGitLabPipelineStatus(pipeline.Status)
+	}
+	return btypes.ChangesetCheckStateUnknown
+}
 
+// latestGitLabPipeline returns the most recent pipeline run that was
+// associated with the merge request, which may live in a changeset event (via
+// webhook) or on the Pipelines field of the merge request itself. It returns
+// nil if the merge request has no pipelines.
+func latestGitLabPipeline(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) *gitlab.Pipeline {
 	// Let's figure out what the last pipeline event we saw in the events was.
 	var lastPipelineEvent *gitlab.Pipeline
 	for _, e := range events {
@@ -469,13 +479,9 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 		// them just to be sure.
 
 		// First up, a special case: if there are no pipelines, we'll try to use
-		// HeadPipeline. If that's empty, then we'll shrug and say we don't
-		// know.
+		// HeadPipeline, which may be nil, too.
 		if len(mr.Pipelines) == 0 {
-			if mr.HeadPipeline != nil {
-				return parseGitLabPipelineStatus(mr.HeadPipeline.Status)
-			}
-			return btypes.ChangesetCheckStateUnknown
+			return mr.HeadPipeline
 		}
 
 		// Sort into descending order so that the pipeline at index 0 is the latest.
@@ -484,10 +490,10 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 			return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt.Time)
 		})
 
-		return parseGitLabPipelineStatus(pipelines[0].Status)
+		return pipelines[0]
 	}
 
-	return parseGitLabPipelineStatus(lastPipelineEvent.Status)
+	return lastPipelineEvent
 }
 
 func parseGitLabPipelineStatus(status gitlab.PipelineStatus) btypes.ChangesetCheckState {
@@ -0,0 +1,335 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// autoMergePolicyColumns are used by the auto-merge policy related Store
+// methods to query and create auto-merge policies.
+var autoMergePolicyColumns = SQLColumns{
+	"batch_change_auto_merge_policies.batch_change_id",
+	"batch_change_auto_merge_policies.user_id",
+	"batch_change_auto_merge_policies.merge_method",
+	"batch_change_auto_merge_policies.required_review_state",
+	"batch_change_auto_merge_policies.required_checks",
+	"batch_change_auto_merge_policies.merge_window_days",
+	"batch_change_auto_merge_policies.merge_window_start",
+	"batch_change_auto_merge_policies.merge_window_end",
+	"batch_change_auto_merge_policies.max_merges_per_hour",
+	"batch_change_auto_merge_policies.created_at",
+	"batch_change_auto_merge_policies.updated_at",
+}
+
+// UpsertAutoMergePolicy creates or replaces the auto-merge policy of the batch
+// change the policy belongs to.
+func (s *Store) UpsertAutoMergePolicy(ctx context.Context, p *btypes.AutoMergePolicy) (err error) {
+	ctx, _, endObservation := s.operations.upsertAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(p.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	now := s.now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+	p.UpdatedAt = now
+
+	q := sqlf.Sprintf(
+		upsertAutoMergePolicyQueryFmtstr,
+		p.BatchChangeID,
+		p.UserID,
+		p.MergeMethod,
+		dbutil.NewNullString(string(p.RequiredReviewState)),
+		p.RequiredChecks,
+		pq.Array(p.MergeWindowDays),
+		p.MergeWindowStart,
+		p.MergeWindowEnd,
+		p.MaxMergesPerHour,
+		p.CreatedAt,
+		p.UpdatedAt,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanAutoMergePolicy(p, sc)
+	})
+}
+
+var upsertAutoMergePolicyQueryFmtstr = `
+INSERT INTO batch_change_auto_merge_policies (
+	batch_change_id,
+	user_id,
+	merge_method,
+	required_review_state,
+	required_checks,
+	merge_window_days,
+	merge_window_start,
+	merge_window_end,
+	max_merges_per_hour,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id) DO UPDATE SET
+	user_id = EXCLUDED.user_id,
+	merge_method = EXCLUDED.merge_method,
+	required_review_state = EXCLUDED.required_review_state,
+	required_checks = EXCLUDED.required_checks,
+	merge_window_days = EXCLUDED.merge_window_days,
+	merge_window_start = EXCLUDED.merge_window_start,
+	merge_window_end = EXCLUDED.merge_window_end,
+	max_merges_per_hour = EXCLUDED.max_merges_per_hour,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+// GetAutoMergePolicy returns the auto-merge policy of the given batch change.
+// ErrNoResults is returned if the batch change has no policy.
+func (s *Store) GetAutoMergePolicy(ctx context.Context, batchChangeID int64) (p *btypes.AutoMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.getAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getAutoMergePolicyQueryFmtstr,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+		batchChangeID,
+	)
+
+	var policy btypes.AutoMergePolicy
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanAutoMergePolicy(&policy, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.BatchChangeID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &policy, nil
+}
+
+var getAutoMergePolicyQueryFmtstr = `
+SELECT %s FROM batch_change_auto_merge_policies
+WHERE batch_change_auto_merge_policies.batch_change_id = %s
+`
+
+// DeleteAutoMergePolicy deletes the auto-merge policy of the given batch
+// change, together with the recorded blockers.
+func (s *Store) DeleteAutoMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteAutoMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteAutoMergePolicyQueryFmtstr, batchChangeID, batchChangeID))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteAutoMergePolicyQueryFmtstr = `
+WITH deleted_blockers AS (
+	DELETE FROM changeset_auto_merge_blockers WHERE batch_change_id = %s
+)
+DELETE FROM batch_change_auto_merge_policies WHERE batch_change_id = %s
+`
+
+// ListAutoMergePolicies returns the auto-merge policies of all batch changes
+// that are not closed.
+func (s *Store) ListAutoMergePolicies(ctx context.Context) (ps []*btypes.AutoMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.listAutoMergePolicies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listAutoMergePoliciesQueryFmtstr,
+		sqlf.Join(autoMergePolicyColumns.ToSqlf(), ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var p btypes.AutoMergePolicy
+		if err := scanAutoMergePolicy(&p, sc); err != nil {
+			return err
+		}
+		ps = append(ps, &p)
+		return nil
+	})
+	return ps, err
+}
+
+var listAutoMergePoliciesQueryFmtstr = `
+SELECT %s FROM batch_change_auto_merge_policies
+INNER JOIN batch_changes ON batch_changes.id = batch_change_auto_merge_policies.batch_change_id
+WHERE batch_changes.closed_at IS NULL
+ORDER BY batch_change_auto_merge_policies.batch_change_id ASC
+`
+
+func scanAutoMergePolicy(p *btypes.AutoMergePolicy, s dbutil.Scanner) error {
+	var reviewState string
+	if err := s.Scan(
+		&p.BatchChangeID,
+		&p.UserID,
+		&p.MergeMethod,
+		&dbutil.NullString{S: &reviewState},
+		&p.RequiredChecks,
+		pq.Array(&p.MergeWindowDays),
+		&p.MergeWindowStart,
+		&p.MergeWindowEnd,
+		&p.MaxMergesPerHour,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	p.RequiredReviewState = btypes.ChangesetReviewState(reviewState)
+	return nil
+}
+
+var autoMergeBlockerInsertColumns = []string{
+	"batch_change_id",
+	"changeset_id",
+	"reason",
+	"updated_at",
+}
+
+var autoMergeBlockerColumns = SQLColumns{
+	"changeset_auto_merge_blockers.batch_change_id",
+	"changeset_auto_merge_blockers.changeset_id",
+	"changeset_auto_merge_blockers.reason",
+	"changeset_auto_merge_blockers.updated_at",
+}
+
+// ReplaceAutoMergeBlockers replaces the recorded auto-merge blockers of the
+// given batch change.
+func (s *Store) ReplaceAutoMergeBlockers(ctx context.Context, batchChangeID int64, blockers []*btypes.AutoMergeBlocker) (err error) {
+	ctx, _, endObservation := s.operations.replaceAutoMergeBlockers.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(blockers)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteAutoMergeBlockersQueryFmtstr, batchChangeID)); err != nil {
+		return err
+	}
+
+	now := s.now()
+	return batch.WithInserter(
+		ctx,
+		tx.Handle(),
+		"changeset_auto_merge_blockers",
+		batch.MaxNumPostgresParameters,
+		autoMergeBlockerInsertColumns,
+		func(inserter *batch.Inserter) error {
+			for _, b := range blockers {
+				b.BatchChangeID = batchChangeID
+				b.UpdatedAt = now
+				if err := inserter.Insert(ctx, b.BatchChangeID, b.ChangesetID, b.Reason, b.UpdatedAt); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+var deleteAutoMergeBlockersQueryFmtstr = `
+DELETE FROM changeset_auto_merge_blockers WHERE batch_change_id = %s
+`
+
+// ListAutoMergeBlockersOpts captures the query options needed for listing the
+// auto-merge blockers of a batch change.
+type ListAutoMergeBlockersOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BatchChangeID int64
+}
+
+// ListAutoMergeBlockers lists the recorded auto-merge blockers of a batch
+// change, ordered by changeset ID.
+func (s *Store) ListAutoMergeBlockers(ctx context.Context, opts ListAutoMergeBlockersOpts) (bs []*btypes.AutoMergeBlocker, next int64, err error) {
+	ctx, _, endObservation := s.operations.listAutoMergeBlockers.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := listAutoMergeBlockersQuery(&opts)
+
+	bs = make([]*btypes.AutoMergeBlocker, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var b btypes.AutoMergeBlocker
+		if err := sc.Scan(&b.BatchChangeID, &b.ChangesetID, &b.Reason, &b.UpdatedAt); err != nil {
+			return err
+		}
+		bs = append(bs, &b)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(bs) == opts.DBLimit() {
+		next = bs[len(bs)-1].ChangesetID
+		bs = bs[:len(bs)-1]
+	}
+
+	return bs, next, err
+}
+
+var listAutoMergeBlockersQueryFmtstr = `
+SELECT %s FROM changeset_auto_merge_blockers
+WHERE %s
+ORDER BY changeset_auto_merge_blockers.changeset_id ASC
+`
+
+func listAutoMergeBlockersQuery(opts *ListAutoMergeBlockersOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("changeset_auto_merge_blockers.batch_change_id = %s", opts.BatchChangeID),
+	}
+
+	if opts.Cursor > 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_blockers.changeset_id >= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listAutoMergeBlockersQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(autoMergeBlockerColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountAutoMergeBlockers returns the number of recorded auto-merge blockers of
+// a batch change.
+func (s *Store) CountAutoMergeBlockers(ctx context.Context, batchChangeID int64) (count int, err error) {
+	ctx, _, endObservation := s.operations.countAutoMergeBlockers.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(countAutoMergeBlockersQueryFmtstr, batchChangeID))
+}
+
+var countAutoMergeBlockersQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_auto_merge_blockers WHERE batch_change_id = %s
+`
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreAutoMergePolicies(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	spec := bt.CreateBatchSpec(t, ctx, s, "auto-merge", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-merge", user.ID, spec.ID)
+	closedBatchChange := bt.CreateBatchChange(t, ctx, s, "closed", user.ID, spec.ID)
+	closedBatchChange.ClosedAt = clock.Now()
+	if err := s.UpdateBatchChange(ctx, closedBatchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+	changesets := []*btypes.Changeset{
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, BatchChange: batchChange.ID}),
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, BatchChange: batchChange.ID}),
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, BatchChange: batchChange.ID}),
+	}
+
+	policy := &btypes.AutoMergePolicy{
+		BatchChangeID:       batchChange.ID,
+		UserID:              user.ID,
+		MergeMethod:         btypes.AutoMergeMethodSquash,
+		RequiredReviewState: btypes.ChangesetReviewStateApproved,
+		RequiredChecks:      btypes.AutoMergeRequiredChecksPassed,
+		MergeWindowDays:     []string{"saturday", "sunday"},
+		MergeWindowStart:    "08:00",
+		MergeWindowEnd:      "18:00",
+		MaxMergesPerHour:    5,
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		if err := s.UpsertAutoMergePolicy(ctx, policy); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := policy.CreatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("invalid CreatedAt: have %s, want %s", have, want)
+		}
+
+		closedPolicy := &btypes.AutoMergePolicy{
+			BatchChangeID:  closedBatchChange.ID,
+			UserID:         user.ID,
+			MergeMethod:    btypes.AutoMergeMethodMerge,
+			RequiredChecks: btypes.AutoMergeRequiredChecksNone,
+		}
+		if err := s.UpsertAutoMergePolicy(ctx, closedPolicy); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("Update", func(t *testing.T) {
+			updated := *policy
+			updated.MaxMergesPerHour = 10
+			updated.RequiredReviewState = ""
+			if err := s.UpsertAutoMergePolicy(ctx, &updated); err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := s.GetAutoMergePolicy(ctx, batchChange.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, &updated); diff != "" {
+				t.Fatal(diff)
+			}
+
+			if err := s.UpsertAutoMergePolicy(ctx, policy); err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetAutoMergePolicy(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, policy); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetAutoMergePolicy(ctx, 0xdeadbeef)
+			if err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListAutoMergePolicies(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The policy of the closed batch change is not returned.
+		if diff := cmp.Diff(have, []*btypes.AutoMergePolicy{policy}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Blockers", func(t *testing.T) {
+		blockers := []*btypes.AutoMergeBlocker{
+			{ChangesetID: changesets[0].ID, Reason: "checks have not passed"},
+			{ChangesetID: changesets[1].ID, Reason: "review is not approved"},
+			{ChangesetID: changesets[2].ID, Reason: "merge window is closed"},
+		}
+		if err := s.ReplaceAutoMergeBlockers(ctx, batchChange.ID, blockers); err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := s.CountAutoMergeBlockers(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, len(blockers); have != want {
+			t.Fatalf("have count %d, want %d", have, want)
+		}
+
+		t.Run("List", func(t *testing.T) {
+			have, _, err := s.ListAutoMergeBlockers(ctx, ListAutoMergeBlockersOpts{BatchChangeID: batchChange.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, blockers); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(blockers); i++ {
+				opts := ListAutoMergeBlockersOpts{BatchChangeID: batchChange.ID, LimitOpts: LimitOpts{Limit: 1}, Cursor: cursor}
+				have, next, err := s.ListAutoMergeBlockers(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, blockers[i-1:i]); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+				cursor = next
+			}
+			if cursor != 0 {
+				t.Fatalf("unexpected cursor %d after last page", cursor)
+			}
+		})
+
+		t.Run("Replace", func(t *testing.T) {
+			if err := s.ReplaceAutoMergeBlockers(ctx, batchChange.ID, blockers[:1]); err != nil {
+				t.Fatal(err)
+			}
+			have, _, err := s.ListAutoMergeBlockers(ctx, ListAutoMergeBlockersOpts{BatchChangeID: batchChange.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, blockers[:1]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteAutoMergePolicy(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.GetAutoMergePolicy(ctx, batchChange.ID); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+
+		count, err := s.CountAutoMergeBlockers(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("blockers not deleted, have count %d", count)
+		}
+
+		if err := s.DeleteAutoMergePolicy(ctx, batchChange.ID); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
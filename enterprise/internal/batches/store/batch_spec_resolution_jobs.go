@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/keegancsmith/sqlf"
@@ -12,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // batchSpecResolutionJobInsertColumns is the list of changeset_jobs columns that are
@@ -19,6 +21,7 @@ import (
 var batchSpecResolutionJobInsertColumns = SQLColumns{
 	"batch_spec_id",
 	"initiator_id",
+	"dry_run",
 
 	"state",
 
@@ -26,7 +29,7 @@ var batchSpecResolutionJobInsertColumns = SQLColumns{
 	"updated_at",
 }
 
-const batchSpecResolutionJobInsertColsFmt = `(%s, %s, %s, %s, %s)`
+const batchSpecResolutionJobInsertColsFmt = `(%s, %s, %s, %s, %s, %s)`
 
 // ChangesetJobColumns are used by the changeset job related Store methods to query
 // and create changeset jobs.
@@ -35,6 +38,8 @@ var batchSpecResolutionJobColums = SQLColumns{
 
 	"batch_spec_resolution_jobs.batch_spec_id",
 	"batch_spec_resolution_jobs.initiator_id",
+	"batch_spec_resolution_jobs.dry_run",
+	"batch_spec_resolution_jobs.dry_run_result",
 
 	"batch_spec_resolution_jobs.state",
 	"batch_spec_resolution_jobs.failure_message",
@@ -102,6 +107,7 @@ func (s *Store) createBatchSpecResolutionJobQuery(wj *btypes.BatchSpecResolution
 		sqlf.Join(batchSpecResolutionJobInsertColumns.ToSqlf(), ","),
 		wj.BatchSpecID,
 		wj.InitiatorID,
+		wj.DryRun,
 		state,
 		wj.CreatedAt,
 		wj.UpdatedAt,
@@ -109,6 +115,28 @@ func (s *Store) createBatchSpecResolutionJobQuery(wj *btypes.BatchSpecResolution
 	)
 }
 
+// SetBatchSpecResolutionJobDryRunResult records the outcome of the dry-run
+// BatchSpecResolutionJob with the given ID.
+func (s *Store) SetBatchSpecResolutionJobDryRunResult(ctx context.Context, id int64, result *btypes.BatchSpecDryRunResult) (err error) {
+	ctx, _, endObservation := s.operations.setBatchSpecResolutionJobDryRunResult.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrap(err, "marshaling dry-run result")
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(setBatchSpecResolutionJobDryRunResultQueryFmtstr, raw, s.now(), id))
+}
+
+var setBatchSpecResolutionJobDryRunResultQueryFmtstr = `
+UPDATE batch_spec_resolution_jobs
+SET dry_run_result = %s, updated_at = %s
+WHERE id = %s AND dry_run
+`
+
 // GetBatchSpecResolutionJobOpts captures the query options needed for getting a BatchSpecResolutionJob
 type GetBatchSpecResolutionJobOpts struct {
 	ID          int64
@@ -221,11 +249,14 @@ func listBatchSpecResolutionJobsQuery(opts ListBatchSpecResolutionJobsOpts) *sql
 func scanBatchSpecResolutionJob(rj *btypes.BatchSpecResolutionJob, s dbutil.Scanner) error {
 	var executionLogs []executor.ExecutionLogEntry
 	var failureMessage string
+	var dryRunResult []byte
 
 	if err := s.Scan(
 		&rj.ID,
 		&rj.BatchSpecID,
 		&rj.InitiatorID,
+		&rj.DryRun,
+		&dryRunResult,
 		&rj.State,
 		&dbutil.NullString{S: &failureMessage},
 		&dbutil.NullTime{Time: &rj.StartedAt},
@@ -247,5 +278,11 @@ func scanBatchSpecResolutionJob(rj *btypes.BatchSpecResolutionJob, s dbutil.Scan
 
 	rj.ExecutionLogs = append(rj.ExecutionLogs, executionLogs...)
 
+	if len(dryRunResult) > 0 {
+		if err := json.Unmarshal(dryRunResult, &rj.DryRunResult); err != nil {
+			return errors.Wrap(err, "scanBatchSpecResolutionJob: failed to unmarshal DryRunResult")
+		}
+	}
+
 	return nil
 }
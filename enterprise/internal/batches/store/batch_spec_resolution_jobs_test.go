@@ -32,6 +32,7 @@ func testStoreBatchSpecResolutionJobs(t *testing.T, ctx context.Context, s *Stor
 			job.State = btypes.BatchSpecResolutionJobStateQueued
 		case 1:
 			job.State = btypes.BatchSpecResolutionJobStateProcessing
+			job.DryRun = true
 		case 2:
 			job.State = btypes.BatchSpecResolutionJobStateFailed
 		}
@@ -149,6 +150,38 @@ func testStoreBatchSpecResolutionJobs(t *testing.T, ctx context.Context, s *Stor
 			}
 		})
 	})
+
+	t.Run("SetDryRunResult", func(t *testing.T) {
+		result := &btypes.BatchSpecDryRunResult{
+			Outcome: btypes.BatchSpecDryRunOutcomeWorkspaces,
+			Workspaces: []*btypes.BatchSpecDryRunWorkspace{
+				{RepoID: 1, RepoName: "github.com/sourcegraph/a", Branch: "main", Path: "", FileMatches: []string{"README.md"}, Steps: []int{0}, MaxChangesets: 1},
+			},
+			Skipped: []*btypes.BatchSpecDryRunSkippedWorkspace{
+				{RepoID: 2, RepoName: "github.com/sourcegraph/b", Branch: "main", Reason: btypes.BatchSpecDryRunSkipReasonIgnored},
+			},
+			MaxChangesets: 1,
+		}
+
+		for _, job := range jobs {
+			if err := s.SetBatchSpecResolutionJobDryRunResult(ctx, job.ID, result); err != nil {
+				t.Fatal(err)
+			}
+
+			// Only dry-run jobs record a result.
+			if job.DryRun {
+				job.DryRunResult = result
+			}
+
+			have, err := s.GetBatchSpecResolutionJob(ctx, GetBatchSpecResolutionJobOpts{ID: job.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, job); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
 }
 
 func TestBatchSpecResolutionJobs_BatchSpecIDUnique(t *testing.T) {
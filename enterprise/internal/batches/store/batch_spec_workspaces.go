@@ -37,6 +37,7 @@ var batchSpecWorkspaceInsertColumns = []string{
 	"skipped",
 	"cached_result_found",
 	"step_cache_results",
+	"repository_metadata",
 
 	"created_at",
 	"updated_at",
@@ -61,6 +62,7 @@ var BatchSpecWorkspaceColums = SQLColumns{
 	"batch_spec_workspaces.skipped",
 	"batch_spec_workspaces.cached_result_found",
 	"batch_spec_workspaces.step_cache_results",
+	"batch_spec_workspaces.repository_metadata",
 
 	"batch_spec_workspaces.created_at",
 	"batch_spec_workspaces.updated_at",
@@ -102,6 +104,13 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				return err
 			}
 
+			var marshaledRepositoryMetadata any
+			if wj.RepositoryMetadata != nil {
+				if marshaledRepositoryMetadata, err = json.Marshal(wj.RepositoryMetadata); err != nil {
+					return err
+				}
+			}
+
 			if err := inserter.Insert(
 				ctx,
 				wj.BatchSpecID,
@@ -117,6 +126,7 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				wj.Skipped,
 				wj.CachedResultFound,
 				marshaledStepCacheResults,
+				marshaledRepositoryMetadata,
 				wj.CreatedAt,
 				wj.UpdatedAt,
 			); err != nil {
@@ -505,6 +515,7 @@ func (s *Store) DisableBatchSpecWorkspaceExecutionCache(ctx context.Context, bat
 
 func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) error {
 	var stepCacheResults json.RawMessage
+	var repositoryMetadata dbutil.NullJSONRawMessage
 
 	if err := s.Scan(
 		&wj.ID,
@@ -521,6 +532,7 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		&wj.Skipped,
 		&wj.CachedResultFound,
 		&stepCacheResults,
+		&repositoryMetadata,
 		&wj.CreatedAt,
 		&wj.UpdatedAt,
 	); err != nil {
@@ -531,6 +543,12 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		return errors.Wrap(err, "scanBatchSpecWorkspace: failed to unmarshal StepCacheResults")
 	}
 
+	if repositoryMetadata.Raw != nil {
+		if err := json.Unmarshal(repositoryMetadata.Raw, &wj.RepositoryMetadata); err != nil {
+			return errors.Wrap(err, "scanBatchSpecWorkspace: failed to unmarshal RepositoryMetadata")
+		}
+	}
+
 	return nil
 }
 
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
@@ -54,6 +55,13 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 			Skipped:            i == 1,
 			CachedResultFound:  i == 1,
 		}
+		if i == 1 {
+			job.RepositoryMetadata = &template.RepositoryMetadata{
+				KeyValuePairs: map[string]string{"owner": "batches", "frozen": ""},
+				Topics:        []string{"go"},
+				CodeOwners:    []string{"@sourcegraph/batches"},
+			}
+		}
 
 		workspaces = append(workspaces, job)
 	}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
@@ -202,8 +203,109 @@ func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 		c.Payload = new(btypes.ChangesetJobClosePayload)
 	case btypes.ChangesetJobTypePublish:
 		c.Payload = new(btypes.ChangesetJobPublishPayload)
+	case btypes.ChangesetJobTypeUpdateBranch:
+		c.Payload = new(btypes.ChangesetJobUpdateBranchPayload)
+	case btypes.ChangesetJobTypeSetReviewersAndLabels:
+		c.Payload = new(btypes.ChangesetJobSetReviewersAndLabelsPayload)
+	case btypes.ChangesetJobTypeRerunFailedChecks:
+		c.Payload = new(btypes.ChangesetJobRerunFailedChecksPayload)
 	default:
 		return errors.Errorf("unknown job type %q", c.JobType)
 	}
 	return json.Unmarshal(raw, &c.Payload)
 }
+
+// ListChangesetJobsOpts captures the query options needed for listing and
+// counting changeset jobs.
+type ListChangesetJobsOpts struct {
+	BatchChangeID int64
+	JobType       btypes.ChangesetJobType
+	States        []btypes.ChangesetJobState
+	CreatedAfter  time.Time
+	// AutoMerge limits the jobs to merge jobs enqueued by an auto-merge policy.
+	AutoMerge bool
+}
+
+// ListChangesetJobs lists the changeset jobs matching the given options.
+func (s *Store) ListChangesetJobs(ctx context.Context, opts ListChangesetJobsOpts) (cs []*btypes.ChangesetJob, err error) {
+	ctx, _, endObservation := s.operations.listChangesetJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listChangesetJobsQueryFmtstr,
+		sqlf.Join(changesetJobColumns.ToSqlf(), ", "),
+		sqlf.Join(listChangesetJobsPreds(&opts), "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.ChangesetJob
+		if err := scanChangesetJob(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listChangesetJobsQueryFmtstr = `
+SELECT %s FROM changeset_jobs
+INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+ORDER BY changeset_jobs.id ASC
+`
+
+// CountChangesetJobs counts the changeset jobs matching the given options.
+func (s *Store) CountChangesetJobs(ctx context.Context, opts ListChangesetJobsOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countChangesetJobsQueryFmtstr,
+		sqlf.Join(listChangesetJobsPreds(&opts), "\n AND "),
+	))
+}
+
+var countChangesetJobsQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_jobs
+INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+`
+
+func listChangesetJobsPreds(opts *ListChangesetJobsOpts) []*sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("repo.deleted_at IS NULL"),
+	}
+
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.batch_change_id = %s", opts.BatchChangeID))
+	}
+
+	if opts.JobType != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.job_type = %s", opts.JobType))
+	}
+
+	if len(opts.States) > 0 {
+		states := make([]*sqlf.Query, 0, len(opts.States))
+		for _, state := range opts.States {
+			states = append(states, sqlf.Sprintf("%s", state.ToDB()))
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.state IN (%s)", sqlf.Join(states, ",")))
+	}
+
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.created_at >= %s", opts.CreatedAfter))
+	}
+
+	if opts.AutoMerge {
+		preds = append(preds, sqlf.Sprintf("(changeset_jobs.payload->>'autoMerge')::boolean IS TRUE"))
+	}
+
+	return preds
+}
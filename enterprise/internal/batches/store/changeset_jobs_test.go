@@ -110,4 +110,66 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, clock b
 			}
 		})
 	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{BatchChangeID: jobs[0].BatchChangeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, jobs[:1]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("ByTypeAndState", func(t *testing.T) {
+			have, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{
+				JobType: btypes.ChangesetJobTypeMerge,
+				States:  []btypes.ChangesetJobState{btypes.ChangesetJobStateQueued},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 0 {
+				t.Fatalf("unexpected jobs returned: %+v", have)
+			}
+		})
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts ListChangesetJobsOpts
+			want int
+		}{
+			"all":               {opts: ListChangesetJobsOpts{}, want: 2},
+			"by batch change":   {opts: ListChangesetJobsOpts{BatchChangeID: jobs[1].BatchChangeID}, want: 1},
+			"by job type":       {opts: ListChangesetJobsOpts{JobType: btypes.ChangesetJobTypeMerge}, want: 0},
+			"created after now": {opts: ListChangesetJobsOpts{CreatedAfter: clock.Now().Add(1)}, want: 0},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.CountChangesetJobs(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("have count %d, want %d", have, tc.want)
+				}
+			})
+		}
+	})
+	t.Run("AutoMerge", func(t *testing.T) {
+		merges := []*btypes.ChangesetJob{
+			{UserID: 1234, BatchChangeID: 999, ChangesetID: changeset.ID, JobType: btypes.ChangesetJobTypeMerge, Payload: &btypes.ChangesetJobMergePayload{AutoMerge: true}},
+			{UserID: 1234, BatchChangeID: 999, ChangesetID: changeset.ID, JobType: btypes.ChangesetJobTypeMerge, Payload: &btypes.ChangesetJobMergePayload{Squash: true}},
+		}
+		if err := s.CreateChangesetJob(ctx, merges...); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{BatchChangeID: 999, AutoMerge: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != merges[0].ID {
+			t.Fatalf("unexpected jobs returned: %+v", have)
+		}
+	})
 }
@@ -54,6 +54,7 @@ var changesetStringColumns = SQLColumns{
 	"diff_stat_added",
 	"diff_stat_deleted",
 	"sync_state",
+	"external_checks",
 	"owned_by_batch_change_id",
 	"current_spec_id",
 	"previous_spec_id",
@@ -98,6 +99,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.diff_stat_added"),
 	sqlf.Sprintf("changesets.diff_stat_deleted"),
 	sqlf.Sprintf("changesets.sync_state"),
+	sqlf.Sprintf("changesets.external_checks"),
 	sqlf.Sprintf("changesets.owned_by_batch_change_id"),
 	sqlf.Sprintf("changesets.current_spec_id"),
 	sqlf.Sprintf("changesets.previous_spec_id"),
@@ -141,6 +143,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_deleted"),
 	sqlf.Sprintf("sync_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("owned_by_batch_change_id"),
 	sqlf.Sprintf("current_spec_id"),
 	sqlf.Sprintf("previous_spec_id"),
@@ -178,6 +181,7 @@ var changesetCodeHostStateInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_deleted"),
 	sqlf.Sprintf("sync_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("syncer_error"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
@@ -206,6 +210,7 @@ var changesetInsertStringColumns = []string{
 	"diff_stat_added",
 	"diff_stat_deleted",
 	"sync_state",
+	"external_checks",
 	"owned_by_batch_change_id",
 	"current_spec_id",
 	"previous_spec_id",
@@ -275,6 +280,11 @@ func (s *Store) CreateChangeset(ctx context.Context, cs ...*btypes.Changeset) (e
 				return err
 			}
 
+			externalChecks, err := externalChecksColumn(c)
+			if err != nil {
+				return err
+			}
+
 			// Not being able to find a title is fine, we just have a NULL in the database then.
 			title, _ := c.Title()
 
@@ -301,6 +311,7 @@ func (s *Store) CreateChangeset(ctx context.Context, cs ...*btypes.Changeset) (e
 				c.DiffStatAdded,
 				c.DiffStatDeleted,
 				syncState,
+				externalChecks,
 				dbutil.NullInt64Column(c.OwnedByBatchChangeID),
 				dbutil.NullInt64Column(c.CurrentSpecID),
 				dbutil.NullInt64Column(c.PreviousSpecID),
@@ -857,6 +868,11 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -883,6 +899,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.DiffStatAdded,
 		c.DiffStatDeleted,
 		syncState,
+		externalChecks,
 		dbutil.NullInt64Column(c.OwnedByBatchChangeID),
 		dbutil.NullInt64Column(c.CurrentSpecID),
 		dbutil.NullInt64Column(c.PreviousSpecID),
@@ -912,7 +929,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 
 var updateChangesetQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1113,6 +1130,11 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -1131,6 +1153,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		c.DiffStatAdded,
 		c.DiffStatDeleted,
 		syncState,
+		externalChecks,
 		c.SyncErrorMessage,
 		dbutil.NullStringColumn(title),
 		c.ID,
@@ -1142,7 +1165,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 
 var updateChangesetCodeHostStateQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1384,7 +1407,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, externalChecks json.RawMessage
 
 	var (
 		externalState          string
@@ -1415,6 +1438,7 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.DiffStatAdded,
 		&t.DiffStatDeleted,
 		&syncState,
+		&externalChecks,
 		&dbutil.NullInt64{N: &t.OwnedByBatchChangeID},
 		&dbutil.NullInt64{N: &t.CurrentSpecID},
 		&dbutil.NullInt64{N: &t.PreviousSpecID},
@@ -1478,6 +1502,12 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	if err = json.Unmarshal(externalChecks, &t.ExternalChecks); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal external checks: %s", externalChecks)
+	}
+	if len(t.ExternalChecks) == 0 {
+		t.ExternalChecks = nil
+	}
 
 	return nil
 }
@@ -1769,6 +1799,16 @@ func batchChangesColumn(c *btypes.Changeset) ([]byte, error) {
 	return json.Marshal(assocsAsMap)
 }
 
+// externalChecksColumn marshals the external checks of the changeset. The
+// column is not nullable, so a changeset without checks is stored as an empty
+// array.
+func externalChecksColumn(c *btypes.Changeset) ([]byte, error) {
+	if c.ExternalChecks == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.ExternalChecks)
+}
+
 func uiPublicationStateColumn(c *btypes.Changeset) *string {
 	var uiPublicationState *string
 	if state := c.UiPublicationState; state != nil {
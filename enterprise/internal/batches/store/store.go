@@ -202,6 +202,14 @@ type operations struct {
 	listBatchSpecWorkspaceFiles  *observation.Operation
 	countBatchSpecWorkspaceFiles *observation.Operation
 
+	upsertAutoMergePolicy    *observation.Operation
+	getAutoMergePolicy       *observation.Operation
+	deleteAutoMergePolicy    *observation.Operation
+	listAutoMergePolicies    *observation.Operation
+	replaceAutoMergeBlockers *observation.Operation
+	listAutoMergeBlockers    *observation.Operation
+	countAutoMergeBlockers   *observation.Operation
+
 	getBulkOperation        *observation.Operation
 	listBulkOperations      *observation.Operation
 	countBulkOperations     *observation.Operation
@@ -214,6 +222,8 @@ type operations struct {
 
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
+	listChangesetJobs  *observation.Operation
+	countChangesetJobs *observation.Operation
 
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
@@ -273,9 +283,10 @@ type operations struct {
 	retryBatchSpecWorkspaceExecutionJobs               *observation.Operation
 	disableBatchSpecWorkspaceExecutionCache            *observation.Operation
 
-	createBatchSpecResolutionJob *observation.Operation
-	getBatchSpecResolutionJob    *observation.Operation
-	listBatchSpecResolutionJobs  *observation.Operation
+	createBatchSpecResolutionJob          *observation.Operation
+	setBatchSpecResolutionJobDryRunResult *observation.Operation
+	getBatchSpecResolutionJob             *observation.Operation
+	listBatchSpecResolutionJobs           *observation.Operation
 
 	listBatchSpecExecutionCacheEntries     *observation.Operation
 	markUsedBatchSpecExecutionCacheEntries *observation.Operation
@@ -346,6 +357,14 @@ func newOperations(observationCtx *observation.Context) *operations {
 			listBatchSpecWorkspaceFiles:  op("ListBatchSpecWorkspaceFiles"),
 			countBatchSpecWorkspaceFiles: op("CountBatchSpecWorkspaceFiles"),
 
+			upsertAutoMergePolicy:    op("UpsertAutoMergePolicy"),
+			getAutoMergePolicy:       op("GetAutoMergePolicy"),
+			deleteAutoMergePolicy:    op("DeleteAutoMergePolicy"),
+			listAutoMergePolicies:    op("ListAutoMergePolicies"),
+			replaceAutoMergeBlockers: op("ReplaceAutoMergeBlockers"),
+			listAutoMergeBlockers:    op("ListAutoMergeBlockers"),
+			countAutoMergeBlockers:   op("CountAutoMergeBlockers"),
+
 			getBulkOperation:        op("GetBulkOperation"),
 			listBulkOperations:      op("ListBulkOperations"),
 			countBulkOperations:     op("CountBulkOperations"),
@@ -358,6 +377,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
+			listChangesetJobs:  op("ListChangesetJobs"),
+			countChangesetJobs: op("CountChangesetJobs"),
 
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
@@ -417,9 +438,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 			retryBatchSpecWorkspaceExecutionJobs:               op("RetryBatchSpecWorkspaceExecutionJobs"),
 			disableBatchSpecWorkspaceExecutionCache:            op("DisableBatchSpecWorkspaceExecutionCache"),
 
-			createBatchSpecResolutionJob: op("CreateBatchSpecResolutionJob"),
-			getBatchSpecResolutionJob:    op("GetBatchSpecResolutionJob"),
-			listBatchSpecResolutionJobs:  op("ListBatchSpecResolutionJobs"),
+			createBatchSpecResolutionJob:          op("CreateBatchSpecResolutionJob"),
+			setBatchSpecResolutionJobDryRunResult: op("SetBatchSpecResolutionJobDryRunResult"),
+			getBatchSpecResolutionJob:             op("GetBatchSpecResolutionJob"),
+			listBatchSpecResolutionJobs:           op("ListBatchSpecResolutionJobs"),
 
 			listBatchSpecExecutionCacheEntries:     op("ListBatchSpecExecutionCacheEntries"),
 			markUsedBatchSpecExecutionCacheEntries: op("MarkUsedBatchSpecExecutionCacheEntries"),
@@ -225,6 +225,7 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 			BaseRef:     workspace.Branch,
 			BaseRev:     workspace.Commit,
 			FileMatches: workspace.FileMatches,
+			Metadata:    workspace.RepositoryMetadata,
 		},
 		latestStepResult.Value,
 		workspace.Path,
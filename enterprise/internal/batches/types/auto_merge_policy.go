@@ -0,0 +1,140 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AutoMergeMethod defines how changesets are merged by an auto-merge policy.
+type AutoMergeMethod string
+
+// AutoMergeMethod constants.
+const (
+	AutoMergeMethodMerge  AutoMergeMethod = "MERGE"
+	AutoMergeMethodSquash AutoMergeMethod = "SQUASH"
+)
+
+// Valid returns true if the given AutoMergeMethod is valid.
+func (m AutoMergeMethod) Valid() bool {
+	switch m {
+	case AutoMergeMethodMerge, AutoMergeMethodSquash:
+		return true
+	default:
+		return false
+	}
+}
+
+// AutoMergeRequiredChecks defines which check states allow a changeset to be
+// merged by an auto-merge policy.
+type AutoMergeRequiredChecks string
+
+// AutoMergeRequiredChecks constants.
+const (
+	// AutoMergeRequiredChecksPassed requires all checks to have passed.
+	// Changesets without any checks are not merged.
+	AutoMergeRequiredChecksPassed AutoMergeRequiredChecks = "PASSED"
+	// AutoMergeRequiredChecksPassedOrNone requires all checks to have passed,
+	// but also merges changesets that don't have any checks.
+	AutoMergeRequiredChecksPassedOrNone AutoMergeRequiredChecks = "PASSED_OR_NONE"
+	// AutoMergeRequiredChecksNone ignores the check state.
+	AutoMergeRequiredChecksNone AutoMergeRequiredChecks = "NONE"
+)
+
+// Valid returns true if the given AutoMergeRequiredChecks is valid.
+func (c AutoMergeRequiredChecks) Valid() bool {
+	switch c {
+	case AutoMergeRequiredChecksPassed,
+		AutoMergeRequiredChecksPassedOrNone,
+		AutoMergeRequiredChecksNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// AutoMergePolicy configures when the open changesets of a batch change are
+// merged automatically.
+type AutoMergePolicy struct {
+	BatchChangeID int64
+	// UserID is the user who configured the policy. Merges are performed on
+	// their behalf, using their credentials.
+	UserID int32
+
+	MergeMethod AutoMergeMethod
+	// RequiredReviewState is the review state a changeset must be in. If
+	// empty, the review state is ignored.
+	RequiredReviewState ChangesetReviewState
+	RequiredChecks      AutoMergeRequiredChecks
+
+	// MergeWindowDays, MergeWindowStart and MergeWindowEnd describe when
+	// merges may happen, using the same format as rollout windows. If all of
+	// them are empty, merges may happen at any time.
+	MergeWindowDays  []string
+	MergeWindowStart string
+	MergeWindowEnd   string
+
+	// MaxMergesPerHour limits the number of merges in the batch change in any
+	// hour. Zero means no limit.
+	MaxMergesPerHour int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Squash returns true if changesets should be squash-merged.
+func (p *AutoMergePolicy) Squash() bool {
+	return p.MergeMethod == AutoMergeMethodSquash
+}
+
+// HasMergeWindow returns true if merges are restricted to a window.
+func (p *AutoMergePolicy) HasMergeWindow() bool {
+	return len(p.MergeWindowDays) > 0 || p.MergeWindowStart != "" || p.MergeWindowEnd != ""
+}
+
+// MergeWindow parses the merge window of the policy. It returns nil if the
+// policy has no merge window.
+func (p *AutoMergePolicy) MergeWindow() (*window.Window, error) {
+	if !p.HasMergeWindow() {
+		return nil, nil
+	}
+
+	return window.ParseWindow(&schema.BatchChangeRolloutWindow{
+		Days:  p.MergeWindowDays,
+		Start: p.MergeWindowStart,
+		End:   p.MergeWindowEnd,
+		Rate:  "unlimited",
+	})
+}
+
+// Validate returns an error if the policy is not valid.
+func (p *AutoMergePolicy) Validate() error {
+	var errs error
+	if !p.MergeMethod.Valid() {
+		errs = errors.Append(errs, errors.Errorf("invalid merge method %q", p.MergeMethod))
+	}
+	if p.RequiredReviewState != "" && !p.RequiredReviewState.Valid() {
+		errs = errors.Append(errs, errors.Errorf("invalid review state %q", p.RequiredReviewState))
+	}
+	if !p.RequiredChecks.Valid() {
+		errs = errors.Append(errs, errors.Errorf("invalid required checks %q", p.RequiredChecks))
+	}
+	if p.MaxMergesPerHour < 0 {
+		errs = errors.Append(errs, errors.New("max merges per hour cannot be negative"))
+	}
+	if _, err := p.MergeWindow(); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "invalid merge window"))
+	}
+	return errs
+}
+
+// AutoMergeBlocker records why an auto-merge policy didn't merge an open
+// changeset.
+type AutoMergeBlocker struct {
+	BatchChangeID int64
+	ChangesetID   int64
+	Reason        string
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,60 @@
+package types
+
+import "testing"
+
+func TestAutoMergePolicyValidate(t *testing.T) {
+	valid := AutoMergePolicy{
+		MergeMethod:    AutoMergeMethodSquash,
+		RequiredChecks: AutoMergeRequiredChecksPassed,
+	}
+
+	for name, tc := range map[string]struct {
+		mod     func(*AutoMergePolicy)
+		wantErr bool
+	}{
+		"valid": {mod: func(*AutoMergePolicy) {}},
+		"valid with window": {mod: func(p *AutoMergePolicy) {
+			p.RequiredReviewState = ChangesetReviewStateApproved
+			p.MergeWindowDays = []string{"monday", "friday"}
+			p.MergeWindowStart = "09:00"
+			p.MergeWindowEnd = "17:00"
+			p.MaxMergesPerHour = 10
+		}},
+		"invalid merge method": {
+			mod:     func(p *AutoMergePolicy) { p.MergeMethod = "REBASE" },
+			wantErr: true,
+		},
+		"invalid review state": {
+			mod:     func(p *AutoMergePolicy) { p.RequiredReviewState = "LGTM" },
+			wantErr: true,
+		},
+		"invalid required checks": {
+			mod:     func(p *AutoMergePolicy) { p.RequiredChecks = "" },
+			wantErr: true,
+		},
+		"negative max merges": {
+			mod:     func(p *AutoMergePolicy) { p.MaxMergesPerHour = -1 },
+			wantErr: true,
+		},
+		"invalid window day": {
+			mod:     func(p *AutoMergePolicy) { p.MergeWindowDays = []string{"someday"} },
+			wantErr: true,
+		},
+		"window start without end": {
+			mod:     func(p *AutoMergePolicy) { p.MergeWindowStart = "09:00" },
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := valid
+			tc.mod(&p)
+
+			err := p.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatal("unexpected nil error")
+			} else if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
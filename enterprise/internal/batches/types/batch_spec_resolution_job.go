@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
 )
 
@@ -45,6 +46,12 @@ type BatchSpecResolutionJob struct {
 	// change this in the future when we split those two operations.
 	InitiatorID int32
 
+	// DryRun is true if the job only previews the workspaces the batch spec
+	// resolves to. Dry-run jobs don't create any workspaces or changeset specs,
+	// they record their outcome in DryRunResult instead.
+	DryRun       bool
+	DryRunResult *BatchSpecDryRunResult
+
 	// workerutil fields
 	State           BatchSpecResolutionJobState
 	FailureMessage  *string
@@ -65,3 +72,99 @@ type BatchSpecResolutionJob struct {
 func (j *BatchSpecResolutionJob) RecordID() int {
 	return int(j.ID)
 }
+
+// BatchSpecDryRunResult is the outcome of a dry-run BatchSpecResolutionJob.
+type BatchSpecDryRunResult struct {
+	// Outcome summarizes whether any workspace would be executed.
+	Outcome BatchSpecDryRunOutcome `json:"outcome"`
+	// Workspaces are the workspaces that would be executed.
+	Workspaces []*BatchSpecDryRunWorkspace `json:"workspaces"`
+	// Skipped are the repositories and workspaces matched by the batch spec that
+	// would not be executed.
+	Skipped []*BatchSpecDryRunSkippedWorkspace `json:"skipped"`
+	// MaxChangesets is the maximum number of changesets the batch spec can
+	// produce. It's an upper bound, since the diffs of the workspaces are only
+	// known once they have been executed.
+	MaxChangesets int `json:"maxChangesets"`
+}
+
+// BatchSpecDryRunWorkspace is a workspace that would be executed.
+type BatchSpecDryRunWorkspace struct {
+	RepoID   api.RepoID   `json:"repoID"`
+	RepoName api.RepoName `json:"repoName"`
+	Branch   string       `json:"branch"`
+	Commit   string       `json:"commit"`
+	Path     string       `json:"path"`
+	// FileMatches are the files matched by the `on` search query within the
+	// workspace.
+	FileMatches []string `json:"fileMatches"`
+	// Steps are the indexes of the steps that would run in the workspace. Steps
+	// with an `if:` condition that can only be evaluated at execution time are
+	// included.
+	Steps []int `json:"steps"`
+	// Files are the files the steps that would run in the workspace define
+	// in `files:`.
+	Files []*BatchSpecDryRunStepFile `json:"files"`
+	// MaxChangesets is the maximum number of changesets the workspace can
+	// produce.
+	MaxChangesets int `json:"maxChangesets"`
+}
+
+// BatchSpecDryRunStepFile is a file a step defines in `files:`, which is
+// written to the container before the step runs.
+type BatchSpecDryRunStepFile struct {
+	// Step is the index of the step.
+	Step int    `json:"step"`
+	Path string `json:"path"`
+	// Content is the rendered content of the file. It's nil if the content
+	// depends on values that are only known at execution time, such as the
+	// outputs of previous steps.
+	Content *string `json:"content,omitempty"`
+}
+
+// BatchSpecDryRunOutcome summarizes the outcome of a dry-run.
+type BatchSpecDryRunOutcome string
+
+// BatchSpecDryRunOutcome constants.
+const (
+	// BatchSpecDryRunOutcomeWorkspaces is used if at least one workspace would
+	// be executed.
+	BatchSpecDryRunOutcomeWorkspaces BatchSpecDryRunOutcome = "WORKSPACES"
+	// BatchSpecDryRunOutcomeAllSkipped is used if the batch spec matched
+	// repositories, but all of their workspaces would be skipped.
+	BatchSpecDryRunOutcomeAllSkipped BatchSpecDryRunOutcome = "ALL_SKIPPED"
+	// BatchSpecDryRunOutcomeNoMatches is used if the batch spec didn't match
+	// any repository.
+	BatchSpecDryRunOutcomeNoMatches BatchSpecDryRunOutcome = "NO_MATCHES"
+)
+
+// BatchSpecDryRunSkipReason describes why a workspace would not be executed.
+type BatchSpecDryRunSkipReason string
+
+// BatchSpecDryRunSkipReason constants.
+const (
+	// BatchSpecDryRunSkipReasonIgnored is used for repositories with a .batchignore file.
+	BatchSpecDryRunSkipReasonIgnored BatchSpecDryRunSkipReason = "IGNORED"
+	// BatchSpecDryRunSkipReasonUnsupported is used for repositories on code hosts
+	// that are not supported by batch changes.
+	BatchSpecDryRunSkipReasonUnsupported BatchSpecDryRunSkipReason = "UNSUPPORTED"
+	// BatchSpecDryRunSkipReasonNoBranch is used for repositories without a
+	// default branch, such as empty repositories.
+	BatchSpecDryRunSkipReasonNoBranch BatchSpecDryRunSkipReason = "NO_BRANCH"
+	// BatchSpecDryRunSkipReasonNoSteps is used for workspaces in which the `if:`
+	// conditions of all steps evaluate to false.
+	BatchSpecDryRunSkipReasonNoSteps BatchSpecDryRunSkipReason = "NO_STEPS"
+	// BatchSpecDryRunSkipReasonOnCondition is used for repositories for which
+	// the `if:` condition of the `on` entry that matched them evaluates to
+	// false.
+	BatchSpecDryRunSkipReasonOnCondition BatchSpecDryRunSkipReason = "ON_CONDITION"
+)
+
+// BatchSpecDryRunSkippedWorkspace is a repository or workspace that would not be executed.
+type BatchSpecDryRunSkippedWorkspace struct {
+	RepoID   api.RepoID                `json:"repoID"`
+	RepoName api.RepoName              `json:"repoName"`
+	Branch   string                    `json:"branch,omitempty"`
+	Path     string                    `json:"path"`
+	Reason   BatchSpecDryRunSkipReason `json:"reason"`
+}
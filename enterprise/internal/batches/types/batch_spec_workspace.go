@@ -5,6 +5,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 type StepCacheResult struct {
@@ -25,6 +26,10 @@ type BatchSpecWorkspace struct {
 	FileMatches        []string
 	OnlyFetchWorkspace bool
 
+	// RepositoryMetadata is the metadata of the repository that's available
+	// in templates. Nil if the repository has no metadata.
+	RepositoryMetadata *template.RepositoryMetadata
+
 	Unsupported bool
 	Ignored     bool
 
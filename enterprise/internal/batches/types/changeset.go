@@ -241,6 +241,30 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetCheck is an individual CI check of a changeset on the code host,
+// such as a GitHub check run, a GitLab pipeline or an Azure Pipelines build.
+type ChangesetCheck struct {
+	// ExternalID identifies what has to be re-run on the code host to re-run
+	// the check: the check suite on GitHub, the pipeline on GitLab and the
+	// build on Azure DevOps. It is empty if the check can't be re-run, such as
+	// for commit statuses posted by external CI systems.
+	ExternalID  string              `json:"externalID,omitempty"`
+	Name        string              `json:"name"`
+	State       ChangesetCheckState `json:"state"`
+	URL         string              `json:"url,omitempty"`
+	StartedAt   time.Time           `json:"startedAt,omitempty"`
+	CompletedAt time.Time           `json:"completedAt,omitempty"`
+}
+
+// Duration returns how long the check ran. It is zero if the check hasn't
+// completed yet or the code host doesn't report when it ran.
+func (c ChangesetCheck) Duration() time.Duration {
+	if c.StartedAt.IsZero() || c.CompletedAt.IsZero() || c.CompletedAt.Before(c.StartedAt) {
+		return 0
+	}
+	return c.CompletedAt.Sub(c.StartedAt)
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -274,6 +298,10 @@ type Changeset struct {
 	DiffStatDeleted       *int32
 	SyncState             ChangesetSyncState
 
+	// ExternalChecks are the individual checks that ExternalCheckState is
+	// combined from.
+	ExternalChecks []ChangesetCheck
+
 	// The batch change that "owns" this changeset: it can create/close
 	// it on code host. If this is 0, it is imported/tracked by a batch change.
 	OwnedByBatchChangeID int64
@@ -345,6 +373,25 @@ func (c *Changeset) IsImporting() bool { return c.Unpublished() && c.CurrentSpec
 // IsImported returns whether the Changeset is imported
 func (c *Changeset) IsImported() bool { return c.OwnedByBatchChangeID == 0 }
 
+// RerunnableFailedChecks returns the external IDs of the failed checks that
+// can be re-run on the code host. Checks that are re-run together, such as
+// the check runs of a GitHub check suite, are only returned once.
+func (c *Changeset) RerunnableFailedChecks() []string {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, check := range c.ExternalChecks {
+		if check.State != ChangesetCheckStateFailed || check.ExternalID == "" {
+			continue
+		}
+		if _, ok := seen[check.ExternalID]; ok {
+			continue
+		}
+		seen[check.ExternalID] = struct{}{}
+		ids = append(ids, check.ExternalID)
+	}
+	return ids
+}
+
 // SetCurrentSpec sets the CurrentSpecID field and copies the diff stat over from the spec.
 func (c *Changeset) SetCurrentSpec(spec *ChangesetSpec) {
 	c.CurrentSpecID = spec.ID
@@ -47,6 +47,10 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+
+	ChangesetJobTypeUpdateBranch          ChangesetJobType = "update_branch"
+	ChangesetJobTypeSetReviewersAndLabels ChangesetJobType = "set_reviewers_and_labels"
+	ChangesetJobTypeRerunFailedChecks     ChangesetJobType = "rerun_failed_checks"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -59,6 +63,9 @@ type ChangesetJobReenqueuePayload struct{}
 
 type ChangesetJobMergePayload struct {
 	Squash bool `json:"squash,omitempty"`
+	// AutoMerge is set on the jobs enqueued by the auto-merge policy of a batch
+	// change.
+	AutoMerge bool `json:"autoMerge,omitempty"`
 }
 
 type ChangesetJobClosePayload struct{}
@@ -67,6 +74,16 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobUpdateBranchPayload struct{}
+
+type ChangesetJobRerunFailedChecksPayload struct{}
+
+type ChangesetJobSetReviewersAndLabelsPayload struct {
+	Reviewers []string `json:"reviewers,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
@@ -840,3 +840,37 @@ func TestChangeset_ResetReconcilerState(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeset_RerunnableFailedChecks(t *testing.T) {
+	c := &Changeset{ExternalChecks: []ChangesetCheck{
+		{ExternalID: "suite-1", Name: "build", State: ChangesetCheckStateFailed},
+		{ExternalID: "suite-1", Name: "test", State: ChangesetCheckStateFailed},
+		{ExternalID: "suite-2", Name: "lint", State: ChangesetCheckStatePassed},
+		{ExternalID: "suite-3", Name: "deploy", State: ChangesetCheckStatePending},
+		{Name: "ci/jenkins", State: ChangesetCheckStateFailed},
+	}}
+
+	have := c.RerunnableFailedChecks()
+	want := []string{"suite-1"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected checks (-want +have):\n%s", diff)
+	}
+}
+
+func TestChangesetCheck_Duration(t *testing.T) {
+	started := time.Unix(10, 0)
+	for name, tc := range map[string]struct {
+		check ChangesetCheck
+		want  time.Duration
+	}{
+		"completed":   {check: ChangesetCheck{StartedAt: started, CompletedAt: started.Add(time.Minute)}, want: time.Minute},
+		"running":     {check: ChangesetCheck{StartedAt: started}, want: 0},
+		"not started": {check: ChangesetCheck{}, want: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.check.Duration(); have != tc.want {
+				t.Errorf("unexpected duration: have=%s want=%s", have, tc.want)
+			}
+		})
+	}
+}
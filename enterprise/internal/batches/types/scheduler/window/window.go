@@ -120,6 +120,16 @@ func parseWeekday(raw string) (time.Weekday, error) {
 	}
 }
 
+// ParseWindow parses a single window outside of a rollout window
+// configuration, for callers that only need to know when the window is open.
+func ParseWindow(raw *schema.BatchChangeRolloutWindow) (*Window, error) {
+	w, err := parseWindow(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
 func parseWindow(raw *schema.BatchChangeRolloutWindow) (Window, error) {
 	w := Window{}
 	var errs error
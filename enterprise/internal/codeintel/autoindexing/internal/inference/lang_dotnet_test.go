@@ -0,0 +1,107 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("csharp")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution",
+			repositoryContents: map[string]string{
+				"App.sln":                   "",
+				"src/App/App.csproj":        "",
+				"src/Lib/Lib.csproj":        "",
+				"test/App.Tests.csproj":     "",
+				"src/App/bin/Gen.csproj":    "",
+				"src/App/obj/Gen.csproj":    "",
+				"examples/Example.csproj":   "",
+				"src/App/Program.cs":        "",
+				"src/Lib/LibraryClasses.cs": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "multiple solutions and standalone projects",
+			repositoryContents: map[string]string{
+				"backend/Backend.sln":            "",
+				"backend/Backend.Tools.sln":      "",
+				"backend/Api/Api.csproj":         "",
+				"tools/Migrator/Migrator.csproj": "",
+				"tools/Seeder/Seeder.csproj":     "",
+				"tools/Seeder/obj/Seeder.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "backend",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore Backend.Tools.sln", "dotnet restore Backend.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "backend",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "Backend.Tools.sln", "Backend.sln"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "tools/Migrator",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore Migrator.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "tools/Migrator",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "Migrator.csproj"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "tools/Seeder",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore Seeder.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "tools/Seeder",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "Seeder.csproj"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "no project files",
+			repositoryContents: map[string]string{
+				"Program.cs": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
@@ -19,6 +19,31 @@ func TestGoGenerator(t *testing.T) {
 	expectedIndexerImage, _ := libs.DefaultIndexerForLang("go")
 
 	testGenerators(t,
+		generatorTestCase{
+			description: "go modules in excluded directories",
+			repositoryContents: map[string]string{
+				"foo/bar/go.mod":        "",
+				"foo/testdata/go.mod":   "",
+				"examples/hello/go.mod": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "foo/bar",
+							Image:    expectedIndexerImage,
+							Commands: []string{netrcString, "go mod download"},
+						},
+					},
+					LocalSteps:       []string{netrcString},
+					Root:             "foo/bar",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-go", "--no-animation"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+				},
+			},
+		},
 		generatorTestCase{
 			description: "go modules",
 			repositoryContents: map[string]string{
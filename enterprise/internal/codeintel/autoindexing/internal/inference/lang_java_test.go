@@ -29,6 +29,77 @@ func TestJavaGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "gradle kotlin project",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":            "",
+				"build.gradle.kts":               "",
+				"app/build.gradle.kts":           "",
+				"buildSrc/build.gradle.kts":      "",
+				"tools/cli/build.gradle.kts":     "",
+				"tools/cli/src/main/kotlin/M.kt": "",
+				"samples/settings.gradle.kts":    "",
+				"samples/build.gradle.kts":       "",
+				"examples/demo/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "independent gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"server/settings.gradle.kts":       "",
+				"server/build.gradle.kts":          "",
+				"server/core/build.gradle.kts":     "",
+				"android/build.gradle.kts":         "",
+				"android/app/build.gradle.kts":     "",
+				"android/app/src/main/kotlin/A.kt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "android",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin project with lsif-java.json",
+			repositoryContents: map[string]string{
+				"lsif-java.json":       "",
+				"build.gradle.kts":     "",
+				"app/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
 		generatorTestCase{
 			description: "java project without lsif-java.json (no match)",
 			repositoryContents: map[string]string{
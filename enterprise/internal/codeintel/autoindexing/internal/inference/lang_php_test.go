@@ -0,0 +1,46 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("php")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "scip-php",
+			repositoryContents: map[string]string{
+				"composer.json":                    "",
+				"composer.lock":                    "",
+				"packages/billing/composer.json":   "",
+				"vendor/acme/http/composer.json":   "",
+				"tests/fixtures/app/composer.json": "",
+				"src/Kernel.php":                   "",
+			},
+			expected: func() []config.IndexJob {
+				var out []config.IndexJob
+				for _, root := range []string{"", "packages/billing"} {
+					out = append(out, config.IndexJob{
+						Steps: []config.DockerStep{
+							{
+								Root:     root,
+								Image:    expectedIndexerImage,
+								Commands: []string{"composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs"},
+							},
+						},
+						LocalSteps:  nil,
+						Root:        root,
+						Indexer:     expectedIndexerImage,
+						IndexerArgs: []string{"scip-php"},
+						Outfile:     "index.scip",
+					})
+				}
+				return out
+			}(),
+		},
+	)
+}
@@ -17,8 +17,10 @@ type indexesAPI struct{}
 
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
+	"csharp":     "sourcegraph/scip-dotnet",
 	"go":         "sourcegraph/scip-go",
 	"java":       "sourcegraph/scip-java",
+	"php":        "sourcegraph/scip-php",
 	"python":     "sourcegraph/scip-python",
 	"rust":       "sourcegraph/scip-rust",
 	"typescript": "sourcegraph/scip-typescript",
@@ -37,6 +39,13 @@ var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/scip-ruby":       "sha256:e553fee039973cda8726d4c8c13cdbb851f82a6fca5daa15798a595ee4042906",
 }
 
+// Indexers that are not pinned to a SHA yet are referenced by tag. To pin one,
+// move it to defaultIndexerSHAs and run update-shas.sh.
+var unpinnedIndexerTags = map[string]string{
+	"sourcegraph/scip-dotnet": "latest",
+	"sourcegraph/scip-php":    "latest",
+}
+
 func DefaultIndexerForLang(language string) (string, bool) {
 	indexer, ok := defaultIndexers[language]
 	if !ok {
@@ -45,6 +54,9 @@ func DefaultIndexerForLang(language string) (string, bool) {
 
 	sha, ok := defaultIndexerSHAs[indexer]
 	if !ok {
+		if tag, ok := unpinnedIndexerTags[indexer]; ok {
+			return fmt.Sprintf("%s:%s", indexer, tag), true
+		}
 		panic(fmt.Sprintf("no SHA set for indexer %q", indexer))
 	}
 
@@ -0,0 +1,111 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "csharp"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine {
+  shared.exclude_paths,
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+}
+
+-- Groups the basenames of the given paths by their directory, and returns the
+-- directories in a stable order.
+local group_by_dir = function(paths)
+  local files_by_dir = {}
+  local dirs = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+    if files_by_dir[dir] == nil then
+      files_by_dir[dir] = {}
+      table.insert(dirs, dir)
+    end
+
+    table.insert(files_by_dir[dir], path.basename(paths[i]))
+  end
+
+  table.sort(dirs)
+  for _, files in pairs(files_by_dir) do
+    table.sort(files)
+  end
+
+  return dirs, files_by_dir
+end
+
+local make_job = function(root, files)
+  local restore_commands = {}
+  for _, file in ipairs(files) do
+    table.insert(restore_commands, "dotnet restore " .. file)
+  end
+
+  return {
+    steps = {
+      {
+        root = root,
+        image = indexer,
+        commands = restore_commands,
+      },
+    },
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index", unpack(files) },
+    outfile = outfile,
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when .sln or .csproj files exist. Each directory containing a solution
+  -- file is indexed through its solutions. Projects that are not nested under the
+  -- directory of a solution are indexed on their own.
+  generate = function(_, paths)
+    local solutions = {}
+    local projects = {}
+    for i = 1, #paths do
+      if path.basename(paths[i]):match "%.sln$" then
+        table.insert(solutions, paths[i])
+      else
+        table.insert(projects, paths[i])
+      end
+    end
+
+    local solution_dirs, solutions_by_dir = group_by_dir(solutions)
+
+    local standalone_projects = {}
+    for _, project in ipairs(projects) do
+      local ancestors = path.ancestors(project)
+      local has_solution = false
+      for i = 1, #ancestors do
+        if solutions_by_dir[ancestors[i]] ~= nil then
+          has_solution = true
+          break
+        end
+      end
+
+      if not has_solution then
+        table.insert(standalone_projects, project)
+      end
+    end
+
+    local project_dirs, projects_by_dir = group_by_dir(standalone_projects)
+
+    local jobs = {}
+    for _, dir in ipairs(solution_dirs) do
+      table.insert(jobs, make_job(dir, solutions_by_dir[dir]))
+    end
+    for _, dir in ipairs(project_dirs) do
+      table.insert(jobs, make_job(dir, projects_by_dir[dir]))
+    end
+
+    return jobs
+  end,
+}
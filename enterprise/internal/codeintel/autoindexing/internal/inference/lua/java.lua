@@ -2,6 +2,8 @@ local path = require "path"
 local recognizer = require "sg.autoindex.recognizer"
 local pattern = require "sg.autoindex.patterns"
 
+local shared = require "sg.autoindex.shared"
+
 local indexer = require("sg.autoindex.indexes").get "java"
 local outfile = "index.scip"
 
@@ -9,6 +11,62 @@ local is_project_structure_supported = function(base)
   return base == "pom.xml" or base == "build.gradle" or base == "build.gradle.kts"
 end
 
+-- Returns the directories of the given paths that are not nested in the directory
+-- of another one of the given paths.
+local outermost_dirs = function(paths)
+  local dirs = {}
+  for i = 1, #paths do
+    dirs[path.dirname(paths[i])] = true
+  end
+
+  local roots = {}
+  for dir in pairs(dirs) do
+    local ancestors = path.ancestors(dir)
+    local nested = false
+    for i = 1, #ancestors do
+      if ancestors[i] ~= dir and dirs[ancestors[i]] then
+        nested = true
+        break
+      end
+    end
+
+    if not nested then
+      table.insert(roots, dir)
+    end
+  end
+
+  table.sort(roots)
+  return roots
+end
+
+local gradle_kotlin_recognizer = recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "settings.gradle.kts",
+    pattern.new_path_basename "build.gradle.kts",
+    pattern.new_path_exclude(pattern.new_path_combine {
+      shared.exclude_paths,
+      pattern.new_path_segment "buildSrc",
+    }),
+  },
+
+  -- Invoked when Gradle Kotlin DSL build files exist and there is no lsif-java.json.
+  -- Subprojects are indexed as part of the outermost Gradle build containing them.
+  generate = function(_, paths)
+    local jobs = {}
+    for _, root in ipairs(outermost_dirs(paths)) do
+      table.insert(jobs, {
+        steps = {},
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-java", "index", "--build-tool=gradle" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
+
 return recognizer.new_path_recognizer {
   patterns = {
     pattern.new_path_extension "java",
@@ -21,21 +79,25 @@ return recognizer.new_path_recognizer {
 
   -- Invoked when Java, Scala, Kotlin, or Gradle build files exist
   generate = function(api)
-    api:register(recognizer.new_path_recognizer {
-      patterns = {
-        pattern.new_path_literal "lsif-java.json",
+    api:register(recognizer.new_fallback_recognizer {
+      recognizer.new_path_recognizer {
+        patterns = {
+          pattern.new_path_literal "lsif-java.json",
+        },
+
+        -- Invoked when lsif-java.json exists in root of repository
+        generate = function(api, paths)
+          return {
+            steps = {},
+            root = "",
+            indexer = indexer,
+            indexer_args = { "scip-java", "index", "--build-tool=scip" },
+            outfile = outfile,
+          }
+        end,
       },
 
-      -- Invoked when lsif-java.json exists in root of repository
-      generate = function(api, paths)
-        return {
-          steps = {},
-          root = "",
-          indexer = indexer,
-          indexer_args = { "scip-java", "index", "--build-tool=scip" },
-          outfile = outfile,
-        }
-      end,
+      gradle_kotlin_recognizer,
     })
 
     return {}
@@ -54,16 +54,20 @@ func FlattenPatterns(pathPatterns []*PathPattern, inverted bool) (patterns []Glo
 }
 
 // FlattenPattern returns the set of patterns matching the given inverted flag on this
-// path pattern or any of its descendants.
-func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []GlobAndPathspecPattern) {
-	if pathPattern.invert == inverted {
-		if pathPattern.pattern.Glob != "" {
-			patterns = append(patterns, pathPattern.pattern)
-		}
-
-		for _, child := range pathPattern.children {
-			patterns = append(patterns, FlattenPattern(child, inverted)...)
-		}
+// path pattern or any of its descendants. The descendants of an exclude pattern are
+// all inverted.
+func FlattenPattern(pathPattern *PathPattern, inverted bool) []GlobAndPathspecPattern {
+	return flattenPattern(pathPattern, inverted, false)
+}
+
+func flattenPattern(pathPattern *PathPattern, inverted, excluded bool) (patterns []GlobAndPathspecPattern) {
+	excluded = excluded || pathPattern.invert
+	if excluded == inverted && pathPattern.pattern.Glob != "" {
+		patterns = append(patterns, pathPattern.pattern)
+	}
+
+	for _, child := range pathPattern.children {
+		patterns = append(patterns, flattenPattern(child, inverted, excluded)...)
 	}
 
 	return
@@ -0,0 +1,49 @@
+package luatypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlattenPattern(t *testing.T) {
+	pattern := NewCombinedPattern([]*PathPattern{
+		NewPattern("**/go.mod", nil),
+		NewExcludePattern([]*PathPattern{
+			NewCombinedPattern([]*PathPattern{
+				NewPattern("**/vendor/**", nil),
+				NewPattern("**/testdata/**", []string{"testdata/"}),
+			}),
+			// Excluding an exclusion still excludes.
+			NewExcludePattern([]*PathPattern{
+				NewPattern("**/examples/**", nil),
+			}),
+		}),
+	})
+
+	tests := []struct {
+		inverted bool
+		want     []GlobAndPathspecPattern
+	}{
+		{
+			inverted: false,
+			want: []GlobAndPathspecPattern{
+				{Glob: "**/go.mod"},
+			},
+		},
+		{
+			inverted: true,
+			want: []GlobAndPathspecPattern{
+				{Glob: "**/vendor/**"},
+				{Glob: "**/testdata/**", Pathspecs: []string{"testdata/"}},
+				{Glob: "**/examples/**"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, FlattenPattern(pattern, tt.inverted)); diff != "" {
+			t.Errorf("unexpected patterns for inverted=%v (-want +got):\n%s", tt.inverted, diff)
+		}
+	}
+}
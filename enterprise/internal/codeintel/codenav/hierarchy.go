@@ -0,0 +1,237 @@
+package codenav
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+)
+
+// enclosingRangeFieldNumber is the field number of `Occurrence.enclosing_range`. The SCIP
+// bindings we depend on predate this field, so it is read from the unknown fields retained
+// on decoded occurrences.
+const enclosingRangeFieldNumber protowire.Number = 7
+
+// callableDefinition is the definition of a function or method within a SCIP document.
+type callableDefinition struct {
+	symbol string
+	// Range of the definition occurrence (the name of the function).
+	nameRange shared.Range
+	// Range of the entire function, including its body.
+	extent shared.Range
+}
+
+// callableDefinitions returns the definitions of functions and methods in the given document
+// ordered by position. The extent of a definition is the enclosing range of its occurrence if
+// the indexer emitted one. Otherwise, the definition is assumed to extend up to the start of
+// the next callable definition in the document.
+func callableDefinitions(document *scip.Document) []callableDefinition {
+	type definitionWithEnclosingRange struct {
+		callableDefinition
+		enclosingRange []int32
+	}
+
+	var candidates []definitionWithEnclosingRange
+	for _, occurrence := range document.Occurrences {
+		if !scip.SymbolRole_Definition.Matches(occurrence) || !isCallableSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		candidates = append(candidates, definitionWithEnclosingRange{
+			callableDefinition: callableDefinition{
+				symbol:    occurrence.Symbol,
+				nameRange: translateSCIPRange(occurrence.Range),
+			},
+			enclosingRange: enclosingRange(occurrence),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return comparePositions(candidates[i].nameRange.Start, candidates[j].nameRange.Start) < 0
+	})
+
+	definitions := make([]callableDefinition, 0, len(candidates))
+	for i, candidate := range candidates {
+		definition := candidate.callableDefinition
+		if n := len(candidate.enclosingRange); n == 3 || n == 4 {
+			definition.extent = translateSCIPRange(candidate.enclosingRange)
+		} else {
+			end := shared.Position{Line: maxPosition, Character: maxPosition}
+			if i+1 < len(candidates) {
+				end = candidates[i+1].nameRange.Start
+			}
+			definition.extent = shared.Range{Start: definition.nameRange.Start, End: end}
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions
+}
+
+// maxPosition is used as the line and character of the end of a document.
+const maxPosition = int(^uint32(0) >> 1)
+
+// enclosingCallable returns the innermost callable definition whose extent contains the given
+// position. Definitions must be ordered by position.
+func enclosingCallable(definitions []callableDefinition, position shared.Position) (callableDefinition, bool) {
+	for i := len(definitions) - 1; i >= 0; i-- {
+		if rangeContainsPosition(definitions[i].extent, position) {
+			return definitions[i], true
+		}
+	}
+
+	return callableDefinition{}, false
+}
+
+// callableDefinitionAt returns the callable definition whose name contains the given position.
+func callableDefinitionAt(definitions []callableDefinition, position shared.Position) (callableDefinition, bool) {
+	for _, definition := range definitions {
+		if rangeContainsPosition(definition.nameRange, position) {
+			return definition, true
+		}
+	}
+
+	return callableDefinition{}, false
+}
+
+// definitionSymbolAt returns the symbol defined at the given range in the given document.
+func definitionSymbolAt(document *scip.Document, rng shared.Range) (string, bool) {
+	for _, occurrence := range document.Occurrences {
+		if scip.SymbolRole_Definition.Matches(occurrence) && translateSCIPRange(occurrence.Range) == rng {
+			return occurrence.Symbol, true
+		}
+	}
+
+	return "", false
+}
+
+// isCallableSymbol returns true if the given symbol names a function or method.
+func isCallableSymbol(symbol string) bool {
+	return lastDescriptorSuffix(symbol) == scip.Descriptor_Method
+}
+
+// isTypeSymbol returns true if the given symbol names a type.
+func isTypeSymbol(symbol string) bool {
+	return lastDescriptorSuffix(symbol) == scip.Descriptor_Type
+}
+
+func lastDescriptorSuffix(symbol string) scip.Descriptor_Suffix {
+	if symbol == "" || scip.IsLocalSymbol(symbol) {
+		return scip.Descriptor_UnspecifiedSuffix
+	}
+
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return scip.Descriptor_UnspecifiedSuffix
+	}
+
+	return parsed.Descriptors[len(parsed.Descriptors)-1].Suffix
+}
+
+// enclosingRange returns the enclosing range of the given occurrence, or nil if the indexer
+// did not emit one.
+func enclosingRange(occurrence *scip.Occurrence) []int32 {
+	var rng []int32
+	b := occurrence.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		fieldNumber, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil
+		}
+		b = b[n:]
+
+		if fieldNumber == enclosingRangeFieldNumber {
+			switch wireType {
+			case protowire.BytesType:
+				packed, n := protowire.ConsumeBytes(b)
+				if n < 0 {
+					return nil
+				}
+				b = b[n:]
+
+				for len(packed) > 0 {
+					v, n := protowire.ConsumeVarint(packed)
+					if n < 0 {
+						return nil
+					}
+					packed = packed[n:]
+					rng = append(rng, int32(v))
+				}
+				continue
+
+			case protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				if n < 0 {
+					return nil
+				}
+				b = b[n:]
+				rng = append(rng, int32(v))
+				continue
+			}
+		}
+
+		n = protowire.ConsumeFieldValue(fieldNumber, wireType, b)
+		if n < 0 {
+			return nil
+		}
+		b = b[n:]
+	}
+
+	return rng
+}
+
+// translateSCIPRange converts a SCIP range into a range.
+func translateSCIPRange(scipRange []int32) shared.Range {
+	r := scip.NewRange(scipRange)
+
+	return shared.Range{
+		Start: shared.Position{Line: int(r.Start.Line), Character: int(r.Start.Character)},
+		End:   shared.Position{Line: int(r.End.Line), Character: int(r.End.Character)},
+	}
+}
+
+// comparePositions returns a negative number if a comes before b, a positive number if a comes
+// after b, and zero if they are equal.
+func comparePositions(a, b shared.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+
+	return a.Character - b.Character
+}
+
+// scipDocumentCache memoizes the SCIP documents read while resolving a hierarchy, as many
+// locations tend to fall into the same handful of documents.
+type scipDocumentCache struct {
+	lsifstore lsifstore.LsifStore
+	documents map[string]*scip.Document
+}
+
+func newSCIPDocumentCache(lsifstore lsifstore.LsifStore) *scipDocumentCache {
+	return &scipDocumentCache{
+		lsifstore: lsifstore,
+		documents: map[string]*scip.Document{},
+	}
+}
+
+// get returns the document at the given path (relative to the upload root) of the given upload.
+// A nil document is returned if the upload has no such document.
+func (c *scipDocumentCache) get(ctx context.Context, uploadID int, path string) (*scip.Document, error) {
+	key := strconv.Itoa(uploadID) + ":" + path
+	if document, ok := c.documents[key]; ok {
+		return document, nil
+	}
+
+	document, err := c.lsifstore.SCIPDocument(ctx, uploadID, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.documents[key] = document
+	return document, nil
+}
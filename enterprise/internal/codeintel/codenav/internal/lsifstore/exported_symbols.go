@@ -0,0 +1,88 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetExportedSymbols returns the definitions of all non-local symbols in the given upload, ordered
+// by path. SCIP does not record the visibility of a symbol, so every global symbol defined by the
+// upload is returned. Symbols defined in multiple documents are reported at their first definition.
+func (s *store) GetExportedSymbols(ctx context.Context, uploadID int) (_ []shared.ExportedSymbol, err error) {
+	ctx, _, endObservation := s.operations.getExportedSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(exportedSymbolsDocumentsQuery, uploadID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	// Documents are decoded one at a time so that we only hold on to the symbols of large uploads
+	var symbols []shared.ExportedSymbol
+	seen := map[string]struct{}{}
+	for rows.Next() {
+		documentData, err := s.scanSingleDocumentDataObject(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		symbols = append(symbols, extractExportedSymbols(documentData.Path, documentData.SCIPData, seen)...)
+	}
+
+	return symbols, nil
+}
+
+const exportedSymbolsDocumentsQuery = `
+SELECT
+	sd.id,
+	sid.document_path,
+	sd.raw_scip_payload
+FROM codeintel_scip_document_lookup sid
+JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
+WHERE sid.upload_id = %s
+ORDER BY sid.document_path
+`
+
+// extractExportedSymbols returns the definitions of the non-local symbols within the given document
+// that are not already in the seen set. The seen set is updated in place.
+func extractExportedSymbols(path string, document *scip.Document, seen map[string]struct{}) []shared.ExportedSymbol {
+	symbolMap := map[string]*scip.SymbolInformation{}
+	for _, symbol := range document.Symbols {
+		symbolMap[symbol.Symbol] = symbol
+	}
+
+	var symbols []shared.ExportedSymbol
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) || !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+		if _, ok := seen[occurrence.Symbol]; ok {
+			continue
+		}
+		seen[occurrence.Symbol] = struct{}{}
+
+		var documentation []string
+		if symbol, ok := symbolMap[occurrence.Symbol]; ok {
+			documentation = symbol.Documentation
+		}
+
+		symbols = append(symbols, shared.ExportedSymbol{
+			Symbol:        occurrence.Symbol,
+			Path:          path,
+			Range:         translateRange(scip.NewRange(occurrence.Range)),
+			Documentation: documentation,
+		})
+	}
+
+	return symbols
+}
@@ -0,0 +1,56 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+)
+
+func TestExtractExportedSymbols(t *testing.T) {
+	const (
+		parse   = "scip-go gomod github.com/example/lib v1.0.0 lib/Parse()."
+		options = "scip-go gomod github.com/example/lib v1.0.0 lib/Options#"
+		other   = "scip-go gomod github.com/other/lib v0.1.0 lib/Other()."
+	)
+
+	document := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{3, 5, 10}, Symbol: parse, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{4, 1, 6}, Symbol: "local 1", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{5, 2, 7}, Symbol: other},
+			{Range: []int32{8, 5, 12}, Symbol: options, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{9, 5, 12}, Symbol: options, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		},
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: parse, Documentation: []string{"```go\nfunc Parse(s string) error\n```", "Parse parses s."}},
+		},
+	}
+
+	seen := map[string]struct{}{}
+	symbols := extractExportedSymbols("lib/parse.go", document, seen)
+
+	expected := []shared.ExportedSymbol{
+		{
+			Symbol:        parse,
+			Path:          "lib/parse.go",
+			Range:         shared.Range{Start: shared.Position{Line: 3, Character: 5}, End: shared.Position{Line: 3, Character: 10}},
+			Documentation: []string{"```go\nfunc Parse(s string) error\n```", "Parse parses s."},
+		},
+		{
+			Symbol: options,
+			Path:   "lib/parse.go",
+			Range:  shared.Range{Start: shared.Position{Line: 8, Character: 5}, End: shared.Position{Line: 8, Character: 12}},
+		},
+	}
+	if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	// Symbols already defined by a previous document are skipped
+	if symbols := extractExportedSymbols("lib/parse_other.go", document, seen); len(symbols) != 0 {
+		t.Errorf("unexpected symbols: %v", symbols)
+	}
+}
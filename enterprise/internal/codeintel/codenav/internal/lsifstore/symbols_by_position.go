@@ -68,7 +68,7 @@ func (s *store) GetMonikersByPosition(ctx context.Context, uploadID int, path st
 			}
 		}
 
-		moniker, err := symbolNameToQualifiedMoniker(occurrence.Symbol, kind)
+		moniker, err := SymbolNameToQualifiedMoniker(occurrence.Symbol, kind)
 		if err != nil {
 			return nil, err
 		}
@@ -77,7 +77,7 @@ func (s *store) GetMonikersByPosition(ctx context.Context, uploadID int, path st
 		if hasSymbol {
 			for _, rel := range symbol.Relationships {
 				if rel.IsImplementation {
-					relatedMoniker, err := symbolNameToQualifiedMoniker(rel.Symbol, precise.Implementation)
+					relatedMoniker, err := SymbolNameToQualifiedMoniker(rel.Symbol, precise.Implementation)
 					if err != nil {
 						return nil, err
 					}
@@ -145,7 +145,10 @@ func (s *store) GetPackageInformation(ctx context.Context, bundleID int, path, p
 	return precise.PackageInformationData{}, false, nil
 }
 
-func symbolNameToQualifiedMoniker(symbolName, kind string) (precise.MonikerData, error) {
+// SymbolNameToQualifiedMoniker converts a SCIP symbol into a moniker of the given kind. The package
+// information identifier of the moniker encodes the package of the symbol and is decoded again by
+// GetPackageInformation.
+func SymbolNameToQualifiedMoniker(symbolName, kind string) (precise.MonikerData, error) {
 	parsedSymbol, err := scip.ParseSymbol(symbolName)
 	if err != nil {
 		return precise.MonikerData{}, err
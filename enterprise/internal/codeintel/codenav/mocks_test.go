@@ -31,6 +31,9 @@ type MockLsifStore struct {
 	// GetDiagnosticsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDiagnostics.
 	GetDiagnosticsFunc *LsifStoreGetDiagnosticsFunc
+	// GetExportedSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetExportedSymbols.
+	GetExportedSymbolsFunc *LsifStoreGetExportedSymbolsFunc
 	// GetHoverFunc is an instance of a mock function object controlling the
 	// behavior of the method GetHover.
 	GetHoverFunc *LsifStoreGetHoverFunc
@@ -83,6 +86,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetExportedSymbolsFunc: &LsifStoreGetExportedSymbolsFunc{
+			defaultHook: func(context.Context, int) (r0 []shared.ExportedSymbol, r1 error) {
+				return
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 string, r1 shared.Range, r2 bool, r3 error) {
 				return
@@ -155,6 +163,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetDiagnostics")
 			},
 		},
+		GetExportedSymbolsFunc: &LsifStoreGetExportedSymbolsFunc{
+			defaultHook: func(context.Context, int) ([]shared.ExportedSymbol, error) {
+				panic("unexpected invocation of MockLsifStore.GetExportedSymbols")
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
 				panic("unexpected invocation of MockLsifStore.GetHover")
@@ -221,6 +234,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetDiagnosticsFunc: &LsifStoreGetDiagnosticsFunc{
 			defaultHook: i.GetDiagnostics,
 		},
+		GetExportedSymbolsFunc: &LsifStoreGetExportedSymbolsFunc{
+			defaultHook: i.GetExportedSymbols,
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: i.GetHover,
 		},
@@ -629,6 +645,115 @@ func (c LsifStoreGetDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetExportedSymbolsFunc describes the behavior when the
+// GetExportedSymbols method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetExportedSymbolsFunc struct {
+	defaultHook func(context.Context, int) ([]shared.ExportedSymbol, error)
+	hooks       []func(context.Context, int) ([]shared.ExportedSymbol, error)
+	history     []LsifStoreGetExportedSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetExportedSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetExportedSymbols(v0 context.Context, v1 int) ([]shared.ExportedSymbol, error) {
+	r0, r1 := m.GetExportedSymbolsFunc.nextHook()(v0, v1)
+	m.GetExportedSymbolsFunc.appendCall(LsifStoreGetExportedSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetExportedSymbols
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetExportedSymbolsFunc) SetDefaultHook(hook func(context.Context, int) ([]shared.ExportedSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetExportedSymbols method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetExportedSymbolsFunc) PushHook(hook func(context.Context, int) ([]shared.ExportedSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetExportedSymbolsFunc) SetDefaultReturn(r0 []shared.ExportedSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared.ExportedSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetExportedSymbolsFunc) PushReturn(r0 []shared.ExportedSymbol, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared.ExportedSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetExportedSymbolsFunc) nextHook() func(context.Context, int) ([]shared.ExportedSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetExportedSymbolsFunc) appendCall(r0 LsifStoreGetExportedSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetExportedSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetExportedSymbolsFunc) History() []LsifStoreGetExportedSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetExportedSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetExportedSymbolsFuncCall is an object that describes an
+// invocation of method GetExportedSymbols on an instance of MockLsifStore.
+type LsifStoreGetExportedSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.ExportedSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetExportedSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetExportedSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetHoverFunc describes the behavior when the GetHover method of
 // the parent MockLsifStore instance is invoked.
 type LsifStoreGetHoverFunc struct {
@@ -14,6 +14,10 @@ import (
 type operations struct {
 	getReferences          *observation.Operation
 	getImplementations     *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getSupertypes          *observation.Operation
+	getSubtypes            *observation.Operation
 	getDiagnostics         *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
@@ -22,6 +26,9 @@ type operations struct {
 	getClosestDumpsForBlob *observation.Operation
 	snapshotForDocument    *observation.Operation
 	visibleUploadsForPath  *observation.Operation
+
+	getSymbolChanges            *observation.Operation
+	getSymbolExternalReferences *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -47,6 +54,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		getReferences:          op("getReferences"),
 		getImplementations:     op("getImplementations"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
+		getSupertypes:          op("getSupertypes"),
+		getSubtypes:            op("getSubtypes"),
 		getDiagnostics:         op("getDiagnostics"),
 		getHover:               op("getHover"),
 		getDefinitions:         op("getDefinitions"),
@@ -55,6 +66,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 		snapshotForDocument:    op("SnapshotForDocument"),
 		visibleUploadsForPath:  op("VisibleUploadsForPath"),
+
+		getSymbolChanges:            op("getSymbolChanges"),
+		getSymbolExternalReferences: op("getSymbolExternalReferences"),
 	}
 }
 
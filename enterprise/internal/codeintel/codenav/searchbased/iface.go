@@ -0,0 +1,25 @@
+package searchbased
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	codeinteltypes "github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type SearchClient interface {
+	// Search runs the given search query on behalf of the actor in the context and returns
+	// the files it matched, including their line and symbol matches.
+	Search(ctx context.Context, query string) ([]*result.FileMatch, error)
+}
+
+type SymbolsClient interface {
+	LocalCodeIntel(ctx context.Context, args types.RepoCommitPath) (*types.LocalCodeIntelPayload, error)
+}
+
+type RankingService interface {
+	GetRepoRanks(ctx context.Context, repoNames []api.RepoName) (map[api.RepoName][]float64, error)
+	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (codeinteltypes.RepoPathRanks, error)
+}
@@ -0,0 +1,513 @@
+package searchbased
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/grafana/regexp"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	// maximumSearchResults is the number of matches requested from each search query issued
+	// while resolving the references of a single symbol.
+	maximumSearchResults = 500
+
+	// The confidence of a text match starts at baseConfidence and is adjusted by the repository
+	// the match occurs in and by the rank of the document containing it. References resolved by
+	// squirrel within the requested file are exact and have a confidence of 1.
+	baseConfidence             = 0.4
+	sourceRepositoryBonus      = 0.3
+	shadowingRepositoryPenalty = 0.2
+	documentRankWeight         = 0.2
+
+	// Grouped references are cached so that subsequent pages of the same request do not re-run
+	// the searches. Entries are short-lived as the searched repositories keep changing.
+	referencesCacheSize = 100
+	referencesCacheTTL  = time.Minute
+)
+
+type Service struct {
+	searchClient    SearchClient
+	symbolsClient   SymbolsClient
+	rankingSvc      RankingService
+	gitserverClient gitserver.Client
+	operations      *operations
+	referencesCache *lru.Cache[referencesCacheKey, cachedReferences]
+}
+
+// referencesCacheKey identifies a references request. Search results depend on the repositories
+// visible to the user, so cached results are never shared between users.
+type referencesCacheKey struct {
+	userID int32
+	args   ReferencesArgs
+}
+
+type cachedReferences struct {
+	groups         []RepositoryReferences
+	shadowingRepos map[api.RepoName]struct{}
+	expiresAt      time.Time
+}
+
+func NewService(
+	observationCtx *observation.Context,
+	searchClient SearchClient,
+	symbolsClient SymbolsClient,
+	rankingSvc RankingService,
+	gitserverClient gitserver.Client,
+) *Service {
+	// lru.New only fails for non-positive sizes
+	referencesCache, _ := lru.New[referencesCacheKey, cachedReferences](referencesCacheSize)
+
+	return &Service{
+		searchClient:    searchClient,
+		symbolsClient:   symbolsClient,
+		rankingSvc:      rankingSvc,
+		gitserverClient: gitserverClient,
+		operations:      newOperations(observationCtx),
+		referencesCache: referencesCache,
+	}
+}
+
+// GetReferences returns the search-based references to the symbol at the given position, grouped
+// by repository. Groups are ordered by the repository of the requested file first, then by repository
+// rank. The returned page contains at most limit groups starting at the given offset. The total number
+// of groups is also returned.
+//
+// Grouped references are cached briefly per user and request, so only the first page runs the searches.
+//
+// Symbols that squirrel determines to be scoped to the requested file (such as local variables) only
+// have references within that file. Definitions found by symbol search are never returned as references.
+func (s *Service) GetReferences(ctx context.Context, args ReferencesArgs, offset, limit int) (_ []RepositoryReferences, totalCount int, err error) {
+	ctx, _, endObservation := s.operations.getReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repo", string(args.Repo)),
+		log.String("commit", string(args.Commit)),
+		log.String("path", args.Path),
+		log.Int("line", args.Line),
+		log.Int("character", args.Character),
+		log.Int("offset", offset),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	key := referencesCacheKey{userID: actor.FromContext(ctx).UID, args: args}
+	if cached, ok := s.referencesCache.Get(key); ok && time.Now().Before(cached.expiresAt) {
+		page, err := s.scorePage(ctx, args.Repo, cached.shadowingRepos, cached.groups, offset, limit)
+		return page, len(cached.groups), err
+	}
+
+	payload, err := s.symbolsClient.LocalCodeIntel(ctx, types.RepoCommitPath{
+		Repo:   string(args.Repo),
+		Commit: string(args.Commit),
+		Path:   args.Path,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	localSymbol := localSymbolAt(payload, args.Line, args.Character)
+
+	var name string
+	if localSymbol != nil {
+		name = localSymbol.Name
+	} else if name, err = s.identifierAt(ctx, args); err != nil {
+		return nil, 0, err
+	}
+	if name == "" {
+		return nil, 0, nil
+	}
+
+	sourceScope := fmt.Sprintf("repo:^%s$@%s", regexp.QuoteMeta(string(args.Repo)), args.Commit)
+	otherScope := fmt.Sprintf("-repo:^%s$", regexp.QuoteMeta(string(args.Repo)))
+
+	sourceDefinitions, err := s.search(ctx, sourceScope, "symbol", "^"+regexp.QuoteMeta(name)+"$", args.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	definitions := definitionLines(sourceDefinitions, name)
+
+	if localSymbol != nil {
+		if _, ok := definitions[lineKey{args.Repo, args.Path, localSymbol.Def.Row}]; !ok {
+			// The symbol is defined in this file but symbol search doesn't know about it, so it's not
+			// visible outside of the enclosing scope. Squirrel already found all of its references.
+			page := paginate([]RepositoryReferences{{
+				RepoID:     args.RepoID,
+				Repo:       args.Repo,
+				Confidence: 1,
+				References: squirrelReferences(args.Commit, args.Path, *localSymbol),
+			}}, offset, limit)
+			return page, 1, nil
+		}
+	}
+
+	otherDefinitions, err := s.search(ctx, otherScope, "symbol", "^"+regexp.QuoteMeta(name)+"$", args.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	shadowingRepos := map[api.RepoName]struct{}{}
+	for key := range definitionLines(otherDefinitions, name) {
+		definitions[key] = struct{}{}
+		shadowingRepos[key.repo] = struct{}{}
+	}
+
+	var matches []*result.FileMatch
+	for _, scope := range []string{sourceScope, otherScope} {
+		scopeMatches, err := s.search(ctx, scope, "file", `\b`+regexp.QuoteMeta(name)+`\b`, args.Path)
+		if err != nil {
+			return nil, 0, err
+		}
+		matches = append(matches, scopeMatches...)
+	}
+
+	groups := groupMatches(args, matches, definitions, payload, localSymbol, name)
+	if err := s.sortGroups(ctx, args.Repo, groups); err != nil {
+		return nil, 0, err
+	}
+
+	s.referencesCache.Add(key, cachedReferences{
+		groups:         groups,
+		shadowingRepos: shadowingRepos,
+		expiresAt:      time.Now().Add(referencesCacheTTL),
+	})
+
+	page, err := s.scorePage(ctx, args.Repo, shadowingRepos, groups, offset, limit)
+	return page, len(groups), err
+}
+
+// scorePage scores the groups of the requested page. Groups are copied before scoring, as the given
+// groups are shared by concurrent requests through the references cache.
+func (s *Service) scorePage(ctx context.Context, sourceRepo api.RepoName, shadowingRepos map[api.RepoName]struct{}, groups []RepositoryReferences, offset, limit int) ([]RepositoryReferences, error) {
+	page := append([]RepositoryReferences(nil), paginate(groups, offset, limit)...)
+	for i := range page {
+		page[i].References = append([]Reference(nil), page[i].References...)
+
+		if err := s.scoreGroup(ctx, sourceRepo, shadowingRepos, &page[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// search runs a case-sensitive regular expression search in the given scope. Results are restricted
+// to files with the same extension as the given path, which approximates the language of the symbol.
+func (s *Service) search(ctx context.Context, scope, resultType, pattern, filePath string) ([]*result.FileMatch, error) {
+	query := fmt.Sprintf("%s type:%s case:yes patterntype:regexp count:%d", scope, resultType, maximumSearchResults)
+	if ext := path.Ext(filePath); ext != "" {
+		query += fmt.Sprintf(" file:%s$", regexp.QuoteMeta(ext))
+	}
+
+	return s.searchClient.Search(ctx, query+" "+pattern)
+}
+
+// identifierAt returns the identifier at the requested position, read from the file's content. The
+// empty string is returned if there is no identifier at that position.
+func (s *Service) identifierAt(ctx context.Context, args ReferencesArgs) (string, error) {
+	content, err := s.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, args.Repo, args.Commit, args.Path)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if args.Line < 0 || args.Line >= len(lines) {
+		return "", nil
+	}
+
+	line := []rune(lines[args.Line])
+	if args.Character < 0 || args.Character >= len(line) || !isIdentifierRune(line[args.Character]) {
+		return "", nil
+	}
+
+	start, end := args.Character, args.Character
+	for start > 0 && isIdentifierRune(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isIdentifierRune(line[end]) {
+		end++
+	}
+
+	return string(line[start:end]), nil
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// localSymbolAt returns the symbol defined in the file whose definition or references contain the
+// given position, or nil if squirrel doesn't know of such a symbol.
+func localSymbolAt(payload *types.LocalCodeIntelPayload, line, character int) *types.Symbol {
+	if payload == nil {
+		return nil
+	}
+
+	for i, symbol := range payload.Symbols {
+		if rangeContains(symbol.Def, line, character) {
+			return &payload.Symbols[i]
+		}
+		for _, ref := range symbol.Refs {
+			if rangeContains(ref, line, character) {
+				return &payload.Symbols[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func rangeContains(r types.Range, line, character int) bool {
+	return r.Row == line && r.Column <= character && character < r.Column+r.Length
+}
+
+type lineKey struct {
+	repo api.RepoName
+	path string
+	line int
+}
+
+// definitionLines returns the lines on which the given symbol is defined according to symbol search.
+func definitionLines(matches []*result.FileMatch, name string) map[lineKey]struct{} {
+	lines := map[lineKey]struct{}{}
+	for _, match := range matches {
+		for _, symbol := range match.Symbols {
+			if symbol.Symbol.Name != name {
+				continue
+			}
+
+			// Symbol lines are 1-indexed
+			lines[lineKey{match.Repo.Name, match.Path, symbol.Symbol.Line - 1}] = struct{}{}
+		}
+	}
+
+	return lines
+}
+
+// squirrelReferences converts the references squirrel resolved for the given symbol.
+func squirrelReferences(commit api.CommitID, path string, symbol types.Symbol) []Reference {
+	references := make([]Reference, 0, len(symbol.Refs))
+	for _, r := range symbol.Refs {
+		references = append(references, Reference{
+			Commit: commit,
+			Path:   path,
+			Range: shared.Range{
+				Start: shared.Position{Line: r.Row, Character: r.Column},
+				End:   shared.Position{Line: r.Row, Character: r.Column + r.Length},
+			},
+			Confidence: 1,
+		})
+	}
+
+	return references
+}
+
+// groupMatches converts the given text matches into references grouped by repository. Matches on
+// lines defining the symbol are skipped. Within the requested file, squirrel's scoping is applied:
+// matches squirrel attributes to another symbol of the same name are skipped, and matches it attributes
+// to the requested symbol are exact.
+func groupMatches(
+	args ReferencesArgs,
+	matches []*result.FileMatch,
+	definitions map[lineKey]struct{},
+	payload *types.LocalCodeIntelPayload,
+	localSymbol *types.Symbol,
+	name string,
+) []RepositoryReferences {
+	exact := map[shared.Position]struct{}{}
+	if localSymbol != nil {
+		for _, r := range localSymbol.Refs {
+			exact[shared.Position{Line: r.Row, Character: r.Column}] = struct{}{}
+		}
+	}
+	shadowed := map[shared.Position]struct{}{}
+	if payload != nil {
+		for _, symbol := range payload.Symbols {
+			if symbol.Name != name || (localSymbol != nil && symbol.Def == localSymbol.Def) {
+				continue
+			}
+			for _, r := range append([]types.Range{symbol.Def}, symbol.Refs...) {
+				shadowed[shared.Position{Line: r.Row, Character: r.Column}] = struct{}{}
+			}
+		}
+	}
+
+	var groups []RepositoryReferences
+	groupIndexes := map[api.RepoName]int{}
+
+	for _, match := range matches {
+		index, ok := groupIndexes[match.Repo.Name]
+		if !ok {
+			index = len(groups)
+			groupIndexes[match.Repo.Name] = index
+			groups = append(groups, RepositoryReferences{RepoID: match.Repo.ID, Repo: match.Repo.Name})
+		}
+
+		inRequestedFile := match.Repo.Name == args.Repo && match.CommitID == args.Commit && match.Path == args.Path
+
+		for _, chunkMatch := range match.ChunkMatches {
+			for _, r := range chunkMatch.Ranges {
+				if _, ok := definitions[lineKey{match.Repo.Name, match.Path, r.Start.Line}]; ok {
+					continue
+				}
+
+				start := shared.Position{Line: r.Start.Line, Character: r.Start.Column}
+				confidence := 0.0
+				if inRequestedFile {
+					if _, ok := shadowed[start]; ok {
+						continue
+					}
+					if _, ok := exact[start]; ok {
+						confidence = 1
+					}
+				}
+
+				groups[index].References = append(groups[index].References, Reference{
+					Commit:     match.CommitID,
+					Path:       match.Path,
+					Range:      shared.Range{Start: start, End: shared.Position{Line: r.End.Line, Character: r.End.Column}},
+					Confidence: confidence,
+				})
+			}
+		}
+	}
+
+	filtered := groups[:0]
+	for _, group := range groups {
+		if len(group.References) > 0 {
+			filtered = append(filtered, group)
+		}
+	}
+
+	return filtered
+}
+
+// sortGroups orders the given groups by the repository of the requested file first, then by repository
+// rank, then by the number of references in each repository.
+func (s *Service) sortGroups(ctx context.Context, sourceRepo api.RepoName, groups []RepositoryReferences) error {
+	repoNames := make([]api.RepoName, 0, len(groups))
+	for _, group := range groups {
+		if group.Repo != sourceRepo {
+			repoNames = append(repoNames, group.Repo)
+		}
+	}
+
+	repoRanks, err := s.rankingSvc.GetRepoRanks(ctx, repoNames)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Repo == sourceRepo) != (groups[j].Repo == sourceRepo) {
+			return groups[i].Repo == sourceRepo
+		}
+		if cmp := compareRanks(repoRanks[groups[i].Repo], repoRanks[groups[j].Repo]); cmp != 0 {
+			return cmp > 0
+		}
+		if len(groups[i].References) != len(groups[j].References) {
+			return len(groups[i].References) > len(groups[j].References)
+		}
+		return groups[i].Repo < groups[j].Repo
+	})
+
+	return nil
+}
+
+// compareRanks compares two rank vectors pairwise by component.
+func compareRanks(a, b []float64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+// scoreGroup sets the confidence of the references in the given group and orders them by decreasing
+// confidence. References from files referenced more often across all repositories are more likely to
+// be actual references to the symbol.
+func (s *Service) scoreGroup(ctx context.Context, sourceRepo api.RepoName, shadowingRepos map[api.RepoName]struct{}, group *RepositoryReferences) error {
+	documentRanks, err := s.rankingSvc.GetDocumentRanks(ctx, group.Repo)
+	if err != nil {
+		return err
+	}
+
+	repositoryConfidence := baseConfidence
+	if group.Repo == sourceRepo {
+		repositoryConfidence += sourceRepositoryBonus
+	} else if _, ok := shadowingRepos[group.Repo]; ok {
+		// The repository defines its own symbol with this name, which is the more likely target
+		repositoryConfidence -= shadowingRepositoryPenalty
+	}
+
+	group.Confidence = 0
+	for i := range group.References {
+		reference := &group.References[i]
+
+		if reference.Confidence < 1 {
+			confidence := repositoryConfidence
+			if rank := documentRanks.Paths[reference.Path]; rank > 0 {
+				confidence += documentRankWeight * rank / (rank + 1)
+			}
+			reference.Confidence = clamp(confidence)
+		}
+
+		if reference.Confidence > group.Confidence {
+			group.Confidence = reference.Confidence
+		}
+	}
+
+	sort.SliceStable(group.References, func(i, j int) bool {
+		a, b := group.References[i], group.References[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line < b.Range.Start.Line
+		}
+		return a.Range.Start.Character < b.Range.Start.Character
+	})
+
+	return nil
+}
+
+func clamp(confidence float64) float64 {
+	if confidence < 0 {
+		return 0
+	}
+	if confidence > 1 {
+		return 1
+	}
+	return confidence
+}
+
+func paginate(groups []RepositoryReferences, offset, limit int) []RepositoryReferences {
+	if offset >= len(groups) {
+		return nil
+	}
+	groups = groups[offset:]
+	if limit >= 0 && len(groups) > limit {
+		groups = groups[:limit]
+	}
+
+	return groups
+}
@@ -0,0 +1,225 @@
+package searchbased
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	codeinteltypes "github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGetReferencesFileScoped(t *testing.T) {
+	symbolsClient := NewMockSymbolsClient()
+	symbolsClient.LocalCodeIntelFunc.SetDefaultReturn(&types.LocalCodeIntelPayload{
+		Symbols: []types.Symbol{
+			{Name: "x", Def: types.Range{Row: 5, Column: 1, Length: 1}, Refs: []types.Range{
+				{Row: 6, Column: 2, Length: 1},
+				{Row: 7, Column: 3, Length: 1},
+			}},
+		},
+	}, nil)
+	searchClient := NewMockSearchClient()
+	svc := NewService(&observation.TestContext, searchClient, symbolsClient, NewMockRankingService(), gitserver.NewMockClient())
+
+	groups, totalCount, err := svc.GetReferences(context.Background(), ReferencesArgs{
+		RepoID:    1,
+		Repo:      "source",
+		Commit:    "deadbeef",
+		Path:      "main.go",
+		Line:      6,
+		Character: 2,
+	}, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+
+	expected := []RepositoryReferences{
+		{RepoID: 1, Repo: "source", Confidence: 1, References: []Reference{
+			{Commit: "deadbeef", Path: "main.go", Range: testRange(6, 2, 3), Confidence: 1},
+			{Commit: "deadbeef", Path: "main.go", Range: testRange(7, 3, 4), Confidence: 1},
+		}},
+	}
+	if diff := cmp.Diff(expected, groups); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	// Only the definitions of the source repository are searched to determine the symbol's scope
+	if calls := searchClient.SearchFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of searches. want=%d have=%d", 1, len(calls))
+	}
+}
+
+func TestGetReferencesRanked(t *testing.T) {
+	symbolsClient := NewMockSymbolsClient()
+	symbolsClient.LocalCodeIntelFunc.SetDefaultReturn(&types.LocalCodeIntelPayload{}, nil)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(ctx context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) ([]byte, error) {
+		return []byte("package main\n\nfunc main() {\n\tlib.Parse(os.Args)\n}\n"), nil
+	})
+
+	source := types.MinimalRepo{ID: 1, Name: "source"}
+	other := types.MinimalRepo{ID: 2, Name: "other"}
+	third := types.MinimalRepo{ID: 3, Name: "third"}
+
+	searchClient := NewMockSearchClient()
+	searchClient.SearchFunc.SetDefaultHook(func(ctx context.Context, query string) ([]*result.FileMatch, error) {
+		isSource := strings.HasPrefix(query, "repo:^source$@deadbeef ")
+		if !strings.HasSuffix(query, " file:\\.go$ ^Parse$") && !strings.HasSuffix(query, ` file:\.go$ \bParse\b`) {
+			t.Fatalf("unexpected query %q", query)
+		}
+
+		if strings.Contains(query, "type:symbol") {
+			if isSource {
+				return nil, nil
+			}
+			return []*result.FileMatch{symbolMatch(other, "abc", "parse.go", "Parse", 4)}, nil
+		}
+
+		if isSource {
+			return []*result.FileMatch{
+				textMatch(source, "deadbeef", "main.go", 3, 5),
+				textMatch(source, "deadbeef", "util.go", 10, 2),
+			}, nil
+		}
+		return []*result.FileMatch{
+			textMatch(other, "abc", "parse.go", 3, 5), // definition
+			textMatch(other, "abc", "parse.go", 8, 1),
+			textMatch(third, "def", "a.go", 1, 1),
+			textMatch(third, "def", "b.go", 2, 1),
+		}, nil
+	})
+
+	rankingSvc := NewMockRankingService()
+	rankingSvc.GetRepoRanksFunc.SetDefaultReturn(map[api.RepoName][]float64{
+		"other": {1, 0},
+		"third": {0, 10},
+	}, nil)
+	rankingSvc.GetDocumentRanksFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName) (codeinteltypes.RepoPathRanks, error) {
+		if repoName == "third" {
+			return codeinteltypes.RepoPathRanks{Paths: map[string]float64{"b.go": 1}}, nil
+		}
+		return codeinteltypes.RepoPathRanks{}, nil
+	})
+
+	svc := NewService(&observation.TestContext, searchClient, symbolsClient, rankingSvc, gitserverClient)
+	args := ReferencesArgs{
+		RepoID:    1,
+		Repo:      "source",
+		Commit:    "deadbeef",
+		Path:      "main.go",
+		Line:      3,
+		Character: 7,
+	}
+
+	groups, totalCount, err := svc.GetReferences(context.Background(), args, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, totalCount)
+	}
+
+	expected := []RepositoryReferences{
+		{RepoID: 1, Repo: "source", Confidence: 0.7, References: []Reference{
+			{Commit: "deadbeef", Path: "main.go", Range: testRange(3, 5, 10), Confidence: 0.7},
+			{Commit: "deadbeef", Path: "util.go", Range: testRange(10, 2, 7), Confidence: 0.7},
+		}},
+		// Ranked higher by user-defined scores, but defines its own symbol of the same name
+		{RepoID: 2, Repo: "other", Confidence: 0.2, References: []Reference{
+			{Commit: "abc", Path: "parse.go", Range: testRange(8, 1, 6), Confidence: 0.2},
+		}},
+		{RepoID: 3, Repo: "third", Confidence: 0.5, References: []Reference{
+			{Commit: "def", Path: "b.go", Range: testRange(2, 1, 6), Confidence: 0.5},
+			{Commit: "def", Path: "a.go", Range: testRange(1, 1, 6), Confidence: 0.4},
+		}},
+	}
+	if diff := cmp.Diff(expected, groups, cmp.Comparer(approximatelyEqual)); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	// Second page
+	groups, totalCount, err = svc.GetReferences(context.Background(), args, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, totalCount)
+	}
+	if len(groups) != 1 || groups[0].Repo != "third" {
+		t.Errorf("unexpected second page: %v", groups)
+	}
+
+	// The second page is served from the cached groups
+	if calls := searchClient.SearchFunc.History(); len(calls) != 4 {
+		t.Errorf("unexpected number of searches. want=%d have=%d", 4, len(calls))
+	}
+	if calls := rankingSvc.GetRepoRanksFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of GetRepoRanks calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff([]api.RepoName{"other", "third"}, calls[0].Arg1); diff != "" {
+		t.Errorf("unexpected repositories ranked (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetReferencesNoIdentifier(t *testing.T) {
+	symbolsClient := NewMockSymbolsClient()
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultReturn([]byte("a := b + c\n"), nil)
+	searchClient := NewMockSearchClient()
+	svc := NewService(&observation.TestContext, searchClient, symbolsClient, NewMockRankingService(), gitserverClient)
+
+	groups, totalCount, err := svc.GetReferences(context.Background(), ReferencesArgs{Repo: "source", Path: "main.go", Line: 0, Character: 4}, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(groups) != 0 || totalCount != 0 {
+		t.Errorf("unexpected references: %v", groups)
+	}
+	if calls := searchClient.SearchFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected searches: %v", calls)
+	}
+}
+
+func testRange(line, startCharacter, endCharacter int) shared.Range {
+	return shared.Range{
+		Start: shared.Position{Line: line, Character: startCharacter},
+		End:   shared.Position{Line: line, Character: endCharacter},
+	}
+}
+
+func textMatch(repo types.MinimalRepo, commit api.CommitID, path string, line, character int) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{Repo: repo, CommitID: commit, Path: path},
+		ChunkMatches: result.ChunkMatches{{
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: line, Column: character},
+				End:   result.Location{Line: line, Column: character + len("Parse")},
+			}},
+		}},
+	}
+}
+
+func symbolMatch(repo types.MinimalRepo, commit api.CommitID, path, name string, line int) *result.FileMatch {
+	file := result.File{Repo: repo, CommitID: commit, Path: path}
+	return &result.FileMatch{
+		File:    file,
+		Symbols: []*result.SymbolMatch{{Symbol: result.Symbol{Name: name, Path: path, Line: line}, File: &file}},
+	}
+}
+
+func approximatelyEqual(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
@@ -0,0 +1,37 @@
+package searchbased
+
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// ReferencesArgs identifies the position of the symbol whose references are requested.
+type ReferencesArgs struct {
+	RepoID    api.RepoID
+	Repo      api.RepoName
+	Commit    api.CommitID
+	Path      string
+	Line      int
+	Character int
+}
+
+// RepositoryReferences are the references to a symbol found in a single repository.
+type RepositoryReferences struct {
+	RepoID api.RepoID
+	Repo   api.RepoName
+
+	// Confidence is the confidence of the most likely reference in this repository.
+	Confidence float64
+	References []Reference
+}
+
+// Reference is a single search-based reference to a symbol.
+type Reference struct {
+	Commit api.CommitID
+	Path   string
+	Range  shared.Range
+
+	// Confidence is a score between 0 and 1 indicating how likely it is that this match
+	// refers to the requested symbol rather than to an unrelated symbol with the same name.
+	Confidence float64
+}
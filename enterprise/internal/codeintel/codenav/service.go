@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	traceLog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
@@ -31,8 +33,15 @@ type Service struct {
 	uploadSvc  UploadService
 	operations *operations
 	logger     log.Logger
+
+	// symbolChangesCache holds the symbol changes between pairs of uploads, keyed by the identifiers
+	// of the base and head upload. Uploads are immutable once processed, so entries never go stale.
+	symbolChangesCache *lru.Cache[[2]int, []SymbolChange]
 }
 
+// symbolChangesCacheSize is the maximum number of upload pairs whose symbol changes are cached.
+const symbolChangesCacheSize = 100
+
 func newService(
 	observationCtx *observation.Context,
 	repoStore database.RepoStore,
@@ -40,13 +49,17 @@ func newService(
 	uploadSvc UploadService,
 	gitserver gitserver.Client,
 ) *Service {
+	// lru.New only fails for non-positive sizes
+	symbolChangesCache, _ := lru.New[[2]int, []SymbolChange](symbolChangesCacheSize)
+
 	return &Service{
-		repoStore:  repoStore,
-		lsifstore:  lsifstore,
-		gitserver:  gitserver,
-		uploadSvc:  uploadSvc,
-		operations: newOperations(observationCtx),
-		logger:     log.Scoped("codenav", ""),
+		repoStore:          repoStore,
+		lsifstore:          lsifstore,
+		gitserver:          gitserver,
+		uploadSvc:          uploadSvc,
+		operations:         newOperations(observationCtx),
+		logger:             log.Scoped("codenav", ""),
+		symbolChangesCache: symbolChangesCache,
 	}
 }
 
@@ -917,6 +930,467 @@ func (s *Service) GetDefinitions(ctx context.Context, args RequestArgs, requestS
 	return adjustedLocations, nil
 }
 
+// GetIncomingCalls returns the functions that call the function at the given position, along with
+// the locations of the calls within each caller. Callers are found by resolving each reference to
+// the function (including references from other repositories) to the function enclosing it. The
+// given cursor is a references cursor, so the call sites of a single caller may be spread over
+// multiple pages.
+func (s *Service) GetIncomingCalls(ctx context.Context, args RequestArgs, requestState RequestState, cursor ReferencesCursor) (_ []CallHierarchyCall, _ ReferencesCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	references, cursor, err := s.GetReferences(ctx, args, requestState, cursor)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferences", len(references)))
+
+	documents := newSCIPDocumentCache(s.lsifstore)
+	calls := make([]CallHierarchyCall, 0, len(references))
+	callIndexes := map[string]int{}
+
+	for _, reference := range references {
+		indexRange, ok, err := s.getIndexedRange(ctx, requestState, reference)
+		if err != nil {
+			return nil, cursor, err
+		}
+		if !ok {
+			continue
+		}
+
+		pathWithoutRoot := strings.TrimPrefix(reference.Path, reference.Dump.Root)
+		document, err := documents.get(ctx, reference.Dump.ID, pathWithoutRoot)
+		if err != nil {
+			return nil, cursor, errors.Wrap(err, "lsifStore.SCIPDocument")
+		}
+		if document == nil {
+			continue
+		}
+
+		caller, ok := enclosingCallable(callableDefinitions(document), indexRange.Start)
+		if !ok || caller.nameRange == indexRange {
+			// Not a call, or the definition of the requested function itself
+			continue
+		}
+
+		key := fmt.Sprintf("%d:%s:%s", reference.Dump.ID, reference.Path, caller.symbol)
+		if i, ok := callIndexes[key]; ok {
+			calls[i].CallSites = append(calls[i].CallSites, reference)
+			continue
+		}
+
+		definition, _, err := s.getUploadLocation(ctx, args, requestState, reference.Dump, shared.Location{
+			DumpID: reference.Dump.ID,
+			Path:   pathWithoutRoot,
+			Range:  caller.nameRange,
+		})
+		if err != nil {
+			return nil, cursor, err
+		}
+
+		callIndexes[key] = len(calls)
+		calls = append(calls, CallHierarchyCall{
+			HierarchyItem: HierarchyItem{Symbol: caller.symbol, Definition: &definition},
+			CallSites:     []shared.UploadLocation{reference},
+		})
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCalls", len(calls)))
+
+	return calls, cursor, nil
+}
+
+// GetOutgoingCalls returns the functions called by the function defined at the given position, along
+// with the locations of the calls within the requested function. The definitions of the callees are
+// resolved as definitions are, so they may occur in other repositories.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args RequestArgs, requestState RequestState) (_ []CallHierarchyCall, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range visibleUploads {
+		trace.AddEvent("TODO Domain Owner", attribute.Int("uploadID", visibleUploads[i].Upload.ID))
+
+		document, err := s.lsifstore.SCIPDocument(ctx, visibleUploads[i].Upload.ID, visibleUploads[i].TargetPathWithoutRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.SCIPDocument")
+		}
+		if document == nil {
+			continue
+		}
+
+		definitions := callableDefinitions(document)
+		function, ok := callableDefinitionAt(definitions, visibleUploads[i].TargetPosition)
+		if !ok {
+			continue
+		}
+
+		// Group the calls made directly from the function by callee. Calls made from functions
+		// nested within the requested function belong to the nested function.
+		var callees []string
+		callSites := map[string][]shared.Range{}
+		for _, occurrence := range document.Occurrences {
+			if scip.SymbolRole_Definition.Matches(occurrence) || !isCallableSymbol(occurrence.Symbol) {
+				continue
+			}
+
+			rng := translateSCIPRange(occurrence.Range)
+			if caller, ok := enclosingCallable(definitions, rng.Start); !ok || caller != function {
+				continue
+			}
+
+			if _, ok := callSites[occurrence.Symbol]; !ok {
+				callees = append(callees, occurrence.Symbol)
+			}
+			callSites[occurrence.Symbol] = append(callSites[occurrence.Symbol], rng)
+		}
+
+		calls := make([]CallHierarchyCall, 0, len(callees))
+		for _, callee := range callees {
+			call := CallHierarchyCall{HierarchyItem: HierarchyItem{Symbol: callee}}
+			for _, rng := range sortRanges(callSites[callee]) {
+				location, _, err := s.getUploadLocation(ctx, args, requestState, visibleUploads[i].Upload, shared.Location{
+					DumpID: visibleUploads[i].Upload.ID,
+					Path:   visibleUploads[i].TargetPathWithoutRoot,
+					Range:  rng,
+				})
+				if err != nil {
+					return nil, err
+				}
+				call.CallSites = append(call.CallSites, location)
+			}
+
+			// Resolve the callee from a call site that exists in the requested commit
+			for _, callSite := range call.CallSites {
+				if callSite.TargetCommit != args.Commit {
+					continue
+				}
+
+				definitions, err := s.GetDefinitions(ctx, RequestArgs{
+					RepositoryID: args.RepositoryID,
+					Commit:       args.Commit,
+					Path:         args.Path,
+					Line:         callSite.TargetRange.Start.Line,
+					Character:    callSite.TargetRange.Start.Character,
+				}, requestState)
+				if err != nil {
+					return nil, err
+				}
+				if len(definitions) > 0 {
+					call.Definition = &definitions[0]
+				}
+				break
+			}
+
+			calls = append(calls, call)
+		}
+		trace.AddEvent("TODO Domain Owner", attribute.Int("numCalls", len(calls)))
+
+		// The first index that defines the function wins
+		return calls, nil
+	}
+
+	return nil, nil
+}
+
+// GetSupertypes returns the types implemented by the type at the given position. Supertypes are the
+// prototypes of a type symbol, so this method pages over prototypes and drops any that are not types.
+func (s *Service) GetSupertypes(ctx context.Context, args RequestArgs, requestState RequestState, cursor ImplementationsCursor) (_ []HierarchyItem, _ ImplementationsCursor, err error) {
+	ctx, _, endObservation := observeResolver(ctx, &err, s.operations.getSupertypes, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	prototypes, cursor, err := s.GetPrototypes(ctx, args, requestState, cursor)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	items, err := s.getTypeHierarchyItems(ctx, requestState, prototypes)
+	return items, cursor, err
+}
+
+// GetSubtypes returns the types implementing the type at the given position. Subtypes are the
+// implementations of a type symbol, so this method pages over implementations and drops any that
+// are not types.
+func (s *Service) GetSubtypes(ctx context.Context, args RequestArgs, requestState RequestState, cursor ImplementationsCursor) (_ []HierarchyItem, _ ImplementationsCursor, err error) {
+	ctx, _, endObservation := observeResolver(ctx, &err, s.operations.getSubtypes, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	implementations, cursor, err := s.GetImplementations(ctx, args, requestState, cursor)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	items, err := s.getTypeHierarchyItems(ctx, requestState, implementations)
+	return items, cursor, err
+}
+
+// getTypeHierarchyItems returns an item for each of the given definition locations that defines a type.
+func (s *Service) getTypeHierarchyItems(ctx context.Context, requestState RequestState, locations []shared.UploadLocation) ([]HierarchyItem, error) {
+	documents := newSCIPDocumentCache(s.lsifstore)
+
+	items := make([]HierarchyItem, 0, len(locations))
+	for i := range locations {
+		indexRange, ok, err := s.getIndexedRange(ctx, requestState, locations[i])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		document, err := documents.get(ctx, locations[i].Dump.ID, strings.TrimPrefix(locations[i].Path, locations[i].Dump.Root))
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.SCIPDocument")
+		}
+		if document == nil {
+			continue
+		}
+
+		if symbol, ok := definitionSymbolAt(document, indexRange); ok && isTypeSymbol(symbol) {
+			items = append(items, HierarchyItem{Symbol: symbol, Definition: &locations[i]})
+		}
+	}
+
+	return items, nil
+}
+
+// getIndexedRange translates the range of the given location back into the commit of its upload.
+// This is the inverse of getUploadLocation. If the translation fails, a false-valued flag is returned.
+func (s *Service) getIndexedRange(ctx context.Context, requestState RequestState, location shared.UploadLocation) (shared.Range, bool, error) {
+	if location.TargetCommit == location.Dump.Commit {
+		return location.TargetRange, true, nil
+	}
+
+	_, indexedRange, ok, err := requestState.GitTreeTranslator.GetTargetCommitRangeFromSourceRange(ctx, location.Dump.Commit, location.Path, location.TargetRange, false)
+	if err != nil {
+		return shared.Range{}, false, errors.Wrap(err, "gitTreeTranslator.GetTargetCommitRangeFromSourceRange")
+	}
+
+	return indexedRange, ok, nil
+}
+
+// GetSymbolChanges returns the exported symbols that were added, removed, or whose signature changed
+// between the given commits of a repository. Uploads of both commits are paired by root and indexer.
+// Uploads without a counterpart at the other commit are ignored, as the lack of their symbols at the
+// other commit says nothing about its API.
+func (s *Service) GetSymbolChanges(ctx context.Context, repositoryID int, baseCommit, headCommit string) (_ []SymbolChange, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getSymbolChanges, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", repositoryID),
+			traceLog.String("baseCommit", baseCommit),
+			traceLog.String("headCommit", headCommit),
+		},
+	})
+	defer endObservation()
+
+	baseDumps, err := s.getUploadsAtCommit(ctx, repositoryID, baseCommit)
+	if err != nil {
+		return nil, err
+	}
+	headDumps, err := s.getUploadsAtCommit(ctx, repositoryID, headCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	headDumpsByKey := make(map[string]uploadsshared.Dump, len(headDumps))
+	for _, dump := range headDumps {
+		headDumpsByKey[uploadPairKey(dump)] = dump
+	}
+
+	var changes []SymbolChange
+	for _, baseDump := range baseDumps {
+		headDump, ok := headDumpsByKey[uploadPairKey(baseDump)]
+		if !ok {
+			continue
+		}
+
+		pairChanges, err := s.getUploadPairSymbolChanges(ctx, baseDump, headDump)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, pairChanges...)
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numChanges", len(changes)))
+
+	return changes, nil
+}
+
+// getUploadPairSymbolChanges returns the symbol changes between the given pair of uploads. Decoding
+// every document of both uploads is expensive, so the changes are cached for subsequent pages.
+func (s *Service) getUploadPairSymbolChanges(ctx context.Context, baseDump, headDump uploadsshared.Dump) ([]SymbolChange, error) {
+	key := [2]int{baseDump.ID, headDump.ID}
+	if changes, ok := s.symbolChangesCache.Get(key); ok {
+		return changes, nil
+	}
+
+	baseSymbols, err := s.lsifstore.GetExportedSymbols(ctx, baseDump.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetExportedSymbols")
+	}
+	headSymbols, err := s.lsifstore.GetExportedSymbols(ctx, headDump.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetExportedSymbols")
+	}
+
+	changes := diffExportedSymbols(baseDump, headDump, baseSymbols, headSymbols)
+	s.symbolChangesCache.Add(key, changes)
+
+	return changes, nil
+}
+
+// getUploadsAtCommit returns the uploads of the given repository indexing exactly the given commit,
+// ordered by root and indexer. Uploads visible from the commit but indexing one of its ancestors are
+// not returned, as their symbols may not reflect the API at the requested commit.
+func (s *Service) getUploadsAtCommit(ctx context.Context, repositoryID int, commit string) ([]uploadsshared.Dump, error) {
+	dumps, err := s.uploadSvc.InferClosestUploads(ctx, repositoryID, commit, "", false, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadSvc.InferClosestUploads")
+	}
+
+	filtered := dumps[:0]
+	for _, dump := range dumps {
+		if dump.Commit == commit {
+			filtered = append(filtered, dump)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, errors.Newf("no precise code intelligence uploads for commit %s", commit)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return uploadPairKey(filtered[i]) < uploadPairKey(filtered[j])
+	})
+
+	return filtered, nil
+}
+
+// symbolExternalReferencesUploadBatchSize is the maximum number of uploads searched for references at once.
+const symbolExternalReferencesUploadBatchSize = 50
+
+// GetSymbolExternalReferences returns up to limit references to the given symbol defined by the given
+// upload from uploads of other repositories. References are found via the package moniker of the symbol,
+// so only uploads depending on the package version indexed by the given upload are searched.
+func (s *Service) GetSymbolExternalReferences(ctx context.Context, dump uploadsshared.Dump, symbol string, limit int) (_ []shared.UploadLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getSymbolExternalReferences, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("uploadID", dump.ID),
+			traceLog.String("symbol", symbol),
+			traceLog.Int("limit", limit),
+		},
+	})
+	defer endObservation()
+
+	moniker, err := lsifstore.SymbolNameToQualifiedMoniker(symbol, precise.Export)
+	if err != nil {
+		return nil, err
+	}
+	packageInformationData, _, err := s.lsifstore.GetPackageInformation(ctx, dump.ID, "", string(moniker.PackageInformationID))
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.PackageInformation")
+	}
+	orderedMonikers := []precise.QualifiedMonikerData{{
+		MonikerData:            moniker,
+		PackageInformationData: packageInformationData,
+	}}
+
+	var locations []shared.UploadLocation
+	for offset := 0; len(locations) < limit; {
+		ids, recordsScanned, totalCount, err := s.uploadSvc.GetUploadIDsWithReferences(
+			ctx,
+			orderedMonikers,
+			[]int{dump.ID},
+			dump.RepositoryID,
+			dump.Commit,
+			symbolExternalReferencesUploadBatchSize,
+			offset,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "uploadSvc.GetUploadIDsWithReferences")
+		}
+		offset += recordsScanned
+
+		dumps, err := s.uploadSvc.GetDumpsByIDs(ctx, ids)
+		if err != nil {
+			return nil, errors.Wrap(err, "uploadSvc.GetDumpsByIDs")
+		}
+
+		// References from other uploads of the same repository are not external
+		dumpsByID := make(map[int]uploadsshared.Dump, len(dumps))
+		externalDumps := make([]uploadsshared.Dump, 0, len(dumps))
+		for _, d := range dumps {
+			if d.RepositoryID != dump.RepositoryID {
+				dumpsByID[d.ID] = d
+				externalDumps = append(externalDumps, d)
+			}
+		}
+
+		if len(externalDumps) > 0 {
+			batchLocations, _, err := s.getBulkMonikerLocations(ctx, externalDumps, orderedMonikers, "references", limit-len(locations), 0)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, location := range batchLocations {
+				d := dumpsByID[location.DumpID]
+
+				locations = append(locations, shared.UploadLocation{
+					Dump:         d,
+					Path:         d.Root + location.Path,
+					TargetCommit: d.Commit,
+					TargetRange:  location.Range,
+				})
+			}
+		}
+
+		if recordsScanned == 0 || offset >= totalCount {
+			break
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numLocations", len(locations)))
+
+	return locations, nil
+}
+
 func (s *Service) GetDiagnostics(ctx context.Context, args RequestArgs, requestState RequestState) (diagnosticsAtUploads []DiagnosticAtUpload, _ int, err error) {
 	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getDiagnostics, serviceObserverThreshold, observation.Args{
 		LogFields: []traceLog.Field{
@@ -0,0 +1,233 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	testSymbolFoo  = "scip-go gomod example v1 pkg/foo()."
+	testSymbolBar  = "scip-go gomod example v1 pkg/bar()."
+	testSymbolBaz  = "scip-go gomod example v1 pkg/baz()."
+	testSymbolType = "scip-go gomod example v1 pkg/Thing#"
+)
+
+func testOccurrence(symbol string, definition bool, rng ...int32) *scip.Occurrence {
+	occurrence := &scip.Occurrence{Symbol: symbol, Range: rng}
+	if definition {
+		occurrence.SymbolRoles = int32(scip.SymbolRole_Definition)
+	}
+
+	return occurrence
+}
+
+func withEnclosingRange(occurrence *scip.Occurrence, rng ...int32) *scip.Occurrence {
+	var packed []byte
+	for _, v := range rng {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	unknown := protowire.AppendTag(nil, enclosingRangeFieldNumber, protowire.BytesType)
+	occurrence.ProtoReflect().SetUnknown(protowire.AppendBytes(unknown, packed))
+
+	return occurrence
+}
+
+func TestCallableDefinitions(t *testing.T) {
+	document := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			testOccurrence(testSymbolType, true, 1, 5, 10),
+			testOccurrence(testSymbolBar, true, 20, 5, 8),
+			withEnclosingRange(testOccurrence(testSymbolFoo, true, 3, 5, 8), 3, 0, 6, 1),
+			testOccurrence(testSymbolBaz, false, 4, 1, 4),
+		},
+	}
+
+	expected := []callableDefinition{
+		{
+			symbol:    testSymbolFoo,
+			nameRange: shared.Range{Start: shared.Position{Line: 3, Character: 5}, End: shared.Position{Line: 3, Character: 8}},
+			extent:    shared.Range{Start: shared.Position{Line: 3, Character: 0}, End: shared.Position{Line: 6, Character: 1}},
+		},
+		{
+			symbol:    testSymbolBar,
+			nameRange: shared.Range{Start: shared.Position{Line: 20, Character: 5}, End: shared.Position{Line: 20, Character: 8}},
+			extent:    shared.Range{Start: shared.Position{Line: 20, Character: 5}, End: shared.Position{Line: maxPosition, Character: maxPosition}},
+		},
+	}
+	definitions := callableDefinitions(document)
+	if diff := cmp.Diff(expected, definitions, cmp.AllowUnexported(callableDefinition{})); diff != "" {
+		t.Fatalf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	if definition, ok := enclosingCallable(definitions, shared.Position{Line: 4, Character: 1}); !ok || definition.symbol != testSymbolFoo {
+		t.Errorf("unexpected enclosing callable %v", definition)
+	}
+	if definition, ok := enclosingCallable(definitions, shared.Position{Line: 10, Character: 0}); ok {
+		t.Errorf("unexpected enclosing callable %v", definition)
+	}
+}
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{}, 0, 0, nil)
+
+	definitionRange := shared.Range{Start: shared.Position{Line: 3, Character: 5}, End: shared.Position{Line: 3, Character: 8}}
+	callRange1 := shared.Range{Start: shared.Position{Line: 12, Character: 2}, End: shared.Position{Line: 12, Character: 5}}
+	callRange2 := shared.Range{Start: shared.Position{Line: 14, Character: 2}, End: shared.Position{Line: 14, Character: 5}}
+	callRange3 := shared.Range{Start: shared.Position{Line: 22, Character: 2}, End: shared.Position{Line: 22, Character: 5}}
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn([]shared.Location{
+		{DumpID: 50, Path: "a.go", Range: definitionRange},
+		{DumpID: 50, Path: "a.go", Range: callRange1},
+		{DumpID: 50, Path: "a.go", Range: callRange2},
+		{DumpID: 50, Path: "a.go", Range: callRange3},
+	}, 4, nil)
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(&scip.Document{
+		Occurrences: []*scip.Occurrence{
+			testOccurrence(testSymbolFoo, true, 3, 5, 8),
+			testOccurrence(testSymbolBar, true, 10, 5, 8),
+			testOccurrence(testSymbolFoo, false, 12, 2, 5),
+			testOccurrence(testSymbolFoo, false, 14, 2, 5),
+			testOccurrence(testSymbolBaz, true, 20, 5, 8),
+			testOccurrence(testSymbolFoo, false, 22, 2, 5),
+		},
+	}, nil)
+
+	mockRequest := RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         3,
+		Character:    6,
+		Limit:        50,
+	}
+	calls, _, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, ReferencesCursor{Phase: "local"})
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	location := func(rng shared.Range) shared.UploadLocation {
+		return shared.UploadLocation{Dump: uploads[0], Path: "sub1/a.go", TargetCommit: mockCommit, TargetRange: rng}
+	}
+	barDefinition := location(shared.Range{Start: shared.Position{Line: 10, Character: 5}, End: shared.Position{Line: 10, Character: 8}})
+	bazDefinition := location(shared.Range{Start: shared.Position{Line: 20, Character: 5}, End: shared.Position{Line: 20, Character: 8}})
+	expectedCalls := []CallHierarchyCall{
+		{
+			HierarchyItem: HierarchyItem{Symbol: testSymbolBar, Definition: &barDefinition},
+			CallSites:     []shared.UploadLocation{location(callRange1), location(callRange2)},
+		},
+		{
+			HierarchyItem: HierarchyItem{Symbol: testSymbolBaz, Definition: &bazDefinition},
+			CallSites:     []shared.UploadLocation{location(callRange3)},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if len(mockLsifStore.SCIPDocumentFunc.History()) != 1 {
+		t.Errorf("expected the document to be read once, read %d times", len(mockLsifStore.SCIPDocumentFunc.History()))
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 50, Commit: mockCommit, Root: "s1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(&scip.Document{
+		Occurrences: []*scip.Occurrence{
+			withEnclosingRange(testOccurrence(testSymbolFoo, true, 3, 5, 8), 3, 0, 9, 1),
+			testOccurrence(testSymbolBar, false, 4, 1, 4),
+			testOccurrence(testSymbolType, false, 5, 1, 6),
+			testOccurrence(testSymbolBaz, false, 6, 1, 4),
+			testOccurrence(testSymbolBar, false, 7, 1, 4),
+			// Outside of foo
+			testOccurrence(testSymbolBaz, false, 12, 1, 4),
+		},
+	}, nil)
+
+	barDefinition := shared.Location{DumpID: 50, Path: "bar.go", Range: testRange1}
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(_ context.Context, _ int, _ string, line, _, _, _ int) ([]shared.Location, int, error) {
+		if line == 4 {
+			return []shared.Location{barDefinition}, 1, nil
+		}
+		return nil, 0, nil
+	})
+	mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.SetDefaultReturn(nil, nil)
+
+	mockRequest := RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         3,
+		Character:    6,
+	}
+	calls, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	location := func(path string, rng shared.Range) shared.UploadLocation {
+		return shared.UploadLocation{Dump: uploads[0], Path: path, TargetCommit: mockCommit, TargetRange: rng}
+	}
+	callSite := func(line int) shared.UploadLocation {
+		return location("s1/main.go", shared.Range{Start: shared.Position{Line: line, Character: 1}, End: shared.Position{Line: line, Character: 4}})
+	}
+	expectedBarDefinition := location("s1/bar.go", testRange1)
+	expectedCalls := []CallHierarchyCall{
+		{
+			HierarchyItem: HierarchyItem{Symbol: testSymbolBar, Definition: &expectedBarDefinition},
+			CallSites:     []shared.UploadLocation{callSite(4), callSite(7)},
+		},
+		{
+			HierarchyItem: HierarchyItem{Symbol: testSymbolBaz},
+			CallSites:     []shared.UploadLocation{callSite(6)},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,193 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestGetSymbolChanges(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	baseDump := uploadsshared.Dump{ID: 50, RepositoryID: 42, Commit: "base", Root: "lib/", Indexer: "scip-go"}
+	headDump := uploadsshared.Dump{ID: 51, RepositoryID: 42, Commit: "head", Root: "lib/", Indexer: "scip-go"}
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]uploadsshared.Dump, error) {
+		if commit == "base" {
+			return []uploadsshared.Dump{baseDump}, nil
+		}
+
+		return []uploadsshared.Dump{
+			headDump,
+			// Indexes an ancestor of the head commit
+			{ID: 52, RepositoryID: 42, Commit: "base", Root: "cmd/", Indexer: "scip-go"},
+			// Has no counterpart at the base commit
+			{ID: 53, RepositoryID: 42, Commit: "head", Root: "web/", Indexer: "scip-typescript"},
+		}, nil
+	})
+
+	const (
+		parseV1   = "scip-go gomod example v1.0.0 lib/Parse()."
+		parseV2   = "scip-go gomod example v1.1.0 lib/Parse()."
+		formatV1  = "scip-go gomod example v1.0.0 lib/Format()."
+		formatV2  = "scip-go gomod example v1.1.0 lib/Format()."
+		optionsV1 = "scip-go gomod example v1.0.0 lib/Options#"
+		readerV2  = "scip-go gomod example v1.1.0 lib/Reader#"
+	)
+
+	rng := shared.Range{Start: shared.Position{Line: 3, Character: 5}, End: shared.Position{Line: 3, Character: 10}}
+	mockLsifStore.GetExportedSymbolsFunc.SetDefaultHook(func(ctx context.Context, uploadID int) ([]shared.ExportedSymbol, error) {
+		switch uploadID {
+		case 50:
+			return []shared.ExportedSymbol{
+				{Symbol: parseV1, Path: "parse.go", Range: rng, Documentation: []string{"func Parse(s string) error", "Parse parses s."}},
+				{Symbol: formatV1, Path: "format.go", Range: rng, Documentation: []string{"func Format() string"}},
+				{Symbol: optionsV1, Path: "options.go", Range: rng, Documentation: []string{"type Options struct"}},
+			}, nil
+		case 51:
+			return []shared.ExportedSymbol{
+				{Symbol: parseV2, Path: "parse.go", Range: rng, Documentation: []string{"func Parse(s string, strict bool) error", "Parse parses s."}},
+				{Symbol: formatV2, Path: "format.go", Range: rng, Documentation: []string{"func Format() string", "Format formats."}},
+				{Symbol: readerV2, Path: "reader.go", Range: rng, Documentation: []string{"type Reader struct"}},
+			}, nil
+		}
+
+		t.Fatalf("unexpected upload %d", uploadID)
+		return nil, nil
+	})
+
+	changes, err := svc.GetSymbolChanges(context.Background(), 42, "base", "head")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	location := func(dump uploadsshared.Dump, path string) shared.UploadLocation {
+		return shared.UploadLocation{Dump: dump, Path: path, TargetCommit: dump.Commit, TargetRange: rng}
+	}
+	expected := []SymbolChange{
+		{
+			Kind: SymbolChangeKindRemoved,
+			Base: &SymbolVersion{Symbol: optionsV1, Definition: location(baseDump, "lib/options.go"), Signature: "type Options struct"},
+		},
+		{
+			Kind: SymbolChangeKindSignatureChanged,
+			Base: &SymbolVersion{Symbol: parseV1, Definition: location(baseDump, "lib/parse.go"), Signature: "func Parse(s string) error", Documentation: "Parse parses s."},
+			Head: &SymbolVersion{Symbol: parseV2, Definition: location(headDump, "lib/parse.go"), Signature: "func Parse(s string, strict bool) error", Documentation: "Parse parses s."},
+		},
+		{
+			Kind: SymbolChangeKindAdded,
+			Head: &SymbolVersion{Symbol: readerV2, Definition: location(headDump, "lib/reader.go"), Signature: "type Reader struct"},
+		},
+	}
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	if calls := mockLsifStore.GetExportedSymbolsFunc.History(); len(calls) != 2 {
+		t.Errorf("unexpected number of GetExportedSymbols calls. want=%d have=%d", 2, len(calls))
+	}
+
+	// Subsequent pages reuse the changes of the upload pair
+	if _, err := svc.GetSymbolChanges(context.Background(), 42, "base", "head"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls := mockLsifStore.GetExportedSymbolsFunc.History(); len(calls) != 2 {
+		t.Errorf("unexpected number of GetExportedSymbols calls. want=%d have=%d", 2, len(calls))
+	}
+}
+
+func TestGetSymbolChangesNoUploadAtCommit(t *testing.T) {
+	mockUploadSvc := NewMockUploadService()
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploadsshared.Dump{{ID: 50, Commit: "ancestor"}}, nil)
+	svc := newService(&observation.TestContext, defaultMockRepoStore(), NewMockLsifStore(), mockUploadSvc, gitserver.NewMockClient())
+
+	if _, err := svc.GetSymbolChanges(context.Background(), 42, "base", "head"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestGetSymbolExternalReferences(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+
+	// Init service
+	svc := newService(&observation.TestContext, defaultMockRepoStore(), mockLsifStore, mockUploadSvc, gitserver.NewMockClient())
+
+	const symbol = "scip-go gomod example v1.0.0 lib/Options#"
+	dump := uploadsshared.Dump{ID: 50, RepositoryID: 42, Commit: "base", Root: "lib/"}
+
+	mockLsifStore.GetPackageInformationFunc.SetDefaultReturn(precise.PackageInformationData{Manager: "gomod", Name: "example", Version: "v1.0.0"}, true, nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{60, 61}, 2, 3, nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{62}, 1, 3, nil)
+	mockUploadSvc.GetDumpsByIDsFunc.SetDefaultHook(func(ctx context.Context, ids []int) ([]uploadsshared.Dump, error) {
+		dumps := map[int]uploadsshared.Dump{
+			60: {ID: 60, RepositoryID: 43, Commit: "c60", Root: "svc/"},
+			61: {ID: 61, RepositoryID: 42, Commit: "c61"},
+			62: {ID: 62, RepositoryID: 44, Commit: "c62"},
+		}
+
+		var matching []uploadsshared.Dump
+		for _, id := range ids {
+			matching = append(matching, dumps[id])
+		}
+		return matching, nil
+	})
+
+	rng := shared.Range{Start: shared.Position{Line: 1, Character: 2}, End: shared.Position{Line: 1, Character: 9}}
+	mockLsifStore.GetBulkMonikerLocationsFunc.SetDefaultHook(func(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit, offset int) ([]shared.Location, int, error) {
+		var locations []shared.Location
+		for _, id := range uploadIDs {
+			locations = append(locations, shared.Location{DumpID: id, Path: "main.go", Range: rng})
+		}
+		return locations, len(locations), nil
+	})
+
+	locations, err := svc.GetSymbolExternalReferences(context.Background(), dump, symbol, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []shared.UploadLocation{
+		{Dump: uploadsshared.Dump{ID: 60, RepositoryID: 43, Commit: "c60", Root: "svc/"}, Path: "svc/main.go", TargetCommit: "c60", TargetRange: rng},
+		{Dump: uploadsshared.Dump{ID: 62, RepositoryID: 44, Commit: "c62"}, Path: "main.go", TargetCommit: "c62", TargetRange: rng},
+	}
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	calls := mockUploadSvc.GetUploadIDsWithReferencesFunc.History()
+	if len(calls) != 2 {
+		t.Fatalf("unexpected number of GetUploadIDsWithReferences calls. want=%d have=%d", 2, len(calls))
+	}
+	expectedMonikers := []precise.QualifiedMonikerData{
+		{
+			MonikerData: precise.MonikerData{
+				Kind:                 "export",
+				Scheme:               "scip-go",
+				Identifier:           symbol,
+				PackageInformationID: "scip:Z29tb2Q:ZXhhbXBsZQ:djEuMC4w",
+			},
+			PackageInformationData: precise.PackageInformationData{Manager: "gomod", Name: "example", Version: "v1.0.0"},
+		},
+	}
+	if diff := cmp.Diff(expectedMonikers, calls[0].Arg1); diff != "" {
+		t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+	}
+	if calls[1].Arg6 != 2 {
+		t.Errorf("unexpected offset. want=%d have=%d", 2, calls[1].Arg6)
+	}
+}
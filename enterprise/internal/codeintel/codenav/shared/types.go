@@ -38,6 +38,15 @@ type UploadLocation struct {
 	TargetRange  Range
 }
 
+// ExportedSymbol is the definition of a non-local symbol within an upload along with the
+// documentation attached to its symbol information.
+type ExportedSymbol struct {
+	Symbol        string
+	Path          string
+	Range         Range
+	Documentation []string
+}
+
 type SnapshotData struct {
 	DocumentOffset int
 	Symbol         string
@@ -0,0 +1,118 @@
+package codenav
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+)
+
+// versionlessSymbolFormatter formats all parts of a symbol except for its package version.
+var versionlessSymbolFormatter = scip.SymbolFormatter{
+	OnError:               func(err error) error { return err },
+	IncludeScheme:         func(_ string) bool { return true },
+	IncludePackageManager: func(_ string) bool { return true },
+	IncludePackageName:    func(_ string) bool { return true },
+	IncludePackageVersion: func(_ string) bool { return false },
+	IncludeDescriptor:     func(_ string) bool { return true },
+}
+
+// symbolChangeKey returns the key used to match a symbol of the base upload with the same symbol of
+// the head upload. Symbols that cannot be parsed are matched verbatim.
+func symbolChangeKey(symbol string) string {
+	if key, err := versionlessSymbolFormatter.Format(symbol); err == nil {
+		return key
+	}
+
+	return symbol
+}
+
+// uploadPairKey returns the key used to pair an upload of the base commit with an upload of the head
+// commit. Uploads are paired when they index the same root with the same indexer.
+func uploadPairKey(dump uploadsshared.Dump) string {
+	return dump.Root + "\x00" + dump.Indexer
+}
+
+// diffExportedSymbols compares the exported symbols of a pair of uploads. The returned changes are
+// ordered by symbol.
+func diffExportedSymbols(baseDump, headDump uploadsshared.Dump, baseSymbols, headSymbols []shared.ExportedSymbol) []SymbolChange {
+	baseByKey := make(map[string]shared.ExportedSymbol, len(baseSymbols))
+	for _, symbol := range baseSymbols {
+		baseByKey[symbolChangeKey(symbol.Symbol)] = symbol
+	}
+	headByKey := make(map[string]shared.ExportedSymbol, len(headSymbols))
+	for _, symbol := range headSymbols {
+		headByKey[symbolChangeKey(symbol.Symbol)] = symbol
+	}
+
+	keys := make([]string, 0, len(baseByKey)+len(headByKey))
+	for key := range baseByKey {
+		keys = append(keys, key)
+	}
+	for key := range headByKey {
+		if _, ok := baseByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []SymbolChange
+	for _, key := range keys {
+		baseSymbol, inBase := baseByKey[key]
+		headSymbol, inHead := headByKey[key]
+
+		switch {
+		case !inBase:
+			changes = append(changes, SymbolChange{
+				Kind: SymbolChangeKindAdded,
+				Head: newSymbolVersion(headDump, headSymbol),
+			})
+
+		case !inHead:
+			changes = append(changes, SymbolChange{
+				Kind: SymbolChangeKindRemoved,
+				Base: newSymbolVersion(baseDump, baseSymbol),
+			})
+
+		default:
+			base := newSymbolVersion(baseDump, baseSymbol)
+			head := newSymbolVersion(headDump, headSymbol)
+
+			if base.Signature != head.Signature {
+				changes = append(changes, SymbolChange{
+					Kind: SymbolChangeKindSignatureChanged,
+					Base: base,
+					Head: head,
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// newSymbolVersion creates the version of an exported symbol within the given upload. Indexers emit
+// the declaration of a symbol as the first documentation block, which is shown at the top of hover
+// text. That block is used as the symbol's signature and the remaining blocks as its documentation.
+func newSymbolVersion(dump uploadsshared.Dump, symbol shared.ExportedSymbol) *SymbolVersion {
+	var signature, documentation string
+	if len(symbol.Documentation) > 0 {
+		signature = strings.TrimSpace(symbol.Documentation[0])
+		documentation = strings.Join(symbol.Documentation[1:], "\n")
+	}
+
+	return &SymbolVersion{
+		Symbol: symbol.Symbol,
+		Definition: shared.UploadLocation{
+			Dump:         dump,
+			Path:         dump.Root + symbol.Path,
+			TargetCommit: dump.Commit,
+			TargetRange:  symbol.Range,
+		},
+		Signature:     signature,
+		Documentation: documentation,
+	}
+}
@@ -20,11 +20,18 @@ type operations struct {
 	references      *observation.Operation
 	implementations *observation.Operation
 	prototypes      *observation.Operation
+	incomingCalls   *observation.Operation
+	outgoingCalls   *observation.Operation
+	supertypes      *observation.Operation
+	subtypes        *observation.Operation
 	diagnostics     *observation.Operation
 	stencil         *observation.Operation
 	ranges          *observation.Operation
 	snapshot        *observation.Operation
 	visibleIndexes  *observation.Operation
+
+	searchBasedReferences *observation.Operation
+	symbolChanges         *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -50,11 +57,18 @@ func newOperations(observationCtx *observation.Context) *operations {
 		references:      op("References"),
 		implementations: op("Implementations"),
 		prototypes:      op("Prototypes"),
+		incomingCalls:   op("IncomingCalls"),
+		outgoingCalls:   op("OutgoingCalls"),
+		supertypes:      op("Supertypes"),
+		subtypes:        op("Subtypes"),
 		diagnostics:     op("Diagnostics"),
 		stencil:         op("Stencil"),
 		ranges:          op("Ranges"),
 		snapshot:        op("Snapshot"),
 		visibleIndexes:  op("VisibleIndexes"),
+
+		searchBasedReferences: op("SearchBasedReferences"),
+		symbolChanges:         op("SymbolChanges"),
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 
 type rootResolver struct {
 	svc                            CodeNavService
+	searchBasedSvc                 SearchBasedService
 	autoindexingSvc                AutoIndexingService
 	gitserverClient                gitserver.Client
 	siteAdminChecker               sharedresolvers.SiteAdminChecker
@@ -37,6 +38,7 @@ type rootResolver struct {
 func NewRootResolver(
 	observationCtx *observation.Context,
 	svc CodeNavService,
+	searchBasedSvc SearchBasedService,
 	autoindexingSvc AutoIndexingService,
 	gitserverClient gitserver.Client,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
@@ -55,6 +57,7 @@ func NewRootResolver(
 
 	return &rootResolver{
 		svc:                            svc,
+		searchBasedSvc:                 searchBasedSvc,
 		autoindexingSvc:                autoindexingSvc,
 		gitserverClient:                gitserverClient,
 		siteAdminChecker:               siteAdminChecker,
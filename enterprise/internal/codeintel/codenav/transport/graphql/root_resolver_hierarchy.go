@@ -0,0 +1,244 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/gitresolvers"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultHierarchyPageSize is the call and type hierarchy result page size when no limit is supplied.
+const DefaultHierarchyPageSize = 100
+
+func (r *gitBlobLSIFDataResolver) IncomingCalls(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.CallHierarchyCallConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Incoming calls are found by paging over references, so the cursor is a references cursor
+	cursor, err := decodeReferencesCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	calls, callsCursor, err := r.codeNavSvc.GetIncomingCalls(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetIncomingCalls")
+	}
+
+	var nextCursor string
+	if callsCursor.Phase != "done" {
+		nextCursor = encodeReferencesCursor(callsCursor)
+	}
+
+	return newCallHierarchyCallConnectionResolver(filterCalls(calls, args.Filter), resolverstubs.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+func (r *gitBlobLSIFDataResolver) OutgoingCalls(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.CallHierarchyCallConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Outgoing calls are bounded by the size of the requested function, so they are
+	// resolved at once and paged over by offset
+	offset := 0
+	if rawCursor != "" {
+		if offset, err = strconv.Atoi(rawCursor); err != nil || offset < 0 {
+			return nil, errors.Newf("invalid cursor: %q", rawCursor)
+		}
+	}
+
+	calls, err := r.codeNavSvc.GetOutgoingCalls(ctx, requestArgs, r.requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetOutgoingCalls")
+	}
+	calls = filterCalls(calls, args.Filter)
+
+	var nextCursor string
+	if offset > len(calls) {
+		offset = len(calls)
+	}
+	if offset+limit < len(calls) {
+		nextCursor = strconv.Itoa(offset + limit)
+		calls = calls[offset : offset+limit]
+	} else {
+		calls = calls[offset:]
+	}
+
+	return newCallHierarchyCallConnectionResolver(calls, resolverstubs.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+func (r *gitBlobLSIFDataResolver) Supertypes(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.HierarchyItemConnectionResolver, err error) {
+	return r.typeHierarchy(ctx, args, r.operations.supertypes, "codeNavSvc.GetSupertypes", r.codeNavSvc.GetSupertypes)
+}
+
+func (r *gitBlobLSIFDataResolver) Subtypes(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.HierarchyItemConnectionResolver, err error) {
+	return r.typeHierarchy(ctx, args, r.operations.subtypes, "codeNavSvc.GetSubtypes", r.codeNavSvc.GetSubtypes)
+}
+
+type getTypeHierarchyFunc func(ctx context.Context, args codenav.RequestArgs, requestState codenav.RequestState, cursor codenav.ImplementationsCursor) ([]codenav.HierarchyItem, codenav.ImplementationsCursor, error)
+
+func (r *gitBlobLSIFDataResolver) typeHierarchy(
+	ctx context.Context,
+	args *resolverstubs.LSIFPagedQueryPositionArgs,
+	operation *observation.Operation,
+	name string,
+	getTypeHierarchy getTypeHierarchyFunc,
+) (_ resolverstubs.HierarchyItemConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, operation, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Type hierarchies are found by paging over prototypes and implementations, so the
+	// cursor is an implementations cursor
+	cursor, err := decodeImplementationsCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	items, itemsCursor, err := getTypeHierarchy(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	var nextCursor string
+	if itemsCursor.Phase != "done" {
+		nextCursor = encodeImplementationsCursor(itemsCursor)
+	}
+
+	if args.Filter != nil && *args.Filter != "" {
+		filtered := items[:0]
+		for _, item := range items {
+			if item.Definition != nil && strings.Contains(item.Definition.Path, *args.Filter) {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+
+	return resolverstubs.NewLazyConnectionResolver(func(ctx context.Context) ([]resolverstubs.HierarchyItemResolver, error) {
+		resolvers := make([]resolverstubs.HierarchyItemResolver, 0, len(items))
+		for _, item := range items {
+			resolver, err := resolveHierarchyItem(ctx, r.locationResolver, item)
+			if err != nil {
+				return nil, err
+			}
+			resolvers = append(resolvers, resolver)
+		}
+
+		return resolvers, nil
+	}, encodeCursor(resolverstubs.NonZeroPtr(nextCursor))), nil
+}
+
+// filterCalls removes the calls whose item is not defined in a file matching the given filter.
+func filterCalls(calls []codenav.CallHierarchyCall, filter *string) []codenav.CallHierarchyCall {
+	if filter == nil || *filter == "" {
+		return calls
+	}
+
+	filtered := calls[:0]
+	for _, call := range calls {
+		if call.Definition != nil && strings.Contains(call.Definition.Path, *filter) {
+			filtered = append(filtered, call)
+		}
+	}
+
+	return filtered
+}
+
+func newCallHierarchyCallConnectionResolver(calls []codenav.CallHierarchyCall, cursor *string, locationResolver *gitresolvers.CachedLocationResolver) resolverstubs.CallHierarchyCallConnectionResolver {
+	return resolverstubs.NewLazyConnectionResolver(func(ctx context.Context) ([]resolverstubs.CallHierarchyCallResolver, error) {
+		resolvers := make([]resolverstubs.CallHierarchyCallResolver, 0, len(calls))
+		for _, call := range calls {
+			item, err := resolveHierarchyItem(ctx, locationResolver, call.HierarchyItem)
+			if err != nil {
+				return nil, err
+			}
+
+			callSites, err := resolveLocations(ctx, locationResolver, call.CallSites)
+			if err != nil {
+				return nil, err
+			}
+
+			resolvers = append(resolvers, &callHierarchyCallResolver{
+				item:      item,
+				callSites: callSites,
+			})
+		}
+
+		return resolvers, nil
+	}, encodeCursor(cursor))
+}
+
+// resolveHierarchyItem creates a HierarchyItemResolver for the given item. The definition of the
+// resolved item is nil if it is unknown or if its commit is not known by gitserver.
+func resolveHierarchyItem(ctx context.Context, locationResolver *gitresolvers.CachedLocationResolver, item codenav.HierarchyItem) (resolverstubs.HierarchyItemResolver, error) {
+	resolver := &hierarchyItemResolver{symbol: item.Symbol}
+	if item.Definition != nil {
+		definition, err := resolveLocation(ctx, locationResolver, *item.Definition)
+		if err != nil {
+			return nil, err
+		}
+		resolver.definition = definition
+	}
+
+	return resolver, nil
+}
+
+//
+//
+
+type hierarchyItemResolver struct {
+	symbol     string
+	definition resolverstubs.LocationResolver
+}
+
+func (r *hierarchyItemResolver) Symbol() string                             { return r.symbol }
+func (r *hierarchyItemResolver) Definition() resolverstubs.LocationResolver { return r.definition }
+
+type callHierarchyCallResolver struct {
+	item      resolverstubs.HierarchyItemResolver
+	callSites []resolverstubs.LocationResolver
+}
+
+func (r *callHierarchyCallResolver) Item() resolverstubs.HierarchyItemResolver { return r.item }
+func (r *callHierarchyCallResolver) CallSites() []resolverstubs.LocationResolver {
+	return r.callSites
+}
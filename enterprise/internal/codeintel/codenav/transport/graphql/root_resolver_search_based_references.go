@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/searchbased"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultSearchBasedReferencesPageSize is the number of repositories with search-based references
+// returned when no limit is supplied.
+const DefaultSearchBasedReferencesPageSize = 10
+
+// 🚨 SECURITY: search and gitserver layers handle authz for query resolution
+func (r *rootResolver) SearchBasedReferences(ctx context.Context, args *resolverstubs.SearchBasedReferencesArgs) (_ resolverstubs.SearchBasedReferenceConnectionResolver, err error) {
+	ctx, _, endObservation := r.operations.searchBasedReferences.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repoID", int(args.Repo.ID)),
+		log.String("commit", string(args.Commit)),
+		log.String("path", args.Path),
+		log.Int("line", int(args.Line)),
+		log.Int("character", int(args.Character)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	limit, offset, err := args.ParseLimitOffset(DefaultSearchBasedReferencesPageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	if offset < 0 {
+		return nil, errors.Newf("invalid cursor: negative offset %d", offset)
+	}
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	groups, totalCount, err := r.searchBasedSvc.GetReferences(ctx, searchbased.ReferencesArgs{
+		RepoID:    args.Repo.ID,
+		Repo:      args.Repo.Name,
+		Commit:    args.Commit,
+		Path:      args.Path,
+		Line:      int(args.Line),
+		Character: int(args.Character),
+	}, int(offset), int(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "searchBasedSvc.GetReferences")
+	}
+
+	locationResolver := r.locationResolverFactory.Create()
+
+	resolvers := make([]resolverstubs.SearchBasedRepositoryReferencesResolver, 0, len(groups))
+	for _, group := range groups {
+		repositoryResolver, err := locationResolver.Repository(ctx, group.RepoID)
+		if err != nil {
+			return nil, err
+		}
+		if repositoryResolver == nil {
+			continue
+		}
+
+		references := make([]resolverstubs.SearchBasedReferenceResolver, 0, len(group.References))
+		for _, reference := range group.References {
+			treeResolver, err := locationResolver.Path(ctx, group.RepoID, string(reference.Commit), reference.Path, false)
+			if err != nil {
+				return nil, err
+			}
+			if treeResolver == nil {
+				continue
+			}
+
+			lspRange := convertRange(reference.Range)
+			references = append(references, &searchBasedReferenceResolver{
+				location:   newLocationResolver(treeResolver, &lspRange),
+				confidence: reference.Confidence,
+			})
+		}
+
+		resolvers = append(resolvers, &searchBasedRepositoryReferencesResolver{
+			repository: repositoryResolver,
+			confidence: group.Confidence,
+			references: references,
+		})
+	}
+
+	var nextCursor string
+	if next := int(offset) + len(groups); next < totalCount {
+		nextCursor = strconv.Itoa(next)
+	}
+
+	return resolverstubs.NewCursorWithTotalCountConnectionResolver(resolvers, nextCursor, int32(totalCount)), nil
+}
+
+type searchBasedRepositoryReferencesResolver struct {
+	repository resolverstubs.RepositoryResolver
+	confidence float64
+	references []resolverstubs.SearchBasedReferenceResolver
+}
+
+func (r *searchBasedRepositoryReferencesResolver) Repository() resolverstubs.RepositoryResolver {
+	return r.repository
+}
+
+func (r *searchBasedRepositoryReferencesResolver) Confidence() float64 {
+	return r.confidence
+}
+
+func (r *searchBasedRepositoryReferencesResolver) References() []resolverstubs.SearchBasedReferenceResolver {
+	return r.references
+}
+
+type searchBasedReferenceResolver struct {
+	location   resolverstubs.LocationResolver
+	confidence float64
+}
+
+func (r *searchBasedReferenceResolver) Location() resolverstubs.LocationResolver { return r.location }
+func (r *searchBasedReferenceResolver) Confidence() float64                      { return r.confidence }
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/gitresolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultSymbolChangesPageSize is the number of symbol changes returned when no limit is supplied.
+const DefaultSymbolChangesPageSize = 50
+
+// DefaultSymbolExternalReferencesPageSize is the number of external references of a changed symbol
+// returned when no limit is supplied.
+const DefaultSymbolExternalReferencesPageSize = 100
+
+// 🚨 SECURITY: repoStore enforces access to the repository and dbstore layer handles authz for external references
+func (r *rootResolver) CodeIntelSymbolChanges(ctx context.Context, id graphql.ID, args *resolverstubs.CodeIntelSymbolChangesArgs) (_ resolverstubs.CodeIntelSymbolChangeConnectionResolver, err error) {
+	ctx, _, endObservation := r.operations.symbolChanges.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repositoryID", string(id)),
+		log.String("base", args.Base),
+		log.String("head", args.Head),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := resolverstubs.UnmarshalID[int](id)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := args.ParseLimitOffset(DefaultSymbolChangesPageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	if offset < 0 {
+		return nil, errors.Newf("invalid cursor: negative offset %d", offset)
+	}
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	repo, err := r.repoStore.Get(ctx, api.RepoID(repositoryID))
+	if err != nil {
+		return nil, err
+	}
+	baseCommit, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, args.Base, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, args.Head, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := r.svc.GetSymbolChanges(ctx, repositoryID, string(baseCommit), string(headCommit))
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetSymbolChanges")
+	}
+
+	page := changes
+	if int(offset) < len(page) {
+		page = page[offset:]
+	} else {
+		page = nil
+	}
+	if int(limit) < len(page) {
+		page = page[:limit]
+	}
+
+	locationResolver := r.locationResolverFactory.Create()
+
+	resolvers := make([]resolverstubs.CodeIntelSymbolChangeResolver, 0, len(page))
+	for _, change := range page {
+		resolvers = append(resolvers, &symbolChangeResolver{
+			svc:              r.svc,
+			change:           change,
+			locationResolver: locationResolver,
+		})
+	}
+
+	var nextCursor string
+	if next := int(offset) + len(page); next < len(changes) {
+		nextCursor = strconv.Itoa(next)
+	}
+
+	return resolverstubs.NewCursorWithTotalCountConnectionResolver(resolvers, nextCursor, int32(len(changes))), nil
+}
+
+type symbolChangeResolver struct {
+	svc              CodeNavService
+	change           codenav.SymbolChange
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *symbolChangeResolver) Kind() string {
+	return string(r.change.Kind)
+}
+
+func (r *symbolChangeResolver) Base() resolverstubs.CodeIntelSymbolVersionResolver {
+	if r.change.Base == nil {
+		return nil
+	}
+
+	return &symbolVersionResolver{version: *r.change.Base, locationResolver: r.locationResolver}
+}
+
+func (r *symbolChangeResolver) Head() resolverstubs.CodeIntelSymbolVersionResolver {
+	if r.change.Head == nil {
+		return nil
+	}
+
+	return &symbolVersionResolver{version: *r.change.Head, locationResolver: r.locationResolver}
+}
+
+// ExternalReferences returns the references to the base version of the symbol from other repositories.
+// These are the call sites that may break by the removal or signature change of the symbol. Added symbols
+// have no external references.
+func (r *symbolChangeResolver) ExternalReferences(ctx context.Context, args *resolverstubs.CodeIntelSymbolExternalReferencesArgs) (resolverstubs.LocationConnectionResolver, error) {
+	limit := resolverstubs.Deref(args.First, DefaultSymbolExternalReferencesPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	if r.change.Base == nil {
+		return newLocationConnectionResolver(nil, nil, r.locationResolver), nil
+	}
+
+	locations, err := r.svc.GetSymbolExternalReferences(ctx, r.change.Base.Definition.Dump, r.change.Base.Symbol, int(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetSymbolExternalReferences")
+	}
+
+	return newLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
+type symbolVersionResolver struct {
+	version          codenav.SymbolVersion
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *symbolVersionResolver) Symbol() string        { return r.version.Symbol }
+func (r *symbolVersionResolver) Signature() string     { return r.version.Signature }
+func (r *symbolVersionResolver) Documentation() string { return r.version.Documentation }
+
+func (r *symbolVersionResolver) Definition(ctx context.Context) (resolverstubs.LocationResolver, error) {
+	return resolveLocation(ctx, r.locationResolver, r.version.Definition)
+}
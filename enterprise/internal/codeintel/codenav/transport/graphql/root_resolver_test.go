@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
@@ -398,3 +399,98 @@ func TestResolveLocations(t *testing.T) {
 		t.Errorf("unexpected canonical url. want=%s have=%s", "/repo53@deadbeef4/-/blob/p4?L42:43-44:45", url)
 	}
 }
+
+func TestCodeIntelSymbolChanges(t *testing.T) {
+	mockCodeNavService := NewMockCodeNavService()
+	repos := database.NewStrictMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*sgtypes.Repo, error) {
+		return &sgtypes.Repo{ID: id, Name: api.RepoName(fmt.Sprintf("repo%d", id))}, nil
+	})
+	gsClient := gitserver.NewMockClient()
+	gsClient.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID("deadbeef-" + spec), nil
+	})
+
+	resolver := &rootResolver{
+		svc:                     mockCodeNavService,
+		gitserverClient:         gsClient,
+		repoStore:               repos,
+		locationResolverFactory: gitresolvers.NewCachedLocationResolverFactory(repos, gsClient),
+		operations:              newOperations(&observation.TestContext),
+	}
+
+	base := &codenav.SymbolVersion{Symbol: "scip-go gomod example v1 lib/Parse().", Signature: "func Parse()"}
+	mockCodeNavService.GetSymbolChangesFunc.SetDefaultReturn([]codenav.SymbolChange{
+		{Kind: codenav.SymbolChangeKindRemoved, Base: base},
+		{Kind: codenav.SymbolChangeKindAdded, Head: &codenav.SymbolVersion{Symbol: "scip-go gomod example v2 lib/Format()."}},
+		{Kind: codenav.SymbolChangeKindSignatureChanged, Base: base, Head: base},
+	}, nil)
+
+	first := int32(2)
+	connection, err := resolver.CodeIntelSymbolChanges(context.Background(), resolverstubs.MarshalID("Repository", 42), &resolverstubs.CodeIntelSymbolChangesArgs{
+		Base:                "main",
+		Head:                "feature",
+		PagedConnectionArgs: resolverstubs.PagedConnectionArgs{ConnectionArgs: resolverstubs.ConnectionArgs{First: &first}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mockrequire.CalledOnceWith(t, mockCodeNavService.GetSymbolChangesFunc, mockrequire.Values(mockrequire.Skip, 42, "deadbeef-main", "deadbeef-feature"))
+
+	nodes, err := connection.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("unexpected number of nodes. want=%d have=%d", 2, len(nodes))
+	}
+	if kind := nodes[0].Kind(); kind != "REMOVED" {
+		t.Errorf("unexpected kind. want=%q have=%q", "REMOVED", kind)
+	}
+	if nodes[1].Base() != nil {
+		t.Errorf("expected added symbol to have no base version")
+	}
+	if totalCount := connection.TotalCount(); totalCount == nil || *totalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%v", 3, totalCount)
+	}
+	if cursor := connection.PageInfo().EndCursor(); cursor == nil || *cursor != "2" {
+		t.Errorf("unexpected end cursor. want=%q have=%v", "2", cursor)
+	}
+
+	// Added symbols have no external references to look up
+	if _, err := nodes[1].ExternalReferences(context.Background(), &resolverstubs.CodeIntelSymbolExternalReferencesArgs{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mockrequire.NotCalled(t, mockCodeNavService.GetSymbolExternalReferencesFunc)
+
+	if _, err := nodes[0].ExternalReferences(context.Background(), &resolverstubs.CodeIntelSymbolExternalReferencesArgs{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mockrequire.CalledOnceWith(t, mockCodeNavService.GetSymbolExternalReferencesFunc, mockrequire.Values(mockrequire.Skip, mockrequire.Skip, base.Symbol, DefaultSymbolExternalReferencesPageSize))
+
+	after := "-1"
+	if _, err := resolver.CodeIntelSymbolChanges(context.Background(), resolverstubs.MarshalID("Repository", 42), &resolverstubs.CodeIntelSymbolChangesArgs{
+		Base:                "main",
+		Head:                "feature",
+		PagedConnectionArgs: resolverstubs.PagedConnectionArgs{After: &after},
+	}); err == nil || !strings.HasPrefix(err.Error(), "invalid cursor") {
+		t.Fatalf("unexpected error. want=%q have=%v", "invalid cursor", err)
+	}
+}
+
+func TestSearchBasedReferencesNegativeOffset(t *testing.T) {
+	resolver := &rootResolver{
+		operations: newOperations(&observation.TestContext),
+	}
+
+	after := "-1"
+	args := &resolverstubs.SearchBasedReferencesArgs{
+		Repo:                &sgtypes.Repo{ID: 42, Name: "repo42"},
+		Commit:              "deadbeef",
+		PagedConnectionArgs: resolverstubs.PagedConnectionArgs{After: &after},
+	}
+	if _, err := resolver.SearchBasedReferences(context.Background(), args); err == nil || !strings.HasPrefix(err.Error(), "invalid cursor") {
+		t.Fatalf("unexpected error. want=%q have=%v", "invalid cursor", err)
+	}
+}
@@ -0,0 +1,82 @@
+package lsp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// maxMessageSize is the largest message we accept from a client. Requests are small as
+// document contents are never sent to the server.
+const maxMessageSize = 1 << 20
+
+type handler struct {
+	logger          log.Logger
+	operations      *operations
+	svc             CodeNavService
+	repoStore       RepoStore
+	dbRepoStore     database.RepoStore
+	gitserverClient gitserver.Client
+	symbolSearcher  SymbolSearcher
+	maxIndexes      int
+	hunkCache       codenav.HunkCache
+	externalURL     func() string
+	upgrader        websocket.Upgrader
+}
+
+func newHandler(
+	logger log.Logger,
+	operations *operations,
+	svc CodeNavService,
+	repoStore RepoStore,
+	dbRepoStore database.RepoStore,
+	gitserverClient gitserver.Client,
+	symbolSearcher SymbolSearcher,
+	maxIndexes int,
+	hunkCache codenav.HunkCache,
+	externalURL func() string,
+) *handler {
+	return &handler{
+		logger:          logger,
+		operations:      operations,
+		svc:             svc,
+		repoStore:       repoStore,
+		dbRepoStore:     dbRepoStore,
+		gitserverClient: gitserverClient,
+		symbolSearcher:  symbolSearcher,
+		maxIndexes:      maxIndexes,
+		hunkCache:       hunkCache,
+		externalURL:     externalURL,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// The default origin check rejects cross-origin upgrade requests made by
+			// browsers. Editors do not send an Origin header.
+		},
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// 🚨 SECURITY: Every request of the connection is resolved on behalf of the actor that
+	// opened it, which must be an authenticated user (e.g., via an access token).
+	if !actor.FromContext(r.Context()).IsAuthenticated() {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(maxMessageSize)
+
+	newSession(h, conn).serve(r.Context())
+}
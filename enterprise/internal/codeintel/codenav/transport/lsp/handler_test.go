@@ -0,0 +1,288 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+	protocol "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testExternalURL = "https://sourcegraph.test"
+
+var (
+	testRepo  = &types.Repo{ID: 42, Name: "github.com/sourcegraph/sourcegraph"}
+	otherRepo = &types.Repo{ID: 50, Name: "github.com/sourcegraph/other"}
+)
+
+func TestHandlerRequiresAuthentication(t *testing.T) {
+	server := httptest.NewServer(newTestHandler(t, NewMockCodeNavService(), NewMockRepoStore(), NewMockSymbolSearcher()))
+	defer server.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err == nil {
+		t.Fatal("expected connection to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unexpected response %v", resp)
+	}
+}
+
+func TestSession(t *testing.T) {
+	mockSvc := NewMockCodeNavService()
+	mockRepoStore := NewMockRepoStore()
+	mockSymbolSearcher := NewMockSymbolSearcher()
+
+	mockRepoStore.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == testRepo.Name {
+			return testRepo, nil
+		}
+		return nil, &database.RepoNotFoundErr{Name: name}
+	})
+	mockRepoStore.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		switch id {
+		case testRepo.ID:
+			return testRepo, nil
+		case otherRepo.ID:
+			return otherRepo, nil
+		}
+		return nil, &database.RepoNotFoundErr{ID: id}
+	})
+	mockRepoStore.ResolveRevFunc.SetDefaultReturn("deadbeef", nil)
+
+	client := newTestClient(t, newTestHandler(t, mockSvc, mockRepoStore, mockSymbolSearcher))
+	position := protocol.TextDocumentPositionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: "file:///home/me/src/sourcegraph/cmd/main.go"},
+		Position:     protocol.Position{Line: 10, Character: 4},
+	}
+
+	t.Run("not initialized", func(t *testing.T) {
+		resp := client.call(t, 1, "textDocument/definition", position)
+		if resp.Error == nil || resp.Error.Code != codeServerNotInitialized {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+	})
+
+	t.Run("initialize", func(t *testing.T) {
+		resp := client.call(t, 2, "initialize", map[string]any{
+			"rootUri": "file:///home/me/src",
+			"initializationOptions": initializationOptions{
+				Workspaces: []workspaceOptions{
+					{URI: "file:///home/me/src/sourcegraph/", Repository: string(testRepo.Name), Revision: "main"},
+				},
+			},
+		})
+
+		var result protocol.InitializeResult
+		resp.unmarshalResult(t, &result)
+		if !result.Capabilities.DefinitionProvider || !result.Capabilities.WorkspaceSymbolProvider {
+			t.Errorf("unexpected capabilities %+v", result.Capabilities)
+		}
+		if history := mockRepoStore.ResolveRevFunc.History(); len(history) != 1 || history[0].Arg2 != "main" {
+			t.Errorf("unexpected revision resolution %+v", history)
+		}
+	})
+
+	mockSvc.GetClosestDumpsForBlobFunc.SetDefaultReturn([]uploadsshared.Dump{{ID: 1, Commit: "deadbeef"}}, nil)
+
+	t.Run("definition", func(t *testing.T) {
+		rng := shared.Range{Start: shared.Position{Line: 1, Character: 2}, End: shared.Position{Line: 1, Character: 5}}
+		mockSvc.GetDefinitionsFunc.SetDefaultReturn([]shared.UploadLocation{
+			{Dump: uploadsshared.Dump{RepositoryID: 42}, Path: "internal/foo.go", TargetCommit: "deadbeef", TargetRange: rng},
+			{Dump: uploadsshared.Dump{RepositoryID: 50}, Path: "lib/a b.go", TargetCommit: "cafebabe", TargetRange: rng},
+			// Not visible to the actor
+			{Dump: uploadsshared.Dump{RepositoryID: 60}, Path: "secret.go", TargetCommit: "cafebabe", TargetRange: rng},
+		}, nil)
+
+		var locations []protocol.Location
+		client.call(t, 3, "textDocument/definition", position).unmarshalResult(t, &locations)
+
+		lspRange := convertRange(rng)
+		expected := []protocol.Location{
+			{URI: "file:///home/me/src/sourcegraph/internal/foo.go", Range: lspRange},
+			{URI: testExternalURL + "/github.com/sourcegraph/other@cafebabe/-/blob/lib/a%20b.go", Range: lspRange},
+		}
+		if diff := cmp.Diff(expected, locations); diff != "" {
+			t.Errorf("unexpected locations (-want +got):\n%s", diff)
+		}
+
+		args := mockSvc.GetDefinitionsFunc.History()[0].Arg1
+		expectedArgs := codenav.RequestArgs{RepositoryID: 42, Commit: "deadbeef", Path: "cmd/main.go", Line: 10, Character: 4}
+		if diff := cmp.Diff(expectedArgs, args); diff != "" {
+			t.Errorf("unexpected request args (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("references", func(t *testing.T) {
+		mockSvc.GetReferencesFunc.PushReturn([]shared.UploadLocation{{Dump: uploadsshared.Dump{RepositoryID: 42}, Path: "a.go", TargetCommit: "deadbeef"}}, codenav.ReferencesCursor{Phase: "remote"}, nil)
+		mockSvc.GetReferencesFunc.PushReturn([]shared.UploadLocation{{Dump: uploadsshared.Dump{RepositoryID: 42}, Path: "b.go", TargetCommit: "deadbeef"}}, codenav.ReferencesCursor{Phase: "done"}, nil)
+
+		var locations []protocol.Location
+		client.call(t, 4, "textDocument/references", protocol.ReferenceParams{TextDocumentPositionParams: position}).unmarshalResult(t, &locations)
+
+		if len(locations) != 2 || locations[1].URI != "file:///home/me/src/sourcegraph/b.go" {
+			t.Errorf("unexpected locations %+v", locations)
+		}
+		if history := mockSvc.GetReferencesFunc.History(); len(history) != 2 || history[1].Arg3.Phase != "remote" {
+			t.Errorf("expected references to be paged, got %+v", history)
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		mockSvc.GetHoverFunc.SetDefaultReturn("```go\nfunc main()\n```", shared.Range{}, true, nil)
+
+		var hover protocol.Hover
+		client.call(t, 5, "textDocument/hover", position).unmarshalResult(t, &hover)
+		if len(hover.Contents) != 1 || hover.Contents[0].Value != "```go\nfunc main()\n```" {
+			t.Errorf("unexpected hover %+v", hover)
+		}
+	})
+
+	t.Run("no precise index", func(t *testing.T) {
+		mockSvc.GetClosestDumpsForBlobFunc.PushReturn(nil, nil)
+
+		var locations []protocol.Location
+		client.call(t, 6, "textDocument/implementation", position).unmarshalResult(t, &locations)
+		if locations == nil || len(locations) != 0 {
+			t.Errorf("expected empty locations, got %+v", locations)
+		}
+	})
+
+	t.Run("outside of workspaces", func(t *testing.T) {
+		resp := client.call(t, 7, "textDocument/definition", protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: "file:///home/me/src/sourcegraph-fork/main.go"},
+		})
+		if resp.Error == nil || resp.Error.Code != codeInvalidParams {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+	})
+
+	t.Run("workspace symbol", func(t *testing.T) {
+		mockSymbolSearcher.SearchSymbolsFunc.SetDefaultReturn([]*result.SymbolMatch{
+			result.NewSymbolMatch(
+				&result.File{Repo: types.MinimalRepo{ID: 42, Name: testRepo.Name}, CommitID: "deadbeef", Path: "cmd/main.go"},
+				3, 5, "main", "function", "", "", "Go", "", false,
+			),
+		}, nil)
+
+		var symbols []protocol.SymbolInformation
+		client.call(t, 8, "workspace/symbol", protocol.WorkspaceSymbolParams{Query: "main"}).unmarshalResult(t, &symbols)
+
+		expected := []protocol.SymbolInformation{
+			{
+				Name: "main",
+				Kind: protocol.SKFunction,
+				Location: protocol.Location{
+					URI:   "file:///home/me/src/sourcegraph/cmd/main.go",
+					Range: protocol.Range{Start: protocol.Position{Line: 2, Character: 5}, End: protocol.Position{Line: 2, Character: 9}},
+				},
+			},
+		}
+		if diff := cmp.Diff(expected, symbols); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+		}
+
+		expectedQuery := `repo:^github\.com/sourcegraph/sourcegraph$@deadbeef type:symbol count:100 main`
+		if query := mockSymbolSearcher.SearchSymbolsFunc.History()[0].Arg1; query != expectedQuery {
+			t.Errorf("unexpected query. want=%q have=%q", expectedQuery, query)
+		}
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		resp := client.call(t, 9, "textDocument/rename", position)
+		if resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+	})
+}
+
+func newTestHandler(t *testing.T, svc CodeNavService, repoStore RepoStore, symbolSearcher SymbolSearcher) http.Handler {
+	hunkCache, err := codenav.NewHunkCache(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newHandler(
+		logtest.Scoped(t),
+		newOperations(&observation.TestContext),
+		svc,
+		repoStore,
+		database.NewMockRepoStore(),
+		gitserver.NewMockClient(),
+		symbolSearcher,
+		50,
+		hunkCache,
+		func() string { return testExternalURL },
+	)
+}
+
+type testClient struct {
+	conn *websocket.Conn
+}
+
+// newTestClient connects to the given handler on behalf of an authenticated user.
+func newTestClient(t *testing.T, handler http.Handler) *testClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(actor.WithActor(r.Context(), actor.FromUser(1))))
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testClient{conn: conn}
+}
+
+// call sends a request and waits for its response.
+func (c *testClient) call(t *testing.T, id uint64, method string, params any) response {
+	t.Helper()
+
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": json.RawMessage(rawParams)}); err != nil {
+		t.Fatalf("failed to send request: %s", err)
+	}
+
+	var resp response
+	if err := c.conn.ReadJSON(&resp); err != nil {
+		t.Fatalf("failed to read response: %s", err)
+	}
+	if resp.ID == nil || resp.ID.Num != id {
+		t.Fatalf("unexpected response identifier %v", resp.ID)
+	}
+
+	return resp
+}
+
+func (r response) unmarshalResult(t *testing.T, v any) {
+	t.Helper()
+
+	if r.Error != nil {
+		t.Fatalf("unexpected error response: %s", r.Error.Message)
+	}
+	if err := json.Unmarshal(*r.Result, v); err != nil {
+		t.Fatalf("failed to unmarshal result: %s", err)
+	}
+}
@@ -0,0 +1,32 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type CodeNavService interface {
+	GetHover(ctx context.Context, args codenav.RequestArgs, requestState codenav.RequestState) (_ string, _ shared.Range, _ bool, err error)
+	GetReferences(ctx context.Context, args codenav.RequestArgs, requestState codenav.RequestState, cursor codenav.ReferencesCursor) (_ []shared.UploadLocation, nextCursor codenav.ReferencesCursor, err error)
+	GetImplementations(ctx context.Context, args codenav.RequestArgs, requestState codenav.RequestState, cursor codenav.ImplementationsCursor) (_ []shared.UploadLocation, nextCursor codenav.ImplementationsCursor, err error)
+	GetDefinitions(ctx context.Context, args codenav.RequestArgs, requestState codenav.RequestState) (_ []shared.UploadLocation, err error)
+	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []uploadsshared.Dump, err error)
+}
+
+type RepoStore interface {
+	Get(ctx context.Context, id api.RepoID) (*types.Repo, error)
+	GetByName(ctx context.Context, name api.RepoName) (*types.Repo, error)
+	ResolveRev(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error)
+}
+
+type SymbolSearcher interface {
+	// SearchSymbols runs the given literal search query on behalf of the actor in the
+	// context and returns the symbols it matched.
+	SearchSymbols(ctx context.Context, query string) ([]*result.SymbolMatch, error)
+}
@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// NewHandler returns an HTTP handler that serves the Language Server Protocol over WebSocket
+// connections. Precise code navigation requests are resolved by the given service, and
+// workspace symbol requests by the given symbol searcher.
+//
+// 🚨 SECURITY: The handler must be served behind the API auth middleware, as requests are
+// resolved on behalf of the actor of the connection request.
+func NewHandler(
+	svc CodeNavService,
+	db database.DB,
+	gitserverClient gitserver.Client,
+	symbolSearcher SymbolSearcher,
+	maxIndexes int,
+	hunkCacheSize int,
+) (http.Handler, error) {
+	logger := log.Scoped(
+		"codenav.lsp",
+		"codeintel codenav language server protocol handler",
+	)
+
+	hunkCache, err := codenav.NewHunkCache(hunkCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHandler(
+		logger,
+		newOperations(observation.NewContext(logger)),
+		svc,
+		backend.NewRepos(logger, db, gitserverClient),
+		db.Repos(),
+		gitserverClient,
+		symbolSearcher,
+		maxIndexes,
+		hunkCache,
+		func() string { return strings.TrimSuffix(conf.ExternalURL(), "/") },
+	), nil
+}
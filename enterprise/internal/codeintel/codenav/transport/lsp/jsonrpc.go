@@ -0,0 +1,65 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	protocol "github.com/sourcegraph/go-lsp"
+)
+
+// JSON-RPC 2.0 and LSP error codes.
+// See https://microsoft.github.io/language-server-protocol/specifications/specification-current/#errorCodes.
+const (
+	codeParseError           = -32700
+	codeInvalidRequest       = -32600
+	codeMethodNotFound       = -32601
+	codeInvalidParams        = -32602
+	codeInternalError        = -32603
+	codeServerNotInitialized = -32002
+	codeRequestCancelled     = -32800
+)
+
+// message is a JSON-RPC 2.0 request or notification sent by the client. Notifications
+// have no identifier. Messages with an identifier and no method are responses to server
+// requests; we never issue any, so those are ignored.
+type message struct {
+	ID     *protocol.ID    `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response. Exactly one of Result and Error is set. The
+// identifier is null when the request could not be parsed.
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *protocol.ID     `json:"id"`
+	Result  *json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object. Handlers return it to fail a request with a
+// specific code; any other error is reported as an internal error.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+func newRPCError(code int, format string, args ...any) *rpcError {
+	return &rpcError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// unmarshalParams decodes the parameters of a request into the given value.
+func unmarshalParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return newRPCError(codeInvalidParams, "missing params")
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return newRPCError(codeInvalidParams, "invalid params: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/regexp"
+	protocol "github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxLocations is the maximum number of references or implementations returned for a
+	// single request. LSP has no notion of paging these results.
+	maxLocations = 1000
+
+	// maxSymbols is the maximum number of workspace symbols returned for a single request.
+	maxSymbols = 100
+)
+
+func (s *session) initialize(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.initialize.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if s.initialized {
+		return nil, newRPCError(codeInvalidRequest, "server is already initialized")
+	}
+
+	var params struct {
+		InitializationOptions initializationOptions `json:"initializationOptions"`
+	}
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	workspaces, err := resolveWorkspaces(ctx, s.handler.repoStore, params.InitializationOptions.Workspaces)
+	if err != nil {
+		return nil, err
+	}
+	s.workspaces = workspaces
+	s.initialized = true
+
+	return protocol.InitializeResult{
+		Capabilities: protocol.ServerCapabilities{
+			DefinitionProvider:      true,
+			ReferencesProvider:      true,
+			HoverProvider:           true,
+			ImplementationProvider:  true,
+			WorkspaceSymbolProvider: true,
+		},
+	}, nil
+}
+
+func (s *session) definition(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.definition.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestState(ctx, rawParams)
+	if err != nil || !ok {
+		return []protocol.Location{}, err
+	}
+
+	locations, err := s.handler.svc.GetDefinitions(ctx, args, requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetDefinitions")
+	}
+
+	return s.convertLocations(ctx, locations)
+}
+
+func (s *session) references(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.references.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestState(ctx, rawParams)
+	if err != nil || !ok {
+		return []protocol.Location{}, err
+	}
+
+	var locations []shared.UploadLocation
+	cursor := codenav.ReferencesCursor{Phase: "local"}
+	for len(locations) < maxLocations {
+		args.Limit = maxLocations - len(locations)
+
+		page, nextCursor, err := s.handler.svc.GetReferences(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.GetReferences")
+		}
+		locations = append(locations, page...)
+
+		if nextCursor.Phase == "done" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	return s.convertLocations(ctx, locations)
+}
+
+func (s *session) implementation(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.implementation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestState(ctx, rawParams)
+	if err != nil || !ok {
+		return []protocol.Location{}, err
+	}
+
+	var locations []shared.UploadLocation
+	cursor := codenav.ImplementationsCursor{Phase: "local"}
+	for len(locations) < maxLocations {
+		args.Limit = maxLocations - len(locations)
+
+		page, nextCursor, err := s.handler.svc.GetImplementations(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.GetImplementations")
+		}
+		locations = append(locations, page...)
+
+		if nextCursor.Phase == "done" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	return s.convertLocations(ctx, locations)
+}
+
+func (s *session) hover(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.hover.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	args, requestState, ok, err := s.requestState(ctx, rawParams)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	text, rng, exists, err := s.handler.svc.GetHover(ctx, args, requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetHover")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	lspRange := convertRange(rng)
+	return &protocol.Hover{
+		Contents: []protocol.MarkedString{protocol.RawMarkedString(text)},
+		Range:    &lspRange,
+	}, nil
+}
+
+func (s *session) workspaceSymbol(ctx context.Context, rawParams json.RawMessage) (_ any, err error) {
+	ctx, _, endObservation := s.handler.operations.workspaceSymbol.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	var params protocol.WorkspaceSymbolParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+	limit := params.Limit
+	if limit <= 0 || limit > maxSymbols {
+		limit = maxSymbols
+	}
+
+	type repoCommit struct {
+		repoID api.RepoID
+		commit api.CommitID
+	}
+	searched := map[repoCommit]struct{}{}
+
+	symbols := []protocol.SymbolInformation{}
+	for _, workspace := range s.workspaces {
+		if len(symbols) >= limit {
+			break
+		}
+		key := repoCommit{workspace.repo.ID, workspace.commit}
+		if _, ok := searched[key]; ok {
+			continue
+		}
+		searched[key] = struct{}{}
+
+		query := fmt.Sprintf("repo:^%s$@%s type:symbol count:%d %s", regexp.QuoteMeta(string(workspace.repo.Name)), workspace.commit, limit-len(symbols), params.Query)
+		matches, err := s.handler.symbolSearcher.SearchSymbols(ctx, query)
+		if err != nil {
+			return nil, errors.Wrap(err, "symbolSearcher.SearchSymbols")
+		}
+
+		for _, match := range matches {
+			if len(symbols) >= limit {
+				break
+			}
+
+			symbols = append(symbols, protocol.SymbolInformation{
+				Name:          match.Symbol.Name,
+				Kind:          match.Symbol.LSPKind(),
+				ContainerName: match.Symbol.Parent,
+				Location: protocol.Location{
+					URI:   s.documentURI(match.File.Repo.ID, match.File.Repo.Name, string(match.File.CommitID), match.File.Path),
+					Range: match.Symbol.Range(),
+				},
+			})
+		}
+	}
+
+	return symbols, nil
+}
+
+// requestState decodes the given text document position and creates the state used by the
+// code navigation service to resolve requests at that position. False is returned if the
+// document is not covered by a precise index.
+func (s *session) requestState(ctx context.Context, rawParams json.RawMessage) (codenav.RequestArgs, codenav.RequestState, bool, error) {
+	var params protocol.TextDocumentPositionParams
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return codenav.RequestArgs{}, codenav.RequestState{}, false, err
+	}
+
+	document, err := s.resolveDocument(ctx, params.TextDocument.URI)
+	if err != nil {
+		return codenav.RequestArgs{}, codenav.RequestState{}, false, err
+	}
+
+	uploads, err := s.handler.svc.GetClosestDumpsForBlob(ctx, int(document.repo.ID), string(document.commit), document.path, true, "")
+	if err != nil || len(uploads) == 0 {
+		return codenav.RequestArgs{}, codenav.RequestState{}, false, err
+	}
+
+	args := codenav.RequestArgs{
+		RepositoryID: int(document.repo.ID),
+		Commit:       string(document.commit),
+		Path:         document.path,
+		Line:         params.Position.Line,
+		Character:    params.Position.Character,
+	}
+	requestState := codenav.NewRequestState(
+		uploads,
+		s.handler.dbRepoStore,
+		authz.DefaultSubRepoPermsChecker,
+		s.handler.gitserverClient,
+		document.repo,
+		string(document.commit),
+		document.path,
+		s.handler.maxIndexes,
+		s.handler.hunkCache,
+	)
+
+	return args, requestState, true, nil
+}
+
+// convertLocations converts the given locations into LSP locations.
+//
+// 🚨 SECURITY: Locations within repositories the actor cannot see are dropped.
+func (s *session) convertLocations(ctx context.Context, locations []shared.UploadLocation) ([]protocol.Location, error) {
+	repos := map[api.RepoID]*types.Repo{}
+
+	converted := make([]protocol.Location, 0, len(locations))
+	for _, location := range locations {
+		repoID := api.RepoID(location.Dump.RepositoryID)
+		repo, ok := repos[repoID]
+		if !ok {
+			var err error
+			if repo, err = s.handler.repoStore.Get(ctx, repoID); err != nil {
+				if !errcode.IsNotFound(err) {
+					return nil, err
+				}
+				repo = nil
+			}
+			repos[repoID] = repo
+		}
+		if repo == nil {
+			continue
+		}
+
+		converted = append(converted, protocol.Location{
+			URI:   s.documentURI(repo.ID, repo.Name, location.TargetCommit, location.Path),
+			Range: convertRange(location.TargetRange),
+		})
+	}
+
+	return converted, nil
+}
+
+func convertRange(r shared.Range) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   protocol.Position{Line: r.End.Line, Character: r.End.Character},
+	}
+}
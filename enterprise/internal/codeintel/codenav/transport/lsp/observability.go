@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type operations struct {
+	definition      *observation.Operation
+	hover           *observation.Operation
+	implementation  *observation.Operation
+	initialize      *observation.Operation
+	references      *observation.Operation
+	workspaceSymbol *observation.Operation
+}
+
+func newOperations(observationCtx *observation.Context) *operations {
+	redMetrics := metrics.NewREDMetrics(
+		observationCtx.Registerer,
+		"codeintel_codenav_transport_lsp",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of method invocations."),
+	)
+
+	op := func(name string) *observation.Operation {
+		return observationCtx.Operation(observation.Op{
+			Name:              fmt.Sprintf("codeintel.codenav.transport.lsp.%s", name),
+			MetricLabelValues: []string{name},
+			Metrics:           redMetrics,
+		})
+	}
+
+	return &operations{
+		definition:      op("definition"),
+		hover:           op("hover"),
+		implementation:  op("implementation"),
+		initialize:      op("initialize"),
+		references:      op("references"),
+		workspaceSymbol: op("workspaceSymbol"),
+	}
+}
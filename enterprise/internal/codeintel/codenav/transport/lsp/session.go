@@ -0,0 +1,238 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	protocol "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxConcurrentRequests is the number of requests of a single connection that are
+	// resolved concurrently. Further requests are not read until one completes.
+	maxConcurrentRequests = 8
+
+	// writeTimeout is the time allowed to write a single message to the client.
+	writeTimeout = 10 * time.Second
+
+	// pingInterval is the interval at which the connection is pinged so that idle
+	// connections are not closed by intermediate proxies.
+	pingInterval = 30 * time.Second
+)
+
+// session is the state of a single client connection. Each message is one JSON-RPC
+// message sent as a text frame.
+type session struct {
+	handler *handler
+	conn    *websocket.Conn
+	logger  log.Logger
+
+	// The following fields are only written by lifecycle requests, which are handled
+	// by the read loop before any later request is read.
+	initialized  bool
+	shuttingDown bool
+	workspaces   []workspace
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+func newSession(handler *handler, conn *websocket.Conn) *session {
+	return &session{
+		handler:  handler,
+		conn:     conn,
+		logger:   handler.logger.With(log.String("remoteAddr", conn.RemoteAddr().String())),
+		inflight: map[string]context.CancelFunc{},
+	}
+}
+
+// serve reads and handles messages until the client exits or the connection is closed.
+func (s *session) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	go s.ping(ctx)
+
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.logger.Debug("failed to read message", log.Error(err))
+			}
+			return
+		}
+
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.reply(nil, nil, newRPCError(codeParseError, "invalid message: %s", err))
+			continue
+		}
+		if msg.Method == "" {
+			continue
+		}
+
+		if msg.ID == nil {
+			if exit := s.notify(msg); exit {
+				return
+			}
+			continue
+		}
+
+		if msg.Method == "initialize" || msg.Method == "shutdown" {
+			// Lifecycle requests change the state of the session, so they are handled
+			// before reading the next message
+			s.handleRequest(ctx, msg)
+			continue
+		}
+
+		semaphore <- struct{}{}
+		requestCtx, requestCancel := context.WithCancel(ctx)
+		key := msg.ID.String()
+		s.mu.Lock()
+		s.inflight[key] = requestCancel
+		s.mu.Unlock()
+
+		wg.Add(1)
+		go func(msg message) {
+			defer func() {
+				s.mu.Lock()
+				delete(s.inflight, key)
+				s.mu.Unlock()
+				requestCancel()
+				<-semaphore
+				wg.Done()
+			}()
+
+			s.handleRequest(requestCtx, msg)
+		}(msg)
+	}
+}
+
+// notify handles a notification. True is returned if the client asked the server to exit.
+func (s *session) notify(msg message) (exit bool) {
+	switch msg.Method {
+	case "exit":
+		return true
+
+	case "$/cancelRequest":
+		var params protocol.CancelParams
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			s.mu.Lock()
+			if cancel, ok := s.inflight[params.ID.String()]; ok {
+				cancel()
+			}
+			s.mu.Unlock()
+		}
+	}
+
+	// All other notifications, including document synchronization, are ignored. Requests
+	// are resolved against the indexed commit of a document rather than the buffer open
+	// in the editor.
+	return false
+}
+
+func (s *session) handleRequest(ctx context.Context, msg message) {
+	result, err := s.handle(ctx, msg)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			if ctx.Err() != nil {
+				rpcErr = newRPCError(codeRequestCancelled, "request cancelled")
+			} else {
+				s.logger.Error("failed to handle request", log.String("method", msg.Method), log.Error(err))
+				rpcErr = newRPCError(codeInternalError, "%s", err)
+			}
+		}
+
+		s.reply(msg.ID, nil, rpcErr)
+		return
+	}
+
+	s.reply(msg.ID, result, nil)
+}
+
+func (s *session) handle(ctx context.Context, msg message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(ctx, msg.Params)
+	case "shutdown":
+		s.shuttingDown = true
+		return nil, nil
+	}
+
+	if !s.initialized {
+		return nil, newRPCError(codeServerNotInitialized, "server not initialized")
+	}
+	if s.shuttingDown {
+		return nil, newRPCError(codeInvalidRequest, "server is shutting down")
+	}
+
+	switch msg.Method {
+	case "textDocument/definition":
+		return s.definition(ctx, msg.Params)
+	case "textDocument/references":
+		return s.references(ctx, msg.Params)
+	case "textDocument/hover":
+		return s.hover(ctx, msg.Params)
+	case "textDocument/implementation":
+		return s.implementation(ctx, msg.Params)
+	case "workspace/symbol":
+		return s.workspaceSymbol(ctx, msg.Params)
+	}
+
+	return nil, newRPCError(codeMethodNotFound, "method %q is not supported", msg.Method)
+}
+
+// reply sends the response to the request with the given identifier.
+func (s *session) reply(id *protocol.ID, result any, rpcErr *rpcError) {
+	resp := response{JSONRPC: "2.0", ID: id}
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		raw, err := json.Marshal(result)
+		if err != nil {
+			s.logger.Error("failed to marshal result", log.Error(err))
+			resp.Error = newRPCError(codeInternalError, "failed to marshal result")
+		} else {
+			resp.Result = (*json.RawMessage)(&raw)
+		}
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := s.conn.WriteJSON(resp); err != nil {
+		s.logger.Debug("failed to write response", log.Error(err))
+	}
+}
+
+// ping periodically pings the client until the given context is canceled.
+func (s *session) ping(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package lsp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	protocol "github.com/sourcegraph/go-lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// initializationOptions are the Sourcegraph-specific options sent by the client in the
+// initialize request.
+type initializationOptions struct {
+	// Workspaces maps local directories to the repositories they are a checkout of.
+	Workspaces []workspaceOptions `json:"workspaces"`
+}
+
+type workspaceOptions struct {
+	// URI is the file URI of the root of the checkout.
+	URI string `json:"uri"`
+	// Repository is the name of the repository on this instance, e.g. github.com/sourcegraph/sourcegraph.
+	Repository string `json:"repository"`
+	// Revision is the revision of the checkout. Defaults to the default branch.
+	Revision string `json:"revision"`
+}
+
+// workspace is a local checkout of a repository at a commit.
+type workspace struct {
+	root   *url.URL
+	repo   *types.Repo
+	commit api.CommitID
+}
+
+// document is a file of a repository at a commit.
+type document struct {
+	repo   *types.Repo
+	commit api.CommitID
+	path   string
+}
+
+// resolveWorkspaces resolves the repository and commit of each of the configured workspaces.
+//
+// 🚨 SECURITY: The repo store filters out repositories the actor in the context cannot see.
+func resolveWorkspaces(ctx context.Context, repoStore RepoStore, options []workspaceOptions) ([]workspace, error) {
+	workspaces := make([]workspace, 0, len(options))
+	for _, option := range options {
+		root, err := url.Parse(option.URI)
+		if err != nil || root.Scheme != "file" {
+			return nil, newRPCError(codeInvalidParams, "workspace %q is not a file URI", option.URI)
+		}
+		root.Path = strings.TrimSuffix(root.Path, "/")
+		root.RawPath = ""
+
+		repo, commit, err := resolveRepoRev(ctx, repoStore, option.Repository, option.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, workspace{root: root, repo: repo, commit: commit})
+	}
+
+	return workspaces, nil
+}
+
+func resolveRepoRev(ctx context.Context, repoStore RepoStore, repoName, rev string) (*types.Repo, api.CommitID, error) {
+	repo, err := repoStore.GetByName(ctx, api.RepoName(repoName))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, "", newRPCError(codeInvalidParams, "repository %q not found", repoName)
+		}
+		return nil, "", err
+	}
+
+	commit, err := repoStore.ResolveRev(ctx, repo, rev)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, "", newRPCError(codeInvalidParams, "revision %q of repository %q not found", rev, repoName)
+		}
+		return nil, "", err
+	}
+
+	return repo, commit, nil
+}
+
+// resolveDocument maps the URI of a text document to a file of a repository at a commit.
+// File URIs are resolved relative to the innermost workspace containing them. Sourcegraph
+// blob URLs, which are returned for locations outside of the workspaces, are resolved as
+// well so that navigation can continue from those documents.
+func (s *session) resolveDocument(ctx context.Context, uri protocol.DocumentURI) (document, error) {
+	if blobURL := strings.TrimPrefix(string(uri), s.handler.externalURL()+"/"); blobURL != string(uri) {
+		repoRev, path, ok := strings.Cut(blobURL, "/-/blob/")
+		if !ok {
+			return document{}, newRPCError(codeInvalidParams, "unsupported document URI %q", uri)
+		}
+		repoName, rev, _ := strings.Cut(repoRev, "@")
+
+		repo, commit, err := resolveRepoRev(ctx, s.handler.repoStore, unescapePath(repoName), unescapePath(rev))
+		if err != nil {
+			return document{}, err
+		}
+
+		return document{repo: repo, commit: commit, path: unescapePath(path)}, nil
+	}
+
+	u, err := url.Parse(string(uri))
+	if err != nil || u.Scheme != "file" {
+		return document{}, newRPCError(codeInvalidParams, "unsupported document URI %q", uri)
+	}
+
+	var (
+		match *workspace
+		path  string
+	)
+	for i, workspace := range s.workspaces {
+		if relative := strings.TrimPrefix(u.Path, workspace.root.Path+"/"); relative != u.Path && u.Host == workspace.root.Host {
+			if match == nil || len(workspace.root.Path) > len(match.root.Path) {
+				match, path = &s.workspaces[i], relative
+			}
+		}
+	}
+	if match == nil {
+		return document{}, newRPCError(codeInvalidParams, "document %q is not within a workspace", uri)
+	}
+
+	return document{repo: match.repo, commit: match.commit, path: path}, nil
+}
+
+// documentURI returns the URI of the given file of a repository at a commit. Files of a
+// workspace are returned as local file URIs; all other files are returned as Sourcegraph
+// blob URLs.
+func (s *session) documentURI(repoID api.RepoID, repoName api.RepoName, commit, path string) protocol.DocumentURI {
+	for _, workspace := range s.workspaces {
+		if workspace.repo.ID == repoID && string(workspace.commit) == commit {
+			u := *workspace.root
+			u.Path += "/" + path
+			return protocol.DocumentURI(u.String())
+		}
+	}
+
+	return protocol.DocumentURI(s.handler.externalURL() + "/" + escapePath(string(repoName)) + "@" + url.PathEscape(commit) + "/-/blob/" + escapePath(path))
+}
+
+// escapePath escapes each segment of the given slash-separated path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// unescapePath reverses escapePath. Paths that are not validly escaped are returned as-is.
+func unescapePath(path string) string {
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		return unescaped
+	}
+
+	return path
+}
@@ -0,0 +1,58 @@
+package lsp
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	protocol "github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolveDocument(t *testing.T) {
+	mockRepoStore := NewMockRepoStore()
+	mockRepoStore.GetByNameFunc.SetDefaultReturn(otherRepo, nil)
+	mockRepoStore.ResolveRevFunc.SetDefaultHook(func(_ context.Context, _ *types.Repo, rev string) (api.CommitID, error) {
+		return api.CommitID(rev), nil
+	})
+
+	s := &session{
+		handler: &handler{repoStore: mockRepoStore, externalURL: func() string { return testExternalURL }},
+		logger:  logtest.Scoped(t),
+		workspaces: []workspace{
+			{root: &url.URL{Scheme: "file", Path: "/src/sourcegraph"}, repo: testRepo, commit: "deadbeef"},
+			{root: &url.URL{Scheme: "file", Path: "/src/sourcegraph/vendor/other"}, repo: otherRepo, commit: "cafebabe"},
+		},
+	}
+
+	testCases := []struct {
+		uri      string
+		expected document
+	}{
+		{"file:///src/sourcegraph/cmd/main.go", document{repo: testRepo, commit: "deadbeef", path: "cmd/main.go"}},
+		{"file:///src/sourcegraph/cmd/a%20b.go", document{repo: testRepo, commit: "deadbeef", path: "cmd/a b.go"}},
+		// The innermost workspace wins
+		{"file:///src/sourcegraph/vendor/other/lib.go", document{repo: otherRepo, commit: "cafebabe", path: "lib.go"}},
+		// Sourcegraph URLs returned for locations outside of the workspaces
+		{testExternalURL + "/github.com/sourcegraph/other@0123abcd/-/blob/lib/a%20b.go", document{repo: otherRepo, commit: "0123abcd", path: "lib/a b.go"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.uri, func(t *testing.T) {
+			document, err := s.resolveDocument(context.Background(), protocol.DocumentURI(testCase.uri))
+			if err != nil {
+				t.Fatalf("unexpected error resolving document: %s", err)
+			}
+			if document != testCase.expected {
+				t.Errorf("unexpected document. want=%+v have=%+v", testCase.expected, document)
+			}
+
+			if uri := s.documentURI(document.repo.ID, document.repo.Name, string(document.commit), document.path); string(uri) != testCase.uri {
+				t.Errorf("unexpected document URI. want=%q have=%q", testCase.uri, uri)
+			}
+		})
+	}
+}
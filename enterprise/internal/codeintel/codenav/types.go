@@ -77,6 +77,48 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// HierarchyItem is the definition of a symbol that is part of a call or type hierarchy. The
+// definition is nil when the location of the symbol's definition is not known.
+type HierarchyItem struct {
+	Symbol     string
+	Definition *shared.UploadLocation
+}
+
+// CallHierarchyCall is a caller or a callee of the requested function together with the
+// locations of the calls. The call sites of incoming calls occur within the caller, and the
+// call sites of outgoing calls occur within the requested function.
+type CallHierarchyCall struct {
+	HierarchyItem
+	CallSites []shared.UploadLocation
+}
+
+// SymbolChangeKind describes how an exported symbol differs between the uploads of two commits.
+type SymbolChangeKind string
+
+const (
+	SymbolChangeKindAdded            SymbolChangeKind = "ADDED"
+	SymbolChangeKindRemoved          SymbolChangeKind = "REMOVED"
+	SymbolChangeKindSignatureChanged SymbolChangeKind = "SIGNATURE_CHANGED"
+)
+
+// SymbolChange is an exported symbol that was added, removed, or whose signature changed between
+// a base and a head commit. Symbols are matched by name without their package version, as indexers
+// generally derive the version from the indexed commit. Base is nil for added symbols and Head is
+// nil for removed symbols.
+type SymbolChange struct {
+	Kind SymbolChangeKind
+	Base *SymbolVersion
+	Head *SymbolVersion
+}
+
+// SymbolVersion is the definition of an exported symbol within the upload of one side of a diff.
+type SymbolVersion struct {
+	Symbol        string
+	Definition    shared.UploadLocation
+	Signature     string
+	Documentation string
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	getStarRank                      *observation.Operation
+	getStarRanks                     *observation.Operation
 	getDocumentRanks                 *observation.Operation
 	getReferenceCountStatistics      *observation.Operation
 	lastUpdatedAt                    *observation.Operation
@@ -56,6 +57,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		getStarRank:                      op("GetStarRank"),
+		getStarRanks:                     op("GetStarRanks"),
 		getDocumentRanks:                 op("GetDocumentRanks"),
 		getReferenceCountStatistics:      op("GetReferenceCountStatistics"),
 		lastUpdatedAt:                    op("LastUpdatedAt"),
@@ -7,6 +7,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -34,6 +35,38 @@ FROM (
 WHERE s.name = %s
 `
 
+func (s *store) GetStarRanks(ctx context.Context, repoNames []api.RepoName) (_ map[api.RepoName]float64, err error) {
+	ctx, _, endObservation := s.operations.getStarRanks.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numRepoNames", len(repoNames)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	names := make([]string, 0, len(repoNames))
+	for _, repoName := range repoNames {
+		names = append(names, string(repoName))
+	}
+
+	return scanStarRanks(s.db.Query(ctx, sqlf.Sprintf(getStarRanksQuery, pq.Array(names))))
+}
+
+const getStarRanksQuery = `
+SELECT
+	s.name,
+	s.rank
+FROM (
+	SELECT
+		name,
+		percent_rank() OVER (ORDER BY stars) AS rank
+	FROM repo
+) s
+WHERE s.name = ANY(%s)
+`
+
+var scanStarRanks = basestore.NewMapScanner(func(s dbutil.Scanner) (repoName api.RepoName, rank float64, _ error) {
+	err := s.Scan(&repoName, &rank)
+	return repoName, rank, err
+})
+
 func (s *store) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string]float64, _ bool, err error) {
 	ctx, _, endObservation := s.operations.getDocumentRanks.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
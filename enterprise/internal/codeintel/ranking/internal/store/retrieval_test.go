@@ -63,6 +63,44 @@ func TestGetStarRank(t *testing.T) {
 	}
 }
 
+func TestGetStarRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO repo (name, stars)
+		VALUES
+			('foo', 1000),
+			('bar',  200),
+			('baz',  300),
+			('bonk',  50),
+			('quux',   0),
+			('honk',   0)
+	`); err != nil {
+		t.Fatalf("failed to insert repos: %s", err)
+	}
+
+	ranks, err := store.GetStarRanks(ctx, []api.RepoName{"foo", "bar", "quux", "missing"})
+	if err != nil {
+		t.Fatalf("unexpected error getting star ranks: %s", err)
+	}
+
+	expected := map[api.RepoName]float64{
+		"foo":  1.0, // 1000
+		"bar":  0.6, // 200
+		"quux": 0.0, // 0
+	}
+	if diff := cmp.Diff(expected, ranks); diff != "" {
+		t.Errorf("unexpected ranks (-want +got):\n%s", diff)
+	}
+}
+
 func TestDocumentRanks(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -19,6 +19,7 @@ type Store interface {
 
 	// Retrieval
 	GetStarRank(ctx context.Context, repoName api.RepoName) (float64, error)
+	GetStarRanks(ctx context.Context, repoNames []api.RepoName) (map[api.RepoName]float64, error)
 	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (map[string]float64, bool, error)
 	GetReferenceCountStatistics(ctx context.Context) (logmean float64, _ error)
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
@@ -37,6 +37,9 @@ type MockStore struct {
 	// GetStarRankFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStarRank.
 	GetStarRankFunc *StoreGetStarRankFunc
+	// GetStarRanksFunc is an instance of a mock function object controlling
+	// the behavior of the method GetStarRanks.
+	GetStarRanksFunc *StoreGetStarRanksFunc
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
@@ -136,6 +139,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetStarRanksFunc: &StoreGetStarRanksFunc{
+			defaultHook: func(context.Context, []api.RepoName) (r0 map[api.RepoName]float64, r1 error) {
+				return
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) (r0 []shared.ExportedUpload, r1 error) {
 				return
@@ -268,6 +276,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStarRank")
 			},
 		},
+		GetStarRanksFunc: &StoreGetStarRanksFunc{
+			defaultHook: func(context.Context, []api.RepoName) (map[api.RepoName]float64, error) {
+				panic("unexpected invocation of MockStore.GetStarRanks")
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
@@ -392,6 +405,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: i.GetStarRank,
 		},
+		GetStarRanksFunc: &StoreGetStarRanksFunc{
+			defaultHook: i.GetStarRanks,
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
@@ -888,6 +904,113 @@ func (c StoreGetStarRankFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetStarRanksFunc describes the behavior when the GetStarRanks method
+// of the parent MockStore instance is invoked.
+type StoreGetStarRanksFunc struct {
+	defaultHook func(context.Context, []api.RepoName) (map[api.RepoName]float64, error)
+	hooks       []func(context.Context, []api.RepoName) (map[api.RepoName]float64, error)
+	history     []StoreGetStarRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStarRanks delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetStarRanks(v0 context.Context, v1 []api.RepoName) (map[api.RepoName]float64, error) {
+	r0, r1 := m.GetStarRanksFunc.nextHook()(v0, v1)
+	m.GetStarRanksFunc.appendCall(StoreGetStarRanksFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetStarRanks method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetStarRanksFunc) SetDefaultHook(hook func(context.Context, []api.RepoName) (map[api.RepoName]float64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStarRanks method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetStarRanksFunc) PushHook(hook func(context.Context, []api.RepoName) (map[api.RepoName]float64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetStarRanksFunc) SetDefaultReturn(r0 map[api.RepoName]float64, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoName) (map[api.RepoName]float64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetStarRanksFunc) PushReturn(r0 map[api.RepoName]float64, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoName) (map[api.RepoName]float64, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetStarRanksFunc) nextHook() func(context.Context, []api.RepoName) (map[api.RepoName]float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetStarRanksFunc) appendCall(r0 StoreGetStarRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetStarRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetStarRanksFunc) History() []StoreGetStarRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetStarRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetStarRanksFuncCall is an object that describes an invocation of
+// method GetStarRanks on an instance of MockStore.
+type StoreGetStarRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoName]float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetStarRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetStarRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetUploadsForRankingFunc describes the behavior when the
 // GetUploadsForRanking method of the parent MockStore instance is invoked.
 type StoreGetUploadsForRankingFunc struct {
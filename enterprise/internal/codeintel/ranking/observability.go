@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	getRepoRank      *observation.Operation
+	getRepoRanks     *observation.Operation
 	getDocumentRanks *observation.Operation
 }
 
@@ -36,6 +37,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		getRepoRank:      op("GetRepoRank"),
+		getRepoRanks:     op("GetRepoRanks"),
 		getDocumentRanks: op("GetDocumentRanks"),
 	}
 }
@@ -56,6 +56,27 @@ func (s *Service) GetRepoRank(ctx context.Context, repoName api.RepoName) (_ []f
 	return []float64{squashRange(userRank), starRank}, nil
 }
 
+// GetRepoRanks returns the rank vectors of the given repositories, as computed by GetRepoRank, with
+// a single query. Repositories that do not exist have no entry in the returned map.
+func (s *Service) GetRepoRanks(ctx context.Context, repoNames []api.RepoName) (_ map[api.RepoName][]float64, err error) {
+	_, _, endObservation := s.operations.getRepoRanks.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	starRanks, err := s.store.GetStarRanks(ctx, repoNames)
+	if err != nil {
+		return nil, err
+	}
+
+	siteConfig := s.getConf.SiteConfig()
+	ranks := make(map[api.RepoName][]float64, len(starRanks))
+	for repoName, starRank := range starRanks {
+		userRank := repoRankFromConfig(siteConfig, string(repoName))
+		ranks[repoName] = []float64{squashRange(userRank), starRank}
+	}
+
+	return ranks, nil
+}
+
 // copy pasta
 // https://github.com/sourcegraph/sourcegraph/blob/942c417363b07c9e0a6377456f1d6a80a94efb99/cmd/frontend/internal/httpapi/search.go#L172
 func repoRankFromConfig(siteConfig schema.SiteConfiguration, repoName string) float64 {
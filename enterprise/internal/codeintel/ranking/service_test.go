@@ -5,6 +5,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -62,6 +63,46 @@ func TestGetRepoRankWithUserBoostedScores(t *testing.T) {
 	}
 }
 
+func TestGetRepoRanks(t *testing.T) {
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	mockConfigQuerier := NewMockSiteConfigQuerier()
+	svc := newService(&observation.TestContext, mockStore, nil, mockConfigQuerier)
+
+	mockStore.GetStarRanksFunc.SetDefaultReturn(map[api.RepoName]float64{
+		"github.com/foo/bar":  0.6,
+		"github.com/baz/bonk": 0.2,
+	}, nil)
+	mockConfigQuerier.SiteConfigFunc.SetDefaultReturn(schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			Ranking: &schema.Ranking{
+				RepoScores: map[string]float64{
+					"github.com/foo": 400,
+				},
+			},
+		},
+	})
+
+	ranks, err := svc.GetRepoRanks(ctx, []api.RepoName{"github.com/foo/bar", "github.com/baz/bonk", "github.com/missing"})
+	if err != nil {
+		t.Fatalf("unexpected error getting repo ranks: %s", err)
+	}
+
+	if len(ranks) != 2 {
+		t.Fatalf("unexpected number of ranks. want=%d have=%d", 2, len(ranks))
+	}
+	if rank := ranks["github.com/foo/bar"]; !cmpFloat(rank[0], 400.0/401.0) || !cmpFloat(rank[1], 0.6) {
+		t.Errorf("unexpected rank for github.com/foo/bar: %v", rank)
+	}
+	if rank := ranks["github.com/baz/bonk"]; !cmpFloat(rank[0], 0) || !cmpFloat(rank[1], 0.2) {
+		t.Errorf("unexpected rank for github.com/baz/bonk: %v", rank)
+	}
+
+	if calls := mockStore.GetStarRanksFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of GetStarRanks calls. want=%d have=%d", 1, len(calls))
+	}
+}
+
 const epsilon = 0.00000001
 
 func cmpFloat(x, y float64) bool {
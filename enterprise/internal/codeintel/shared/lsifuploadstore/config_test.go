@@ -106,6 +106,55 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigAzure(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":                          "Azure",
+		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":                           "lsif-uploads",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_NAME":               "test-account",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_MANAGED_IDENTITY_CLIENT_ID": "test-client-id",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Bucket != "lsif-uploads" {
+		t.Errorf("unexpected value for Azure.Bucket. want=%s have=%s", "lsif-uploads", config.Bucket)
+	}
+	if config.AzureAccountName != "test-account" {
+		t.Errorf("unexpected value for Azure.AccountName. want=%s have=%s", "test-account", config.AzureAccountName)
+	}
+	if config.AzureAccountKey != "" {
+		t.Errorf("unexpected value for Azure.AccountKey. want=%s have=%s", "", config.AzureAccountKey)
+	}
+	if config.AzureManagedIdentityClientID != "test-client-id" {
+		t.Errorf("unexpected value for Azure.ManagedIdentityClientID. want=%s have=%s", "test-client-id", config.AzureManagedIdentityClientID)
+	}
+}
+
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":         "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_ROOT": "/data/uploads",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.FilesystemRoot != "/data/uploads" {
+		t.Errorf("unexpected value for Filesystem.Root. want=%s have=%s", "/data/uploads", config.FilesystemRoot)
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
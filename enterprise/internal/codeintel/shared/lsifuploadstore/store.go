@@ -26,6 +26,15 @@ func New(ctx context.Context, observationCtx *observation.Context, conf *Config)
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
 		},
+		Azure: uploadstore.AzureConfig{
+			AccountName:             conf.AzureAccountName,
+			AccountKey:              conf.AzureAccountKey,
+			ManagedIdentityClientID: conf.AzureManagedIdentityClientID,
+			Endpoint:                conf.AzureEndpoint,
+		},
+		Filesystem: uploadstore.FilesystemConfig{
+			Root: conf.FilesystemRoot,
+		},
 	}
 
 	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "codeintel", "uploadstore"))
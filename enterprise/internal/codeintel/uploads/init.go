@@ -129,6 +129,7 @@ func NewExpirationTasks(
 	return background.NewExpirationTasks(
 		scopedContext("expiration", observationCtx),
 		uploadSvc.store,
+		uploadSvc.lsifstore,
 		policySvc,
 		uploadSvc.gitserverClient,
 		repoStore,
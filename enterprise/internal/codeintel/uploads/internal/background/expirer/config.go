@@ -16,6 +16,10 @@ type Config struct {
 	RepositoryProcessDelay time.Duration
 	UploadBatchSize        int
 	UploadProcessDelay     time.Duration
+
+	RepositoryStorageBudget     int64
+	GlobalStorageBudget         int64
+	GlobalStorageBudgetInterval time.Duration
 }
 
 func (c *Config) Load() {
@@ -33,4 +37,7 @@ func (c *Config) Load() {
 	c.RepositoryProcessDelay = c.GetInterval(repositoryProcessDelay, "24h", "The minimum frequency that the same repository's uploads can be considered for expiration.")
 	c.UploadBatchSize = c.GetInt(uploadBatchSize, "100", "The number of uploads to consider for expiration at a time.")
 	c.UploadProcessDelay = c.GetInterval(uploadProcessDelay, "24h", "The minimum frequency that the same upload record can be considered for expiration.")
+	c.RepositoryStorageBudget = int64(c.GetInt("CODEINTEL_UPLOAD_EXPIRER_REPOSITORY_STORAGE_BUDGET_BYTES", "0", "The maximum number of bytes the precise code intel data of a single repository may occupy in the codeintel-db before its oldest unprotected uploads are expired. Zero disables the limit."))
+	c.GlobalStorageBudget = int64(c.GetInt("CODEINTEL_UPLOAD_EXPIRER_GLOBAL_STORAGE_BUDGET_BYTES", "0", "The maximum number of bytes all precise code intel data may occupy in the codeintel-db before the oldest unprotected uploads are expired. Zero disables the limit."))
+	c.GlobalStorageBudgetInterval = c.GetInterval("CODEINTEL_UPLOAD_EXPIRER_GLOBAL_STORAGE_BUDGET_INTERVAL", "1h", "How frequently to compare the total size of precise code intel data against the global storage budget.")
 }
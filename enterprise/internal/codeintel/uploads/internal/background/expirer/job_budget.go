@@ -0,0 +1,211 @@
+package expirer
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
+	policiesshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// handleGlobalStorageBudget expires the oldest unprotected uploads across all repositories until
+// the precise code intel data of all remaining uploads fits into the global storage budget.
+func (s *expirer) handleGlobalStorageBudget(ctx context.Context, cfg *Config, now time.Time, metrics *ExpirationMetrics) error {
+	// Commit maps are expensive to build, so we only build them for repositories owning uploads
+	// we'd like to expire, and only once per repository.
+	protectionByRepositoryID := map[int]*budgetProtection{}
+
+	isProtected := func(ctx context.Context, upload shared.Upload) (bool, error) {
+		protection, ok := protectionByRepositoryID[upload.RepositoryID]
+		if !ok {
+			commitMap, policies, err := s.buildCommitMap(ctx, upload.RepositoryID, cfg, now)
+			if err != nil {
+				return false, err
+			}
+
+			protection = newBudgetProtection(s.store, commitMap, policies, cfg)
+			protectionByRepositoryID[upload.RepositoryID] = protection
+		}
+
+		return protection.isProtected(ctx, upload)
+	}
+
+	return s.handleStorageBudget(ctx, 0, cfg.GlobalStorageBudget, isProtected, cfg, metrics)
+}
+
+// handleStorageBudget expires the oldest unprotected uploads of the given repository (or of all
+// repositories if repositoryID is zero) until the size of the remaining uploads is within budget.
+//
+// Expired uploads are removed by the expiredUploadDeleter, which frees their data in the codeintel-db.
+// Documents shared with other uploads stay around, so the actual reduction in size can be smaller
+// than estimated.
+func (s *expirer) handleStorageBudget(
+	ctx context.Context,
+	repositoryID int,
+	budget int64,
+	isProtected func(ctx context.Context, upload shared.Upload) (bool, error),
+	cfg *Config,
+	metrics *ExpirationMetrics,
+) error {
+	// The upload records and their data live in different databases, so the total size is summed
+	// up a batch of uploads at a time.
+	var totalSize int64
+	if err := s.forEachUnexpiredUploadBatch(ctx, repositoryID, false, cfg, func(uploads []shared.Upload, sizes map[int]int64) (bool, error) {
+		for _, size := range sizes {
+			totalSize += size
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	if totalSize <= budget {
+		return nil
+	}
+
+	// Only uploads that have been installed into the commit graph are candidates. Other uploads would
+	// look as if they were visible from no commit, and so as if they were not protected. Candidates
+	// are visited oldest first, and only until enough of them were expired.
+	var expiredUploadIDs []int
+	if err := s.forEachUnexpiredUploadBatch(ctx, repositoryID, true, cfg, func(uploads []shared.Upload, sizes map[int]int64) (bool, error) {
+		for _, upload := range uploads {
+			if totalSize <= budget {
+				return false, nil
+			}
+
+			size, ok := sizes[upload.ID]
+			if !ok || size == 0 {
+				// Expiring this upload wouldn't free any space
+				continue
+			}
+
+			metrics.NumUploadsScanned.Inc()
+
+			protected, err := isProtected(ctx, upload)
+			if err != nil {
+				return false, err
+			}
+			if protected {
+				continue
+			}
+
+			expiredUploadIDs = append(expiredUploadIDs, upload.ID)
+			totalSize -= size
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	if len(expiredUploadIDs) == 0 {
+		return nil
+	}
+
+	if err := s.store.UpdateUploadRetention(ctx, nil, expiredUploadIDs); err != nil {
+		return errors.Wrap(err, "uploadSvc.UpdateUploadRetention")
+	}
+
+	metrics.NumUploadsExpired.Add(float64(len(expiredUploadIDs)))
+	metrics.NumUploadsExpiredByStorageBudget.Add(float64(len(expiredUploadIDs)))
+	return nil
+}
+
+// forEachUnexpiredUploadBatch calls f with batches of the completed and unexpired uploads of the given
+// repository (or of all repositories if repositoryID is zero), oldest first, along with the size of
+// their data in the codeintel-db. Iteration stops once f returns false or an error.
+func (s *expirer) forEachUnexpiredUploadBatch(
+	ctx context.Context,
+	repositoryID int,
+	inCommitGraph bool,
+	cfg *Config,
+	f func(uploads []shared.Upload, sizes map[int]int64) (bool, error),
+) error {
+	afterID := 0
+	for {
+		uploads, err := s.store.GetUnexpiredUploadsAfter(ctx, repositoryID, inCommitGraph, afterID, cfg.UploadBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "uploadSvc.GetUnexpiredUploadsAfter")
+		}
+		if len(uploads) == 0 {
+			return nil
+		}
+		afterID = uploads[len(uploads)-1].ID
+
+		ids := make([]int, 0, len(uploads))
+		for _, upload := range uploads {
+			ids = append(ids, upload.ID)
+		}
+		sizes, err := s.lsifStore.GetUploadDataSizes(ctx, ids)
+		if err != nil {
+			return errors.Wrap(err, "lsifStore.GetUploadDataSizes")
+		}
+
+		if ok, err := f(uploads, sizes); err != nil || !ok {
+			return err
+		}
+	}
+}
+
+// budgetProtection determines which uploads of a repository must never be expired to satisfy a
+// storage budget, regardless of their age: uploads visible at the tip of the default branch and
+// uploads visible from a commit matched by a tag retention policy (such as tagged releases).
+type budgetProtection struct {
+	store      store.Store
+	tagCommits map[string]struct{}
+	cfg        *Config
+}
+
+func newBudgetProtection(store store.Store, commitMap map[string][]policies.PolicyMatch, configurationPolicies []policiesshared.ConfigurationPolicy, cfg *Config) *budgetProtection {
+	tagPolicyIDs := map[int]struct{}{}
+	for _, policy := range configurationPolicies {
+		if policy.Type == policiesshared.GitObjectTypeTag {
+			tagPolicyIDs[policy.ID] = struct{}{}
+		}
+	}
+
+	tagCommits := map[string]struct{}{}
+	for commit, policyMatches := range commitMap {
+		for _, policyMatch := range policyMatches {
+			if policyMatch.PolicyID == nil {
+				continue
+			}
+			if _, ok := tagPolicyIDs[*policyMatch.PolicyID]; ok {
+				tagCommits[commit] = struct{}{}
+			}
+		}
+	}
+
+	return &budgetProtection{
+		store:      store,
+		tagCommits: tagCommits,
+		cfg:        cfg,
+	}
+}
+
+func (p *budgetProtection) isProtected(ctx context.Context, upload shared.Upload) (bool, error) {
+	if upload.VisibleAtTip {
+		return true, nil
+	}
+	if len(p.tagCommits) == 0 {
+		return false, nil
+	}
+
+	var token *string
+	for first := true; first || token != nil; first = false {
+		commits, nextToken, err := p.store.GetCommitsVisibleToUpload(ctx, upload.ID, p.cfg.CommitBatchSize, token)
+		if err != nil {
+			return false, errors.Wrap(err, "uploadSvc.CommitsVisibleToUpload")
+		}
+		token = nextToken
+
+		for _, commit := range commits {
+			if _, ok := p.tagCommits[commit]; ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
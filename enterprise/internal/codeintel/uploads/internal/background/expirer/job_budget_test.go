@@ -0,0 +1,132 @@
+package expirer
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
+	policiesshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestHandleStorageBudget(t *testing.T) {
+	// Uploads are listed oldest first
+	uploads := []shared.Upload{
+		{ID: 1, RepositoryID: 50, VisibleAtTip: true}, // protected (tip)
+		{ID: 2, RepositoryID: 50},                     // protected (tagged release)
+		{ID: 3, RepositoryID: 50},
+		{ID: 4, RepositoryID: 50}, // not yet in commit graph
+		{ID: 5, RepositoryID: 50},
+		{ID: 6, RepositoryID: 50},
+		{ID: 7, RepositoryID: 50}, // no SCIP data
+	}
+	notInCommitGraph := map[int]struct{}{4: {}}
+	sizes := map[int]int64{1: 100, 2: 100, 3: 100, 4: 100, 5: 100, 6: 100}
+	visibleCommits := map[int][]string{
+		2: {"deadbeef02", "v1.0.0"},
+		3: {"deadbeef03", "main"},
+		5: {"deadbeef05"},
+		6: {"deadbeef06"},
+	}
+
+	store := NewMockStore()
+	store.GetUnexpiredUploadsAfterFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, inCommitGraph bool, afterID, limit int) ([]shared.Upload, error) {
+		var filtered []shared.Upload
+		for _, upload := range uploads {
+			if _, ok := notInCommitGraph[upload.ID]; ok && inCommitGraph {
+				continue
+			}
+			if upload.ID > afterID && len(filtered) < limit {
+				filtered = append(filtered, upload)
+			}
+		}
+		return filtered, nil
+	})
+	store.GetCommitsVisibleToUploadFunc.SetDefaultHook(func(ctx context.Context, uploadID, limit int, token *string) ([]string, *string, error) {
+		return visibleCommits[uploadID], nil, nil
+	})
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.GetUploadDataSizesFunc.SetDefaultHook(func(ctx context.Context, ids []int) (map[int]int64, error) {
+		filtered := map[int]int64{}
+		for _, id := range ids {
+			if size, ok := sizes[id]; ok {
+				filtered[id] = size
+			}
+		}
+		return filtered, nil
+	})
+
+	configurationPolicies := []policiesshared.ConfigurationPolicy{
+		{ID: 1, Type: policiesshared.GitObjectTypeCommit},
+		{ID: 2, Type: policiesshared.GitObjectTypeTag},
+	}
+	commitMap := map[string][]policies.PolicyMatch{
+		"main":   {{Name: "main", PolicyID: intPtr(1)}},
+		"v1.0.0": {{Name: "v1.0.0", PolicyID: intPtr(2)}},
+	}
+
+	cfg := &Config{
+		UploadBatchSize: 2,
+		CommitBatchSize: 100,
+	}
+	uploadExpirer := &expirer{
+		store:     store,
+		lsifStore: lsifStore,
+	}
+
+	// 600 bytes in total; the oldest unprotected uploads in the commit graph are dropped until
+	// the remaining uploads fit into 450 bytes
+	protection := newBudgetProtection(store, commitMap, configurationPolicies, cfg)
+	if err := uploadExpirer.handleStorageBudget(context.Background(), 50, 450, protection.isProtected, cfg, NewExpirationMetrics(&observation.TestContext)); err != nil {
+		t.Fatalf("unexpected error handling storage budget: %s", err)
+	}
+
+	var expiredIDs []int
+	for _, call := range store.UpdateUploadRetentionFunc.History() {
+		if len(call.Arg1) != 0 {
+			t.Errorf("unexpected protected upload identifiers: %v", call.Arg1)
+		}
+		expiredIDs = append(expiredIDs, call.Arg2...)
+	}
+	sort.Ints(expiredIDs)
+
+	if diff := cmp.Diff([]int{3, 5}, expiredIDs); diff != "" {
+		t.Errorf("unexpected expired upload identifiers (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleStorageBudgetWithinBudget(t *testing.T) {
+	store := NewMockStore()
+	store.GetUnexpiredUploadsAfterFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, inCommitGraph bool, afterID, limit int) ([]shared.Upload, error) {
+		if afterID > 0 {
+			return nil, nil
+		}
+		return []shared.Upload{{ID: 1}, {ID: 2}}, nil
+	})
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.GetUploadDataSizesFunc.SetDefaultReturn(map[int]int64{1: 100, 2: 100}, nil)
+
+	cfg := &Config{UploadBatchSize: 100, CommitBatchSize: 100}
+	uploadExpirer := &expirer{
+		store:     store,
+		lsifStore: lsifStore,
+	}
+
+	isProtected := func(ctx context.Context, upload shared.Upload) (bool, error) { return false, nil }
+	if err := uploadExpirer.handleStorageBudget(context.Background(), 0, 200, isProtected, cfg, NewExpirationMetrics(&observation.TestContext)); err != nil {
+		t.Fatalf("unexpected error handling storage budget: %s", err)
+	}
+
+	if calls := store.UpdateUploadRetentionFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected calls to UpdateUploadRetention: %v", calls)
+	}
+	if calls := store.GetUnexpiredUploadsAfterFunc.History(); len(calls) != 2 {
+		t.Errorf("unexpected number of calls to GetUnexpiredUploadsAfter. want=%d have=%d", 2, len(calls))
+	}
+}
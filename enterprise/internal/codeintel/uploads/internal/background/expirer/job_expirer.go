@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies"
 	policiesshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -21,6 +22,7 @@ import (
 func NewUploadExpirer(
 	observationCtx *observation.Context,
 	store store.Store,
+	lsifStore lsifstore.Store,
 	repoStore database.RepoStore,
 	policySvc PolicyService,
 	gitserverClient gitserver.Client,
@@ -28,6 +30,7 @@ func NewUploadExpirer(
 ) goroutine.BackgroundRoutine {
 	expirer := &expirer{
 		store:         store,
+		lsifStore:     lsifStore,
 		repoStore:     repoStore,
 		policySvc:     policySvc,
 		policyMatcher: policies.NewMatcher(gitserverClient, policies.RetentionExtractor, true, false),
@@ -44,9 +47,14 @@ func NewUploadExpirer(
 
 type expirer struct {
 	store         store.Store
+	lsifStore     lsifstore.Store
 	repoStore     database.RepoStore
 	policySvc     PolicyService
 	policyMatcher PolicyMatcher
+
+	// lastGlobalStorageBudgetScan is the time the total size of all uploads was last
+	// compared against the global storage budget.
+	lastGlobalStorageBudgetScan time.Time
 }
 
 // handleExpiredUploadsBatch compares the age of upload records against the age of uploads
@@ -55,23 +63,34 @@ type expirer struct {
 // Uploads that are older than the protected retention age are marked as expired. Expired records with
 // no dependents will be removed by the expiredUploadDeleter.
 func (s *expirer) HandleExpiredUploadsBatch(ctx context.Context, metrics *ExpirationMetrics, cfg *Config) (err error) {
+	now := timeutil.Now()
+
+	// The global storage budget spans all repositories, so it's checked independently of the
+	// repository batches below and much less frequently.
+	if cfg.GlobalStorageBudget > 0 && now.Sub(s.lastGlobalStorageBudgetScan) >= cfg.GlobalStorageBudgetInterval {
+		// A failing scan is retried on the next interval rather than on every invocation, and
+		// doesn't hold up the repository batches below.
+		s.lastGlobalStorageBudgetScan = now
+		if budgetErr := s.handleGlobalStorageBudget(ctx, cfg, now, metrics); budgetErr != nil {
+			err = errors.Append(err, errors.Wrap(budgetErr, "handleGlobalStorageBudget"))
+		}
+	}
+
 	// Get the batch of repositories that we'll handle in this invocation of the periodic goroutine. This
 	// set should contain repositories that have yet to be updated, or that have been updated least recently.
 	// This allows us to update every repository reliably, even if it takes a long time to process through
 	// the backlog. Note that this set of repositories require a fresh commit graph, so we're not trying to
 	// process records that have been uploaded but the commits from which they are visible have yet to be
 	// determined (and appearing as if they are visible to no commit).
-	repositories, err := s.store.SetRepositoriesForRetentionScan(ctx, cfg.RepositoryProcessDelay, cfg.RepositoryBatchSize)
-	if err != nil {
-		return errors.Wrap(err, "uploadSvc.SelectRepositoriesForRetentionScan")
+	repositories, repositoriesErr := s.store.SetRepositoriesForRetentionScan(ctx, cfg.RepositoryProcessDelay, cfg.RepositoryBatchSize)
+	if repositoriesErr != nil {
+		return errors.Append(err, errors.Wrap(repositoriesErr, "uploadSvc.SelectRepositoriesForRetentionScan"))
 	}
 	if len(repositories) == 0 {
 		// All repositories updated recently enough
-		return nil
+		return err
 	}
 
-	now := timeutil.Now()
-
 	for _, repositoryID := range repositories {
 		if repositoryErr := s.handleRepository(ctx, repositoryID, cfg, now, metrics); repositoryErr != nil {
 			if err == nil {
@@ -92,7 +111,7 @@ func (s *expirer) handleRepository(ctx context.Context, repositoryID int, cfg *C
 	// never be empty as we have multiple protected data retention policies on the global scope so
 	// that all data visible from a tag or branch tip is protected for at least a short amount of
 	// time after upload.
-	commitMap, err := s.buildCommitMap(ctx, repositoryID, cfg, now)
+	commitMap, policies, err := s.buildCommitMap(ctx, repositoryID, cfg, now)
 	if err != nil {
 		return err
 	}
@@ -127,9 +146,12 @@ func (s *expirer) handleRepository(ctx context.Context, repositoryID int, cfg *C
 			LastRetentionScanBefore: &lastRetentionScanBefore,
 			InCommitGraph:           true,
 		})
-		if err != nil || len(uploads) == 0 {
+		if err != nil {
 			return err
 		}
+		if len(uploads) == 0 {
+			break
+		}
 
 		if err := s.handleUploads(ctx, commitMap, uploads, cfg, metrics, now); err != nil {
 			// Note that we collect errors in the lop of the handleUploads call, but we will still terminate
@@ -138,11 +160,20 @@ func (s *expirer) handleRepository(ctx context.Context, repositoryID int, cfg *C
 			return err
 		}
 	}
+
+	if cfg.RepositoryStorageBudget <= 0 {
+		return nil
+	}
+
+	// Once uploads have been expired by their retention policies, expire the oldest remaining uploads
+	// until the repository fits into its storage budget.
+	protection := newBudgetProtection(s.store, commitMap, policies, cfg)
+	return s.handleStorageBudget(ctx, repositoryID, cfg.RepositoryStorageBudget, protection.isProtected, cfg, metrics)
 }
 
 // buildCommitMap will iterate the complete set of configuration policies that apply to a particular
 // repository and build a map from commits to the policies that apply to them.
-func (s *expirer) buildCommitMap(ctx context.Context, repositoryID int, cfg *Config, now time.Time) (map[string][]policies.PolicyMatch, error) {
+func (s *expirer) buildCommitMap(ctx context.Context, repositoryID int, cfg *Config, now time.Time) (map[string][]policies.PolicyMatch, []policiesshared.ConfigurationPolicy, error) {
 	var (
 		offset   int
 		policies []policiesshared.ConfigurationPolicy
@@ -150,7 +181,7 @@ func (s *expirer) buildCommitMap(ctx context.Context, repositoryID int, cfg *Con
 
 	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	repoName := repo.Name
 
@@ -163,7 +194,7 @@ func (s *expirer) buildCommitMap(ctx context.Context, repositoryID int, cfg *Con
 			Offset:           offset,
 		})
 		if err != nil {
-			return nil, errors.Wrap(err, "policySvc.GetConfigurationPolicies")
+			return nil, nil, errors.Wrap(err, "policySvc.GetConfigurationPolicies")
 		}
 
 		offset += len(policyBatch)
@@ -175,7 +206,12 @@ func (s *expirer) buildCommitMap(ctx context.Context, repositoryID int, cfg *Con
 	}
 
 	// Get the set of commits within this repository that match a data retention policy
-	return s.policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repoName, policies, now)
+	commitMap, err := s.policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repoName, policies, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitMap, policies, nil
 }
 
 func (s *expirer) handleUploads(
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestUploadExpirer(t *testing.T) {
@@ -90,6 +91,31 @@ func TestUploadExpirer(t *testing.T) {
 	}
 }
 
+func TestUploadExpirerGlobalStorageBudgetError(t *testing.T) {
+	store := NewMockStore()
+	store.GetUnexpiredUploadsAfterFunc.SetDefaultReturn(nil, errors.New("uh-oh"))
+	uploadExpirer := &expirer{store: store}
+
+	err := uploadExpirer.HandleExpiredUploadsBatch(context.Background(), NewExpirationMetrics(&observation.TestContext), &Config{
+		RepositoryProcessDelay:      24 * time.Hour,
+		RepositoryBatchSize:         100,
+		UploadBatchSize:             100,
+		GlobalStorageBudget:         1,
+		GlobalStorageBudgetInterval: time.Hour,
+	})
+	if err == nil {
+		t.Fatal("expected an error from handle")
+	}
+
+	// The repository batch is still processed, and the failed scan is not retried right away
+	if calls := store.SetRepositoriesForRetentionScanFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of calls to SetRepositoriesForRetentionScan. want=%d have=%d", 1, len(calls))
+	}
+	if uploadExpirer.lastGlobalStorageBudgetScan.IsZero() {
+		t.Error("expected the global storage budget scan time to be updated")
+	}
+}
+
 func setupMockPolicyService() *MockPolicyService {
 	policies := []policiesshared.ConfigurationPolicy{
 		{ID: 1, RepositoryID: nil},
@@ -123,6 +123,7 @@ func NewCommitGraphUpdater(
 func NewExpirationTasks(
 	observationCtx *observation.Context,
 	store uploadsstore.Store,
+	lsifstore lsifstore.Store,
 	policySvc expirer.PolicyService,
 	gitserverClient gitserver.Client,
 	repoStore database.RepoStore,
@@ -132,6 +133,7 @@ func NewExpirationTasks(
 		expirer.NewUploadExpirer(
 			observationCtx,
 			store,
+			lsifstore,
 			repoStore,
 			policySvc,
 			gitserverClient,
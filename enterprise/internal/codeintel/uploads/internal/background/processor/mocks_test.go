@@ -275,6 +275,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetRepositoriesMaxStaleAge.
 	GetRepositoriesMaxStaleAgeFunc *StoreGetRepositoriesMaxStaleAgeFunc
+	// GetUnexpiredUploadsAfterFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUnexpiredUploadsAfter.
+	GetUnexpiredUploadsAfterFunc *StoreGetUnexpiredUploadsAfterFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *StoreGetUploadByIDFunc
@@ -539,6 +542,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUnexpiredUploadsAfterFunc: &StoreGetUnexpiredUploadsAfterFunc{
+			defaultHook: func(context.Context, int, bool, int, int) (r0 []shared.Upload, r1 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 shared.Upload, r1 bool, r2 error) {
 				return
@@ -866,6 +874,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetRepositoriesMaxStaleAge")
 			},
 		},
+		GetUnexpiredUploadsAfterFunc: &StoreGetUnexpiredUploadsAfterFunc{
+			defaultHook: func(context.Context, int, bool, int, int) ([]shared.Upload, error) {
+				panic("unexpected invocation of MockStore.GetUnexpiredUploadsAfter")
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (shared.Upload, bool, error) {
 				panic("unexpected invocation of MockStore.GetUploadByID")
@@ -1137,6 +1150,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetRepositoriesMaxStaleAgeFunc: &StoreGetRepositoriesMaxStaleAgeFunc{
 			defaultHook: i.GetRepositoriesMaxStaleAge,
 		},
+		GetUnexpiredUploadsAfterFunc: &StoreGetUnexpiredUploadsAfterFunc{
+			defaultHook: i.GetUnexpiredUploadsAfter,
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -4369,6 +4385,125 @@ func (c StoreGetRepositoriesMaxStaleAgeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetUnexpiredUploadsAfterFunc describes the behavior when the
+// GetUnexpiredUploadsAfter method of the parent MockStore instance is
+// invoked.
+type StoreGetUnexpiredUploadsAfterFunc struct {
+	defaultHook func(context.Context, int, bool, int, int) ([]shared.Upload, error)
+	hooks       []func(context.Context, int, bool, int, int) ([]shared.Upload, error)
+	history     []StoreGetUnexpiredUploadsAfterFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnexpiredUploadsAfter delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnexpiredUploadsAfter(v0 context.Context, v1 int, v2 bool, v3 int, v4 int) ([]shared.Upload, error) {
+	r0, r1 := m.GetUnexpiredUploadsAfterFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetUnexpiredUploadsAfterFunc.appendCall(StoreGetUnexpiredUploadsAfterFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnexpiredUploadsAfter method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUnexpiredUploadsAfterFunc) SetDefaultHook(hook func(context.Context, int, bool, int, int) ([]shared.Upload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnexpiredUploadsAfter method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetUnexpiredUploadsAfterFunc) PushHook(hook func(context.Context, int, bool, int, int) ([]shared.Upload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUnexpiredUploadsAfterFunc) SetDefaultReturn(r0 []shared.Upload, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, bool, int, int) ([]shared.Upload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUnexpiredUploadsAfterFunc) PushReturn(r0 []shared.Upload, r1 error) {
+	f.PushHook(func(context.Context, int, bool, int, int) ([]shared.Upload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUnexpiredUploadsAfterFunc) nextHook() func(context.Context, int, bool, int, int) ([]shared.Upload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUnexpiredUploadsAfterFunc) appendCall(r0 StoreGetUnexpiredUploadsAfterFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUnexpiredUploadsAfterFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUnexpiredUploadsAfterFunc) History() []StoreGetUnexpiredUploadsAfterFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUnexpiredUploadsAfterFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUnexpiredUploadsAfterFuncCall is an object that describes an
+// invocation of method GetUnexpiredUploadsAfter on an instance of
+// MockStore.
+type StoreGetUnexpiredUploadsAfterFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUnexpiredUploadsAfterFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUnexpiredUploadsAfterFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockStore instance is invoked.
 type StoreGetUploadByIDFunc struct {
@@ -8669,6 +8804,9 @@ type MockLSIFStore struct {
 	// object controlling the behavior of the method
 	// DeleteUnreferencedDocuments.
 	DeleteUnreferencedDocumentsFunc *LSIFStoreDeleteUnreferencedDocumentsFunc
+	// GetUploadDataSizesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadDataSizes.
+	GetUploadDataSizesFunc *LSIFStoreGetUploadDataSizesFunc
 	// IDsWithMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method IDsWithMeta.
 	IDsWithMetaFunc *LSIFStoreIDsWithMetaFunc
@@ -8708,6 +8846,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		GetUploadDataSizesFunc: &LSIFStoreGetUploadDataSizesFunc{
+			defaultHook: func(context.Context, []int) (r0 map[int]int64, r1 error) {
+				return
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) (r0 []int, r1 error) {
 				return
@@ -8760,6 +8903,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.DeleteUnreferencedDocuments")
 			},
 		},
+		GetUploadDataSizesFunc: &LSIFStoreGetUploadDataSizesFunc{
+			defaultHook: func(context.Context, []int) (map[int]int64, error) {
+				panic("unexpected invocation of MockLSIFStore.GetUploadDataSizes")
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) ([]int, error) {
 				panic("unexpected invocation of MockLSIFStore.IDsWithMeta")
@@ -8808,6 +8956,9 @@ func NewMockLSIFStoreFrom(i lsifstore.Store) *MockLSIFStore {
 		DeleteUnreferencedDocumentsFunc: &LSIFStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: i.DeleteUnreferencedDocuments,
 		},
+		GetUploadDataSizesFunc: &LSIFStoreGetUploadDataSizesFunc{
+			defaultHook: i.GetUploadDataSizes,
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: i.IDsWithMeta,
 		},
@@ -9068,6 +9219,115 @@ func (c LSIFStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreGetUploadDataSizesFunc describes the behavior when the
+// GetUploadDataSizes method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreGetUploadDataSizesFunc struct {
+	defaultHook func(context.Context, []int) (map[int]int64, error)
+	hooks       []func(context.Context, []int) (map[int]int64, error)
+	history     []LSIFStoreGetUploadDataSizesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadDataSizes delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) GetUploadDataSizes(v0 context.Context, v1 []int) (map[int]int64, error) {
+	r0, r1 := m.GetUploadDataSizesFunc.nextHook()(v0, v1)
+	m.GetUploadDataSizesFunc.appendCall(LSIFStoreGetUploadDataSizesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadDataSizes
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreGetUploadDataSizesFunc) SetDefaultHook(hook func(context.Context, []int) (map[int]int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadDataSizes method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreGetUploadDataSizesFunc) PushHook(hook func(context.Context, []int) (map[int]int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreGetUploadDataSizesFunc) SetDefaultReturn(r0 map[int]int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) (map[int]int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreGetUploadDataSizesFunc) PushReturn(r0 map[int]int64, r1 error) {
+	f.PushHook(func(context.Context, []int) (map[int]int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreGetUploadDataSizesFunc) nextHook() func(context.Context, []int) (map[int]int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreGetUploadDataSizesFunc) appendCall(r0 LSIFStoreGetUploadDataSizesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreGetUploadDataSizesFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreGetUploadDataSizesFunc) History() []LSIFStoreGetUploadDataSizesFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreGetUploadDataSizesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreGetUploadDataSizesFuncCall is an object that describes an
+// invocation of method GetUploadDataSizes on an instance of MockLSIFStore.
+type LSIFStoreGetUploadDataSizesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreGetUploadDataSizesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreGetUploadDataSizesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreIDsWithMetaFunc describes the behavior when the IDsWithMeta
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreIDsWithMetaFunc struct {
@@ -1,14 +1,12 @@
 package processor
 
 import (
-	"bytes"
 	"context"
 	"io"
 	"sort"
 
 	"github.com/sourcegraph/scip/bindings/go/scip"
 	"go.opentelemetry.io/otel/attribute"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
@@ -20,48 +18,66 @@ import (
 // correlateSCIP reads the content of the given reader as a SCIP index object. The index is processed in
 // the background, and processed documents are emitted on a channel to be persisted to the database.
 //
+// The index is never held in memory as a whole. Documents are spilled to a temporary file while the
+// metadata and external symbols are collected, and are then decoded and emitted one path at a time.
+//
 // **NOTE TO CONSUMERS OF THIS FUNCTION** (see `readPackageAndPackageReferences` for a concrete impl):
 //
 // As a side-effect of processing documents, a symbol map is built to determine which symbols should
 // be advertised as part of our cross-index/cross-repository metadata. Consumers must expect to consume
 // the set of processed documents *before* accessing the package or package reference channels - they
 // will not be written to until the documents channel has been closed. Consumers should process both
-// package and package reference channels concurrently.
+// package and package reference channels concurrently. Once the documents channel has been closed,
+// consumers must check the returned `Err` function for errors that occurred while reading documents.
 func correlateSCIP(
 	ctx context.Context,
 	r io.Reader,
-	rSize int64,
 	root string,
 	getChildren pathexistence.GetChildrenFunc,
-) (lsifstore.ProcessedSCIPData, error) {
-	index, err := readIndex(r, rSize)
+) (_ lsifstore.ProcessedSCIPData, err error) {
+	index, err := readSpilledIndex(r)
 	if err != nil {
 		return lsifstore.ProcessedSCIPData{}, err
 	}
+	defer func() {
+		if err != nil {
+			_ = index.Close()
+		}
+	}()
 
-	ignorePaths, err := ignorePaths(ctx, index.Documents, root, getChildren)
+	ignorePaths, err := ignorePaths(ctx, index.Paths(), root, getChildren)
 	if err != nil {
 		return lsifstore.ProcessedSCIPData{}, err
 	}
 
 	var (
-		documents             = make(chan lsifstore.ProcessedSCIPDocument)
-		packages              = make(chan precise.Package)
-		packageReferences     = make(chan precise.PackageReference)
-		externalSymbolsByName = readExternalSymbols(index)
+		documents         = make(chan lsifstore.ProcessedSCIPDocument)
+		packages          = make(chan precise.Package)
+		packageReferences = make(chan precise.PackageReference)
+		documentsErr      error
 	)
 
 	go func() {
+		defer index.Close()
 		defer close(documents)
 
 		packageSet := map[precise.Package]bool{}
-		for _, document := range scip.SortDocuments(scip.FlattenDocuments(index.Documents)) {
-			if _, ok := ignorePaths[document.RelativePath]; ok {
+		for _, group := range index.DocumentGroups() {
+			if _, ok := ignorePaths[group[0].path]; ok {
 				continue
 			}
 
+			document, err := index.ReadDocument(group)
+			if err != nil {
+				// Packages are incomplete, consumers will stop at the error instead
+				documentsErr = err
+				close(packages)
+				close(packageReferences)
+				return
+			}
+
 			select {
-			case documents <- processDocument(document, externalSymbolsByName):
+			case documents <- processDocument(document, index.ExternalSymbolsByName):
 			case <-ctx.Done():
 				return
 			}
@@ -133,6 +149,7 @@ func correlateSCIP(
 		Documents:         documents,
 		Packages:          packages,
 		PackageReferences: packageReferences,
+		Err:               func() error { return documentsErr },
 	}, nil
 }
 
@@ -187,70 +204,24 @@ loop:
 	return packages, packageReferences, nil
 }
 
-// readIndex unmarshals a SCIP index from the given reader. The given reader is in practice
-// a gzip deflate layer. We pass the _uncompressed_ size of the reader's payload, which we
-// store at upload time, as a hint to the total buffer size we'll be returning. If this value
-// is undersized, the standard slice resizing behavior (symmetric to io.ReadAll) is used.
-func readIndex(r io.Reader, n int64) (*scip.Index, error) {
-	content, err := readAllWithSizeHint(r, n)
-	if err != nil {
-		return nil, err
-	}
-
-	var index scip.Index
-	if err := proto.Unmarshal(content, &index); err != nil {
-		return nil, err
-	}
-
-	return &index, nil
-}
-
-// Copied from io.ReadAll, but uses the given initial size for the buffer to
-// attempt to reduce temporary slice allocations during large reads. If the
-// given size is zero, then this function has the same behavior as io.ReadAll.
-func readAllWithSizeHint(r io.Reader, n int64) ([]byte, error) {
-	if n == 0 {
-		return io.ReadAll(r)
-	}
-
-	buf := bytes.NewBuffer(make([]byte, 0, n))
-	_, err := io.Copy(buf, r)
-	return buf.Bytes(), err
-}
-
-// ignorePaths returns a set consisting of the relative paths of documents in the give
-// slice that are not resolvable via Git.
-func ignorePaths(ctx context.Context, documents []*scip.Document, root string, getChildren pathexistence.GetChildrenFunc) (map[string]struct{}, error) {
-	paths := make([]string, 0, len(documents))
-	for _, document := range documents {
-		paths = append(paths, document.RelativePath)
-	}
-
+// ignorePaths returns a set consisting of the given relative document paths that are not
+// resolvable via Git.
+func ignorePaths(ctx context.Context, paths []string, root string, getChildren pathexistence.GetChildrenFunc) (map[string]struct{}, error) {
 	checker, err := pathexistence.NewExistenceChecker(ctx, root, paths, getChildren)
 	if err != nil {
 		return nil, err
 	}
 
 	ignorePathMap := map[string]struct{}{}
-	for _, document := range documents {
-		if !checker.Exists(document.RelativePath) {
-			ignorePathMap[document.RelativePath] = struct{}{}
+	for _, path := range paths {
+		if !checker.Exists(path) {
+			ignorePathMap[path] = struct{}{}
 		}
 	}
 
 	return ignorePathMap, nil
 }
 
-// readExternalSymbols inverts the external symbols from the given index into a map keyed by name.
-func readExternalSymbols(index *scip.Index) map[string]*scip.SymbolInformation {
-	externalSymbolsByName := make(map[string]*scip.SymbolInformation, len(index.ExternalSymbols))
-	for _, symbol := range index.ExternalSymbols {
-		externalSymbolsByName[symbol.Symbol] = symbol
-	}
-
-	return externalSymbolsByName
-}
-
 // processDocument canonicalizes and serializes the given document for persistence.
 func processDocument(document *scip.Document, externalSymbolsByName map[string]*scip.SymbolInformation) lsifstore.ProcessedSCIPDocument {
 	// Stash path here as canonicalization removes it
@@ -388,6 +359,9 @@ func writeSCIPData(
 
 			numDocuments += 1
 		}
+		if err := correlatedSCIPData.Err(); err != nil {
+			return err
+		}
 		trace.AddEvent("TODO Domain Owner", attribute.Int64("numDocuments", int64(numDocuments)))
 
 		count, err := scipWriter.Flush(ctx)
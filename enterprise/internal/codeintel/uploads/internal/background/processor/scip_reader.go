@@ -0,0 +1,264 @@
+package processor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Field numbers of the SCIP protobuf messages that are decoded by hand while streaming
+// an index. See https://github.com/sourcegraph/scip/blob/main/scip.proto.
+const (
+	scipIndexMetadataFieldNumber        protowire.Number = 1
+	scipIndexDocumentsFieldNumber       protowire.Number = 2
+	scipIndexExternalSymbolsFieldNumber protowire.Number = 3
+	scipDocumentRelativePathFieldNumber protowire.Number = 1
+)
+
+// maxSCIPFieldSize is the largest length-delimited field we'll read from an index. The
+// protobuf wire format does not allow a single message larger than 2GB.
+const maxSCIPFieldSize = math.MaxInt32
+
+// spilledIndex is a SCIP index whose documents have been written to a temporary file
+// rather than kept in memory. The metadata and external symbols, which are needed to
+// process every document, are held in memory.
+type spilledIndex struct {
+	Metadata              *scip.Metadata
+	ExternalSymbolsByName map[string]*scip.SymbolInformation
+
+	file      *os.File
+	documents []spilledDocument
+}
+
+// spilledDocument is the location of a single encoded document in the spill file.
+type spilledDocument struct {
+	path   string
+	offset int64
+	length int
+}
+
+// readSpilledIndex reads a SCIP index from the given reader one top-level field at a time.
+// Encoded documents are copied to a temporary file along with their relative path so that
+// they can be decoded one at a time later. This keeps memory usage proportional to the
+// size of the largest document instead of the size of the index. The caller must close
+// the returned index to release the temporary file.
+func readSpilledIndex(r io.Reader) (_ *spilledIndex, err error) {
+	file, err := os.CreateTemp("", "scip-documents-*")
+	if err != nil {
+		return nil, err
+	}
+	// Unlink the file immediately so that it's cleaned up once closed, even if this
+	// process does not get a chance to clean up after itself.
+	if err := os.Remove(file.Name()); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+		}
+	}()
+
+	index := &spilledIndex{
+		Metadata:              &scip.Metadata{},
+		ExternalSymbolsByName: map[string]*scip.SymbolInformation{},
+		file:                  file,
+	}
+
+	var (
+		br     = bufio.NewReader(r)
+		bw     = bufio.NewWriter(file)
+		buf    []byte
+		offset int64
+	)
+
+	for {
+		tag, err := binary.ReadUvarint(br)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "reading field tag")
+		}
+		fieldNumber, wireType := protowire.DecodeTag(tag)
+
+		if wireType != protowire.BytesType {
+			if err := skipSCIPField(br, wireType); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading field length")
+		}
+		if size > maxSCIPFieldSize {
+			return nil, errors.Newf("field %d is too large (%d bytes)", fieldNumber, size)
+		}
+		if uint64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, errors.Wrapf(err, "reading field %d", fieldNumber)
+		}
+
+		switch fieldNumber {
+		case scipIndexMetadataFieldNumber:
+			// Repeated occurrences of a singular message field are merged
+			if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(buf, index.Metadata); err != nil {
+				return nil, errors.Wrap(err, "decoding metadata")
+			}
+
+		case scipIndexDocumentsFieldNumber:
+			path, err := documentRelativePath(buf)
+			if err != nil {
+				return nil, errors.Wrap(err, "decoding document path")
+			}
+			if _, err := bw.Write(buf); err != nil {
+				return nil, errors.Wrap(err, "spilling document")
+			}
+
+			index.documents = append(index.documents, spilledDocument{
+				path:   path,
+				offset: offset,
+				length: len(buf),
+			})
+			offset += int64(len(buf))
+
+		case scipIndexExternalSymbolsFieldNumber:
+			var symbol scip.SymbolInformation
+			if err := proto.Unmarshal(buf, &symbol); err != nil {
+				return nil, errors.Wrap(err, "decoding external symbol")
+			}
+			index.ExternalSymbolsByName[symbol.Symbol] = &symbol
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return nil, errors.Wrap(err, "spilling documents")
+	}
+
+	return index, nil
+}
+
+// skipSCIPField discards the value of a non length-delimited field from the given reader.
+func skipSCIPField(r *bufio.Reader, wireType protowire.Type) error {
+	var err error
+	switch wireType {
+	case protowire.VarintType:
+		_, err = binary.ReadUvarint(r)
+	case protowire.Fixed32Type:
+		_, err = r.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = r.Discard(8)
+	default:
+		return errors.Newf("unsupported wire type %d", wireType)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return errors.Wrap(err, "skipping field")
+}
+
+// documentRelativePath extracts the relative path from an encoded document without
+// decoding its occurrences and symbols.
+func documentRelativePath(b []byte) (path string, _ error) {
+	for len(b) > 0 {
+		fieldNumber, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if fieldNumber == scipDocumentRelativePathFieldNumber && wireType == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return "", protowire.ParseError(n)
+			}
+			b = b[n:]
+
+			// The last value wins for singular fields
+			path = string(value)
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(fieldNumber, wireType, b)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+
+	return path, nil
+}
+
+// Paths returns the relative paths of all documents in the index.
+func (i *spilledIndex) Paths() []string {
+	paths := make([]string, 0, len(i.documents))
+	for _, document := range i.documents {
+		paths = append(paths, document.path)
+	}
+
+	return paths
+}
+
+// DocumentGroups returns the spilled documents grouped by relative path, ordered by path.
+// Within a group, documents retain the order in which they occurred in the index.
+func (i *spilledIndex) DocumentGroups() [][]spilledDocument {
+	documents := make([]spilledDocument, len(i.documents))
+	copy(documents, i.documents)
+	sort.SliceStable(documents, func(a, b int) bool { return documents[a].path < documents[b].path })
+
+	var groups [][]spilledDocument
+	for len(documents) > 0 {
+		n := 1
+		for n < len(documents) && documents[n].path == documents[0].path {
+			n++
+		}
+
+		groups = append(groups, documents[:n:n])
+		documents = documents[n:]
+	}
+
+	return groups
+}
+
+// ReadDocument decodes the given group of spilled documents, which all share the same
+// relative path, into a single document.
+func (i *spilledIndex) ReadDocument(group []spilledDocument) (*scip.Document, error) {
+	documents := make([]*scip.Document, 0, len(group))
+	for _, spilled := range group {
+		buf := make([]byte, spilled.length)
+		if _, err := i.file.ReadAt(buf, spilled.offset); err != nil {
+			return nil, errors.Wrapf(err, "reading document %q", spilled.path)
+		}
+
+		var document scip.Document
+		if err := proto.Unmarshal(buf, &document); err != nil {
+			return nil, errors.Wrapf(err, "decoding document %q", spilled.path)
+		}
+		documents = append(documents, &document)
+	}
+
+	if len(documents) == 1 {
+		return documents[0], nil
+	}
+	return scip.FlattenDocuments(documents)[0], nil
+}
+
+// Close removes the spilled documents.
+func (i *spilledIndex) Close() error {
+	return i.file.Close()
+}
@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestReadSpilledIndex(t *testing.T) {
+	index := &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo: &scip.ToolInfo{Name: "scip-test", Version: "1.0.0"},
+		},
+		Documents: []*scip.Document{
+			{RelativePath: "b.go", Occurrences: []*scip.Occurrence{{Symbol: "local 1"}}},
+			{RelativePath: "a.go", Occurrences: []*scip.Occurrence{{Symbol: "local 2"}}},
+			{RelativePath: "b.go", Occurrences: []*scip.Occurrence{{Symbol: "local 3"}}},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: "scip-test go ext 1.0.0 ext/Ext#"},
+		},
+	}
+	content, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fields we don't know about are skipped
+	content = protowire.AppendTag(content, 15, protowire.VarintType)
+	content = protowire.AppendVarint(content, 42)
+	content = protowire.AppendTag(content, 16, protowire.BytesType)
+	content = protowire.AppendBytes(content, []byte("unknown"))
+
+	spilled, err := readSpilledIndex(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error reading index: %s", err)
+	}
+	defer spilled.Close()
+
+	if diff := cmp.Diff(index.Metadata, spilled.Metadata, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+	if _, ok := spilled.ExternalSymbolsByName["scip-test go ext 1.0.0 ext/Ext#"]; !ok || len(spilled.ExternalSymbolsByName) != 1 {
+		t.Errorf("unexpected external symbols %v", spilled.ExternalSymbolsByName)
+	}
+	if diff := cmp.Diff([]string{"b.go", "a.go", "b.go"}, spilled.Paths()); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	var documents []*scip.Document
+	for _, group := range spilled.DocumentGroups() {
+		document, err := spilled.ReadDocument(group)
+		if err != nil {
+			t.Fatalf("unexpected error reading document: %s", err)
+		}
+		documents = append(documents, document)
+	}
+
+	expectedDocuments := []*scip.Document{
+		{RelativePath: "a.go", Occurrences: []*scip.Occurrence{{Symbol: "local 2"}}},
+		{RelativePath: "b.go", Occurrences: []*scip.Occurrence{{Symbol: "local 1"}, {Symbol: "local 3"}}},
+	}
+	if diff := cmp.Diff(expectedDocuments, documents, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadSpilledIndexTruncated(t *testing.T) {
+	content, err := proto.Marshal(&scip.Index{
+		Documents: []*scip.Document{{RelativePath: "a.go"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readSpilledIndex(bytes.NewReader(content[:len(content)-1])); err == nil {
+		t.Fatal("expected an error reading a truncated index")
+	}
+}
@@ -31,14 +31,8 @@ func TestCorrelateSCIP(t *testing.T) {
 		return r
 	}
 
-	content, err := io.ReadAll(testReader())
-	if err != nil {
-		t.Fatalf("unexpected error reading test reader: %s", err)
-	}
-	n := int64(len(content))
-
 	// Correlate and consume channels from returned object
-	correlatedSCIPData, err := correlateSCIP(ctx, testReader(), n, "", func(ctx context.Context, dirnames []string) (map[string][]string, error) {
+	correlatedSCIPData, err := correlateSCIP(ctx, testReader(), "", func(ctx context.Context, dirnames []string) (map[string][]string, error) {
 		return scipDirectoryChildren, nil
 	})
 	if err != nil {
@@ -48,6 +42,9 @@ func TestCorrelateSCIP(t *testing.T) {
 	for document := range correlatedSCIPData.Documents {
 		documents = append(documents, document)
 	}
+	if err := correlatedSCIPData.Err(); err != nil {
+		t.Fatalf("unexpected error reading documents: %s", err)
+	}
 	packages, packageReferences, err := readPackageAndPackageReferences(ctx, correlatedSCIPData)
 	if err != nil {
 		t.Fatalf("unexpected error reading processed SCIP: %s", err)
@@ -32,6 +32,10 @@ type ProcessedSCIPData struct {
 	Documents         <-chan ProcessedSCIPDocument
 	Packages          <-chan precise.Package
 	PackageReferences <-chan precise.PackageReference
+
+	// Err returns the error that stopped the production of documents early, if any.
+	// It must only be called once the documents channel has been closed.
+	Err func() error
 }
 
 type ProcessedMetadata struct {
@@ -150,6 +154,7 @@ type scipWriter struct {
 	symbolNameInserter *batch.Inserter
 	symbolInserter     *batch.Inserter
 	count              uint32
+	dataSize           int64
 	batchPayloadSum    int
 	batch              []bufferedDocument
 }
@@ -190,6 +195,7 @@ func (s *scipWriter) InsertDocument(ctx context.Context, path string, scipDocume
 		payloadHash:  hashPayload(payload),
 	})
 	s.batchPayloadSum += len(payload)
+	s.dataSize += int64(len(path) + len(compressedPayload))
 
 	if len(s.batch) >= DocumentsBatchSize {
 		if err := s.flush(ctx); err != nil {
@@ -319,6 +325,7 @@ func (s *scipWriter) flush(ctx context.Context) error {
 		if err := s.symbolNameInserter.Insert(ctx, id, prefix, parentID); err != nil {
 			return err
 		}
+		s.dataSize += int64(len(prefix))
 
 		return nil
 	}); err != nil {
@@ -362,6 +369,7 @@ func (s *scipWriter) flush(ctx context.Context) error {
 			}
 
 			atomic.AddUint32(&s.count, 1)
+			s.dataSize += int64(len(definitionRanges) + len(referenceRanges) + len(implementationRanges) + len(typeDefinitionRanges))
 		}
 	}
 
@@ -398,6 +406,11 @@ func (s *scipWriter) Flush(ctx context.Context) (uint32, error) {
 		return 0, err
 	}
 
+	// Record the size of the data we've written so that uploads can be expired by storage budget
+	if err := s.db.Exec(ctx, sqlf.Sprintf(scipWriterFlushDataSizeQuery, s.dataSize, s.uploadID)); err != nil {
+		return 0, err
+	}
+
 	return s.count, nil
 }
 
@@ -439,6 +452,12 @@ SELECT
 FROM t_codeintel_scip_symbols source
 `
 
+const scipWriterFlushDataSizeQuery = `
+UPDATE codeintel_scip_metadata
+SET data_size = %s
+WHERE upload_id = %s
+`
+
 // hashPayload returns a sha256 checksum of the given payload.
 func hashPayload(payload []byte) []byte {
 	hash := sha256.New()
@@ -15,6 +15,7 @@ type operations struct {
 	deleteLsifDataByUploadIds                 *observation.Operation
 	deleteUnreferencedDocuments               *observation.Operation
 	insertDefinitionsAndReferencesForDocument *observation.Operation
+	getUploadDataSizes                        *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -45,5 +46,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		deleteLsifDataByUploadIds:                 op("DeleteLsifDataByUploadIds"),
 		deleteUnreferencedDocuments:               op("DeleteUnreferencedDocuments"),
 		insertDefinitionsAndReferencesForDocument: op("InsertDefinitionsAndReferencesForDocument"),
+		getUploadDataSizes:                        op("GetUploadDataSizes"),
 	}
 }
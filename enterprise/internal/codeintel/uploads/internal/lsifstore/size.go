@@ -0,0 +1,76 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUploadDataSizes returns a map from upload identifiers to the approximate number of bytes
+// the upload's data occupies in the codeintel-db. Uploads without SCIP data are absent from the
+// returned map.
+//
+// The size of uploads written before sizes were tracked is calculated from the stored data and
+// saved so that it's only calculated once. Documents shared between uploads are counted towards
+// every upload referencing them.
+func (s *store) GetUploadDataSizes(ctx context.Context, ids []int) (_ map[int]int64, err error) {
+	ctx, _, endObservation := s.operations.getUploadDataSizes.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numIDs", len(ids)),
+		otlog.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return scanUploadDataSizes(s.db.Query(ctx, sqlf.Sprintf(
+		getUploadDataSizesQuery,
+		pq.Array(ids),
+		pq.Array(ids),
+	)))
+}
+
+const getUploadDataSizesQuery = `
+WITH
+backfilled AS (
+	UPDATE codeintel_scip_metadata m
+	SET data_size = (
+		SELECT COALESCE(SUM(octet_length(dl.document_path) + octet_length(d.raw_scip_payload)), 0)
+		FROM codeintel_scip_document_lookup dl
+		JOIN codeintel_scip_documents d ON d.id = dl.document_id
+		WHERE dl.upload_id = m.upload_id
+	) + (
+		SELECT COALESCE(SUM(octet_length(sn.name_segment)), 0)
+		FROM codeintel_scip_symbol_names sn
+		WHERE sn.upload_id = m.upload_id
+	) + (
+		SELECT COALESCE(SUM(
+			COALESCE(octet_length(ss.definition_ranges), 0) +
+			COALESCE(octet_length(ss.reference_ranges), 0) +
+			COALESCE(octet_length(ss.implementation_ranges), 0) +
+			COALESCE(octet_length(ss.type_definition_ranges), 0)
+		), 0)
+		FROM codeintel_scip_symbols ss
+		WHERE ss.upload_id = m.upload_id
+	)
+	WHERE m.upload_id = ANY(%s) AND m.data_size IS NULL
+	RETURNING m.upload_id, m.data_size
+)
+SELECT upload_id, data_size FROM backfilled
+UNION ALL
+SELECT m.upload_id, m.data_size
+FROM codeintel_scip_metadata m
+WHERE m.upload_id = ANY(%s) AND m.data_size IS NOT NULL
+`
+
+var scanUploadDataSizes = basestore.NewMapScanner(func(s dbutil.Scanner) (uploadID int, size int64, _ error) {
+	err := s.Scan(&uploadID, &size)
+	return uploadID, size, err
+})
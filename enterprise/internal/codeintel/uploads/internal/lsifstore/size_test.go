@@ -0,0 +1,72 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUploadDataSizes(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := newInternal(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	if err := store.WithTransaction(ctx, func(tx Store) error {
+		if err := tx.InsertMetadata(ctx, 42, ProcessedMetadata{TextDocumentEncoding: "UTF8", ToolName: "scip-test"}); err != nil {
+			return err
+		}
+
+		scipWriter, err := tx.NewSCIPWriter(ctx, 42)
+		if err != nil {
+			return err
+		}
+		if err := scipWriter.InsertDocument(ctx, "internal/util.go", &scip.Document{
+			Symbols: []*scip.SymbolInformation{
+				{Symbol: "foo.bar.ident"},
+			},
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{3, 25, 3, 30}, Symbol: "foo.bar.ident", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{4, 25, 4, 30}, Symbol: "foo.bar.ident"},
+			},
+		}); err != nil {
+			return err
+		}
+
+		_, err = scipWriter.Flush(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to write SCIP data: %s", err)
+	}
+
+	sizes, err := store.GetUploadDataSizes(ctx, []int{42, 43})
+	if err != nil {
+		t.Fatalf("unexpected error getting upload data sizes: %s", err)
+	}
+	trackedSize, ok := sizes[42]
+	if !ok || trackedSize <= 0 {
+		t.Fatalf("expected a positive size for upload 42, have %v", sizes)
+	}
+	if _, ok := sizes[43]; ok {
+		t.Fatalf("unexpected size for upload without SCIP data")
+	}
+
+	// Sizes of uploads written before sizes were tracked are backfilled from the stored data
+	if err := store.db.Exec(ctx, sqlf.Sprintf(`UPDATE codeintel_scip_metadata SET data_size = NULL`)); err != nil {
+		t.Fatalf("unexpected error clearing data size: %s", err)
+	}
+	sizes, err = store.GetUploadDataSizes(ctx, []int{42})
+	if err != nil {
+		t.Fatalf("unexpected error getting upload data sizes: %s", err)
+	}
+	if sizes[42] != trackedSize {
+		t.Fatalf("unexpected backfilled size. want=%d have=%d", trackedSize, sizes[42])
+	}
+}
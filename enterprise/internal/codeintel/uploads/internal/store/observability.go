@@ -38,6 +38,7 @@ type operations struct {
 	// Uploads
 	getIndexers                          *observation.Operation
 	getUploads                           *observation.Operation
+	getUnexpiredUploadsAfter             *observation.Operation
 	getUploadByID                        *observation.Operation
 	getUploadsByIDs                      *observation.Operation
 	getVisibleUploadsMatchingMonikers    *observation.Operation
@@ -144,6 +145,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		// Uploads
 		getIndexers:                          op("GetIndexers"),
 		getUploads:                           op("GetUploads"),
+		getUnexpiredUploadsAfter:             op("GetUnexpiredUploadsAfter"),
 		getUploadByID:                        op("GetUploadByID"),
 		getUploadsByIDs:                      op("GetUploadsByIDs"),
 		getVisibleUploadsMatchingMonikers:    op("GetVisibleUploadsMatchingMonikers"),
@@ -22,6 +22,7 @@ type Store interface {
 
 	// Upload records
 	GetUploads(ctx context.Context, opts shared.GetUploadsOptions) ([]shared.Upload, int, error)
+	GetUnexpiredUploadsAfter(ctx context.Context, repositoryID int, inCommitGraph bool, afterID, limit int) ([]shared.Upload, error)
 	GetUploadByID(ctx context.Context, id int) (shared.Upload, bool, error)
 	GetDumpsByIDs(ctx context.Context, ids []int) ([]shared.Dump, error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) ([]shared.Upload, error)
@@ -85,6 +85,41 @@ func (s *store) GetUploads(ctx context.Context, opts shared.GetUploadsOptions) (
 	return a, b, err
 }
 
+// GetUnexpiredUploadsAfter returns up to limit completed and unexpired uploads of the given repository
+// (or of all repositories if repositoryID is zero) with an identifier greater than afterID, ordered by
+// identifier. Identifiers are assigned in upload order, so passing the identifier of the last upload of
+// the previous batch pages through the uploads oldest first without counting or skipping rows.
+func (s *store) GetUnexpiredUploadsAfter(ctx context.Context, repositoryID int, inCommitGraph bool, afterID, limit int) (_ []shared.Upload, err error) {
+	ctx, _, endObservation := s.operations.getUnexpiredUploadsAfter.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Bool("inCommitGraph", inCommitGraph),
+		log.Int("afterID", afterID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tableExpr, conds, cte := buildGetConditionsAndCte(shared.GetUploadsOptions{
+		State:         "completed",
+		RepositoryID:  repositoryID,
+		InCommitGraph: inCommitGraph,
+	})
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, err
+	}
+	conds = append(conds, authzConds, sqlf.Sprintf("u.id > %s", afterID))
+
+	return scanUploadComplete(s.db.Query(ctx, sqlf.Sprintf(
+		getUploadsSelectQuery,
+		buildCTEPrefix(cte),
+		tableExpr,
+		sqlf.Join(conds, " AND "),
+		sqlf.Sprintf("u.id"),
+		limit,
+		0,
+	)))
+}
+
 const getUploadsSelectQuery = `
 %s -- Dynamic CTE definitions for use in the WHERE clause
 SELECT
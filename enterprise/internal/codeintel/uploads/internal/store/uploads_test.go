@@ -466,6 +466,44 @@ func TestGetUploadsByIDs(t *testing.T) {
 	})
 }
 
+func TestGetUnexpiredUploadsAfter(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db,
+		shared.Upload{ID: 1},
+		shared.Upload{ID: 2},
+		shared.Upload{ID: 3}, // expired
+		shared.Upload{ID: 4, State: "queued"},
+		shared.Upload{ID: 5},
+		shared.Upload{ID: 6, RepositoryID: 51},
+	)
+	if _, err := db.ExecContext(ctx, "UPDATE lsif_uploads SET expired = true WHERE id = 3"); err != nil {
+		t.Fatalf("unexpected error marking upload as expired: %s", err)
+	}
+
+	var ids []int
+	for afterID := 0; ; {
+		uploads, err := store.GetUnexpiredUploadsAfter(ctx, 50, false, afterID, 2)
+		if err != nil {
+			t.Fatalf("unexpected error getting uploads: %s", err)
+		}
+		if len(uploads) == 0 {
+			break
+		}
+		for _, upload := range uploads {
+			ids = append(ids, upload.ID)
+		}
+		afterID = uploads[len(uploads)-1].ID
+	}
+
+	if diff := cmp.Diff([]int{1, 2, 5}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+}
+
 func TestGetVisibleUploadsMatchingMonikers(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
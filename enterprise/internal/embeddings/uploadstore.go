@@ -28,15 +28,22 @@ type EmbeddingsUploadStoreConfig struct {
 	GCSProjectID               string
 	GCSCredentialsFile         string
 	GCSCredentialsFileContents string
+
+	AzureAccountName             string
+	AzureAccountKey              string
+	AzureManagedIdentityClientID string
+	AzureEndpoint                string
+
+	FilesystemRoot string
 }
 
 func (c *EmbeddingsUploadStoreConfig) Load() {
-	c.Backend = strings.ToLower(c.Get("EMBEDDINGS_UPLOAD_BACKEND", "blobstore", "The target file service for embeddings. S3, GCS, and Blobstore are supported."))
+	c.Backend = strings.ToLower(c.Get("EMBEDDINGS_UPLOAD_BACKEND", "blobstore", "The target file service for embeddings. S3, GCS, Azure, Filesystem, and Blobstore are supported."))
 	c.ManageBucket = c.GetBool("EMBEDDINGS_UPLOAD_MANAGE_BUCKET", "false", "Whether or not the client should manage the target bucket configuration.")
 	c.Bucket = c.Get("EMBEDDINGS_UPLOAD_BUCKET", "embeddings", "The name of the bucket to store embeddings in.")
 
-	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" {
-		c.AddError(errors.Errorf("invalid backend %q for EMBEDDINGS_UPLOAD_BACKEND: must be S3, GCS, or Blobstore", c.Backend))
+	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" && c.Backend != "azure" && c.Backend != "filesystem" {
+		c.AddError(errors.Errorf("invalid backend %q for EMBEDDINGS_UPLOAD_BACKEND: must be S3, GCS, Azure, Filesystem, or Blobstore", c.Backend))
 	}
 
 	if c.Backend == "blobstore" || c.Backend == "s3" {
@@ -54,6 +61,13 @@ func (c *EmbeddingsUploadStoreConfig) Load() {
 		c.GCSProjectID = c.Get("EMBEDDINGS_UPLOAD_GCP_PROJECT_ID", "", "The project containing the GCS bucket.")
 		c.GCSCredentialsFile = c.GetOptional("EMBEDDINGS_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE", "The path to a service account key file with access to GCS.")
 		c.GCSCredentialsFileContents = c.GetOptional("EMBEDDINGS_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE_CONTENT", "The contents of a service account key file with access to GCS.")
+	} else if c.Backend == "azure" {
+		c.AzureAccountName = c.Get("EMBEDDINGS_UPLOAD_AZURE_ACCOUNT_NAME", "", "The storage account containing the Azure Blob Storage container.")
+		c.AzureAccountKey = c.GetOptional("EMBEDDINGS_UPLOAD_AZURE_ACCOUNT_KEY", "An access key of the storage account. If unset, the managed identity of the host is used.")
+		c.AzureManagedIdentityClientID = c.GetOptional("EMBEDDINGS_UPLOAD_AZURE_MANAGED_IDENTITY_CLIENT_ID", "The client ID of a user-assigned managed identity with access to the storage account.")
+		c.AzureEndpoint = c.GetOptional("EMBEDDINGS_UPLOAD_AZURE_ENDPOINT", "The blob service endpoint of the storage account. Defaults to https://<account>.blob.core.windows.net.")
+	} else if c.Backend == "filesystem" {
+		c.FilesystemRoot = c.Get("EMBEDDINGS_UPLOAD_FILESYSTEM_ROOT", "", "The directory in which embeddings are stored.")
 	}
 }
 
@@ -77,6 +91,15 @@ func NewEmbeddingsUploadStore(ctx context.Context, observationCtx *observation.C
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
 		},
+		Azure: uploadstore.AzureConfig{
+			AccountName:             conf.AzureAccountName,
+			AccountKey:              conf.AzureAccountKey,
+			ManagedIdentityClientID: conf.AzureManagedIdentityClientID,
+			Endpoint:                conf.AzureEndpoint,
+		},
+		Filesystem: uploadstore.FilesystemConfig{
+			Root: conf.FilesystemRoot,
+		},
 	}
 	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "embeddings", "uploadstore"))
 }
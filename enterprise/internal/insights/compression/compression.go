@@ -85,8 +85,17 @@ func (g *gitserverFilter) Filter(ctx context.Context, sampleTimes []time.Time, n
 
 	executions := make(map[api.CommitID]*QueryExecution)
 	prev := ""
+	exhausted := false
 	for _, sampleTime := range sampleTimes {
+		if exhausted {
+			// there were no commits at a more recent sample time, so there can't be any at this one either.
+			nodes = append(nodes, QueryExecution{RecordingTime: sampleTime})
+			continue
+		}
 		commit, got, err := getCommit(sampleTime, prev)
+		if err == nil && !got {
+			exhausted = true
+		}
 		if err != nil || !got {
 			// if for some reason we aren't able to figure this out right now we will fall back to uncompressed points.
 			// This is somewhat a left over from a historical version where not every commit would have compression data,
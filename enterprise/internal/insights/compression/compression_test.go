@@ -73,6 +73,15 @@ func Test_GitserverFilter(t *testing.T) {
 			times: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
 		},
+		{
+			name:              "no commits are looked up before a point without commits",
+			want:              autogold.Expect(`{"Executions":[{"Revision":"","RecordingTime":"2021-01-01T00:00:00Z","SharedRecordings":null},{"Revision":"","RecordingTime":"2021-02-01T00:00:00Z","SharedRecordings":null},{"Revision":"3","RecordingTime":"2021-03-01T00:00:00Z","SharedRecordings":null},{"Revision":"4","RecordingTime":"2021-04-01T00:00:00Z","SharedRecordings":null}],"RecordCount":4}`),
+			fakeCommitFetcher: buildFakeFetcher("1", nil, "3", "4"),
+			times: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -91,7 +100,7 @@ func Test_GitserverFilter(t *testing.T) {
 }
 
 // buildFakeFetcher returns a fake commit fetcher where each element in the input slice maps to a distinct timestamp in the provided order. Input
-// can be either string (representing a hash) or an error
+// can be either string (representing a hash) or an error. Any other value leaves a timestamp without commits.
 func buildFakeFetcher(input ...any) fakeCommitFetcher {
 	current := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 	fetcher := fakeCommitFetcher{
@@ -153,7 +153,15 @@ func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, compres
 		if err != nil {
 			return &reqContext, jobs, err
 		}
-		searchPlan := compressionPlan.Filter(ctx, req.SampleTimes, req.Repo.Name)
+		// Sample times before the first commit can never have results, so there is no need to
+		// look up commits for them. This matters for series backfilled from an early start time.
+		sampleTimes := make([]time.Time, 0, numberOfSamples)
+		for _, sampleTime := range req.SampleTimes {
+			if !sampleTime.Before(firstHEADCommit.Author.Date) {
+				sampleTimes = append(sampleTimes, sampleTime)
+			}
+		}
+		searchPlan := compressionPlan.Filter(ctx, sampleTimes, req.Repo.Name)
 		ratio := 1.0
 		if numberOfSamples > 0 {
 			ratio = float64(len(searchPlan.Executions)) / float64(numberOfSamples)
@@ -138,10 +138,7 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 		return errors.Wrap(err, "backfill.SetScope")
 	}
 
-	sampleTimes := timeseries.BuildSampleTimes(12, timeseries.TimeInterval{
-		Unit:  types.IntervalUnit(series.SampleIntervalUnit),
-		Value: series.SampleIntervalValue,
-	}, series.CreatedAt.Truncate(time.Minute))
+	sampleTimes := backfillSampleTimes(series)
 
 	if err := h.timeseriesStore.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{
 		{
@@ -196,3 +193,18 @@ func parseQuery(series types.InsightSeries) (query.Plan, error) {
 	}
 	return plan, nil
 }
+
+// backfillSampleTimes returns the points in time a series is backfilled at. Series
+// with a backfill start time are sampled from that time up to their creation,
+// all others over a fixed number of points before their creation.
+func backfillSampleTimes(series *types.InsightSeries) []time.Time {
+	interval := timeseries.TimeInterval{
+		Unit:  types.IntervalUnit(series.SampleIntervalUnit),
+		Value: series.SampleIntervalValue,
+	}
+	now := series.CreatedAt.Truncate(time.Minute)
+	if series.BackfillStartAt != nil {
+		return timeseries.BuildSampleTimesFrom(*series.BackfillStartAt, interval, now)
+	}
+	return timeseries.BuildSampleTimes(12, interval, now)
+}
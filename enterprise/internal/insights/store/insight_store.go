@@ -414,6 +414,7 @@ func scanDataSeries(rows *sql.Rows, queryErr error) (_ []types.InsightSeries, er
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.BackfillStartAt,
 		); err != nil {
 			return []types.InsightSeries{}, err
 		}
@@ -488,6 +489,7 @@ func scanInsightViewSeries(rows *sql.Rows, queryErr error) (_ []types.InsightVie
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.BackfillStartAt,
 		); err != nil {
 			return []types.InsightViewSeries{}, err
 		}
@@ -734,6 +736,7 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 		series.GenerationMethod,
 		series.GroupBy,
 		series.RepositoryCriteria,
+		series.BackfillStartAt,
 	))
 	var id int
 	err := row.Scan(&id)
@@ -820,6 +823,7 @@ type MatchSeriesArgs struct {
 	StepIntervalValue         int
 	GenerateFromCaptureGroups bool
 	GroupBy                   *string
+	BackfillStartAt           *time.Time
 }
 
 func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesArgs) (_ types.InsightSeries, found bool, _ error) {
@@ -827,9 +831,13 @@ func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesA
 	if args.GroupBy != nil {
 		groupByClause = sqlf.Sprintf("group_by = %s", *args.GroupBy)
 	}
+	backfillStartClause := sqlf.Sprintf("backfill_start_at IS NULL")
+	if args.BackfillStartAt != nil {
+		backfillStartClause = sqlf.Sprintf("backfill_start_at = %s", *args.BackfillStartAt)
+	}
 	where := sqlf.Sprintf(
-		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s",
-		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause,
+		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s AND %s",
+		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause, backfillStartClause,
 	)
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, where)
@@ -969,8 +977,9 @@ const createInsightSeriesSql = `
 INSERT INTO insight_series (series_id, query, created_at, oldest_historical_at, last_recorded_at,
                             next_recording_after, last_snapshot_at, next_snapshot_after, repositories,
 							sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-							just_in_time, generation_method, group_by, needs_migration, repository_criteria)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s)
+							just_in_time, generation_method, group_by, needs_migration, repository_criteria,
+							backfill_start_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s, %s)
 RETURNING id;`
 
 const getInsightByViewSql = `
@@ -980,7 +989,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.backfill_start_at
 FROM (%s) iv
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
          JOIN insight_series i ON ivs.insight_series_id = i.id
@@ -995,7 +1004,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.backfill_start_at
 FROM dashboard_insight_view as dbiv
 		 JOIN insight_view iv ON iv.id = dbiv.insight_view_id
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
@@ -1009,7 +1018,8 @@ const getInsightDataSeriesSql = `
 SELECT id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after,
 last_snapshot_at, next_snapshot_after, (CASE WHEN deleted_at IS NULL THEN TRUE ELSE FALSE END) AS enabled,
 sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria
+just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria,
+backfill_start_at
 FROM insight_series
 WHERE %s
 `
@@ -1036,7 +1046,7 @@ SELECT iv.id, 0 as dashboard_insight_id, iv.unique_id, iv.title, iv.description,
        i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 	   iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 	   default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.backfill_start_at
 
 FROM insight_view iv
 JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
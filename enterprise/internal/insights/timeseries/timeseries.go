@@ -24,6 +24,41 @@ func BuildSampleTimes(numPoints int, interval TimeInterval, now time.Time) []tim
 	return times
 }
 
+// MaxSampleTimes is the maximum number of points a series can be backfilled over
+// when it is backfilled from a start time.
+const MaxSampleTimes = 1000
+
+// BuildSampleTimesFrom returns the sample times between start and now in ascending
+// order. Points are generated by stepping backwards from now so that they line up
+// with the recordings made after now. If start does not fall on an interval
+// boundary it is included as the first point, so the series begins exactly at
+// start.
+//
+// At most MaxSampleTimes points are returned; if there are more points between
+// start and now the oldest ones are dropped and the first returned time will be
+// after start.
+func BuildSampleTimesFrom(start time.Time, interval TimeInterval, now time.Time) []time.Time {
+	times := []time.Time{now}
+	current := now
+	for len(times) < MaxSampleTimes {
+		next := interval.StepBackwards(current)
+		if !next.Before(current) || next.Before(start) {
+			break
+		}
+		current = next
+		times = append(times, current)
+	}
+	if len(times) < MaxSampleTimes && current.After(start) {
+		times = append(times, start)
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	return times
+}
+
 func MakeRecordingsFromTimes(times []time.Time, snapshot bool) []types.RecordingTime {
 	recordings := make([]types.RecordingTime, 0, len(times))
 	for _, t := range times {
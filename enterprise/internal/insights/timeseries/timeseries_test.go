@@ -84,3 +84,49 @@ func TestBuildSampleTimes(t *testing.T) {
 		}).Equal(t, buildSampleTimeTest(6, TimeInterval{Unit: types.Year, Value: 1}, startTime))
 	})
 }
+
+func TestBuildSampleTimesFrom(t *testing.T) {
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	buildSampleTimeTest := func(start time.Time, interval TimeInterval) (times []string) {
+		got := BuildSampleTimesFrom(start, interval, now)
+		for _, st := range got {
+			times = append(times, st.String())
+		}
+		return times
+	}
+
+	t.Run("start on interval boundary", func(t *testing.T) {
+		autogold.Expect([]string{
+			"2021-09-01 00:00:00 +0000 UTC", "2021-10-01 00:00:00 +0000 UTC",
+			"2021-11-01 00:00:00 +0000 UTC",
+			"2021-12-01 00:00:00 +0000 UTC",
+		}).Equal(t, buildSampleTimeTest(time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC), TimeInterval{Unit: types.Month, Value: 1}))
+	})
+
+	t.Run("start between interval boundaries", func(t *testing.T) {
+		autogold.Expect([]string{
+			"2019-03-15 00:00:00 +0000 UTC", "2019-12-01 00:00:00 +0000 UTC",
+			"2020-12-01 00:00:00 +0000 UTC",
+			"2021-12-01 00:00:00 +0000 UTC",
+		}).Equal(t, buildSampleTimeTest(time.Date(2019, 3, 15, 0, 0, 0, 0, time.UTC), TimeInterval{Unit: types.Year, Value: 1}))
+	})
+
+	t.Run("start in the future", func(t *testing.T) {
+		autogold.Expect([]string{"2021-12-01 00:00:00 +0000 UTC"}).Equal(t, buildSampleTimeTest(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), TimeInterval{Unit: types.Month, Value: 1}))
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+		autogold.Expect([]string{"2021-11-01 00:00:00 +0000 UTC", "2021-12-01 00:00:00 +0000 UTC"}).Equal(t, buildSampleTimeTest(time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC), TimeInterval{Unit: types.Day, Value: 0}))
+	})
+
+	t.Run("capped at max sample times", func(t *testing.T) {
+		got := BuildSampleTimesFrom(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), TimeInterval{Unit: types.Day, Value: 1}, now)
+		if len(got) != MaxSampleTimes {
+			t.Fatalf("unexpected number of sample times: want %d, got %d", MaxSampleTimes, len(got))
+		}
+		if !got[len(got)-1].Equal(now) {
+			t.Errorf("expected last sample time to be now, got %s", got[len(got)-1])
+		}
+	})
+}
@@ -43,6 +43,7 @@ type InsightViewSeries struct {
 	SupportsAugmentation          bool
 	RepositoryCriteria            *string
 	SeriesNumSamples              *int32
+	BackfillStartAt               *time.Time
 }
 
 type Insight struct {
@@ -111,6 +112,9 @@ type InsightSeries struct {
 	BackfillAttempts           int32
 	SupportsAugmentation       bool
 	RepositoryCriteria         *string
+	// BackfillStartAt is the time of the first point to backfill. When nil the
+	// series is backfilled over a fixed number of points from its creation time.
+	BackfillStartAt *time.Time
 }
 
 type IntervalUnit string
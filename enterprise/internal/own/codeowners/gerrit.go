@@ -0,0 +1,340 @@
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GerritOwnersFileName is the name of the files that define the owners of a
+// directory in Gerrit.
+const GerritOwnersFileName = "OWNERS"
+
+// maxGerritIncludeDepth bounds the chain of files included by an OWNERS file.
+const maxGerritIncludeDepth = 10
+
+// GerritParseError is returned for OWNERS files, or files included from them,
+// that are not in the Gerrit format.
+type GerritParseError struct {
+	Path string
+	// Line is the line number of the invalid line, or 0 if the error is not
+	// about a single line.
+	Line    int32
+	Message string
+}
+
+func (e *GerritParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+}
+
+// ParseGerritOwners resolves the Gerrit OWNERS files at the given paths,
+// relative to the repository root, into the rules of a single CODEOWNERS file.
+// The contents of a file are returned by read, which is also used for files
+// included from OWNERS files.
+//
+// The owners of a directory are the ones listed in its OWNERS file and,
+// unless it says `set noparent`, the owners of the parent directories. The
+// resulting rules are ordered such that the rules for a directory come after
+// the rules of its parents, so the last matching rule has the owners of the
+// closest OWNERS file. `per-file` owners are added to the owners of the
+// directory, unless they are defined with `set noparent`.
+//
+// Directories owned by `*` have no specific owner, and references to files in
+// other projects are ignored.
+//
+// Files named OWNERS are not always Gerrit OWNERS files, Prow for example uses
+// a YAML format. If onInvalid is non-nil, files that fail to parse with a
+// *GerritParseError are passed to it and skipped, as if they did not exist.
+// Otherwise, the first such file fails the whole parse. Errors returned by
+// read always fail the whole parse.
+func ParseGerritOwners(paths []string, read func(path string) ([]byte, error), onInvalid func(path string, err error)) (*codeownerspb.File, error) {
+	files := make(map[string]*gerritOwnersFile, len(paths))
+	dirs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		p = strings.TrimPrefix(p, "/")
+		if path.Base(p) != GerritOwnersFileName {
+			return nil, errors.Errorf("not an OWNERS file: %s", p)
+		}
+		f, err := parseGerritOwnersFile(p, read, 0)
+		if err != nil {
+			var parseErr *GerritParseError
+			if onInvalid == nil || !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			onInvalid(p, parseErr)
+			continue
+		}
+		dir := gerritDir(p)
+		files[dir] = f
+		dirs = append(dirs, dir)
+	}
+
+	// Parents have to be resolved first, and their rules have to come before
+	// the rules of their subdirectories.
+	sort.Slice(dirs, func(i, j int) bool {
+		if di, dj := strings.Count(dirs[i], "/"), strings.Count(dirs[j], "/"); di != dj {
+			return di < dj
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	var rules []*codeownerspb.Rule
+	effective := make(map[string][]string, len(dirs))
+	for _, dir := range dirs {
+		f := files[dir]
+
+		var owners []string
+		if !f.everyone {
+			owners = appendUnique(nil, f.owners...)
+			if !f.noParent {
+				owners = appendUnique(owners, effective[gerritParent(dir, files)]...)
+			}
+		}
+		effective[dir] = owners
+		rules = append(rules, &codeownerspb.Rule{
+			Pattern:    gerritDirPattern(dir),
+			Owner:      gerritOwners(owners),
+			LineNumber: f.line,
+		})
+
+		for _, pf := range f.perFile {
+			var perFileOwners []string
+			if !pf.everyone {
+				perFileOwners = appendUnique(nil, pf.owners...)
+				if !pf.noParent {
+					perFileOwners = appendUnique(perFileOwners, owners...)
+				}
+			}
+			rules = append(rules, &codeownerspb.Rule{
+				Pattern:    "/" + path.Join(dir, pf.glob),
+				Owner:      gerritOwners(perFileOwners),
+				LineNumber: pf.line,
+			})
+		}
+	}
+	return &codeownerspb.File{Rule: rules}, nil
+}
+
+// gerritOwnersFile is the parsed content of a single OWNERS file.
+type gerritOwnersFile struct {
+	// owners are the emails of the owners of the directory.
+	owners []string
+	// everyone is true if anyone may approve changes to the directory.
+	everyone bool
+	// noParent is true if the owners of the parent directories are not
+	// inherited.
+	noParent bool
+	// line is the line number of the first entry in the file.
+	line int32
+
+	perFile []*gerritPerFileRule
+}
+
+// gerritPerFileRule holds the owners of the files in a directory matching glob.
+type gerritPerFileRule struct {
+	glob     string
+	owners   []string
+	everyone bool
+	noParent bool
+	line     int32
+}
+
+func parseGerritOwnersFile(filePath string, read func(path string) ([]byte, error), depth int) (*gerritOwnersFile, error) {
+	if depth > maxGerritIncludeDepth {
+		return nil, &GerritParseError{Path: filePath, Message: "too many nested includes"}
+	}
+	content, err := read(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", filePath)
+	}
+
+	f := &gerritOwnersFile{}
+	perFile := make(map[string]*gerritPerFileRule)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := int32(0)
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if i := strings.IndexRune(line, commentStart); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+
+		case line == "set noparent":
+			f.noParent = true
+
+		case line == "*":
+			f.everyone = true
+
+		case strings.HasPrefix(line, "per-file "):
+			globs, owners, ok := strings.Cut(strings.TrimPrefix(line, "per-file "), "=")
+			if !ok {
+				return nil, &GerritParseError{Path: filePath, Line: lineNumber, Message: "per-file without owners: " + line}
+			}
+			for _, glob := range strings.Split(globs, ",") {
+				glob = strings.TrimSpace(glob)
+				rule, ok := perFile[glob]
+				if !ok {
+					rule = &gerritPerFileRule{glob: glob, line: lineNumber}
+					perFile[glob] = rule
+					f.perFile = append(f.perFile, rule)
+				}
+				if strings.TrimSpace(owners) == "set noparent" {
+					rule.noParent = true
+					continue
+				}
+				for _, owner := range strings.FieldsFunc(owners, isGerritOwnerSeparator) {
+					if owner == "*" {
+						rule.everyone = true
+						continue
+					}
+					emails, err := gerritFileOwners(filePath, owner, read, depth)
+					if err != nil {
+						return nil, err
+					}
+					rule.owners = appendUnique(rule.owners, emails...)
+				}
+			}
+
+		case strings.HasPrefix(line, "include "), strings.HasPrefix(line, "file:"):
+			included, err := includeGerritOwnersFile(filePath, strings.TrimPrefix(strings.TrimPrefix(line, "include "), "file:"), read, depth)
+			if err != nil {
+				return nil, err
+			}
+			if included == nil {
+				continue
+			}
+			f.owners = appendUnique(f.owners, included.owners...)
+			f.everyone = f.everyone || included.everyone
+			// Only `include` imports the per-file rules, `file:` only imports
+			// the owners.
+			if strings.HasPrefix(line, "include ") {
+				f.perFile = append(f.perFile, included.perFile...)
+			}
+
+		case strings.ContainsAny(line, " \t="):
+			return nil, &GerritParseError{Path: filePath, Line: lineNumber, Message: "failed to parse line: " + line}
+
+		default:
+			f.owners = appendUnique(f.owners, line)
+		}
+
+		if f.line == 0 {
+			f.line = lineNumber
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &GerritParseError{Path: filePath, Message: err.Error()}
+	}
+	return f, nil
+}
+
+// gerritFileOwners returns the owners denoted by a per-file owner, which is
+// either an email or a reference to the owners of another file.
+func gerritFileOwners(filePath, owner string, read func(path string) ([]byte, error), depth int) ([]string, error) {
+	if !strings.HasPrefix(owner, "file:") {
+		return []string{owner}, nil
+	}
+	included, err := includeGerritOwnersFile(filePath, strings.TrimPrefix(owner, "file:"), read, depth)
+	if err != nil || included == nil {
+		return nil, err
+	}
+	return included.owners, nil
+}
+
+// includeGerritOwnersFile parses the file referenced from the OWNERS file at
+// filePath. References are relative to the directory of the OWNERS file, or
+// to the repository root if they start with `/` or `//`. It returns nil if
+// the reference is to another project.
+func includeGerritOwnersFile(filePath, ref string, read func(path string) ([]byte, error), depth int) (*gerritOwnersFile, error) {
+	ref = strings.TrimSpace(ref)
+	if strings.Contains(ref, ":") {
+		// A file in another project or branch, like `project:branch:path`.
+		return nil, nil
+	}
+	var target string
+	if strings.HasPrefix(ref, "/") {
+		target = path.Clean(strings.TrimLeft(ref, "/"))
+	} else {
+		target = path.Join(path.Dir(filePath), ref)
+	}
+	f, err := parseGerritOwnersFile(target, read, depth+1)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &GerritParseError{Path: filePath, Message: "included file does not exist: " + target}
+	}
+	return f, err
+}
+
+func isGerritOwnerSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
+
+// gerritDir returns the directory of the OWNERS file at filePath, or "" for
+// the repository root.
+func gerritDir(filePath string) string {
+	dir := path.Dir(filePath)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// gerritParent returns the closest parent directory of dir that has an OWNERS
+// file. It returns "" for the repository root, also if there's no OWNERS file
+// at the root.
+func gerritParent(dir string, files map[string]*gerritOwnersFile) string {
+	for dir != "" {
+		dir = gerritDir(dir)
+		if _, ok := files[dir]; ok {
+			return dir
+		}
+	}
+	return ""
+}
+
+// gerritDirPattern returns the pattern that matches all files in the tree
+// rooted at dir.
+func gerritDirPattern(dir string) string {
+	if dir == "" {
+		return "*"
+	}
+	return "/" + dir + "/"
+}
+
+func gerritOwners(emails []string) []*codeownerspb.Owner {
+	var owners []*codeownerspb.Owner
+	for _, email := range emails {
+		owners = append(owners, ParseOwner(email))
+	}
+	return owners
+}
+
+func appendUnique(owners []string, more ...string) []string {
+	for _, o := range more {
+		found := false
+		for _, existing := range owners {
+			if existing == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,169 @@
+package codeowners_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type gerritRepo map[string]string
+
+func (r gerritRepo) read(path string) ([]byte, error) {
+	content, ok := r[path]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return []byte(content), nil
+}
+
+func TestParseGerritOwners(t *testing.T) {
+	repo := gerritRepo{
+		"OWNERS": `# Owners of the whole repository.
+root@example.com
+per-file *.md=docs@example.com
+`,
+		"backend/OWNERS": `backend@example.com
+per-file BUILD.bazel=set noparent
+per-file BUILD.bazel=build@example.com
+`,
+		"backend/security/OWNERS": `set noparent
+security@example.com
+`,
+		"frontend/OWNERS": `file://build/FRONTEND_OWNERS
+per-file *.css,*.scss=design@example.com, *
+`,
+		"build/FRONTEND_OWNERS": `frontend@example.com
+`,
+		"third_party/OWNERS": `*
+include other-project:/OWNERS
+`,
+	}
+	got, err := codeowners.ParseGerritOwners([]string{
+		"third_party/OWNERS",
+		"backend/security/OWNERS",
+		"frontend/OWNERS",
+		"backend/OWNERS",
+		"OWNERS",
+	}, repo.read, nil)
+	require.NoError(t, err)
+
+	want := []*codeownerspb.Rule{
+		{
+			Pattern:    "*",
+			Owner:      []*codeownerspb.Owner{{Email: "root@example.com"}},
+			LineNumber: 2,
+		},
+		{
+			Pattern:    "/*.md",
+			Owner:      []*codeownerspb.Owner{{Email: "docs@example.com"}, {Email: "root@example.com"}},
+			LineNumber: 3,
+		},
+		{
+			Pattern:    "/backend/",
+			Owner:      []*codeownerspb.Owner{{Email: "backend@example.com"}, {Email: "root@example.com"}},
+			LineNumber: 1,
+		},
+		{
+			Pattern:    "/backend/BUILD.bazel",
+			Owner:      []*codeownerspb.Owner{{Email: "build@example.com"}},
+			LineNumber: 2,
+		},
+		{
+			Pattern:    "/frontend/",
+			Owner:      []*codeownerspb.Owner{{Email: "frontend@example.com"}, {Email: "root@example.com"}},
+			LineNumber: 1,
+		},
+		{
+			// Anyone can own stylesheets.
+			Pattern:    "/frontend/*.css",
+			LineNumber: 2,
+		},
+		{
+			Pattern:    "/frontend/*.scss",
+			LineNumber: 2,
+		},
+		{
+			Pattern:    "/third_party/",
+			LineNumber: 1,
+		},
+		{
+			Pattern:    "/backend/security/",
+			Owner:      []*codeownerspb.Owner{{Email: "security@example.com"}},
+			LineNumber: 1,
+		},
+	}
+	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+
+	// The closest OWNERS file determines the owners of a file.
+	rs := codeowners.NewRuleset(codeowners.GitRulesetSource{Path: "OWNERS"}, got)
+	assert.Equal(t, "/backend/", rs.Match("backend/main.go").GetPattern())
+	assert.Equal(t, "/backend/security/", rs.Match("backend/security/auth.go").GetPattern())
+	assert.Equal(t, "*", rs.Match("backend.go").GetPattern())
+}
+
+func TestParseGerritOwnersErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"invalid line":      "set parent\n",
+		"per-file syntax":   "per-file *.go\n",
+		"missing include":   "include /does/not/exist\n",
+		"recursive include": "include OWNERS\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := codeowners.ParseGerritOwners([]string{"OWNERS"}, gerritRepo{"OWNERS": content}.read, nil)
+			var parseErr *codeowners.GerritParseError
+			assert.ErrorAs(t, err, &parseErr)
+		})
+	}
+}
+
+func TestParseGerritOwnersReadErrors(t *testing.T) {
+	readErr := errors.New("gitserver unavailable")
+	read := func(path string) ([]byte, error) {
+		if path == "backend/OWNERS" {
+			return nil, readErr
+		}
+		return []byte("root@example.com\n"), nil
+	}
+
+	// Read errors are not passed to onInvalid, they fail the whole parse.
+	_, err := codeowners.ParseGerritOwners([]string{"OWNERS", "backend/OWNERS"}, read, func(path string, err error) {
+		t.Errorf("unexpected invalid file %s: %s", path, err)
+	})
+	assert.ErrorIs(t, err, readErr)
+}
+
+func TestParseGerritOwnersSkipsInvalidFiles(t *testing.T) {
+	repo := gerritRepo{
+		"OWNERS": "root@example.com\n",
+		// A Prow OWNERS file.
+		"kubernetes/OWNERS": `approvers:
+- alice
+reviewers:
+- bob
+`,
+		"kubernetes/api/OWNERS": "api@example.com\n",
+	}
+
+	var invalid []string
+	got, err := codeowners.ParseGerritOwners([]string{
+		"OWNERS",
+		"kubernetes/OWNERS",
+		"kubernetes/api/OWNERS",
+	}, repo.read, func(path string, err error) {
+		assert.Error(t, err)
+		invalid = append(invalid, path)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubernetes/OWNERS"}, invalid)
+
+	// The skipped file doesn't break the inheritance from the root.
+	rs := codeowners.NewRuleset(codeowners.GitRulesetSource{Path: "OWNERS"}, got)
+	assert.Equal(t, []*codeownerspb.Owner{{Email: "root@example.com"}}, rs.Match("kubernetes/main.go").GetOwner())
+	assert.Equal(t, []*codeownerspb.Owner{{Email: "api@example.com"}, {Email: "root@example.com"}}, rs.Match("kubernetes/api/types.go").GetOwner())
+}
@@ -14,6 +14,16 @@ import (
 // Parse parses CODEOWNERS file given as a Reader and returns the proto
 // representation of all rules within. The rules are in the same order
 // as in the file, since this matters for evaluation.
+//
+// Besides the GitHub syntax, Parse understands the GitLab and Bitbucket
+// extensions of the format:
+//   - GitLab sections, which may be optional (`^[Section]`), require a number
+//     of approvals (`[Section][2]`) and list default owners (`[Section] @owner`)
+//     for the rules of the section that don't list owners themselves.
+//     Approval requirements are not relevant to ownership and are ignored.
+//   - Bitbucket inline groups (`@@@group @member1 @member2`), which are expanded
+//     where referenced as `@@group`, exclusions (`!pattern`), which result in
+//     rules without owners, and configuration directives, which are ignored.
 func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 	scanner := bufio.NewScanner(codeownersFile)
 	var rs []*codeownerspb.Rule
@@ -22,7 +32,10 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 	for scanner.Scan() {
 		p.nextLine(scanner.Text())
 		lineNumber++
-		if p.isBlank() {
+		if p.isBlank() || p.isDirective() {
+			continue
+		}
+		if p.matchGroup() {
 			continue
 		}
 		if p.matchSection() {
@@ -40,9 +53,15 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 			SectionName: strings.TrimSpace(strings.ToLower(p.section)),
 			LineNumber:  lineNumber,
 		}
+		if strings.HasPrefix(r.Pattern, exclusionPrefix) {
+			// Excluded paths have no owners, whatever is listed.
+			r.Pattern = strings.TrimPrefix(r.Pattern, exclusionPrefix)
+			owners = nil
+		} else if len(owners) == 0 {
+			owners = p.sectionOwners
+		}
 		for _, ownerText := range owners {
-			o := ParseOwner(ownerText)
-			r.Owner = append(r.Owner, o)
+			r.Owner = append(r.Owner, p.parseOwners(ownerText)...)
 		}
 		rs = append(rs, &r)
 	}
@@ -73,6 +92,10 @@ type parsing struct {
 	line string
 	// The most recently defined section, or "" if none.
 	section string
+	// The default owners of the most recently defined section.
+	sectionOwners []string
+	// groups maps the names of Bitbucket inline groups to their members.
+	groups map[string][]string
 }
 
 // nextLine advances parsing to focus on the next line.
@@ -113,20 +136,76 @@ func (p *parsing) matchRule() (string, []string, bool) {
 	return filePattern, owners, true
 }
 
-var sectionPattern = lazyregexp.New(`^\s*\^?\s*\[([^\]]+)\]\s*(?:\[[0-9]+\])?\s*$`)
+// sectionPattern matches a section header like `[section name] @default-owner`.
+// The optional leading `^` and the approval count are captured only so that
+// they are not mistaken for owners.
+var sectionPattern = lazyregexp.New(`^\s*(\^?)\s*\[([^\]]+)\]\s*(?:\[([0-9]+)\])?((?:\s+\S+)*)\s*$`)
 
 // matchSection tries to extract a section which looks like `[section name]`.
 // A section can also be defined as `^[Section]`, meaning it is optional for approval.
 // It can also be `[Section][2]`, meaning two approvals are required.
+// The section header can be followed by the default owners for the rules
+// in the section.
 func (p *parsing) matchSection() bool {
 	match := sectionPattern.FindStringSubmatch(p.lineWithoutComments())
-	if len(match) != 2 {
+	if len(match) != 5 {
+		return false
+	}
+	p.section = match[2]
+	p.sectionOwners = strings.Fields(match[4])
+	return true
+}
+
+const (
+	groupDefinitionPrefix = "@@@"
+	groupReferencePrefix  = "@@"
+	exclusionPrefix       = "!"
+)
+
+// matchGroup tries to extract a Bitbucket inline group definition which looks
+// like `@@@group @member1 member2@example.com`.
+func (p *parsing) matchGroup() bool {
+	fields := strings.Fields(p.lineWithoutComments())
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], groupDefinitionPrefix) {
 		return false
 	}
-	p.section = match[1]
+	if p.groups == nil {
+		p.groups = make(map[string][]string)
+	}
+	name := strings.TrimPrefix(fields[0], groupDefinitionPrefix)
+	p.groups[name] = append(p.groups[name], fields[1:]...)
 	return true
 }
 
+// parseOwners returns the owners denoted by ownerText, expanding references
+// to Bitbucket inline groups defined further up in the file. References to
+// other groups are kept as a handle, as they refer to groups defined on the
+// code host.
+func (p *parsing) parseOwners(ownerText string) []*codeownerspb.Owner {
+	if !strings.HasPrefix(ownerText, groupReferencePrefix) {
+		return []*codeownerspb.Owner{ParseOwner(ownerText)}
+	}
+	name := strings.TrimPrefix(ownerText, groupReferencePrefix)
+	members, ok := p.groups[name]
+	if !ok {
+		return []*codeownerspb.Owner{{Handle: name}}
+	}
+	owners := make([]*codeownerspb.Owner, 0, len(members))
+	for _, m := range members {
+		// Groups don't nest, so members are not expanded again.
+		owners = append(owners, ParseOwner(m))
+	}
+	return owners
+}
+
+// isDirective returns true if the current line configures the Bitbucket
+// Code Owners plugin, like `CODEOWNERS.toplevel.subdirectory_overrides enable`
+// or a merge check like `Check(@@reviewers >= 2)`.
+func (p *parsing) isDirective() bool {
+	line := strings.TrimSpace(p.lineWithoutComments())
+	return strings.HasPrefix(line, "CODEOWNERS.") || strings.HasPrefix(line, "Check(")
+}
+
 // isBlank returns true if the current line has no semantically relevant
 // content. It can be blank while containing comments or whitespace.
 func (p *parsing) isBlank() bool {
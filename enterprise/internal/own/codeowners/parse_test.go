@@ -573,3 +573,81 @@ func TestParseSectionWithComment(t *testing.T) {
 	}
 	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
 }
+
+func TestParseSectionDefaultOwners(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`[Documentation] @docs-team docs@example.com
+docs/
+README.md @readme-owner
+
+^[Frontend][2] @frontend-team
+*.ts
+`))
+	require.NoError(t, err)
+	want := []*codeownerspb.Rule{
+		{
+			Pattern:     "docs/",
+			SectionName: "documentation",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "docs-team"},
+				{Email: "docs@example.com"},
+			},
+			LineNumber: 2,
+		},
+		{
+			Pattern:     "README.md",
+			SectionName: "documentation",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "readme-owner"},
+			},
+			LineNumber: 3,
+		},
+		{
+			Pattern:     "*.ts",
+			SectionName: "frontend",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "frontend-team"},
+			},
+			LineNumber: 6,
+		},
+	}
+	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+}
+
+func TestParseBitbucketExample(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`CODEOWNERS.toplevel.subdirectory_overrides enable
+
+@@@Backend @alice bob@example.com
+*.go @@Backend @carol
+/docs/ @@writers
+!/docs/generated/
+
+Check(@@Backend >= 1)
+`))
+	require.NoError(t, err)
+	want := []*codeownerspb.Rule{
+		{
+			Pattern: "*.go",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "alice"},
+				{Email: "bob@example.com"},
+				{Handle: "carol"},
+			},
+			LineNumber: 4,
+		},
+		{
+			// Groups that are not defined inline are defined on the code host.
+			Pattern: "/docs/",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "writers"},
+			},
+			LineNumber: 5,
+		},
+		{
+			Pattern:    "/docs/generated/",
+			LineNumber: 6,
+		},
+	}
+	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+}
@@ -0,0 +1,376 @@
+package own
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// InferredOwner is a likely owner of a file, inferred from signals other than
+// CODEOWNERS.
+type InferredOwner struct {
+	Handle string
+	Email  string
+	// Score is the weighted sum of the signals, each normalized to the candidate
+	// with the most activity for that signal. Higher is more likely an owner.
+	Score float64
+
+	// Contributions is the number of recent commits to the file.
+	Contributions int
+	// Views is the number of views of the file on Sourcegraph.
+	Views int
+	// Reviews is the number of recent reviews of changesets in the repository.
+	Reviews int
+}
+
+// Owner returns the inferred owner in the format of CODEOWNERS owners.
+func (o InferredOwner) Owner() *codeownerspb.Owner {
+	return &codeownerspb.Owner{Handle: o.Handle, Email: o.Email}
+}
+
+// InferenceConfig holds the weights of the signals ownership inference blends.
+type InferenceConfig struct {
+	ContributorsWeight float64
+	ViewsWeight        float64
+	ReviewersWeight    float64
+	MaxOwners          int
+}
+
+// InferenceConfigFromSiteConfig returns the inference configuration from the
+// "own.inference" site configuration, with defaults filled in.
+func InferenceConfigFromSiteConfig(c *schema.OwnInference) InferenceConfig {
+	cfg := InferenceConfig{
+		ContributorsWeight: 1,
+		ViewsWeight:        0.5,
+		ReviewersWeight:    0.5,
+		MaxOwners:          3,
+	}
+	if c == nil {
+		return cfg
+	}
+	if c.RecentContributorsWeight != nil {
+		cfg.ContributorsWeight = *c.RecentContributorsWeight
+	}
+	if c.RecentViewsWeight != nil {
+		cfg.ViewsWeight = *c.RecentViewsWeight
+	}
+	if c.ReviewersWeight != nil {
+		cfg.ReviewersWeight = *c.ReviewersWeight
+	}
+	if c.MaxOwners > 0 {
+		cfg.MaxOwners = c.MaxOwners
+	}
+	return cfg
+}
+
+// InferredOwnersAsFallback returns true if inferred owners should be used for
+// files without a CODEOWNERS entry.
+func InferredOwnersAsFallback() bool {
+	c := conf.Get().OwnInference
+	return c != nil && c.UseAsFallback
+}
+
+// InferOwners ranks the likely owners of the file at path in the given repository by
+// blending recent contributors, recent viewers and changeset reviewers, weighted as
+// configured in the site configuration.
+func (s *service) InferOwners(ctx context.Context, repoID api.RepoID, path string) ([]InferredOwner, error) {
+	cfg := InferenceConfigFromSiteConfig(conf.Get().OwnInference)
+
+	var candidates candidateSet
+
+	if cfg.ContributorsWeight > 0 {
+		contributors, err := s.db.RecentContributionSignals().FindRecentAuthors(ctx, repoID, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "RecentContributionSignals.FindRecentAuthors")
+		}
+		for _, c := range contributors {
+			candidates.add("", c.AuthorEmail).Contributions += c.ContributionCount
+		}
+	}
+
+	if cfg.ViewsWeight > 0 {
+		views, err := s.db.RecentViewSignal().List(ctx, database.ListRecentViewSignalOpts{RepoID: repoID, Path: path})
+		if err != nil {
+			return nil, errors.Wrap(err, "RecentViewSignal.List")
+		}
+		for _, v := range views {
+			handle, email, err := s.user(ctx, v.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if handle == "" {
+				// The user has been deleted.
+				continue
+			}
+			candidates.add(handle, email).Views += v.ViewsCount
+		}
+	}
+
+	if cfg.ReviewersWeight > 0 {
+		reviewers, err := s.recentReviewers(ctx, repoID)
+		if err != nil {
+			return nil, err
+		}
+		for userID, count := range reviewers {
+			handle, email, err := s.user(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			if handle == "" {
+				// The user has been deleted.
+				continue
+			}
+			candidates.add(handle, email).Reviews += count
+		}
+	}
+
+	return rankCandidates(candidates.owners, cfg), nil
+}
+
+// user returns the username and primary email of the user, caching them for the
+// lifetime of the service.
+func (s *service) user(ctx context.Context, userID int32) (handle, email string, err error) {
+	s.mu.Lock()
+	v, ok := s.userCache[userID]
+	s.mu.Unlock()
+	if ok {
+		return v.handle, v.email, nil
+	}
+
+	user, err := s.db.Users().GetByID(ctx, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return "", "", errors.Wrap(err, "Users.GetByID")
+	}
+	if user != nil {
+		handle = user.Username
+		email, _, err = s.db.UserEmails().GetPrimaryEmail(ctx, userID)
+		if err != nil && !errcode.IsNotFound(err) {
+			return "", "", errors.Wrap(err, "UserEmails.GetPrimaryEmail")
+		}
+	}
+
+	s.mu.Lock()
+	s.userCache[userID] = ownerKey{handle: handle, email: email}
+	s.mu.Unlock()
+	return handle, email, nil
+}
+
+// recentReviewers returns the number of recent reviews in the repository per
+// Sourcegraph user, caching them for the lifetime of the service. Reviewers are
+// known by their login on the code host, so only those with an external account
+// on the code host of the repository are returned.
+func (s *service) recentReviewers(ctx context.Context, repoID api.RepoID) (map[int32]int, error) {
+	s.mu.Lock()
+	reviewers, ok := s.reviewerCache[repoID]
+	s.mu.Unlock()
+	if ok {
+		return reviewers, nil
+	}
+
+	// Owners of many files in the same repository are inferred concurrently, so
+	// the reviewers are only fetched once.
+	v, err, _ := s.group.Do(fmt.Sprintf("reviewers:%d", repoID), func() (any, error) {
+		logins, err := s.reviewers.RecentReviewers(ctx, repoID)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewers := make(map[int32]int)
+		if len(logins) > 0 {
+			repo, err := s.db.Repos().Get(ctx, repoID)
+			if err != nil {
+				return nil, errors.Wrap(err, "Repos.Get")
+			}
+			names := make([]string, 0, len(logins))
+			for login := range logins {
+				names = append(names, login)
+			}
+			sort.Strings(names)
+			users, err := s.codeHostUsers(ctx, repo.ExternalRepo.ServiceType, repo.ExternalRepo.ServiceID, names)
+			if err != nil {
+				return nil, err
+			}
+			for login, count := range logins {
+				if userID, ok := users[strings.ToLower(login)]; ok {
+					reviewers[userID] += count
+				}
+			}
+		}
+
+		s.mu.Lock()
+		s.reviewerCache[repoID] = reviewers
+		s.mu.Unlock()
+		return reviewers, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[int32]int), nil
+}
+
+// codeHostUsers returns the IDs of the users whose external account on the code
+// host has one of the given logins, keyed by their lowercased login.
+//
+// The login is only stored in the encrypted account data, so rather than
+// decrypting every account of the code host, only the accounts of users whose
+// username or verified email is one of the logins, and the accounts whose ID is
+// one of the logins, are read and checked.
+func (s *service) codeHostUsers(ctx context.Context, serviceType, serviceID string, logins []string) (map[string]int32, error) {
+	users, err := s.db.Users().GetByUsernames(ctx, logins...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Users.GetByUsernames")
+	}
+	emails, err := s.db.UserEmails().GetVerifiedEmails(ctx, logins...)
+	if err != nil {
+		return nil, errors.Wrap(err, "UserEmails.GetVerifiedEmails")
+	}
+
+	userIDs := make([]int32, 0, len(users)+len(emails))
+	for _, u := range users {
+		userIDs = append(userIDs, u.ID)
+	}
+	for _, e := range emails {
+		userIDs = append(userIDs, e.UserID)
+	}
+
+	var accounts []*extsvc.Account
+	if len(userIDs) > 0 {
+		byUser, err := s.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			UserIDs:        userIDs,
+			ServiceType:    serviceType,
+			ServiceID:      serviceID,
+			ExcludeExpired: true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "UserExternalAccounts.List")
+		}
+		accounts = append(accounts, byUser...)
+	}
+	byAccountID, err := s.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		AccountIDs:     logins,
+		ServiceType:    serviceType,
+		ServiceID:      serviceID,
+		ExcludeExpired: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "UserExternalAccounts.List")
+	}
+	accounts = append(accounts, byAccountID...)
+
+	wanted := make(map[string]struct{}, len(logins))
+	for _, login := range logins {
+		wanted[strings.ToLower(login)] = struct{}{}
+	}
+
+	result := make(map[string]int32, len(accounts))
+	for _, a := range accounts {
+		login, err := accountLogin(ctx, a)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading external account data")
+		}
+		login = strings.ToLower(login)
+		if _, ok := wanted[login]; ok {
+			result[login] = a.UserID
+		}
+	}
+	return result, nil
+}
+
+// candidateSet merges the signals of the same person, who may be known by a
+// handle, an email or both depending on the signal.
+type candidateSet struct {
+	owners   []*InferredOwner
+	byHandle map[string]*InferredOwner
+	byEmail  map[string]*InferredOwner
+}
+
+func (s *candidateSet) add(handle, email string) *InferredOwner {
+	if s.byHandle == nil {
+		s.byHandle = make(map[string]*InferredOwner)
+		s.byEmail = make(map[string]*InferredOwner)
+	}
+	handleKey, emailKey := strings.ToLower(handle), strings.ToLower(email)
+
+	o := s.byEmail[emailKey]
+	if o == nil {
+		o = s.byHandle[handleKey]
+	}
+	if o == nil {
+		o = &InferredOwner{}
+		s.owners = append(s.owners, o)
+	}
+
+	if o.Handle == "" && handle != "" {
+		o.Handle = handle
+		s.byHandle[handleKey] = o
+	}
+	if o.Email == "" && email != "" {
+		o.Email = email
+		s.byEmail[emailKey] = o
+	}
+	return o
+}
+
+// rankCandidates scores the candidates and returns the cfg.MaxOwners with the
+// highest score.
+//
+// Each signal is normalized to the candidate with the most activity for that
+// signal before it is weighted, so that the weights are comparable even though a
+// file is usually viewed much more often than it is changed.
+func rankCandidates(candidates []*InferredOwner, cfg InferenceConfig) []InferredOwner {
+	var maxContributions, maxViews, maxReviews int
+	for _, c := range candidates {
+		maxContributions = collections.Max(maxContributions, c.Contributions)
+		maxViews = collections.Max(maxViews, c.Views)
+		maxReviews = collections.Max(maxReviews, c.Reviews)
+	}
+
+	ranked := make([]InferredOwner, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Handle == "" && c.Email == "" {
+			// Nothing to identify the owner by.
+			continue
+		}
+		o := *c
+		o.Score = cfg.ContributorsWeight*ratio(o.Contributions, maxContributions) +
+			cfg.ViewsWeight*ratio(o.Views, maxViews) +
+			cfg.ReviewersWeight*ratio(o.Reviews, maxReviews)
+		if o.Score <= 0 {
+			continue
+		}
+		ranked = append(ranked, o)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].Handle != ranked[j].Handle {
+			return ranked[i].Handle < ranked[j].Handle
+		}
+		return ranked[i].Email < ranked[j].Email
+	})
+
+	if cfg.MaxOwners > 0 && len(ranked) > cfg.MaxOwners {
+		ranked = ranked[:cfg.MaxOwners]
+	}
+	return ranked
+}
+
+func ratio(n, max int) float64 {
+	if max == 0 {
+		return 0
+	}
+	return float64(n) / float64(max)
+}
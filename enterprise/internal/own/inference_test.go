@@ -0,0 +1,180 @@
+package own
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeReviewersSource map[api.RepoID]map[string]int
+
+func (s fakeReviewersSource) RecentReviewers(_ context.Context, repoID api.RepoID) (map[string]int, error) {
+	return s[repoID], nil
+}
+
+func TestInferOwners(t *testing.T) {
+	const repoID = api.RepoID(1)
+
+	contributions := database.NewMockRecentContributionSignalStore()
+	contributions.FindRecentAuthorsFunc.SetDefaultHook(func(_ context.Context, id api.RepoID, path string) ([]database.RecentContributorSummary, error) {
+		if id != repoID || path != "cmd/main.go" {
+			return nil, nil
+		}
+		return []database.RecentContributorSummary{
+			{AuthorName: "Alice", AuthorEmail: "alice@example.com", ContributionCount: 10},
+			{AuthorName: "Bob", AuthorEmail: "bob@example.com", ContributionCount: 5},
+		}, nil
+	})
+
+	views := database.NewMockRecentViewSignalStore()
+	views.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ListRecentViewSignalOpts) ([]database.RecentViewSummary, error) {
+		if opts.RepoID != repoID || opts.Path != "cmd/main.go" {
+			return nil, nil
+		}
+		return []database.RecentViewSummary{
+			{UserID: 1, ViewsCount: 100},
+			{UserID: 2, ViewsCount: 50},
+		}, nil
+	})
+
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*itypes.User, error) {
+		return &itypes.User{ID: id, Username: map[int32]string{1: "alice", 2: "carol"}[id]}, nil
+	})
+	userEmails := database.NewMockUserEmailsStore()
+	userEmails.GetPrimaryEmailFunc.SetDefaultHook(func(_ context.Context, id int32) (string, bool, error) {
+		return map[int32]string{1: "Alice@example.com", 2: "carol@example.com"}[id], true, nil
+	})
+
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&itypes.Repo{
+		ID:           repoID,
+		ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"},
+	}, nil)
+
+	// Reviewers are matched on the accounts of the users whose username is one
+	// of the logins. dave has no Sourcegraph user, so their reviews are ignored.
+	users.GetByUsernamesFunc.SetDefaultHook(func(_ context.Context, usernames ...string) ([]*itypes.User, error) {
+		var found []*itypes.User
+		for _, username := range usernames {
+			if username == "carol" {
+				found = append(found, &itypes.User{ID: 2, Username: "carol"})
+			}
+		}
+		return found, nil
+	})
+	accounts := database.NewMockUserExternalAccountsStore()
+	accounts.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opts.ServiceType != extsvc.TypeGitHub || opts.ServiceID != "https://github.com/" {
+			return nil, nil
+		}
+		if len(opts.UserIDs) != 1 || opts.UserIDs[0] != 2 {
+			return nil, nil
+		}
+		return []*extsvc.Account{{
+			UserID: 2,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+				AccountID:   "42",
+			},
+			AccountData: extsvc.AccountData{
+				Data: extsvc.NewUnencryptedData(json.RawMessage(`{"login": "Carol"}`)),
+			},
+		}}, nil
+	})
+
+	db := edb.NewMockEnterpriseDB()
+	db.RecentContributionSignalsFunc.SetDefaultReturn(contributions)
+	db.RecentViewSignalFunc.SetDefaultReturn(views)
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(userEmails)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.UserExternalAccountsFunc.SetDefaultReturn(accounts)
+
+	newService := func() *service {
+		svc := NewService(gitserver.NewMockClient(), db).(*service)
+		svc.reviewers = fakeReviewersSource{repoID: {"carol": 4, "dave": 2}}
+		return svc
+	}
+
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	t.Run("default weights", func(t *testing.T) {
+		conf.Mock(&conf.Unified{})
+
+		owners, err := newService().InferOwners(context.Background(), repoID, "cmd/main.go")
+		require.NoError(t, err)
+		assert.Equal(t, []InferredOwner{
+			// The viewer alice and the contributor with the same email are one person.
+			{Handle: "alice", Email: "alice@example.com", Score: 1*1 + 0.5*1, Contributions: 10, Views: 100},
+			{Handle: "carol", Email: "carol@example.com", Score: 0.5*0.5 + 0.5*1, Views: 50, Reviews: 4},
+			{Email: "bob@example.com", Score: 1 * 0.5, Contributions: 5},
+		}, owners)
+	})
+
+	t.Run("configured weights", func(t *testing.T) {
+		zero, reviews := 0.0, 2.0
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			OwnInference: &schema.OwnInference{
+				RecentViewsWeight: &zero,
+				ReviewersWeight:   &reviews,
+				MaxOwners:         2,
+			},
+		}})
+
+		svc := newService()
+		owners, err := svc.InferOwners(context.Background(), repoID, "cmd/main.go")
+		require.NoError(t, err)
+		assert.Equal(t, []InferredOwner{
+			{Handle: "carol", Email: "carol@example.com", Score: 2 * 1, Reviews: 4},
+			{Email: "alice@example.com", Score: 1 * 1, Contributions: 10},
+		}, owners)
+		// Views are ignored, so they are only listed by the previous test.
+		assert.Len(t, views.ListFunc.History(), 1)
+		// Each service looks up the accounts of the reviewers once, by user and
+		// by account ID, and never lists every account on the code host.
+		assert.Len(t, accounts.ListFunc.History(), 4)
+		for _, call := range accounts.ListFunc.History() {
+			assert.True(t, len(call.Arg1.UserIDs) > 0 || len(call.Arg1.AccountIDs) > 0)
+		}
+	})
+}
+
+func TestRankCandidates(t *testing.T) {
+	cfg := InferenceConfig{ContributorsWeight: 1, ViewsWeight: 1, ReviewersWeight: 1, MaxOwners: 10}
+
+	t.Run("ties are sorted by handle and email", func(t *testing.T) {
+		got := rankCandidates([]*InferredOwner{
+			{Email: "b@example.com", Contributions: 1},
+			{Handle: "z", Contributions: 1},
+			{Email: "a@example.com", Contributions: 1},
+		}, cfg)
+		assert.Equal(t, []InferredOwner{
+			{Email: "a@example.com", Score: 1, Contributions: 1},
+			{Email: "b@example.com", Score: 1, Contributions: 1},
+			{Handle: "z", Score: 1, Contributions: 1},
+		}, got)
+	})
+
+	t.Run("owners without signal or identity are dropped", func(t *testing.T) {
+		got := rankCandidates([]*InferredOwner{
+			{Handle: "a", Views: 1},
+			{Handle: "b"},
+			{Reviews: 3},
+		}, cfg)
+		assert.Equal(t, []InferredOwner{{Handle: "a", Score: 1, Views: 1}}, got)
+	})
+}
@@ -0,0 +1,143 @@
+package own
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	bstore "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// reviewersSource returns the people who recently reviewed changes to a repository.
+type reviewersSource interface {
+	// RecentReviewers returns the number of recent reviews per reviewer login on
+	// the code host of the repository.
+	RecentReviewers(ctx context.Context, repoID api.RepoID) (map[string]int, error)
+}
+
+// reviewWindow matches the window of the recent contributors signal.
+const reviewWindow = 90 * 24 * time.Hour
+
+// maxReviewedChangesets bounds the number of changesets per repository whose
+// reviews are considered.
+const maxReviewedChangesets = 1000
+
+// reviewEventKinds are the changeset events that record a review by a person.
+var reviewEventKinds = []btypes.ChangesetEventKind{
+	btypes.ChangesetEventKindGitHubReviewed,
+	btypes.ChangesetEventKindGitLabApproved,
+	btypes.ChangesetEventKindBitbucketServerApproved,
+	btypes.ChangesetEventKindBitbucketServerReviewed,
+	btypes.ChangesetEventKindBitbucketCloudApproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequested,
+	btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
+	btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
+	btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
+	btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+	btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
+}
+
+// changesetReviewersSource returns the reviewers of changesets tracked by batch
+// changes. Changeset events are not associated with files, so the signal applies
+// to the whole repository.
+type changesetReviewersSource struct {
+	store *bstore.Store
+	now   func() time.Time
+}
+
+func newChangesetReviewersSource(db database.DB) *changesetReviewersSource {
+	observationCtx := observation.NewContext(log.Scoped("own.reviewers", "changeset reviewers for ownership inference"))
+	return &changesetReviewersSource{
+		// Reading changeset events doesn't need the encryption key, which is only
+		// used for code host credentials.
+		store: bstore.New(db, observationCtx, nil),
+		now:   time.Now,
+	}
+}
+
+func (s *changesetReviewersSource) RecentReviewers(ctx context.Context, repoID api.RepoID) (map[string]int, error) {
+	changesets, _, err := s.store.ListChangesets(ctx, bstore.ListChangesetsOpts{
+		LimitOpts: bstore.LimitOpts{Limit: maxReviewedChangesets},
+		RepoIDs:   []api.RepoID{repoID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListChangesets")
+	}
+	if len(changesets) == 0 {
+		return map[string]int{}, nil
+	}
+
+	ids := make([]int64, 0, len(changesets))
+	for _, c := range changesets {
+		ids = append(ids, c.ID)
+	}
+	events, _, err := s.store.ListChangesetEvents(ctx, bstore.ListChangesetEventsOpts{
+		ChangesetIDs: ids,
+		Kinds:        reviewEventKinds,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListChangesetEvents")
+	}
+
+	return countReviews(events, s.now().Add(-reviewWindow)), nil
+}
+
+// countReviews returns the number of reviews per reviewer login in events that
+// happened after since.
+func countReviews(events []*btypes.ChangesetEvent, since time.Time) map[string]int {
+	reviewers := make(map[string]int)
+	for _, e := range events {
+		if e.Timestamp().Before(since) {
+			continue
+		}
+		if author := e.ReviewAuthor(); author != "" {
+			reviewers[author]++
+		}
+	}
+	return reviewers
+}
+
+// accountLogin returns the login of the external account on its code host, in
+// the form changeset events identify reviewers by. It returns an empty string
+// for code hosts reviewers can't be matched on.
+func accountLogin(ctx context.Context, account *extsvc.Account) (string, error) {
+	switch account.ServiceType {
+	case extsvc.TypeGitHub:
+		user, _, err := github.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil || user == nil || user.Login == nil {
+			return "", err
+		}
+		return *user.Login, nil
+
+	case extsvc.TypeGitLab:
+		user, _, err := gitlab.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil || user == nil {
+			return "", err
+		}
+		return user.Username, nil
+
+	case extsvc.TypeAzureDevOps:
+		// Reviewers are identified by their unique name, which is their email.
+		profile, _, err := azuredevops.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil || profile == nil {
+			return "", err
+		}
+		return strings.ToLower(profile.EmailAddress), nil
+
+	case extsvc.TypeBitbucketCloud:
+		// Reviewers are identified by their UUID, which is the account ID.
+		return account.AccountID, nil
+	}
+	return "", nil
+}
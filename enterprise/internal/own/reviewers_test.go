@@ -0,0 +1,70 @@
+package own
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestCountReviews(t *testing.T) {
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	review := func(author string, at time.Time) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindGitHubReviewed,
+			Metadata: &github.PullRequestReview{
+				Author:    github.Actor{Login: author},
+				CreatedAt: at,
+				UpdatedAt: at,
+			},
+		}
+	}
+
+	got := countReviews([]*btypes.ChangesetEvent{
+		review("alice", now.Add(-time.Hour)),
+		review("alice", now.Add(-24*time.Hour)),
+		review("bob", now.Add(-time.Hour)),
+		// Outside of the window.
+		review("carol", now.Add(-reviewWindow-time.Hour)),
+		// Without an author.
+		review("", now),
+	}, now.Add(-reviewWindow))
+
+	assert.Equal(t, map[string]int{"alice": 2, "bob": 1}, got)
+}
+
+func TestAccountLogin(t *testing.T) {
+	ctx := context.Background()
+	account := func(serviceType, accountID, data string) *extsvc.Account {
+		a := &extsvc.Account{AccountSpec: extsvc.AccountSpec{ServiceType: serviceType, AccountID: accountID}}
+		if data != "" {
+			a.Data = extsvc.NewUnencryptedData(json.RawMessage(data))
+		}
+		return a
+	}
+
+	for name, tc := range map[string]struct {
+		account *extsvc.Account
+		want    string
+	}{
+		"github":          {account(extsvc.TypeGitHub, "1", `{"login": "alice"}`), "alice"},
+		"github no data":  {account(extsvc.TypeGitHub, "1", ""), ""},
+		"gitlab":          {account(extsvc.TypeGitLab, "1", `{"username": "alice"}`), "alice"},
+		"azure devops":    {account(extsvc.TypeAzureDevOps, "1", `{"emailAddress": "Alice@example.com"}`), "alice@example.com"},
+		"bitbucket cloud": {account(extsvc.TypeBitbucketCloud, "{uuid}", `{"username": "alice"}`), "{uuid}"},
+		"unsupported":     {account(extsvc.TypeBitbucketServer, "1", `{"name": "alice"}`), ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := accountLogin(ctx, tc.account)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -34,31 +35,58 @@ func NewFileHasOwnersJob(child job.Job, features *search.Features, includeOwners
 	}
 }
 
+// NewFileHasInferredOwnersJob returns a job that filters files by their owners
+// inferred from recent contributions, views and reviews, ignoring CODEOWNERS.
+func NewFileHasInferredOwnersJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
+	return &fileHasOwnersJob{
+		child:         child,
+		features:      features,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+		inferredOnly:  true,
+	}
+}
+
 type fileHasOwnersJob struct {
 	child    job.Job
 	features *search.Features
 
 	includeOwners []string
 	excludeOwners []string
+
+	// inferredOnly is true if files are filtered by their inferred owners only.
+	inferredOnly bool
 }
 
 func (s *fileHasOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
 	if s.features == nil || !s.features.CodeOwnershipSearch {
-		return nil, &featureFlagError{predicate: "file:has.owner()"}
+		predicate := "file:has.owner()"
+		if s.inferredOnly {
+			predicate = "file:has.inferred.owner()"
+		}
+		return nil, &featureFlagError{predicate: predicate}
 	}
 	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
 	defer finish(alert, err)
 
 	var (
-		mu   sync.Mutex
-		errs error
+		mu        sync.Mutex
+		errs      error
+		remaining = limits.MaxInferredOwnershipMatches
 	)
 
 	rules := NewRulesCache(clients.Gitserver, clients.DB)
 
 	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		if s.inferredOnly {
+			// Backends may send more matches than they were asked for. These are
+			// dropped instead of inferring their owners.
+			mu.Lock()
+			event.Results = limitMatches(event.Results, &remaining)
+			mu.Unlock()
+		}
 		var err error
-		event.Results, err = applyCodeOwnershipFiltering(ctx, &rules, s.includeOwners, s.excludeOwners, event.Results)
+		event.Results, err = applyCodeOwnershipFiltering(ctx, &rules, s.inferredOnly, s.includeOwners, s.excludeOwners, event.Results)
 		if err != nil {
 			mu.Lock()
 			errs = errors.Append(errs, err)
@@ -75,6 +103,9 @@ func (s *fileHasOwnersJob) Run(ctx context.Context, clients job.RuntimeClients,
 }
 
 func (s *fileHasOwnersJob) Name() string {
+	if s.inferredOnly {
+		return "FileHasInferredOwnersFilterJob"
+	}
 	return "FileHasOwnersFilterJob"
 }
 
@@ -104,6 +135,7 @@ func (s *fileHasOwnersJob) MapChildren(fn job.MapFunc) job.Job {
 func applyCodeOwnershipFiltering(
 	ctx context.Context,
 	rules *RulesCache,
+	inferredOnly bool,
 	includeOwners,
 	excludeOwners []string,
 	matches []result.Match,
@@ -120,17 +152,11 @@ matchesLoop:
 			continue
 		}
 
-		file, err := rules.GetFromCacheOrFetch(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID)
+		owners, err := rules.GetOwners(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID, mm.File.Path, inferredOnly)
 		if err != nil {
 			errs = errors.Append(errs, err)
 			continue matchesLoop
 		}
-		rule := file.Match(mm.File.Path)
-		var owners []*codeownerspb.Owner
-		// If match.
-		if rule != nil {
-			owners = rule.GetOwner()
-		}
 		for _, owner := range includeOwners {
 			if !containsOwner(owners, owner) {
 				continue matchesLoop
@@ -148,6 +174,16 @@ matchesLoop:
 	return filtered, errs
 }
 
+// limitMatches returns the leading matches that fit in the remaining budget and
+// deducts them from it.
+func limitMatches(matches []result.Match, remaining *int) []result.Match {
+	if len(matches) > *remaining {
+		matches = matches[:*remaining]
+	}
+	*remaining -= len(matches)
+	return matches
+}
+
 // containsOwner searches within emails and handles in a case-insensitive
 // manner. Empty string passed as search term means any, so the predicate
 // returns true if there is at least one owner, and false otherwise.
@@ -13,10 +13,13 @@ import (
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestFeatureFlaggedFileHasOwnerJob(t *testing.T) {
@@ -306,9 +309,49 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 
 			rules := NewRulesCache(gitserverClient, db)
 
-			matches, _ := applyCodeOwnershipFiltering(ctx, &rules, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
+			matches, _ := applyCodeOwnershipFiltering(ctx, &rules, false, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
 
 			tt.want.Equal(t, matches)
 		})
 	}
 }
+
+func TestApplyInferredOwnershipFiltering(t *testing.T) {
+	zero := 0.0
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		OwnInference: &schema.OwnInference{RecentViewsWeight: &zero, ReviewersWeight: &zero},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	contributions := database.NewMockRecentContributionSignalStore()
+	contributions.FindRecentAuthorsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoID, path string) ([]database.RecentContributorSummary, error) {
+		if path != "main.go" {
+			return nil, nil
+		}
+		return []database.RecentContributorSummary{{AuthorEmail: "alice@example.com", ContributionCount: 3}}, nil
+	})
+	db := edb.NewMockEnterpriseDB()
+	db.RecentContributionSignalsFunc.SetDefaultReturn(contributions)
+
+	rules := NewRulesCache(gitserver.NewMockClient(), db)
+
+	matches, err := applyCodeOwnershipFiltering(context.Background(), &rules, true, []string{"alice@example.com"}, nil, []result.Match{
+		&result.FileMatch{File: result.File{Path: "main.go"}},
+		&result.FileMatch{File: result.File{Path: "README.md"}},
+	})
+	require.NoError(t, err)
+	autogold.Expect([]result.Match{&result.FileMatch{File: result.File{Path: "main.go"}}}).Equal(t, matches)
+}
+
+func TestLimitMatches(t *testing.T) {
+	match := func(path string) result.Match {
+		return &result.FileMatch{File: result.File{Path: path}}
+	}
+
+	remaining := 3
+	assert.Equal(t, []result.Match{match("a"), match("b")}, limitMatches([]result.Match{match("a"), match("b")}, &remaining))
+	assert.Equal(t, 1, remaining)
+	assert.Equal(t, []result.Match{match("c")}, limitMatches([]result.Match{match("c"), match("d")}, &remaining))
+	assert.Equal(t, 0, remaining)
+	assert.Empty(t, limitMatches([]result.Match{match("e")}, &remaining))
+}
@@ -17,8 +17,14 @@ type RulesKey struct {
 	commitID api.CommitID
 }
 
+type inferredOwnersKey struct {
+	repoID api.RepoID
+	path   string
+}
+
 type RulesCache struct {
 	rules      map[RulesKey]*codeowners.Ruleset
+	inferred   map[inferredOwnersKey][]*codeownerspb.Owner
 	ownService own.Service
 
 	mu sync.RWMutex
@@ -27,6 +33,7 @@ type RulesCache struct {
 func NewRulesCache(gs gitserver.Client, db database.DB) RulesCache {
 	return RulesCache{
 		rules:      make(map[RulesKey]*codeowners.Ruleset),
+		inferred:   make(map[inferredOwnersKey][]*codeownerspb.Owner),
 		ownService: own.NewService(gs, db),
 	}
 }
@@ -55,3 +62,49 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoN
 	}
 	return c.rules[key], nil
 }
+
+// GetInferredOwners returns the owners of the file inferred from signals other
+// than CODEOWNERS, most likely owner first.
+func (c *RulesCache) GetInferredOwners(ctx context.Context, repoID api.RepoID, path string) ([]*codeownerspb.Owner, error) {
+	key := inferredOwnersKey{repoID, path}
+	c.mu.RLock()
+	owners, ok := c.inferred[key]
+	c.mu.RUnlock()
+	if ok {
+		return owners, nil
+	}
+
+	inferred, err := c.ownService.InferOwners(ctx, repoID, path)
+	if err != nil {
+		return nil, err
+	}
+	owners = make([]*codeownerspb.Owner, 0, len(inferred))
+	for _, o := range inferred {
+		owners = append(owners, o.Owner())
+	}
+
+	c.mu.Lock()
+	c.inferred[key] = owners
+	c.mu.Unlock()
+	return owners, nil
+}
+
+// GetOwners returns the owners of the file. Unless inferredOnly is set, these are
+// the owners from CODEOWNERS. Inferred owners are returned if inferredOnly is set,
+// or if the file has no CODEOWNERS entry and inferred owners are configured to be
+// used as a fallback.
+func (c *RulesCache) GetOwners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string, inferredOnly bool) ([]*codeownerspb.Owner, error) {
+	if !inferredOnly {
+		rs, err := c.GetFromCacheOrFetch(ctx, repoName, repoID, commitID)
+		if err != nil {
+			return nil, err
+		}
+		if rule := rs.Match(path); rule != nil && len(rule.GetOwner()) > 0 {
+			return rule.GetOwner(), nil
+		}
+		if !own.InferredOwnersAsFallback() {
+			return nil, nil
+		}
+	}
+	return c.GetInferredOwners(ctx, repoID, path)
+}
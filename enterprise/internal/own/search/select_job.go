@@ -117,19 +117,18 @@ func getCodeOwnersFromMatches(
 		if !ok {
 			continue
 		}
-		rs, err := rules.GetFromCacheOrFetch(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID)
+		owners, err := rules.GetOwners(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID, mm.File.Path, false)
 		if err != nil {
 			errs = errors.Append(errs, err)
 			continue
 		}
-		rule := rs.Match(mm.File.Path)
 		// No match.
-		if rule == nil || len(rule.GetOwner()) == 0 {
+		if len(owners) == 0 {
 			hasResultWithNoOwners = true
 			continue
 		}
 
-		resolvedOwners, err := rules.ownService.ResolveOwnersWithType(ctx, rule.GetOwner())
+		resolvedOwners, err := rules.ownService.ResolveOwnersWithType(ctx, owners)
 		if err != nil {
 			errs = errors.Append(errs, err)
 			continue
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/singleflight"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
@@ -17,11 +21,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Service gives access to code ownership data.
-// At this point only data from CODEOWNERS file is presented, if available.
+// Service gives access to code ownership data from CODEOWNERS files, and to owners
+// inferred from other signals.
 type Service interface {
 	// RulesetForRepo returns a CODEOWNERS file ruleset from a given repository at given commit ID.
 	// If a CODEOWNERS file has been manually ingested for the repository, it will prioritise returning that file.
@@ -31,6 +36,10 @@ type Service interface {
 	// ResolveOwnersWithType takes a list of codeownerspb.Owner and attempts to retrieve more information about the
 	// owner from the users and teams databases.
 	ResolveOwnersWithType(context.Context, []*codeownerspb.Owner) ([]codeowners.ResolvedOwner, error)
+
+	// InferOwners returns the likely owners of a file ranked by recent contributions,
+	// views and changeset reviews, regardless of CODEOWNERS.
+	InferOwners(ctx context.Context, repoID api.RepoID, path string) ([]InferredOwner, error)
 }
 
 var _ Service = &service{}
@@ -40,15 +49,25 @@ func NewService(g gitserver.Client, db database.DB) Service {
 		gitserverClient: g,
 		db:              edb.NewEnterpriseDB(db),
 		ownerCache:      make(map[ownerKey]codeowners.ResolvedOwner),
+		reviewers:       newChangesetReviewersSource(db),
+		userCache:       make(map[int32]ownerKey),
+		reviewerCache:   make(map[api.RepoID]map[int32]int),
+		group:           &singleflight.Group{},
+		logger:          log.Scoped("own", "code ownership service"),
 	}
 }
 
 type service struct {
 	gitserverClient gitserver.Client
 	db              edb.EnterpriseDB
+	reviewers       reviewersSource
 
-	mu         sync.Mutex
-	ownerCache map[ownerKey]codeowners.ResolvedOwner
+	mu            sync.Mutex
+	ownerCache    map[ownerKey]codeowners.ResolvedOwner
+	userCache     map[int32]ownerKey
+	reviewerCache map[api.RepoID]map[int32]int
+	group         *singleflight.Group
+	logger        log.Logger
 }
 
 type ownerKey struct {
@@ -69,7 +88,9 @@ var codeownersLocations = []string{
 }
 
 // RulesetForRepo makes a best effort attempt to return a CODEOWNERS file ruleset
-// from one of the possible codeownersLocations, or the ingested codeowners files. It returns nil if no match is found.
+// from one of the possible codeownersLocations, or the ingested codeowners files.
+// Repositories without a CODEOWNERS file fall back to the Gerrit OWNERS files in
+// the repository. It returns nil if no match is found.
 func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
 	ingestedCodeowners, err := s.db.Codeowners().GetCodeownersForRepo(ctx, repoID)
 	if err != nil && !errcode.IsNotFound(err) {
@@ -97,7 +118,58 @@ func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, rep
 		}
 		return nil, err
 	}
-	return nil, nil
+	return s.gerritRulesetForRepo(ctx, repoName, repoID, commitID)
+}
+
+// gerritRulesetForRepo returns the ruleset resolved from the Gerrit OWNERS files
+// in the repository, or nil if there are none.
+func (s *service) gerritRulesetForRepo(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	paths, err := s.gitserverClient.LsFiles(
+		ctx,
+		authz.DefaultSubRepoPermsChecker,
+		repoName,
+		commitID,
+		gitdomain.PathspecLiteral(codeowners.GerritOwnersFileName),
+		gitdomain.PathspecSuffix("/"+codeowners.GerritOwnersFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	// Other tools, like Prow, also use files named OWNERS. These are skipped, so
+	// that they don't prevent the remaining files from being used.
+	read := func(path string) ([]byte, error) {
+		return s.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repoName, commitID, path)
+	}
+	invalid := map[string]struct{}{}
+	onInvalid := func(path string, err error) {
+		invalid[path] = struct{}{}
+		s.logger.Warn("skipping OWNERS file that is not in the Gerrit format",
+			log.String("repo", string(repoName)),
+			log.String("commit", string(commitID)),
+			log.String("path", path),
+			log.Error(err))
+	}
+	pbfile, err := codeowners.ParseGerritOwners(paths, read, onInvalid)
+	if err != nil {
+		return nil, err
+	}
+	validPaths := paths[:0]
+	for _, path := range paths {
+		if _, ok := invalid[strings.TrimPrefix(path, "/")]; !ok {
+			validPaths = append(validPaths, path)
+		}
+	}
+	if len(validPaths) == 0 {
+		return nil, nil
+	}
+	// Rules come from many OWNERS files, the top-most one is linked as the source.
+	sort.Slice(validPaths, func(i, j int) bool {
+		return strings.Count(validPaths[i], "/") < strings.Count(validPaths[j], "/")
+	})
+	return codeowners.NewRuleset(codeowners.GitRulesetSource{Repo: repoID, Commit: commitID, Path: validPaths[0]}, pbfile), nil
 }
 
 func (s *service) ResolveOwnersWithType(ctx context.Context, protoOwners []*codeownerspb.Owner) ([]codeowners.ResolvedOwner, error) {
@@ -101,6 +101,60 @@ func TestOwnersCannotFindFile(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestOwnersServesGerritOwnersFiles(t *testing.T) {
+	repo := repoFiles{
+		{"repo", "SHA", "OWNERS"}:     "root@example.com\n",
+		{"repo", "SHA", "cmd/OWNERS"}: "cmd@example.com\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+	git.LsFilesFunc.SetDefaultReturn([]string{"cmd/OWNERS", "OWNERS"}, nil)
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, edb.CodeownersFileNotFoundError{})
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+
+	got, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	assert.Equal(t, codeowners.GitRulesetSource{Repo: 1, Commit: "SHA", Path: "OWNERS"}, got.GetSource())
+	assert.Equal(t, []*codeownerspb.Owner{{Email: "cmd@example.com"}, {Email: "root@example.com"}}, got.Match("cmd/main.go").GetOwner())
+}
+
+func TestOwnersSkipsProwOwnersFiles(t *testing.T) {
+	repo := repoFiles{
+		{"repo", "SHA", "OWNERS"}:     "approvers:\n- alice\nreviewers:\n- bob\n",
+		{"repo", "SHA", "cmd/OWNERS"}: "cmd@example.com\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+	git.LsFilesFunc.SetDefaultReturn([]string{"cmd/OWNERS", "OWNERS"}, nil)
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, edb.CodeownersFileNotFoundError{})
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+
+	got, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	assert.Equal(t, codeowners.GitRulesetSource{Repo: 1, Commit: "SHA", Path: "cmd/OWNERS"}, got.GetSource())
+	assert.Equal(t, []*codeownerspb.Owner{{Email: "cmd@example.com"}}, got.Match("cmd/main.go").GetOwner())
+	assert.Nil(t, got.Match("main.go"))
+
+	// Without any Gerrit OWNERS file, the repository has no owners.
+	git.LsFilesFunc.SetDefaultReturn([]string{"OWNERS"}, nil)
+	got, err = NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Files that can't be read are not skipped.
+	readErr := errors.New("gitserver unavailable")
+	git.ReadFileFunc.SetDefaultReturn(nil, readErr)
+	git.LsFilesFunc.SetDefaultReturn([]string{"cmd/OWNERS", "OWNERS"}, nil)
+	_, err = NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	assert.ErrorIs(t, err, readErr)
+}
+
 func TestOwnersServesIngestedFile(t *testing.T) {
 	t.Run("return manually ingested codeowners file", func(t *testing.T) {
 		codeownersProto := &codeownerspb.File{
@@ -17,6 +17,10 @@ func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Feature
 	return ownsearch.NewFileHasOwnersJob(child, features, includeOwners, excludeOwners)
 }
 
+func (e *enterpriseJobs) FileHasInferredOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
+	return ownsearch.NewFileHasInferredOwnersJob(child, features, includeOwners, excludeOwners)
+}
+
 func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Features) job.Job {
 	return ownsearch.NewSelectOwnersJob(child, features)
 }
@@ -0,0 +1,282 @@
+package adminanalytics
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/segmentio/parquet-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UsageExportFormat is the file format of a usage export.
+type UsageExportFormat string
+
+const (
+	UsageExportFormatCSV     UsageExportFormat = "csv"
+	UsageExportFormatParquet UsageExportFormat = "parquet"
+)
+
+// Valid returns true if f is a supported export format.
+func (f UsageExportFormat) Valid() bool {
+	return f == UsageExportFormatCSV || f == UsageExportFormatParquet
+}
+
+// ContentType returns the media type of files in the format.
+func (f UsageExportFormat) ContentType() string {
+	if f == UsageExportFormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv"
+}
+
+// UsageRow is the number of times a user triggered an event on a day.
+type UsageRow struct {
+	Date time.Time
+	// UserID is 0 for anonymous users, whose usage is aggregated into a single
+	// row per day, event and repository.
+	UserID   int32
+	Username string
+	// Feature is the admin analytics dashboard the event is counted in, or
+	// "other".
+	Feature string
+	Event   string
+	// Repository is the name of the repository the event happened in, or empty
+	// if the event did not happen on a repository page.
+	Repository string
+	Count      int64
+}
+
+// usageFeatures groups the events counted by the admin analytics dashboards
+// by feature. Keep this in sync with the events used by the dashboards.
+var usageFeatures = []struct {
+	name   string
+	events []string
+}{
+	{"search", []string{
+		"SearchResultsQueried",
+		"SearchResultClicked",
+		"ViewBlob",
+		"CodeCopied",
+		"GoToCodeHostClicked",
+		"vscode.open.file",
+		"openInAtom.open.file",
+		"openineditor.open.file",
+		"openInWebstorm.open.file",
+	}},
+	{"code_intelligence", []string{
+		"findReferences",
+		"goToDefinition",
+		"goToDefinition.preloaded",
+		"codeintel.lsifDefinitions",
+		"codeintel.lsifDefinitions.xrepo",
+		"codeintel.lsifReferences",
+		"codeintel.lsifReferences.xrepo",
+		"codeintel.searchDefinitions",
+		"codeintel.searchDefinitions.xrepo",
+		"codeintel.searchReferences",
+		"codeintel.searchReferences.xrepo",
+	}},
+	{"notebooks", []string{
+		"SearchNotebookCreated",
+		"SearchNotebookPageViewed",
+		"SearchNotebookRunAllBlocks",
+		"SearchNotebookRunBlock",
+	}},
+	{"code_insights", []string{
+		"InsightDataPointClick",
+		"InsightHover",
+	}},
+	{"extensions", []string{
+		"IDESearchSubmitted",
+		"VSCESearchSubmitted",
+	}},
+}
+
+const usageExportQuery = `
+SELECT
+	usage.date,
+	usage.user_id,
+	COALESCE(users.username, ''),
+	usage.feature,
+	usage.name,
+	usage.repository,
+	usage.event_count
+FROM (
+	SELECT
+		timestamp::date AS date,
+		user_id,
+		%s AS feature,
+		name,
+		%s AS repository,
+		COUNT(*) AS event_count
+	FROM event_logs
+	WHERE %s
+	GROUP BY date, user_id, feature, name, repository
+) usage
+LEFT JOIN users ON users.id = usage.user_id
+ORDER BY usage.date, usage.user_id, usage.feature, usage.name, usage.repository
+`
+
+// usageRepositoryExpr extracts the repository name from the URL of events on
+// repository pages, like https://sourcegraph.example.com/github.com/foo/bar@rev/-/blob/main.go.
+func usageRepositoryExpr() *sqlf.Query {
+	return sqlf.Sprintf(
+		`CASE WHEN url LIKE %s THEN split_part(split_part(regexp_replace(url, '^[a-z]+://[^/]+/', ''), '/-/', 1), '@', 1) ELSE '' END`,
+		"%/-/%",
+	)
+}
+
+func usageFeatureExpr() *sqlf.Query {
+	cases := make([]*sqlf.Query, 0, len(usageFeatures))
+	for _, f := range usageFeatures {
+		events := make([]*sqlf.Query, 0, len(f.events))
+		for _, name := range f.events {
+			events = append(events, sqlf.Sprintf("%s", name))
+		}
+		cases = append(cases, sqlf.Sprintf("WHEN name IN (%s) THEN %s", sqlf.Join(events, ", "), f.name))
+	}
+	return sqlf.Sprintf("CASE %s ELSE 'other' END", sqlf.Join(cases, " "))
+}
+
+// ExportUsage writes the daily usage per user, feature and repository between
+// the days from and to, both inclusive, to w in the given format. It returns
+// the number of rows written.
+//
+// The same users and events as in the admin analytics dashboards are
+// excluded.
+func ExportUsage(ctx context.Context, db database.DB, from, to time.Time, format UsageExportFormat, w io.Writer) (int, error) {
+	conds, err := getDefaultConds(ctx, db, false)
+	if err != nil {
+		return 0, err
+	}
+	conds = append(conds,
+		sqlf.Sprintf("timestamp >= %s", truncateDay(from)),
+		sqlf.Sprintf("timestamp < %s", truncateDay(to).AddDate(0, 0, 1)),
+	)
+
+	uw, err := newUsageWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	q := sqlf.Sprintf(usageExportQuery, usageFeatureExpr(), usageRepositoryExpr(), sqlf.Join(conds, " AND "))
+	rows, err := db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var r UsageRow
+		if err := rows.Scan(&r.Date, &r.UserID, &r.Username, &r.Feature, &r.Event, &r.Repository, &r.Count); err != nil {
+			return count, err
+		}
+		if err := uw.Write(r); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	return count, uw.Close()
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// usageWriter writes usage rows to a file in an export format.
+type usageWriter interface {
+	Write(r UsageRow) error
+	// Close writes any buffered data. It does not close the underlying writer.
+	Close() error
+}
+
+func newUsageWriter(format UsageExportFormat, w io.Writer) (usageWriter, error) {
+	switch format {
+	case UsageExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(usageCSVHeader); err != nil {
+			return nil, err
+		}
+		return &csvUsageWriter{w: cw}, nil
+	case UsageExportFormatParquet:
+		return newParquetUsageWriter(w, usageParquetRowGroupSize), nil
+	default:
+		return nil, errors.Errorf("unsupported export format %q", format)
+	}
+}
+
+var usageCSVHeader = []string{"date", "user_id", "username", "feature", "event", "repository", "event_count"}
+
+type csvUsageWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvUsageWriter) Write(r UsageRow) error {
+	return w.w.Write([]string{
+		r.Date.Format("2006-01-02"),
+		strconv.FormatInt(int64(r.UserID), 10),
+		r.Username,
+		r.Feature,
+		r.Event,
+		r.Repository,
+		strconv.FormatInt(r.Count, 10),
+	})
+}
+
+func (w *csvUsageWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// parquetUsageRow is the schema of Parquet exports. Dates are stored as the
+// number of days since the Unix epoch.
+type parquetUsageRow struct {
+	Date       int32  `parquet:"date,date"`
+	UserID     int32  `parquet:"user_id"`
+	Username   string `parquet:"username,dict"`
+	Feature    string `parquet:"feature,dict"`
+	Event      string `parquet:"event,dict"`
+	Repository string `parquet:"repository,dict"`
+	Count      int64  `parquet:"event_count"`
+}
+
+// usageParquetRowGroupSize is the number of rows per row group of Parquet
+// exports. Rows are buffered in memory until their row group is written.
+const usageParquetRowGroupSize = 50_000
+
+type parquetUsageWriter struct {
+	w *parquet.GenericWriter[parquetUsageRow]
+}
+
+func newParquetUsageWriter(w io.Writer, rowGroupSize int64) *parquetUsageWriter {
+	return &parquetUsageWriter{w: parquet.NewGenericWriter[parquetUsageRow](w, parquet.MaxRowsPerRowGroup(rowGroupSize))}
+}
+
+func (w *parquetUsageWriter) Write(r UsageRow) error {
+	_, err := w.w.Write([]parquetUsageRow{{
+		Date:       int32(truncateDay(r.Date).Unix() / (24 * 60 * 60)),
+		UserID:     r.UserID,
+		Username:   r.Username,
+		Feature:    r.Feature,
+		Event:      r.Event,
+		Repository: r.Repository,
+		Count:      r.Count,
+	}})
+	return err
+}
+
+func (w *parquetUsageWriter) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,94 @@
+package adminanalytics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segmentio/parquet-go"
+)
+
+var testUsageRows = []UsageRow{
+	{Date: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), UserID: 1, Username: "alice", Feature: "search", Event: "SearchResultsQueried", Count: 12},
+	{Date: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), UserID: 1, Username: "alice", Feature: "search", Event: "ViewBlob", Repository: "github.com/sourcegraph/sourcegraph", Count: 3},
+	{Date: time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC), Feature: "other", Event: "ViewHome", Count: 40},
+}
+
+func writeUsageRows(t *testing.T, format UsageExportFormat) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := newUsageWriter(format, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range testUsageRows {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUsageWriterCSV(t *testing.T) {
+	want := `date,user_id,username,feature,event,repository,event_count
+2023-05-01,1,alice,search,SearchResultsQueried,,12
+2023-05-01,1,alice,search,ViewBlob,github.com/sourcegraph/sourcegraph,3
+2023-05-02,0,,other,ViewHome,,40
+`
+	if diff := cmp.Diff(want, string(writeUsageRows(t, UsageExportFormatCSV))); diff != "" {
+		t.Fatalf("unexpected CSV (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageWriterParquet(t *testing.T) {
+	data := writeUsageRows(t, UsageExportFormatParquet)
+
+	rows, err := parquet.Read[parquetUsageRow](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []parquetUsageRow{
+		{Date: 19478, UserID: 1, Username: "alice", Feature: "search", Event: "SearchResultsQueried", Count: 12},
+		{Date: 19478, UserID: 1, Username: "alice", Feature: "search", Event: "ViewBlob", Repository: "github.com/sourcegraph/sourcegraph", Count: 3},
+		{Date: 19479, Feature: "other", Event: "ViewHome", Count: 40},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageWriterParquetRowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w := newParquetUsageWriter(&buf, 2)
+	for _, r := range testUsageRows {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int64
+	for _, rg := range f.RowGroups() {
+		sizes = append(sizes, rg.NumRows())
+	}
+	if diff := cmp.Diff([]int64{2, 1}, sizes); diff != "" {
+		t.Fatalf("unexpected row group sizes (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageWriterUnsupportedFormat(t *testing.T) {
+	if _, err := newUsageWriter("xlsx", &bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
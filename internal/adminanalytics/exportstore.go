@@ -0,0 +1,240 @@
+package adminanalytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UsageExport is a background job that exports the daily usage between two
+// days to the upload store.
+type UsageExport struct {
+	ID              int
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt *time.Time
+	ExecutionLogs   []executor.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+
+	InitiatorID *int32
+	Format      UsageExportFormat
+	// RangeStart and RangeEnd are the first and last day of the export.
+	RangeStart time.Time
+	RangeEnd   time.Time
+	// ObjectKey and RowCount are set once the export is uploaded.
+	ObjectKey *string
+	RowCount  *int
+}
+
+func (e *UsageExport) RecordID() int {
+	return e.ID
+}
+
+// UsageExportObjectKey returns the key of the file of an export in the upload
+// store.
+func UsageExportObjectKey(id int, format UsageExportFormat) string {
+	return fmt.Sprintf("usage-export-%d.%s", id, format)
+}
+
+// UsageExportNotFoundErr is returned when a usage export does not exist.
+type UsageExportNotFoundErr struct {
+	id int
+}
+
+func (e *UsageExportNotFoundErr) Error() string {
+	return fmt.Sprintf("usage export not found: id=%d", e.id)
+}
+
+func (e *UsageExportNotFoundErr) NotFound() bool {
+	return true
+}
+
+var usageExportColumns = []*sqlf.Query{
+	sqlf.Sprintf("usage_analytics_exports.id"),
+	sqlf.Sprintf("usage_analytics_exports.state"),
+	sqlf.Sprintf("usage_analytics_exports.failure_message"),
+	sqlf.Sprintf("usage_analytics_exports.queued_at"),
+	sqlf.Sprintf("usage_analytics_exports.started_at"),
+	sqlf.Sprintf("usage_analytics_exports.finished_at"),
+	sqlf.Sprintf("usage_analytics_exports.process_after"),
+	sqlf.Sprintf("usage_analytics_exports.num_resets"),
+	sqlf.Sprintf("usage_analytics_exports.num_failures"),
+	sqlf.Sprintf("usage_analytics_exports.last_heartbeat_at"),
+	sqlf.Sprintf("usage_analytics_exports.execution_logs"),
+	sqlf.Sprintf("usage_analytics_exports.worker_hostname"),
+	sqlf.Sprintf("usage_analytics_exports.cancel"),
+	sqlf.Sprintf("usage_analytics_exports.initiator_id"),
+	sqlf.Sprintf("usage_analytics_exports.format"),
+	sqlf.Sprintf("usage_analytics_exports.range_start"),
+	sqlf.Sprintf("usage_analytics_exports.range_end"),
+	sqlf.Sprintf("usage_analytics_exports.object_key"),
+	sqlf.Sprintf("usage_analytics_exports.row_count"),
+}
+
+func scanUsageExport(s dbutil.Scanner) (*UsageExport, error) {
+	var e UsageExport
+	var executionLogs []executor.ExecutionLogEntry
+
+	if err := s.Scan(
+		&e.ID,
+		&e.State,
+		&e.FailureMessage,
+		&e.QueuedAt,
+		&e.StartedAt,
+		&e.FinishedAt,
+		&e.ProcessAfter,
+		&e.NumResets,
+		&e.NumFailures,
+		&e.LastHeartbeatAt,
+		pq.Array(&executionLogs),
+		&e.WorkerHostname,
+		&e.Cancel,
+		&e.InitiatorID,
+		&e.Format,
+		&e.RangeStart,
+		&e.RangeEnd,
+		&e.ObjectKey,
+		&e.RowCount,
+	); err != nil {
+		return nil, err
+	}
+	e.ExecutionLogs = append(e.ExecutionLogs, executionLogs...)
+	return &e, nil
+}
+
+func NewUsageExportWorkerStore(observationCtx *observation.Context, dbHandle basestore.TransactableHandle) dbworkerstore.Store[*UsageExport] {
+	return dbworkerstore.New(observationCtx, dbHandle, dbworkerstore.Options[*UsageExport]{
+		Name:              "usage_analytics_export_worker",
+		TableName:         "usage_analytics_exports",
+		ColumnExpressions: usageExportColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(scanUsageExport),
+		OrderByExpression: sqlf.Sprintf("usage_analytics_exports.queued_at, usage_analytics_exports.id"),
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      5,
+		MaxNumRetries:     1,
+	})
+}
+
+// UsageExportStore stores the usage exports requested by site admins.
+type UsageExportStore interface {
+	// Create enqueues an export of the usage between the days from and to, both
+	// inclusive.
+	Create(ctx context.Context, initiatorID int32, format UsageExportFormat, from, to time.Time) (*UsageExport, error)
+	GetByID(ctx context.Context, id int) (*UsageExport, error)
+	// List returns the most recently queued exports.
+	List(ctx context.Context, limit int) ([]*UsageExport, error)
+	// MarkUploaded records the upload store key and number of rows of an
+	// export.
+	MarkUploaded(ctx context.Context, id int, objectKey string, rowCount int) error
+	// ListFinishedBefore returns the oldest exports that finished, successfully
+	// or not, before the given time.
+	ListFinishedBefore(ctx context.Context, before time.Time, limit int) ([]*UsageExport, error)
+	// Delete deletes an export. It does not delete its file in the upload store.
+	Delete(ctx context.Context, id int) error
+}
+
+type usageExportStore struct {
+	*basestore.Store
+}
+
+func NewUsageExportStore(other basestore.ShareableStore) UsageExportStore {
+	return &usageExportStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+const createUsageExportQuery = `
+INSERT INTO usage_analytics_exports (initiator_id, format, range_start, range_end)
+VALUES (%s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *usageExportStore) Create(ctx context.Context, initiatorID int32, format UsageExportFormat, from, to time.Time) (*UsageExport, error) {
+	if !format.Valid() {
+		return nil, errors.Errorf("unsupported export format %q", format)
+	}
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return nil, errors.New("the end of the range is before its start")
+	}
+
+	q := sqlf.Sprintf(createUsageExportQuery, dbutil.NullInt32Column(initiatorID), format, from, to, sqlf.Join(usageExportColumns, ", "))
+	return scanUsageExport(s.QueryRow(ctx, q))
+}
+
+const getUsageExportQuery = `
+SELECT %s
+FROM usage_analytics_exports
+WHERE id = %s
+`
+
+func (s *usageExportStore) GetByID(ctx context.Context, id int) (*UsageExport, error) {
+	q := sqlf.Sprintf(getUsageExportQuery, sqlf.Join(usageExportColumns, ", "), id)
+	e, found, err := basestore.NewFirstScanner(scanUsageExport)(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &UsageExportNotFoundErr{id: id}
+	}
+	return e, nil
+}
+
+const listUsageExportsQuery = `
+SELECT %s
+FROM usage_analytics_exports
+ORDER BY queued_at DESC, id DESC
+LIMIT %s
+`
+
+func (s *usageExportStore) List(ctx context.Context, limit int) ([]*UsageExport, error) {
+	q := sqlf.Sprintf(listUsageExportsQuery, sqlf.Join(usageExportColumns, ", "), limit)
+	return basestore.NewSliceScanner(scanUsageExport)(s.Query(ctx, q))
+}
+
+const markUsageExportUploadedQuery = `
+UPDATE usage_analytics_exports
+SET object_key = %s, row_count = %s
+WHERE id = %s
+`
+
+func (s *usageExportStore) MarkUploaded(ctx context.Context, id int, objectKey string, rowCount int) error {
+	return s.Exec(ctx, sqlf.Sprintf(markUsageExportUploadedQuery, objectKey, rowCount, id))
+}
+
+const listUsageExportsFinishedBeforeQuery = `
+SELECT %s
+FROM usage_analytics_exports
+WHERE state IN ('completed', 'errored', 'failed') AND finished_at < %s
+ORDER BY finished_at, id
+LIMIT %s
+`
+
+func (s *usageExportStore) ListFinishedBefore(ctx context.Context, before time.Time, limit int) ([]*UsageExport, error) {
+	q := sqlf.Sprintf(listUsageExportsFinishedBeforeQuery, sqlf.Join(usageExportColumns, ", "), before, limit)
+	return basestore.NewSliceScanner(scanUsageExport)(s.Query(ctx, q))
+}
+
+const deleteUsageExportQuery = `
+DELETE FROM usage_analytics_exports
+WHERE id = %s
+`
+
+func (s *usageExportStore) Delete(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteUsageExportQuery, id))
+}
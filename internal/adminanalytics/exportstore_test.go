@@ -0,0 +1,147 @@
+package adminanalytics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestExportUsage(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, e := range []struct {
+		name   string
+		userID int32
+		url    string
+		time   time.Time
+	}{
+		{"SearchResultsQueried", user.ID, "https://sourcegraph.example.com/search?q=foo", day},
+		{"SearchResultsQueried", user.ID, "https://sourcegraph.example.com/search?q=bar", day},
+		{"ViewBlob", user.ID, "https://sourcegraph.example.com/github.com/foo/bar@main/-/blob/main.go", day},
+		{"findReferences", user.ID, "https://sourcegraph.example.com/github.com/foo/bar/-/blob/main.go?L1", day},
+		{"ViewHome", 0, "https://sourcegraph.example.com/", day.AddDate(0, 0, 1)},
+		// Outside of the range.
+		{"ViewHome", 0, "https://sourcegraph.example.com/", day.AddDate(0, 0, 2)},
+	} {
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO event_logs (name, argument, url, user_id, anonymous_user_id, source, version, timestamp)
+			VALUES ($1, '{}', $2, $3, 'anonymous', 'WEB', 'version', $4)
+		`, e.name, e.url, e.userID, e.time); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	count, err := ExportUsage(ctx, db, day, day.AddDate(0, 0, 1), UsageExportFormatCSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("unexpected row count %d", count)
+	}
+
+	want := `date,user_id,username,feature,event,repository,event_count
+2023-05-01,1,alice,code_intelligence,findReferences,github.com/foo/bar,1
+2023-05-01,1,alice,search,SearchResultsQueried,,2
+2023-05-01,1,alice,search,ViewBlob,github.com/foo/bar,1
+2023-05-02,0,,other,ViewHome,,1
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("unexpected export (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageExportStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := NewUsageExportStore(db)
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	if _, err := store.Create(ctx, user.ID, UsageExportFormatCSV, to, from); err == nil {
+		t.Fatal("expected an error for an inverted range")
+	}
+	if _, err := store.Create(ctx, user.ID, "xlsx", from, to); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+
+	first, err := store.Create(ctx, user.ID, UsageExportFormatCSV, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Create(ctx, user.ID, UsageExportFormatParquet, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.State != "queued" || *first.InitiatorID != user.ID || !first.RangeStart.Equal(from) || !first.RangeEnd.Equal(to) {
+		t.Fatalf("unexpected export %+v", first)
+	}
+
+	exports, err := store.List(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exports) != 2 || exports[0].ID != second.ID || exports[1].ID != first.ID {
+		t.Fatalf("unexpected exports %+v", exports)
+	}
+
+	if err := store.MarkUploaded(ctx, first.ID, "usage-export-1.csv", 12); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetByID(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ObjectKey == nil || *got.ObjectKey != "usage-export-1.csv" || got.RowCount == nil || *got.RowCount != 12 {
+		t.Fatalf("unexpected export %+v", got)
+	}
+
+	if _, err := store.GetByID(ctx, 1000); err == nil {
+		t.Fatal("expected a not found error")
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE usage_analytics_exports SET state = 'completed', finished_at = $1 WHERE id = $2`, from, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	finished, err := store.ListFinishedBefore(ctx, from.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second export is still queued.
+	if len(finished) != 1 || finished[0].ID != first.ID {
+		t.Fatalf("unexpected exports %+v", finished)
+	}
+	if finished, err = store.ListFinishedBefore(ctx, from, 10); err != nil || len(finished) != 0 {
+		t.Fatalf("unexpected exports %+v, err=%v", finished, err)
+	}
+
+	if err := store.Delete(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetByID(ctx, first.ID); err == nil {
+		t.Fatal("expected a not found error")
+	}
+}
@@ -2,7 +2,9 @@ package audit
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sourcegraph/log"
@@ -19,9 +21,10 @@ import (
 func Log(ctx context.Context, logger log.Logger, record Record) {
 	act := actor.FromContext(ctx)
 
-	// internal actors add a lot of noise to the audit log
+	// internal actors add a lot of noise to the audit log, but security events are
+	// always part of it
 	siteConfig := conf.SiteConfig()
-	if act.Internal && !IsEnabled(siteConfig, InternalTraffic) {
+	if act.Internal && !record.SecurityEvent && !IsEnabled(siteConfig, InternalTraffic) {
 		return
 	}
 
@@ -41,6 +44,43 @@ func Log(ctx context.Context, logger log.Logger, record Record) {
 	loggerFunc := getLoggerFuncWithSeverity(logger, siteConfig)
 	// message string looks like: #{record.Action} (sampling immunity token: #{auditId})
 	loggerFunc(fmt.Sprintf("%s (sampling immunity token: %s)", record.Action, auditId), fields...)
+
+	writeToSinks(ctx, logger, auditId, act, client, record)
+}
+
+// writeToSinks hands the record to all registered sinks. Failures are logged, but
+// never fail the audited operation.
+func writeToSinks(ctx context.Context, logger log.Logger, auditId string, act *actor.Actor, client *requestclient.Client, record Record) {
+	registered := registeredSinks()
+	if len(registered) == 0 {
+		return
+	}
+
+	var recordFields json.RawMessage
+	if record.Data != nil {
+		var err error
+		if recordFields, err = json.Marshal(record.Data); err != nil {
+			logger.Error("failed to write audit log entry to sinks", log.String("auditId", auditId), log.Error(err))
+			return
+		}
+	}
+	entry := Entry{
+		AuditID: auditId,
+		// Postgres stores timestamps with microsecond precision, truncate so that
+		// hashes computed before and after storing the entry match.
+		CreatedAt:    time.Now().UTC().Truncate(time.Microsecond),
+		Actor:        actorId(act),
+		IP:           ip(client),
+		ForwardedFor: forwardedFor(client),
+		Entity:       record.Entity,
+		Action:       record.Action,
+		Fields:       recordFields,
+	}
+	for _, s := range registered {
+		if err := s.Write(ctx, entry); err != nil {
+			logger.Error("failed to write audit log entry to sink", log.String("auditId", auditId), log.Error(err))
+		}
+	}
 }
 
 func actorId(act *actor.Actor) string {
@@ -72,8 +112,15 @@ type Record struct {
 	Entity string
 	// Action describes the state change relevant to the audit log
 	Action string
-	// Fields hold any additional context relevant to the Action
+	// Fields hold any additional context relevant to the Action, as written to the
+	// process logs
 	Fields []log.Field
+	// Data holds the additional context written to the audit log sinks, usually
+	// the same as Fields. Values must be encodable as JSON.
+	Data map[string]any
+	// SecurityEvent marks records of security events, which are logged regardless of
+	// the InternalTraffic setting
+	SecurityEvent bool
 }
 
 type AuditLogSetting = int
@@ -119,6 +166,12 @@ func getLoggerFuncWithSeverity(logger log.Logger, cfg schema.SiteConfiguration)
 	return logger.Info
 }
 
+// IsStorageEnabled returns whether audit log entries are stored in the database.
+func IsStorageEnabled(cfg schema.SiteConfiguration) bool {
+	auditCfg := getAuditCfg(cfg)
+	return auditCfg != nil && auditCfg.Storage != nil && auditCfg.Storage.Enabled
+}
+
 func getAuditCfg(cfg schema.SiteConfiguration) *schema.AuditLog {
 	if logCg := cfg.Log; logCg != nil {
 		return logCg.AuditLog
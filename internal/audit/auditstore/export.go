@@ -0,0 +1,91 @@
+package auditstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit/sinks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// exportBatchSize is the maximum number of entries passed to an exporter at once.
+const exportBatchSize = 500
+
+// defaultRetentionDays is used when audit log storage is enabled without an explicit
+// retention.
+const defaultRetentionDays = 365
+
+var exportLockKey = locker.StringKey("audit_log_export")
+
+// Export delivers the entries stored since the previous run to each exporter, in the
+// order they were stored. Delivery to an exporter stops at the first failed batch and
+// resumes from there on the next run, so entries are delivered at least once. Only
+// one instance exports at a time, concurrent calls return immediately.
+func Export(ctx context.Context, store Store, exporters []sinks.Exporter) (err error) {
+	if len(exporters) == 0 {
+		return nil
+	}
+
+	locked, unlock, err := locker.NewWith(store, "audit_log").Lock(ctx, exportLockKey, false)
+	if err != nil || !locked {
+		return err
+	}
+	defer func() { err = unlock(err) }()
+
+	for _, exporter := range exporters {
+		if exportErr := exportTo(ctx, store, exporter); exportErr != nil {
+			err = errors.Append(err, errors.Wrapf(exportErr, "exporting audit log to %q", exporter.Name()))
+		}
+	}
+	return err
+}
+
+func exportTo(ctx context.Context, store Store, exporter sinks.Exporter) error {
+	cursor, err := store.SinkCursor(ctx, exporter.Name())
+	if err != nil {
+		return err
+	}
+
+	for {
+		entries, err := store.List(ctx, ListOpts{
+			LimitOffset: &database.LimitOffset{Limit: exportBatchSize},
+			AfterID:     cursor,
+			Ascending:   true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		if err := exporter.Export(ctx, entries); err != nil {
+			return err
+		}
+		cursor = entries[len(entries)-1].ID
+		if err := store.SetSinkCursor(ctx, exporter.Name(), cursor); err != nil {
+			return err
+		}
+
+		if len(entries) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// DeleteExpired deletes the entries that are older than the configured retention and
+// returns the number of deleted entries.
+func DeleteExpired(ctx context.Context, store Store, storage *schema.AuditLogStorage, now time.Time) (int, error) {
+	if storage == nil || !storage.Enabled {
+		return 0, nil
+	}
+
+	retentionDays := storage.RetentionDays
+	if retentionDays <= 0 {
+		retentionDays = defaultRetentionDays
+	}
+	return store.DeleteOlderThan(ctx, now.AddDate(0, 0, -retentionDays))
+}
@@ -0,0 +1,153 @@
+package auditstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// sinkBufferSize is the number of entries that can wait to be stored before
+	// new entries are dropped. Dropped entries remain in the process logs.
+	sinkBufferSize = 10000
+	// sinkBatchSize is the maximum number of entries stored in one transaction.
+	sinkBatchSize = 500
+	// sinkFlushInterval is the maximum time an entry waits to be stored.
+	sinkFlushInterval = time.Second
+)
+
+// Sink is an audit.Sink that stores entries in the database when audit log storage
+// is enabled in the site configuration. Entries are buffered and stored in batches
+// by a background routine, so that audited operations never wait on the database
+// or on the lock that serializes the hash chain.
+type Sink struct {
+	store         Store
+	logger        log.Logger
+	getConfig     func() schema.SiteConfiguration
+	flushInterval time.Duration
+
+	entries  chan audit.Entry
+	done     chan struct{}
+	finished chan struct{}
+	stopOnce sync.Once
+}
+
+var (
+	_ audit.Sink                  = &Sink{}
+	_ goroutine.BackgroundRoutine = &Sink{}
+)
+
+// NewSink returns a Sink that stores entries with the given store. Entries are only
+// stored while the sink is started.
+func NewSink(logger log.Logger, store Store) *Sink {
+	return newSink(logger, store, conf.SiteConfig, sinkFlushInterval)
+}
+
+func newSink(logger log.Logger, store Store, getConfig func() schema.SiteConfiguration, flushInterval time.Duration) *Sink {
+	return &Sink{
+		store:         store,
+		logger:        logger.Scoped("auditstore", "buffered audit log storage"),
+		getConfig:     getConfig,
+		flushInterval: flushInterval,
+		entries:       make(chan audit.Entry, sinkBufferSize),
+		done:          make(chan struct{}),
+		finished:      make(chan struct{}),
+	}
+}
+
+// Write enqueues the entry to be stored. It never blocks, and returns an error when
+// the buffer is full.
+func (s *Sink) Write(_ context.Context, entry audit.Entry) error {
+	if !audit.IsStorageEnabled(s.getConfig()) {
+		return nil
+	}
+
+	select {
+	case s.entries <- entry:
+		return nil
+	default:
+		return errors.New("audit log storage buffer is full, dropping entry")
+	}
+}
+
+// Start stores the buffered entries until Stop is called.
+func (s *Sink) Start() {
+	defer close(s.finished)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*audit.Entry, 0, sinkBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.flush(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, &entry)
+			if len(batch) == sinkBatchSize {
+				flush()
+			}
+
+		case <-ticker.C:
+			flush()
+
+		case <-s.done:
+			// Store whatever is still buffered before shutting down.
+			for {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, &entry)
+					if len(batch) == sinkBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stop stores the remaining buffered entries and blocks until Start returns.
+func (s *Sink) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+	<-s.finished
+}
+
+func (s *Sink) flush(batch []*audit.Entry) {
+	storage := StorageConfig(s.getConfig())
+	chain := storage != nil && storage.TamperEvident
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := s.store.InsertBatch(ctx, batch, chain); err != nil {
+		s.logger.Error("failed to store audit log entries",
+			log.Int("count", len(batch)),
+			log.String("firstAuditId", batch[0].AuditID),
+			log.Error(err))
+	}
+}
+
+// StorageConfig returns the audit log storage configuration, or nil if none is set.
+func StorageConfig(cfg schema.SiteConfiguration) *schema.AuditLogStorage {
+	if cfg.Log == nil || cfg.Log.AuditLog == nil {
+		return nil
+	}
+	return cfg.Log.AuditLog.Storage
+}
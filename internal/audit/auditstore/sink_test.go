@@ -0,0 +1,99 @@
+package auditstore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// batchRecordingStore records the batches passed to InsertBatch. Calling any other
+// method panics.
+type batchRecordingStore struct {
+	Store
+
+	mu      sync.Mutex
+	batches [][]string
+	chained []bool
+}
+
+func (s *batchRecordingStore) InsertBatch(_ context.Context, entries []*audit.Entry, chain bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	auditIDs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		auditIDs = append(auditIDs, e.AuditID)
+	}
+	s.batches = append(s.batches, auditIDs)
+	s.chained = append(s.chained, chain)
+	return nil
+}
+
+func storageConfig(storage *schema.AuditLogStorage) func() schema.SiteConfiguration {
+	return func() schema.SiteConfiguration {
+		return schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{Storage: storage}}}
+	}
+}
+
+func TestSink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores buffered entries in batches", func(t *testing.T) {
+		store := &batchRecordingStore{}
+		// Use a long interval so that entries are only stored on Stop.
+		sink := newSink(logtest.Scoped(t), store, storageConfig(&schema.AuditLogStorage{Enabled: true, TamperEvident: true}), time.Hour)
+		go sink.Start()
+
+		for _, auditID := range []string{"a1", "a2", "a3"} {
+			require.NoError(t, sink.Write(ctx, audit.Entry{AuditID: auditID}))
+		}
+		sink.Stop()
+
+		assert.Equal(t, [][]string{{"a1", "a2", "a3"}}, store.batches)
+		assert.Equal(t, []bool{true}, store.chained)
+	})
+
+	t.Run("flushes on interval", func(t *testing.T) {
+		store := &batchRecordingStore{}
+		sink := newSink(logtest.Scoped(t), store, storageConfig(&schema.AuditLogStorage{Enabled: true}), time.Millisecond)
+		go sink.Start()
+		defer sink.Stop()
+
+		require.NoError(t, sink.Write(ctx, audit.Entry{AuditID: "a1"}))
+		assert.Eventually(t, func() bool {
+			store.mu.Lock()
+			defer store.mu.Unlock()
+			return len(store.batches) == 1
+		}, 5*time.Second, time.Millisecond)
+	})
+
+	t.Run("storage disabled", func(t *testing.T) {
+		store := &batchRecordingStore{}
+		sink := newSink(logtest.Scoped(t), store, storageConfig(nil), time.Hour)
+		go sink.Start()
+
+		require.NoError(t, sink.Write(ctx, audit.Entry{AuditID: "a1"}))
+		sink.Stop()
+
+		assert.Empty(t, store.batches)
+	})
+
+	t.Run("full buffer drops entries", func(t *testing.T) {
+		store := &batchRecordingStore{}
+		// The sink is not started, so nothing drains the buffer.
+		sink := newSink(logtest.Scoped(t), store, storageConfig(&schema.AuditLogStorage{Enabled: true}), time.Hour)
+
+		for i := 0; i < sinkBufferSize; i++ {
+			require.NoError(t, sink.Write(ctx, audit.Entry{}))
+		}
+		assert.Error(t, sink.Write(ctx, audit.Entry{}))
+	})
+}
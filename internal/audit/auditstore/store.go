@@ -0,0 +1,279 @@
+package auditstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+)
+
+// Store provides access to the durable audit trail in the `audit_log_entries` table.
+type Store interface {
+	basestore.ShareableStore
+
+	// Insert stores the given entry and sets its ID. When chain is true, the entry is
+	// linked to the most recent hashed entry and its hashes are set.
+	Insert(ctx context.Context, entry *audit.Entry, chain bool) error
+	// InsertBatch stores the given entries in order in a single transaction and sets
+	// their IDs. When chain is true, the entries are linked to the most recent hashed
+	// entry and to each other, and their hashes are set.
+	InsertBatch(ctx context.Context, entries []*audit.Entry, chain bool) error
+	// List returns all entries matching the given options.
+	List(ctx context.Context, opts ListOpts) ([]*audit.Entry, error)
+	// Count counts all entries matching the given options.
+	Count(ctx context.Context, opts ListOpts) (int, error)
+	// DeleteOlderThan deletes all entries created before the given time and returns
+	// the number of deleted entries.
+	DeleteOlderThan(ctx context.Context, before time.Time) (int, error)
+
+	// SinkCursor returns the ID of the last entry delivered to the given sink.
+	SinkCursor(ctx context.Context, sink string) (int64, error)
+	// SetSinkCursor records the ID of the last entry delivered to the given sink.
+	SetSinkCursor(ctx context.Context, sink string, lastEntryID int64) error
+}
+
+// ListOpts provide the options when listing audit log entries.
+type ListOpts struct {
+	*database.LimitOffset
+
+	// Actor filters entries by the actor who took the action.
+	Actor string
+	// Entity filters entries by the audited entity.
+	Entity string
+	// Action filters entries by the action taken.
+	Action string
+	// Since and Until filter entries by their creation time. Since is inclusive,
+	// Until is exclusive.
+	Since *time.Time
+	Until *time.Time
+	// AfterID only returns entries with a greater ID.
+	AfterID int64
+	// BeforeID only returns entries with a smaller ID.
+	BeforeID int64
+	// OnlyHashed only returns entries that are part of the hash chain.
+	OnlyHashed bool
+	// Ascending orders entries from oldest to newest. By default, the newest entries
+	// are returned first.
+	Ascending bool
+}
+
+func (opts ListOpts) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if opts.Actor != "" {
+		preds = append(preds, sqlf.Sprintf("actor = %s", opts.Actor))
+	}
+	if opts.Entity != "" {
+		preds = append(preds, sqlf.Sprintf("entity = %s", opts.Entity))
+	}
+	if opts.Action != "" {
+		preds = append(preds, sqlf.Sprintf("action = %s", opts.Action))
+	}
+	if opts.Since != nil {
+		preds = append(preds, sqlf.Sprintf("created_at >= %s", *opts.Since))
+	}
+	if opts.Until != nil {
+		preds = append(preds, sqlf.Sprintf("created_at < %s", *opts.Until))
+	}
+	if opts.AfterID != 0 {
+		preds = append(preds, sqlf.Sprintf("id > %s", opts.AfterID))
+	}
+	if opts.BeforeID != 0 {
+		preds = append(preds, sqlf.Sprintf("id < %s", opts.BeforeID))
+	}
+	if opts.OnlyHashed {
+		preds = append(preds, sqlf.Sprintf("hash IS NOT NULL"))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Join(preds, "\n AND ")
+}
+
+type store struct {
+	*basestore.Store
+}
+
+// New instantiates and returns a new Store using the given database handle.
+func New(db database.DB) Store {
+	return &store{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+// chainLockKey is the advisory lock serializing chained inserts, so that every entry
+// links to its actual predecessor.
+var chainLockKey = locker.StringKey("audit_log_entries_chain")
+
+func (s *store) Insert(ctx context.Context, entry *audit.Entry, chain bool) error {
+	return s.InsertBatch(ctx, []*audit.Entry{entry}, chain)
+}
+
+func (s *store) InsertBatch(ctx context.Context, entries []*audit.Entry, chain bool) (err error) {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var previousHash string
+	if chain {
+		// The lock is held until the transaction ends, so it is taken once per batch.
+		if _, err := locker.NewWith(tx, "audit_log").LockInTransaction(ctx, chainLockKey, true); err != nil {
+			return err
+		}
+		previousHash, _, err = basestore.ScanFirstString(tx.Query(ctx, sqlf.Sprintf(lastHashQuery)))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if len(entry.Fields) == 0 {
+			entry.Fields = json.RawMessage(`{}`)
+		}
+		if chain {
+			entry.PreviousHash = previousHash
+			entry.Hash = entry.ComputeHash(previousHash)
+			previousHash = entry.Hash
+		}
+
+		id, _, err := basestore.ScanFirstInt64(tx.Query(ctx, sqlf.Sprintf(
+			insertQuery,
+			entry.AuditID,
+			entry.CreatedAt,
+			entry.Actor,
+			entry.IP,
+			entry.ForwardedFor,
+			entry.Entity,
+			entry.Action,
+			string(entry.Fields),
+			dbutil.NewNullString(entry.PreviousHash),
+			dbutil.NewNullString(entry.Hash),
+		)))
+		if err != nil {
+			return err
+		}
+		entry.ID = id
+	}
+	return nil
+}
+
+const lastHashQuery = `
+SELECT hash FROM audit_log_entries WHERE hash IS NOT NULL ORDER BY id DESC LIMIT 1
+`
+
+const insertQuery = `
+INSERT INTO audit_log_entries (audit_id, created_at, actor, ip, forwarded_for, entity, action, fields, previous_hash, hash)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id
+`
+
+func (s *store) List(ctx context.Context, opts ListOpts) ([]*audit.Entry, error) {
+	order := sqlf.Sprintf("id DESC")
+	if opts.Ascending {
+		order = sqlf.Sprintf("id ASC")
+	}
+
+	return scanEntries(s.Query(ctx, sqlf.Sprintf(
+		listQuery,
+		sqlf.Join(columns, ", "),
+		opts.sqlConds(),
+		order,
+		opts.LimitOffset.SQL(),
+	)))
+}
+
+const listQuery = `
+SELECT %s FROM audit_log_entries
+WHERE %s
+ORDER BY %s
+%s
+`
+
+func (s *store) Count(ctx context.Context, opts ListOpts) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		"SELECT COUNT(*) FROM audit_log_entries WHERE %s",
+		opts.sqlConds(),
+	)))
+	return count, err
+}
+
+func (s *store) DeleteOlderThan(ctx context.Context, before time.Time) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(deleteOlderThanQuery, before)))
+	return count, err
+}
+
+const deleteOlderThanQuery = `
+WITH deleted AS (
+	DELETE FROM audit_log_entries WHERE created_at < %s RETURNING 1
+)
+SELECT COUNT(*) FROM deleted
+`
+
+func (s *store) SinkCursor(ctx context.Context, sink string) (int64, error) {
+	id, _, err := basestore.ScanFirstInt64(s.Query(ctx, sqlf.Sprintf(
+		"SELECT last_entry_id FROM audit_log_sink_cursors WHERE sink = %s",
+		sink,
+	)))
+	return id, err
+}
+
+func (s *store) SetSinkCursor(ctx context.Context, sink string, lastEntryID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(setSinkCursorQuery, sink, lastEntryID))
+}
+
+const setSinkCursorQuery = `
+INSERT INTO audit_log_sink_cursors (sink, last_entry_id, updated_at)
+VALUES (%s, %s, NOW())
+ON CONFLICT (sink) DO UPDATE SET last_entry_id = EXCLUDED.last_entry_id, updated_at = EXCLUDED.updated_at
+`
+
+var columns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("audit_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("actor"),
+	sqlf.Sprintf("ip"),
+	sqlf.Sprintf("forwarded_for"),
+	sqlf.Sprintf("entity"),
+	sqlf.Sprintf("action"),
+	sqlf.Sprintf("fields"),
+	sqlf.Sprintf("previous_hash"),
+	sqlf.Sprintf("hash"),
+}
+
+var scanEntries = basestore.NewSliceScanner(scanEntry)
+
+func scanEntry(s dbutil.Scanner) (*audit.Entry, error) {
+	var e audit.Entry
+	var fields []byte
+	if err := s.Scan(
+		&e.ID,
+		&e.AuditID,
+		&e.CreatedAt,
+		&e.Actor,
+		&e.IP,
+		&e.ForwardedFor,
+		&e.Entity,
+		&e.Action,
+		&fields,
+		&dbutil.NullString{S: &e.PreviousHash},
+		&dbutil.NullString{S: &e.Hash},
+	); err != nil {
+		return nil, err
+	}
+	e.Fields = fields
+	return &e, nil
+}
@@ -0,0 +1,169 @@
+package auditstore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/audit/sinks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestEntry(action string, createdAt time.Time) *audit.Entry {
+	return &audit.Entry{
+		AuditID:   action,
+		CreatedAt: createdAt,
+		Actor:     "1",
+		IP:        "192.168.0.1",
+		Entity:    "site config",
+		Action:    action,
+		Fields:    json.RawMessage(`{"b": 2, "a": 1}`),
+	}
+}
+
+func TestStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, store.Insert(ctx, newTestEntry("created", now.Add(-3*time.Hour)), false))
+	// The remaining entries are chained to each other within a single batch.
+	batch := []*audit.Entry{
+		newTestEntry("updated", now.Add(-2*time.Hour)),
+		newTestEntry("deleted", now.Add(-1*time.Hour)),
+	}
+	require.NoError(t, store.InsertBatch(ctx, batch, true))
+	require.Less(t, batch[0].ID, batch[1].ID)
+	assert.Equal(t, batch[0].Hash, batch[1].PreviousHash)
+
+	t.Run("List", func(t *testing.T) {
+		entries, err := store.List(ctx, ListOpts{})
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "deleted", entries[0].Action)
+
+		entries, err = store.List(ctx, ListOpts{Action: "updated"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "updated", entries[0].Action)
+
+		since := now.Add(-2 * time.Hour)
+		entries, err = store.List(ctx, ListOpts{Since: &since, Ascending: true})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "updated", entries[0].Action)
+
+		count, err := store.Count(ctx, ListOpts{OnlyHashed: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("hash chain survives storage", func(t *testing.T) {
+		entries, err := store.List(ctx, ListOpts{OnlyHashed: true, Ascending: true})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Empty(t, entries[0].PreviousHash)
+
+		chain := make([]audit.Entry, 0, len(entries))
+		for _, e := range entries {
+			chain = append(chain, *e)
+		}
+		require.NoError(t, audit.VerifyChain(chain))
+
+		_, err = db.ExecContext(ctx, "UPDATE audit_log_entries SET actor = '2' WHERE id = $1", entries[1].ID)
+		require.NoError(t, err)
+		entries, err = store.List(ctx, ListOpts{OnlyHashed: true, Ascending: true})
+		require.NoError(t, err)
+		chain = chain[:0]
+		for _, e := range entries {
+			chain = append(chain, *e)
+		}
+		var chainErr *audit.ChainError
+		require.ErrorAs(t, audit.VerifyChain(chain), &chainErr)
+		assert.Equal(t, entries[1].ID, chainErr.EntryID)
+	})
+
+	t.Run("SinkCursor", func(t *testing.T) {
+		cursor, err := store.SinkCursor(ctx, "file:/audit.log")
+		require.NoError(t, err)
+		assert.Zero(t, cursor)
+
+		require.NoError(t, store.SetSinkCursor(ctx, "file:/audit.log", 2))
+		require.NoError(t, store.SetSinkCursor(ctx, "file:/audit.log", 3))
+		cursor, err = store.SinkCursor(ctx, "file:/audit.log")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), cursor)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		deleted, err := DeleteExpired(ctx, store, &schema.AuditLogStorage{Enabled: true, RetentionDays: 1}, now.Add(24*time.Hour).Add(-150*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		count, err := store.Count(ctx, ListOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
+
+type recordingExporter struct {
+	name     string
+	batches  [][]*audit.Entry
+	failNext bool
+}
+
+func (e *recordingExporter) Name() string { return e.name }
+
+func (e *recordingExporter) Export(_ context.Context, entries []*audit.Entry) error {
+	if e.failNext {
+		e.failNext = false
+		return assert.AnError
+	}
+	e.batches = append(e.batches, entries)
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db)
+	ctx := context.Background()
+
+	insert := func(action string) {
+		require.NoError(t, store.Insert(ctx, newTestEntry(action, time.Now().UTC().Truncate(time.Microsecond)), false))
+	}
+
+	insert("first")
+	exporter := &recordingExporter{name: "test", failNext: true}
+
+	// A failed delivery is retried on the next run.
+	require.Error(t, Export(ctx, store, []sinks.Exporter{exporter}))
+	require.NoError(t, Export(ctx, store, []sinks.Exporter{exporter}))
+	require.Len(t, exporter.batches, 1)
+	assert.Equal(t, "first", exporter.batches[0][0].Action)
+
+	// Only new entries are delivered.
+	insert("second")
+	require.NoError(t, Export(ctx, store, []sinks.Exporter{exporter}))
+	require.Len(t, exporter.batches, 2)
+	require.Len(t, exporter.batches[1], 1)
+	assert.Equal(t, "second", exporter.batches[1][0].Action)
+}
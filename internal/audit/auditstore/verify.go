@@ -0,0 +1,58 @@
+package auditstore
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// verifyBatchSize is the number of entries loaded at once when verifying the chain.
+const verifyBatchSize = 1000
+
+// Verify checks the hash chain of all tamper-evident entries, from oldest to newest,
+// and returns the number of checked entries. An *audit.ChainError is returned for the
+// first entry that does not match the chain.
+func Verify(ctx context.Context, store Store) (checked int, err error) {
+	var previous *audit.Entry
+	for {
+		entries, err := store.List(ctx, ListOpts{
+			LimitOffset: &database.LimitOffset{Limit: verifyBatchSize},
+			AfterID:     afterID(previous),
+			OnlyHashed:  true,
+			Ascending:   true,
+		})
+		if err != nil {
+			return checked, err
+		}
+		if len(entries) == 0 {
+			return checked, nil
+		}
+
+		// Include the last entry of the previous batch, so that the link between
+		// batches is verified too.
+		chain := make([]audit.Entry, 0, len(entries)+1)
+		if previous != nil {
+			chain = append(chain, *previous)
+		}
+		for _, e := range entries {
+			chain = append(chain, *e)
+		}
+		if err := audit.VerifyChain(chain); err != nil {
+			return checked, err
+		}
+
+		checked += len(entries)
+		previous = entries[len(entries)-1]
+		if len(entries) < verifyBatchSize {
+			return checked, nil
+		}
+	}
+}
+
+func afterID(e *audit.Entry) int64 {
+	if e == nil {
+		return 0
+	}
+	return e.ID
+}
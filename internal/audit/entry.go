@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Entry is the durable representation of an audit log record, as handed to sinks.
+type Entry struct {
+	// ID is assigned by the database once the entry is stored.
+	ID int64 `json:"id,omitempty"`
+	// AuditID is the sampling immunity token that is also written to the process logs.
+	AuditID   string    `json:"auditId"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Actor is the user ID, anonymous user ID or "unknown".
+	Actor        string `json:"actor"`
+	IP           string `json:"ip"`
+	ForwardedFor string `json:"forwardedFor"`
+
+	Entity string `json:"entity"`
+	Action string `json:"action"`
+	// Fields holds the additional context of the record as a JSON object.
+	Fields json.RawMessage `json:"fields,omitempty"`
+
+	// PreviousHash and Hash are only set when tamper evidence is enabled.
+	PreviousHash string `json:"previousHash,omitempty"`
+	Hash         string `json:"hash,omitempty"`
+}
+
+// ComputeHash returns the hex encoded SHA-256 hash of the entry contents chained to
+// the hash of the preceding entry. The ID and hash fields are not part of the hash,
+// as they are assigned when the entry is stored.
+func (e Entry) ComputeHash(previousHash string) string {
+	h := sha256.New()
+	for _, part := range []string{
+		previousHash,
+		e.AuditID,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.Actor,
+		e.IP,
+		e.ForwardedFor,
+		e.Entity,
+		e.Action,
+		canonicalJSON(e.Fields),
+	} {
+		// Length-prefix every part so that moving bytes between adjacent parts
+		// changes the hash.
+		h.Write([]byte(strconv.Itoa(len(part))))
+		h.Write([]byte{':'})
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalJSON returns the compact encoding of raw with sorted object keys, as the
+// database does not preserve the formatting of stored JSON.
+func canonicalJSON(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return string(raw)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(raw)
+	}
+	return string(b)
+}
+
+// ChainError describes the first entry at which a hash chain is broken.
+type ChainError struct {
+	EntryID int64
+	Reason  string
+}
+
+func (e *ChainError) Error() string {
+	return "audit log entry " + strconv.FormatInt(e.EntryID, 10) + ": " + e.Reason
+}
+
+// VerifyChain checks that the given entries, ordered by ID, form an unbroken hash
+// chain. The chain starts at the previous hash of the first entry, which allows
+// verifying a trail whose oldest entries were removed by retention. A *ChainError
+// is returned for the first entry that was modified, removed or reordered.
+func VerifyChain(entries []Entry) error {
+	for i, e := range entries {
+		if e.Hash == "" {
+			return &ChainError{EntryID: e.ID, Reason: "entry has no hash"}
+		}
+		if i > 0 && e.PreviousHash != entries[i-1].Hash {
+			return &ChainError{EntryID: e.ID, Reason: "previous hash does not match the preceding entry"}
+		}
+		if e.ComputeHash(e.PreviousHash) != e.Hash {
+			return &ChainError{EntryID: e.ID, Reason: "hash does not match the entry contents"}
+		}
+	}
+	return nil
+}
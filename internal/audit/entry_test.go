@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+func TestComputeHash(t *testing.T) {
+	entry := Entry{
+		AuditID:   "a",
+		CreatedAt: time.Date(2023, 5, 17, 12, 0, 0, 0, time.UTC),
+		Actor:     "1",
+		Entity:    "test entity",
+		Action:    "test action",
+		Fields:    json.RawMessage(`{"b": 1, "a": "x"}`),
+	}
+	hash := entry.ComputeHash("")
+
+	t.Run("stable across JSON formatting", func(t *testing.T) {
+		reformatted := entry
+		reformatted.Fields = json.RawMessage(`{"a":"x","b":1}`)
+		assert.Equal(t, hash, reformatted.ComputeHash(""))
+	})
+
+	t.Run("depends on previous hash", func(t *testing.T) {
+		assert.NotEqual(t, hash, entry.ComputeHash("previous"))
+	})
+
+	t.Run("depends on contents", func(t *testing.T) {
+		modified := entry
+		modified.Action = "other action"
+		assert.NotEqual(t, hash, modified.ComputeHash(""))
+
+		// Moving bytes between adjacent fields must change the hash.
+		shifted := entry
+		shifted.Entity = "test entitytest"
+		shifted.Action = " action"
+		assert.NotEqual(t, hash, shifted.ComputeHash(""))
+	})
+}
+
+func TestVerifyChain(t *testing.T) {
+	newChain := func() []Entry {
+		var entries []Entry
+		previousHash := ""
+		for i := 1; i <= 3; i++ {
+			e := Entry{
+				ID:           int64(i),
+				AuditID:      "audit",
+				CreatedAt:    time.Date(2023, 5, 17, 12, 0, i, 0, time.UTC),
+				Actor:        "1",
+				Entity:       "entity",
+				Action:       "action",
+				Fields:       json.RawMessage(`{}`),
+				PreviousHash: previousHash,
+			}
+			e.Hash = e.ComputeHash(previousHash)
+			previousHash = e.Hash
+			entries = append(entries, e)
+		}
+		return entries
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, VerifyChain(newChain()))
+		// Verification can start in the middle of the chain.
+		assert.NoError(t, VerifyChain(newChain()[1:]))
+	})
+
+	tests := []struct {
+		name    string
+		modify  func([]Entry) []Entry
+		entryID int64
+	}{
+		{
+			name: "modified entry",
+			modify: func(entries []Entry) []Entry {
+				entries[1].Actor = "2"
+				return entries
+			},
+			entryID: 2,
+		},
+		{
+			name: "removed entry",
+			modify: func(entries []Entry) []Entry {
+				return append(entries[:1], entries[2:]...)
+			},
+			entryID: 3,
+		},
+		{
+			name: "missing hash",
+			modify: func(entries []Entry) []Entry {
+				entries[2].Hash = ""
+				return entries
+			},
+			entryID: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChain(tt.modify(newChain()))
+			var chainErr *ChainError
+			require.ErrorAs(t, err, &chainErr)
+			assert.Equal(t, tt.entryID, chainErr.EntryID)
+		})
+	}
+}
+
+type recordingSink struct {
+	entries []Entry
+}
+
+func (s *recordingSink) Write(_ context.Context, entry Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestLogWritesToSinks(t *testing.T) {
+	s := &recordingSink{}
+	RegisterSink(s)
+	t.Cleanup(func() {
+		sinksMu.Lock()
+		sinks = nil
+		sinksMu.Unlock()
+	})
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.168.0.1", ForwardedFor: "10.0.0.1"})
+	logger, _ := logtest.Captured(t)
+
+	Log(ctx, logger, Record{
+		Entity: "test entity",
+		Action: "test audit action",
+		Fields: []log.Field{log.String("additional", "stuff")},
+		Data:   map[string]any{"additional": "stuff", "count": 2},
+	})
+
+	require.Len(t, s.entries, 1)
+	entry := s.entries[0]
+	assert.NotEmpty(t, entry.AuditID)
+	assert.Equal(t, "1", entry.Actor)
+	assert.Equal(t, "192.168.0.1", entry.IP)
+	assert.Equal(t, "10.0.0.1", entry.ForwardedFor)
+	assert.Equal(t, "test entity", entry.Entity)
+	assert.Equal(t, "test audit action", entry.Action)
+	assert.JSONEq(t, `{"additional":"stuff","count":2}`, string(entry.Fields))
+}
+
+func TestLogSecurityEventsOfInternalActors(t *testing.T) {
+	s := &recordingSink{}
+	RegisterSink(s)
+	t.Cleanup(func() {
+		sinksMu.Lock()
+		sinks = nil
+		sinksMu.Unlock()
+	})
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger, _ := logtest.Captured(t)
+
+	Log(ctx, logger, Record{Entity: "test entity", Action: "internal action"})
+	Log(ctx, logger, Record{Entity: "security events", Action: "security event", SecurityEvent: true})
+
+	require.Len(t, s.entries, 1)
+	assert.Equal(t, "security event", s.entries[0].Action)
+}
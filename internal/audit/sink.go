@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// Sink receives every audit log entry in addition to the process logger.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+var (
+	sinksMu sync.RWMutex
+	sinks   []Sink
+)
+
+// RegisterSink adds a sink that receives all entries subsequently passed to Log by
+// this process. It is meant to be called once on service startup.
+func RegisterSink(s Sink) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks = append(sinks, s)
+}
+
+func registeredSinks() []Sink {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	return sinks
+}
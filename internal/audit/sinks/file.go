@@ -0,0 +1,57 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultFilePrefix is the object key prefix used when none is configured.
+const defaultFilePrefix = "audit-log"
+
+type fileExporter struct {
+	prefix string
+	store  uploadstore.Store
+}
+
+// NewFileExporter returns an exporter that writes every batch of entries as an
+// object of JSON lines to the given upload store. Objects are keyed by the IDs of
+// the first and last entry of the batch, so that they sort in the order the entries
+// were stored.
+func NewFileExporter(cfg schema.AuditLogFileSink, store uploadstore.Store) Exporter {
+	prefix := cfg.Prefix
+	if prefix == "" {
+		prefix = defaultFilePrefix
+	}
+	return &fileExporter{prefix: prefix, store: store}
+}
+
+func (e *fileExporter) Name() string {
+	return "file:" + e.prefix
+}
+
+func (e *fileExporter) Export(ctx context.Context, entries []*audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return errors.Wrap(err, "encoding audit log entry")
+		}
+	}
+
+	key := fmt.Sprintf("%s/%020d-%020d.jsonl", e.prefix, entries[0].ID, entries[len(entries)-1].ID)
+	if _, err := e.store.Upload(ctx, key, &buf); err != nil {
+		return errors.Wrap(err, "uploading audit log file")
+	}
+	return nil
+}
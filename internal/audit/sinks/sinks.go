@@ -0,0 +1,43 @@
+// Package sinks implements the external destinations the stored audit log can be
+// streamed to.
+package sinks
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Exporter delivers batches of stored audit log entries to an external destination.
+// Entries are passed in the order they were stored.
+type Exporter interface {
+	// Name identifies the destination. It is used to track which entries were
+	// already delivered, so it must be stable across restarts.
+	Name() string
+	// Export delivers the given entries. When an error is returned, the whole batch
+	// is delivered again on the next attempt.
+	Export(ctx context.Context, entries []*audit.Entry) error
+}
+
+// FromConfig returns the exporters configured in the site configuration. The file
+// sink writes to the given upload store, and is skipped when it is nil.
+func FromConfig(cfg *schema.AuditLogSinks, doer httpcli.Doer, uploadStore uploadstore.Store) []Exporter {
+	if cfg == nil {
+		return nil
+	}
+
+	var exporters []Exporter
+	if cfg.Syslog != nil {
+		exporters = append(exporters, NewSyslogExporter(*cfg.Syslog))
+	}
+	if cfg.File != nil && uploadStore != nil {
+		exporters = append(exporters, NewFileExporter(*cfg.File, uploadStore))
+	}
+	if cfg.Webhook != nil {
+		exporters = append(exporters, NewWebhookExporter(*cfg.Webhook, doer))
+	}
+	return exporters
+}
@@ -0,0 +1,175 @@
+package sinks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func testEntries() []*audit.Entry {
+	return []*audit.Entry{
+		{
+			ID:        1,
+			AuditID:   "a1",
+			CreatedAt: time.Date(2023, 5, 17, 12, 0, 0, 0, time.UTC),
+			Actor:     "1",
+			IP:        "192.168.0.1",
+			Entity:    "site config",
+			Action:    "updated",
+			Fields:    json.RawMessage(`{"key":"value"}`),
+		},
+		{
+			ID:        2,
+			AuditID:   "a2",
+			CreatedAt: time.Date(2023, 5, 17, 12, 0, 1, 0, time.UTC),
+			Actor:     "2",
+			IP:        "192.168.0.2",
+			Entity:    `quote " and ] bracket`,
+			Action:    "deleted",
+		},
+	}
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	msg, err := formatRFC5424(testEntries()[1], "host name", "sourcegraph")
+	require.NoError(t, err)
+
+	want := `<110>1 2023-05-17T12:00:01Z host_name sourcegraph - audit [audit@32473 auditId="a2" actor="2" ip="192.168.0.2" entity="quote \" and \] bracket" action="deleted"] `
+	assert.True(t, strings.HasPrefix(msg, want), "got %q", msg)
+
+	var body audit.Entry
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(msg, want)), &body))
+	assert.Equal(t, "a2", body.AuditID)
+}
+
+func TestSyslogExporter(t *testing.T) {
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+
+		exporter := NewSyslogExporter(schema.AuditLogSyslogSink{Address: conn.LocalAddr().String()})
+		require.NoError(t, exporter.Export(context.Background(), testEntries()))
+
+		buf := make([]byte, 4096)
+		for _, auditID := range []string{"a1", "a2"} {
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := conn.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Contains(t, string(buf[:n]), `auditId="`+auditID+`"`)
+		}
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+
+		received := make(chan []byte, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			b, _ := io.ReadAll(conn)
+			received <- b
+		}()
+
+		exporter := NewSyslogExporter(schema.AuditLogSyslogSink{Network: "tcp", Address: ln.Addr().String()})
+		require.NoError(t, exporter.Export(context.Background(), testEntries()))
+
+		// Every message is prefixed with its length.
+		r := bufio.NewReader(strings.NewReader(string(<-received)))
+		for _, auditID := range []string{"a1", "a2"} {
+			var n int
+			_, err := fmt.Fscan(r, &n)
+			require.NoError(t, err)
+			sep, err := r.ReadByte()
+			require.NoError(t, err)
+			require.Equal(t, byte(' '), sep)
+			msg := make([]byte, n)
+			_, err = io.ReadFull(r, msg)
+			require.NoError(t, err)
+			assert.Contains(t, string(msg), `auditId="`+auditID+`"`)
+		}
+	})
+}
+
+func TestFileExporter(t *testing.T) {
+	objects := map[string][]byte{}
+	store := mocks.NewMockStore()
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		b, err := io.ReadAll(r)
+		objects[key] = b
+		return int64(len(b)), err
+	})
+	exporter := NewFileExporter(schema.AuditLogFileSink{}, store)
+
+	require.NoError(t, exporter.Export(context.Background(), testEntries()[:1]))
+	require.NoError(t, exporter.Export(context.Background(), testEntries()[1:]))
+
+	keys := []string{
+		"audit-log/00000000000000000001-00000000000000000001.jsonl",
+		"audit-log/00000000000000000002-00000000000000000002.jsonl",
+	}
+	require.Len(t, objects, len(keys))
+
+	var auditIDs []string
+	for _, key := range keys {
+		require.Contains(t, objects, key)
+		scanner := bufio.NewScanner(bytes.NewReader(objects[key]))
+		for scanner.Scan() {
+			var entry audit.Entry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+			auditIDs = append(auditIDs, entry.AuditID)
+		}
+		require.NoError(t, scanner.Err())
+	}
+	assert.Equal(t, []string{"a1", "a2"}, auditIDs)
+
+	store.UploadFunc.SetDefaultReturn(0, errors.New("unavailable"))
+	assert.Error(t, exporter.Export(context.Background(), testEntries()))
+}
+
+func TestWebhookExporter(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+		status    = http.StatusOK
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	exporter := NewWebhookExporter(schema.AuditLogWebhookSink{Url: srv.URL, Secret: "secret"}, http.DefaultClient)
+	require.NoError(t, exporter.Export(context.Background(), testEntries()))
+
+	var entries []audit.Entry
+	require.NoError(t, json.Unmarshal(body, &entries))
+	assert.Len(t, entries, 2)
+	assert.Equal(t, Sign("secret", body), signature)
+
+	status = http.StatusInternalServerError
+	assert.Error(t, exporter.Export(context.Background(), testEntries()))
+}
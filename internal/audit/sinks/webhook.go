@@ -0,0 +1,76 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 signature of the request body
+// when a webhook secret is configured.
+const SignatureHeader = "X-Sourcegraph-Signature"
+
+type webhookExporter struct {
+	url    string
+	secret string
+	doer   httpcli.Doer
+}
+
+// NewWebhookExporter returns an exporter that POSTs every batch of entries as a JSON
+// array to the configured URL.
+func NewWebhookExporter(cfg schema.AuditLogWebhookSink, doer httpcli.Doer) Exporter {
+	return &webhookExporter{
+		url:    cfg.Url,
+		secret: cfg.Secret,
+		doer:   doer,
+	}
+}
+
+func (e *webhookExporter) Name() string {
+	return "webhook:" + e.url
+}
+
+func (e *webhookExporter) Export(ctx context.Context, entries []*audit.Entry) error {
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "marshalling audit log entries")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(e.secret, body))
+	}
+
+	resp, err := e.doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending audit log entries")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Newf("unexpected status code %d from audit log webhook", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -12,6 +12,8 @@ import (
 
 type CodeNavServiceResolver interface {
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
+	SearchBasedReferences(ctx context.Context, args *SearchBasedReferencesArgs) (SearchBasedReferenceConnectionResolver, error)
+	CodeIntelSymbolChanges(ctx context.Context, id graphql.ID, args *CodeIntelSymbolChangesArgs) (CodeIntelSymbolChangeConnectionResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -33,6 +35,10 @@ type GitBlobLSIFDataResolver interface {
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Prototypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFPagedQueryPositionArgs) (CallHierarchyCallConnectionResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFPagedQueryPositionArgs) (CallHierarchyCallConnectionResolver, error)
+	Supertypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (HierarchyItemConnectionResolver, error)
+	Subtypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (HierarchyItemConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	VisibleIndexes(ctx context.Context) (_ *[]PreciseIndexResolver, err error)
 	Snapshot(ctx context.Context, args *struct{ IndexID graphql.ID }) (_ *[]SnapshotDataResolver, err error)
@@ -93,6 +99,21 @@ type LocationResolver interface {
 	CanonicalURL() string
 }
 
+type (
+	CallHierarchyCallConnectionResolver = PagedConnectionResolver[CallHierarchyCallResolver]
+	HierarchyItemConnectionResolver     = PagedConnectionResolver[HierarchyItemResolver]
+)
+
+type HierarchyItemResolver interface {
+	Symbol() string
+	Definition() LocationResolver
+}
+
+type CallHierarchyCallResolver interface {
+	Item() HierarchyItemResolver
+	CallSites() []LocationResolver
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
@@ -124,3 +145,55 @@ type DiagnosticResolver interface {
 	Message() (*string, error)
 	Location(ctx context.Context) (LocationResolver, error)
 }
+
+type SearchBasedReferencesArgs struct {
+	Repo      *types.Repo
+	Commit    api.CommitID
+	Path      string
+	Line      int32
+	Character int32
+	PagedConnectionArgs
+}
+
+type (
+	SearchBasedReferenceConnectionResolver = PagedConnectionWithTotalCountResolver[SearchBasedRepositoryReferencesResolver]
+)
+
+type SearchBasedRepositoryReferencesResolver interface {
+	Repository() RepositoryResolver
+	Confidence() float64
+	References() []SearchBasedReferenceResolver
+}
+
+type SearchBasedReferenceResolver interface {
+	Location() LocationResolver
+	Confidence() float64
+}
+
+type CodeIntelSymbolChangesArgs struct {
+	Base string
+	Head string
+	PagedConnectionArgs
+}
+
+type (
+	CodeIntelSymbolChangeConnectionResolver = PagedConnectionWithTotalCountResolver[CodeIntelSymbolChangeResolver]
+)
+
+type CodeIntelSymbolChangeResolver interface {
+	Kind() string
+	Base() CodeIntelSymbolVersionResolver
+	Head() CodeIntelSymbolVersionResolver
+	ExternalReferences(ctx context.Context, args *CodeIntelSymbolExternalReferencesArgs) (LocationConnectionResolver, error)
+}
+
+type CodeIntelSymbolExternalReferencesArgs struct {
+	First *int32
+}
+
+type CodeIntelSymbolVersionResolver interface {
+	Symbol() string
+	Definition(ctx context.Context) (LocationResolver, error)
+	Signature() string
+	Documentation() string
+}
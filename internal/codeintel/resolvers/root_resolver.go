@@ -153,6 +153,14 @@ func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArg
 	return r.codenavResolver.GitBlobLSIFData(ctx, args)
 }
 
+func (r *Resolver) SearchBasedReferences(ctx context.Context, args *SearchBasedReferencesArgs) (_ SearchBasedReferenceConnectionResolver, err error) {
+	return r.codenavResolver.SearchBasedReferences(ctx, args)
+}
+
+func (r *Resolver) CodeIntelSymbolChanges(ctx context.Context, id graphql.ID, args *CodeIntelSymbolChangesArgs) (_ CodeIntelSymbolChangeConnectionResolver, err error) {
+	return r.codenavResolver.CodeIntelSymbolChanges(ctx, id, args)
+}
+
 func (r *Resolver) ConfigurationPolicyByID(ctx context.Context, id graphql.ID) (_ CodeIntelligenceConfigurationPolicyResolver, err error) {
 	return r.policiesRootResolver.ConfigurationPolicyByID(ctx, id)
 }
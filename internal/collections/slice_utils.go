@@ -14,6 +14,14 @@ func Min[T constraints.Ordered](a T, b T) T {
 	return b
 }
 
+// Returns maximum of 2 numbers
+func Max[T constraints.Ordered](a T, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // NaturalCompare is a comparator function that will help sort numbers in natural order
 // when used in sort.Slice.
 // For example, 1, 2, 3, 10, 11, 12, 20, 21, 22, 100, 101, 102, 200, 201, 202, ...
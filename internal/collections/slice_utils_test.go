@@ -50,6 +50,32 @@ func Test_Min(t *testing.T) {
 	})
 }
 
+func Test_Max(t *testing.T) {
+	t.Run("Returns first int that is greater", func(t *testing.T) {
+		got := Max(2, 1)
+		want := 2
+		if got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Returns second int that is greater", func(t *testing.T) {
+		got := Max(1, 2)
+		want := 2
+		if got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Works with infinity", func(t *testing.T) {
+		got := Max(1.5, math.Inf(1))
+		want := math.Inf(1)
+		if got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
 func Test_SplitIntoChunks(t *testing.T) {
 	t.Run("Splits a slice into chunks of size 3", func(t *testing.T) {
 		got, err := SplitIntoChunks([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 3)
@@ -1,8 +1,10 @@
 package conf
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -18,6 +20,67 @@ func diff(before, after *Unified) (fields map[string]struct{}) {
 	return diff
 }
 
+// SiteConfigFieldChange describes a site configuration field whose value differs
+// between two versions of the site configuration.
+type SiteConfigFieldChange struct {
+	// Key is the name of the field. Fields nested in experimentalFeatures are named
+	// "experimentalFeatures::<field>".
+	Key string
+	// Before and After are the JSON values of the field, or nil if it is unset.
+	Before, After json.RawMessage
+}
+
+// DiffSiteConfig returns the fields that have different values between the two site
+// configurations, sorted by key. Fields are compared by value, so comments, formatting
+// and the order of fields do not produce changes.
+//
+// 🚨 SECURITY: The returned values are copied from the given site configurations, so
+// callers showing them to users should pass redacted contents.
+func DiffSiteConfig(before, after string) ([]SiteConfigFieldChange, error) {
+	var beforeCfg, afterCfg schema.SiteConfiguration
+	if err := parseConfigData(before, &beforeCfg); err != nil {
+		return nil, err
+	}
+	if err := parseConfigData(after, &afterCfg); err != nil {
+		return nil, err
+	}
+
+	beforeFields := getJSONFields(beforeCfg, "")
+	afterFields := getJSONFields(afterCfg, "")
+
+	changed := diffStruct(beforeCfg, afterCfg, "")
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changes := make([]SiteConfigFieldChange, 0, len(keys))
+	for _, key := range keys {
+		beforeValue, err := marshalFieldValue(beforeFields[key])
+		if err != nil {
+			return nil, err
+		}
+		afterValue, err := marshalFieldValue(afterFields[key])
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, SiteConfigFieldChange{Key: key, Before: beforeValue, After: afterValue})
+	}
+
+	return changes, nil
+}
+
+// marshalFieldValue returns the JSON encoding of the given field value, or nil if the
+// value is the zero value of its type (i.e. the field is unset).
+func marshalFieldValue(v any) (json.RawMessage, error) {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
 func diffStruct(before, after any, prefix string) (fields map[string]struct{}) {
 	fields = make(map[string]struct{})
 	beforeFields := getJSONFields(before, prefix)
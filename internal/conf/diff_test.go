@@ -1,6 +1,7 @@
 package conf
 
 import (
+	"encoding/json"
 	"reflect"
 	"sort"
 	"testing"
@@ -90,3 +91,31 @@ func toSlice(m map[string]struct{}) []string {
 	}
 	return s
 }
+
+func TestDiffSiteConfig(t *testing.T) {
+	before := `{
+  // Comments and formatting are ignored.
+  "externalURL": "https://sourcegraph.example.com",
+  "disableAutoGitUpdates": true,
+  "experimentalFeatures": {"structuralSearch": "enabled"},
+}`
+	after := `{"experimentalFeatures": {"structuralSearch": "disabled"}, "externalURL": "https://sourcegraph.example.com", "maxReposToSearch": 100}`
+
+	got, err := DiffSiteConfig(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SiteConfigFieldChange{
+		{Key: "disableAutoGitUpdates", Before: json.RawMessage(`true`)},
+		{Key: "experimentalFeatures::structuralSearch", Before: json.RawMessage(`"enabled"`), After: json.RawMessage(`"disabled"`)},
+		{Key: "maxReposToSearch", After: json.RawMessage(`100`)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v want %+v", got, want)
+	}
+
+	if _, err := DiffSiteConfig(before, `{"externalURL": `); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
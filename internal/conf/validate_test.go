@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -311,6 +312,31 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_AuditLogWebhookSecret(t *testing.T) {
+	const cfg = `{
+  "log": {
+    "auditLog": {
+      "gitserverAccess": false,
+      "graphQL": false,
+      "internalTraffic": false,
+      "sinks": {
+        "webhook": {
+          "secret": "%s",
+          "url": "https://siem.example.com"
+        }
+      }
+    }
+  }
+}`
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: fmt.Sprintf(cfg, "strongsecret")})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfg, "REDACTED"), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: fmt.Sprintf(cfg, "strongsecret")})
+	require.NoError(t, err)
+	assert.Equal(t, "strongsecret", gjson.Get(unredacted, "log.auditLog.sinks.webhook.secret").String())
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		testSecrets{
@@ -40,6 +40,13 @@ type ConfStore interface {
 	// responsible for ensuring this or that the response never makes it to a user.
 	SiteGetLatest(ctx context.Context) (*SiteConfig, error)
 
+	// SiteGetByID returns the site config with the given ID. This returns nil, nil if
+	// there is no site config with that ID.
+	//
+	// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+	// responsible for ensuring this or that the response never makes it to a user.
+	SiteGetByID(ctx context.Context, id int32) (*SiteConfig, error)
+
 	// ListSiteConfigs will list the configs of type "site".
 	//
 	// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
@@ -136,6 +143,25 @@ func (s *confStore) SiteGetLatest(ctx context.Context) (_ *SiteConfig, err error
 	return tx.getLatest(ctx)
 }
 
+const getSiteConfigByIDFmtStr = `
+SELECT %s -- siteConfigColumns
+FROM critical_and_site_config
+WHERE id = %s AND type = 'site'
+`
+
+func (s *confStore) SiteGetByID(ctx context.Context, id int32) (*SiteConfig, error) {
+	q := sqlf.Sprintf(
+		getSiteConfigByIDFmtStr,
+		sqlf.Join(siteConfigColumns, ","),
+		id,
+	)
+	config, err := scanSiteConfigRow(s.QueryRow(ctx, q))
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return config, err
+}
+
 const listSiteConfigsFmtStr = `
 SELECT
 	id,
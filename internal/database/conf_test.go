@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/log/logtest"
@@ -382,6 +383,30 @@ func TestGetSiteConfigCount(t *testing.T) {
 	}
 }
 
+func TestSiteGetByID(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	s := db.Conf()
+	createDummySiteConfigs(t, ctx, s)
+
+	siteConfig, err := s.SiteGetByID(ctx, 3)
+	require.NoError(t, err)
+	require.NotNil(t, siteConfig)
+	assert.Equal(t, int32(3), siteConfig.ID)
+	assert.Equal(t, int32(1), siteConfig.AuthorUserID)
+	assert.Equal(t, `{"auth.Providers": []}`, siteConfig.Contents)
+
+	siteConfig, err = s.SiteGetByID(ctx, 42)
+	require.NoError(t, err)
+	assert.Nil(t, siteConfig)
+}
+
 func TestListSiteConfigs(t *testing.T) {
 	toIntPtr := func(n int) *int { return &n }
 	toStringPtr := func(n string) *string { return &n }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type RecordEncrypter struct {
@@ -129,6 +130,132 @@ func (s *RecordEncrypter) DecryptBatch(ctx context.Context, config EncryptionCon
 	return len(decryptedValues), nil
 }
 
+// CountByKey returns the number of records encrypted with the given key and the number of
+// records encrypted with any other (previous) key. Unencrypted records are not counted.
+func (s *RecordEncrypter) CountByKey(ctx context.Context, config EncryptionConfig, key encryption.Key) (numCurrent int, numPrevious int, _ error) {
+	if key == nil {
+		return 0, 0, nil
+	}
+
+	keyIdent, err := keyIdentifier(ctx, key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	countQuery := sqlf.Sprintf(`
+		SELECT
+			(SELECT COUNT(*) FROM %s WHERE %s = %s) AS current,
+			(SELECT COUNT(*) FROM %s WHERE %s NOT IN ('', %s, %s)) AS previous
+		`,
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		keyIdent,
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		encryption.UnmigratedEncryptionKeyID,
+		keyIdent,
+	)
+	if err := s.QueryRow(ctx, countQuery).Scan(&numCurrent, &numPrevious); err != nil {
+		return 0, 0, err
+	}
+
+	return numCurrent, numPrevious, nil
+}
+
+// ReencryptBatchResult describes a batch of records processed by ReencryptBatch.
+type ReencryptBatchResult struct {
+	// Count is the number of records that were re-encrypted.
+	Count int
+	// LastID is the highest ID of the records in the batch, or zero if there were none.
+	// Passing it as afterID to the next call continues with the records after this batch.
+	LastID int
+	// FailedIDs are the IDs of the records in the batch that could not be decrypted with
+	// the given key, in ascending order. These records are left unchanged.
+	FailedIDs []int
+}
+
+// ReencryptBatch re-encrypts a batch of records with an ID greater than afterID that were
+// encrypted with a key other than the given key. The given key must be able to decrypt the
+// existing records, which is the case for keys that have been configured along with
+// encryption.keys.previousKeys. Records that cannot be decrypted are skipped and reported
+// in the result, so that they do not prevent the remaining records from being re-encrypted.
+func (s *RecordEncrypter) ReencryptBatch(ctx context.Context, config EncryptionConfig, key encryption.Key, afterID int) (result ReencryptBatchResult, err error) {
+	if key == nil {
+		return result, nil
+	}
+
+	keyIdent, err := keyIdentifier(ctx, key)
+	if err != nil {
+		return result, err
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	values, err := config.Scan(tx.Query(ctx, sqlf.Sprintf(
+		"SELECT %s FROM %s WHERE %s NOT IN ('', %s, %s) AND %s > %s ORDER BY %s ASC LIMIT %s FOR UPDATE SKIP LOCKED",
+		fields(config),
+		quote(config.TableName),
+		quote(config.KeyIDFieldName),
+		encryption.UnmigratedEncryptionKeyID,
+		keyIdent,
+		quote(config.IDFieldName),
+		afterID,
+		quote(config.IDFieldName),
+		config.Limit,
+	)))
+	if err != nil {
+		return result, err
+	}
+
+	decryptedValues := make(map[int][]string, len(values))
+	for id, ev := range values {
+		if id > result.LastID {
+			result.LastID = id
+		}
+
+		vs, err := decryptRecord(ctx, key, ev)
+		if err != nil {
+			result.FailedIDs = append(result.FailedIDs, id)
+			continue
+		}
+		decryptedValues[id] = vs
+	}
+	sort.Ints(result.FailedIDs)
+
+	encryptedValues, err := encryptValues(ctx, key, decryptedValues)
+	if err != nil {
+		return ReencryptBatchResult{}, err
+	}
+
+	for id, ev := range encryptedValues {
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			"UPDATE %s SET %s WHERE %s = %s",
+			quote(config.TableName),
+			updatePairs(config, ev),
+			quote(config.IDFieldName),
+			id,
+		)); err != nil {
+			return ReencryptBatchResult{}, err
+		}
+	}
+
+	result.Count = len(encryptedValues)
+	return result, nil
+}
+
+func keyIdentifier(ctx context.Context, key encryption.Key) (string, error) {
+	version, err := key.Version(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get encryption key version")
+	}
+
+	return version.JSON(), nil
+}
+
 func fields(c EncryptionConfig) *sqlf.Query {
 	names := make([]*sqlf.Query, 0, len(c.EncryptedFieldNames)+2)
 	names = append(names, quote(c.IDFieldName), quote(c.KeyIDFieldName))
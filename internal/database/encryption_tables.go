@@ -15,6 +15,7 @@ type EncryptionConfig struct {
 	UpdateAsBytes       bool
 	Scan                func(basestore.Rows, error) (map[int]Encrypted, error)
 	Key                 func() encryption.Key
+	RingKey             func(keyring.Ring) encryption.Key
 	Limit               int
 }
 
@@ -26,6 +27,8 @@ var EncryptionConfigs = []EncryptionConfig{
 	webhooklogsEncryptionConfig,
 	executorSecretsEncryptionConfig,
 	outboundWebhooksEncryptionConfig,
+	webhooksEncryptionConfig,
+	gitHubAppsEncryptionConfig,
 }
 
 var externalServicesEncryptionConfig = EncryptionConfig{
@@ -35,6 +38,7 @@ var externalServicesEncryptionConfig = EncryptionConfig{
 	EncryptedFieldNames: []string{"config"},
 	Scan:                basestore.NewMapScanner(scanEncryptedString),
 	Key:                 func() encryption.Key { return keyring.Default().ExternalServiceKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.ExternalServiceKey },
 	Limit:               100,
 }
 
@@ -45,6 +49,7 @@ var userExternalAccountsEncryptionConfig = EncryptionConfig{
 	EncryptedFieldNames: []string{"auth_data", "account_data"},
 	Scan:                basestore.NewMapScanner(scanEncryptedStringPair),
 	Key:                 func() encryption.Key { return keyring.Default().UserExternalAccountKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.UserExternalAccountKey },
 	Limit:               100,
 }
 
@@ -56,6 +61,7 @@ var userCredentialsEncryptionConfig = EncryptionConfig{
 	UpdateAsBytes:       true,
 	Scan:                basestore.NewMapScanner(scanEncryptedBytea),
 	Key:                 func() encryption.Key { return keyring.Default().BatchChangesCredentialKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.BatchChangesCredentialKey },
 	Limit:               5,
 }
 
@@ -67,6 +73,7 @@ var batchChangesSiteCredentialsEncryptionConfig = EncryptionConfig{
 	UpdateAsBytes:       true,
 	Scan:                basestore.NewMapScanner(scanEncryptedBytea),
 	Key:                 func() encryption.Key { return keyring.Default().BatchChangesCredentialKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.BatchChangesCredentialKey },
 	Limit:               5,
 }
 
@@ -77,6 +84,7 @@ var webhooklogsEncryptionConfig = EncryptionConfig{
 	EncryptedFieldNames: []string{"request", "response"},
 	Scan:                basestore.NewMapScanner(scanEncryptedStringPair),
 	Key:                 func() encryption.Key { return keyring.Default().WebhookLogKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.WebhookLogKey },
 	Limit:               5,
 }
 
@@ -88,6 +96,7 @@ var executorSecretsEncryptionConfig = EncryptionConfig{
 	UpdateAsBytes:       true,
 	Scan:                basestore.NewMapScanner(scanEncryptedBytea),
 	Key:                 func() encryption.Key { return keyring.Default().ExecutorSecretKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.ExecutorSecretKey },
 	Limit:               5,
 }
 
@@ -98,6 +107,29 @@ var outboundWebhooksEncryptionConfig = EncryptionConfig{
 	EncryptedFieldNames: []string{"url", "secret"},
 	Scan:                basestore.NewMapScanner(scanEncryptedStringPair),
 	Key:                 func() encryption.Key { return keyring.Default().OutboundWebhookKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.OutboundWebhookKey },
+	Limit:               5,
+}
+
+var webhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"secret"},
+	Scan:                basestore.NewMapScanner(scanEncryptedString),
+	Key:                 func() encryption.Key { return keyring.Default().WebhookKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.WebhookKey },
+	Limit:               5,
+}
+
+var gitHubAppsEncryptionConfig = EncryptionConfig{
+	TableName:           "github_apps",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"client_secret", "private_key"},
+	Scan:                basestore.NewMapScanner(scanEncryptedStringPair),
+	Key:                 func() encryption.Key { return keyring.Default().GitHubAppKey },
+	RingKey:             func(r keyring.Ring) encryption.Key { return r.GitHubAppKey },
 	Limit:               5,
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -14,6 +15,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/rotation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestRecordEncrypter(t *testing.T) {
@@ -160,3 +163,201 @@ func (k *base64Key) Decrypt(ctx context.Context, cipherText []byte) (*encryption
 	secret := encryption.NewSecret(string(text))
 	return &secret, nil
 }
+
+func TestRecordEncrypterReencrypt(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	oldKey := &base64Key{}
+	newKey := &prefixedBase64Key{}
+	encrypter := NewRecordEncrypter(db)
+
+	if err := encrypter.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	var writtenValues []string
+	for i := 0; i < 10; i++ {
+		data := fmt.Sprintf("data-%d", i)
+		encrypted, keyID, err := encryption.MaybeEncrypt(ctx, oldKey, data)
+		if err != nil {
+			t.Fatalf("failed to encrypt test data: %s", err)
+		}
+
+		if err := encrypter.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i+1, keyID, encrypted)); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+
+		writtenValues = append(writtenValues, data)
+	}
+	sort.Strings(writtenValues)
+
+	config := EncryptionConfig{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		Limit:               5,
+	}
+
+	// The rotated key must decrypt with the old key and encrypt with the new key
+	key := rotation.New(newKey, oldKey)
+
+	// Re-encrypt data in chunks
+	var afterID int
+	for i := 0; i < 2; i++ {
+		result, err := encrypter.ReencryptBatch(ctx, config, key, afterID)
+		if err != nil {
+			t.Fatalf("unexpected error re-encrypting batch: %s", err)
+		}
+		if result.Count != 5 {
+			t.Errorf("unexpected count. want=%d have=%d", 5, result.Count)
+		}
+		if want := 5 * (i + 1); result.LastID != want {
+			t.Errorf("unexpected last ID. want=%d have=%d", want, result.LastID)
+		}
+		afterID = result.LastID
+
+		numCurrent, numPrevious, err := encrypter.CountByKey(ctx, config, key)
+		if err != nil {
+			t.Fatalf("unexpected error counting records: %s", err)
+		}
+		if want := 5 * (i + 1); numCurrent != want {
+			t.Errorf("unexpected numCurrent. want=%d have=%d", want, numCurrent)
+		}
+		if want := 10 - 5*(i+1); numPrevious != want {
+			t.Errorf("unexpected numPrevious. want=%d have=%d", want, numPrevious)
+		}
+	}
+
+	// Nothing left to re-encrypt
+	for _, afterID := range []int{afterID, 0} {
+		result, err := encrypter.ReencryptBatch(ctx, config, key, afterID)
+		if err != nil {
+			t.Fatalf("unexpected error re-encrypting batch: %s", err)
+		}
+		if diff := cmp.Diff(ReencryptBatchResult{}, result); diff != "" {
+			t.Errorf("unexpected result (-want +got):\n%s", diff)
+		}
+	}
+
+	// Expect all data to be readable by the new key alone
+	encryptedValues, err := config.Scan(encrypter.Query(ctx, sqlf.Sprintf("SELECT id, encryption_key_id, data FROM test_encryptable")))
+	if err != nil {
+		t.Fatalf("failed to query data: %s", err)
+	}
+	decryptedValues, err := decryptValues(ctx, newKey, encryptedValues)
+	if err != nil {
+		t.Fatalf("failed to decrypt data: %s", err)
+	}
+	var data []string
+	for _, vs := range decryptedValues {
+		data = append(data, vs...)
+	}
+	sort.Strings(data)
+	if diff := cmp.Diff(writtenValues, data); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecordEncrypterReencryptUndecryptable(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	oldKey := &base64Key{}
+	newKey := &prefixedBase64Key{}
+	encrypter := NewRecordEncrypter(db)
+
+	if err := encrypter.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		encrypted, keyID, err := encryption.MaybeEncrypt(ctx, oldKey, fmt.Sprintf("data-%d", i))
+		if err != nil {
+			t.Fatalf("failed to encrypt test data: %s", err)
+		}
+		if i == 2 {
+			// Not valid base64, so neither key can decrypt it
+			encrypted = "corrupt!"
+		}
+
+		if err := encrypter.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i, keyID, encrypted)); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+	}
+
+	config := EncryptionConfig{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		Limit:               2,
+	}
+	key := rotation.New(newKey, oldKey)
+
+	// The undecryptable record is skipped and reported, and the next batch continues after it
+	for _, tc := range []struct {
+		afterID int
+		want    ReencryptBatchResult
+	}{
+		{afterID: 0, want: ReencryptBatchResult{Count: 1, LastID: 2, FailedIDs: []int{2}}},
+		{afterID: 2, want: ReencryptBatchResult{Count: 1, LastID: 3}},
+		{afterID: 3, want: ReencryptBatchResult{}},
+		{afterID: 0, want: ReencryptBatchResult{LastID: 2, FailedIDs: []int{2}}},
+	} {
+		result, err := encrypter.ReencryptBatch(ctx, config, key, tc.afterID)
+		if err != nil {
+			t.Fatalf("unexpected error re-encrypting batch: %s", err)
+		}
+		if diff := cmp.Diff(tc.want, result); diff != "" {
+			t.Errorf("unexpected result after ID %d (-want +got):\n%s", tc.afterID, diff)
+		}
+	}
+
+	numCurrent, numPrevious, err := encrypter.CountByKey(ctx, config, key)
+	if err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	}
+	if numCurrent != 2 || numPrevious != 1 {
+		t.Errorf("unexpected counts. want current=2 previous=1 have current=%d previous=%d", numCurrent, numPrevious)
+	}
+}
+
+type prefixedBase64Key struct{}
+
+func (k *prefixedBase64Key) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	return encryption.KeyVersion{
+		Type:    "base64",
+		Name:    "prefixed-base64",
+		Version: "0-test",
+	}, nil
+}
+
+func (k *prefixedBase64Key) Encrypt(ctx context.Context, value []byte) ([]byte, error) {
+	return []byte("prefixed:" + base64.StdEncoding.EncodeToString(value)), nil
+}
+
+func (k *prefixedBase64Key) Decrypt(ctx context.Context, cipherText []byte) (*encryption.Secret, error) {
+	if !strings.HasPrefix(string(cipherText), "prefixed:") {
+		return nil, errors.New("incorrect prefix")
+	}
+
+	text, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(string(cipherText), "prefixed:"))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := encryption.NewSecret(string(text))
+	return &secret, nil
+}
@@ -37,14 +37,9 @@ func encryptValues(ctx context.Context, key encryption.Key, m map[int][]string)
 func decryptValues(ctx context.Context, key encryption.Key, m map[int]Encrypted) (map[int][]string, error) {
 	decryptedMap := make(map[int][]string, len(m))
 	for id, ev := range m {
-		decryptedValues := make([]string, 0, len(ev.Values))
-		for _, v := range ev.Values {
-			dv, err := encryption.MaybeDecrypt(ctx, key, v, ev.KeyID)
-			if err != nil {
-				return nil, err
-			}
-
-			decryptedValues = append(decryptedValues, dv)
+		decryptedValues, err := decryptRecord(ctx, key, ev)
+		if err != nil {
+			return nil, err
 		}
 
 		decryptedMap[id] = decryptedValues
@@ -52,3 +47,17 @@ func decryptValues(ctx context.Context, key encryption.Key, m map[int]Encrypted)
 
 	return decryptedMap, nil
 }
+
+func decryptRecord(ctx context.Context, key encryption.Key, ev Encrypted) ([]string, error) {
+	decryptedValues := make([]string, 0, len(ev.Values))
+	for _, v := range ev.Values {
+		dv, err := encryption.MaybeDecrypt(ctx, key, v, ev.KeyID)
+		if err != nil {
+			return nil, err
+		}
+
+		decryptedValues = append(decryptedValues, dv)
+	}
+
+	return decryptedValues, nil
+}
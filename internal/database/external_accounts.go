@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/sourcegraph/log"
 
@@ -414,6 +415,11 @@ type ExternalAccountsListOptions struct {
 	ClientID    string
 	AccountID   string
 
+	// UserIDs and AccountIDs, if set, restrict the accounts to those of any of
+	// the given users or with any of the given account IDs.
+	UserIDs    []int32
+	AccountIDs []string
+
 	// Only one of these should be set
 	ExcludeExpired bool
 	OnlyExpired    bool
@@ -526,6 +532,12 @@ func (s *userExternalAccountsStore) listSQL(opt ExternalAccountsListOptions) (co
 	if opt.AccountID != "" {
 		conds = append(conds, sqlf.Sprintf("account_id=%s", opt.AccountID))
 	}
+	if len(opt.UserIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = ANY(%s)", pq.Array(opt.UserIDs)))
+	}
+	if len(opt.AccountIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("account_id = ANY(%s)", pq.Array(opt.AccountIDs)))
+	}
 	if opt.ExcludeExpired {
 		conds = append(conds, sqlf.Sprintf("expired_at IS NULL"))
 	}
@@ -268,6 +268,29 @@ func TestExternalAccounts_List(t *testing.T) {
 				AccountID: "33333",
 			},
 		},
+		{
+			name:        "ListByAccountIDs",
+			expectedIDs: []int32{userIDs[0], userIDs[2]},
+			args: ExternalAccountsListOptions{
+				AccountIDs: []string{"11", "3", "33333"},
+			},
+		},
+		{
+			name:        "ListByUserIDs",
+			expectedIDs: []int32{userIDs[1], userIDs[2]},
+			args: ExternalAccountsListOptions{
+				UserIDs: []int32{userIDs[1], userIDs[2]},
+			},
+		},
+		{
+			name:        "ListByUserIDsAndService",
+			expectedIDs: []int32{userIDs[1]},
+			args: ExternalAccountsListOptions{
+				UserIDs:     []int32{userIDs[1], userIDs[2]},
+				ServiceType: "xa",
+				ServiceID:   "xb",
+			},
+		},
 		{
 			name:        "ListByService",
 			expectedIDs: []int32{userIDs[0], userIDs[1]},
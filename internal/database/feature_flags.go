@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -34,7 +36,10 @@ type FeatureFlagStore interface {
 	GetOrgOverridesForUser(ctx context.Context, userID int32) ([]*ff.Override, error)
 	GetOrgOverrideForFlag(ctx context.Context, orgID int32, flagName string) (*ff.Override, error)
 	GetUserFlags(context.Context, int32) (map[string]bool, error)
+	GetUserFlagsForRepo(ctx context.Context, userID int32, repoName string) (map[string]bool, error)
+	GetUserVariants(context.Context, int32) (map[string]string, error)
 	GetAnonymousUserFlags(ctx context.Context, anonymousUID string) (map[string]bool, error)
+	GetAnonymousUserVariants(ctx context.Context, anonymousUID string) (map[string]string, error)
 	GetGlobalFeatureFlags(context.Context) (map[string]bool, error)
 	GetOrgFeatureFlag(ctx context.Context, orgID int32, flagName string) (bool, error)
 }
@@ -63,8 +68,12 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_name,
 			flag_type,
 			bool_value,
-			rollout
+			rollout,
+			variants,
+			rules
 		) VALUES (
+			%s,
+			%s,
 			%s,
 			%s,
 			%s,
@@ -74,33 +83,26 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type,
 			bool_value,
 			rollout,
+			variants,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	cols, err := featureFlagColumnValues(flag)
+	if err != nil {
+		return nil, err
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
 		newFeatureFlagFmtStr,
 		flag.Name,
-		flagType,
-		boolVal,
-		rollout))
+		cols.flagType,
+		cols.boolVal,
+		cols.rollout,
+		cols.variants,
+		cols.rules))
 	return scanFeatureFlag(row)
 }
 
@@ -111,6 +113,8 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type = %s,
 			bool_value = %s,
 			rollout = %s,
+			variants = %s,
+			rules = %s,
 			updated_at = NOW()
 		WHERE flag_name = %s
 		RETURNING
@@ -118,37 +122,77 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type,
 			bool_value,
 			rollout,
+			variants,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	cols, err := featureFlagColumnValues(flag)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag.Variants != nil {
+		overrides, err := f.GetOverridesForFlag(ctx, flag.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(overrides) > 0 {
+			return nil, errors.Wrap(errVariantOverride, "delete the overrides of the flag before making it multivariate")
+		}
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
 		updateFeatureFlagFmtStr,
-		flagType,
-		boolVal,
-		rollout,
+		cols.flagType,
+		cols.boolVal,
+		cols.rollout,
+		cols.variants,
+		cols.rules,
 		flag.Name,
 	))
 	return scanFeatureFlag(row)
 }
 
+// featureFlagColumns are the values of the type specific columns of a feature
+// flag row.
+type featureFlagColumns struct {
+	flagType string
+	boolVal  *bool
+	rollout  *int32
+	variants []byte
+	rules    []byte
+}
+
+func featureFlagColumnValues(flag *ff.FeatureFlag) (cols featureFlagColumns, err error) {
+	if err := flag.Validate(); err != nil {
+		return cols, err
+	}
+
+	switch {
+	case flag.Bool != nil:
+		cols.flagType = "bool"
+		cols.boolVal = &flag.Bool.Value
+	case flag.Rollout != nil:
+		cols.flagType = "rollout"
+		cols.rollout = &flag.Rollout.Rollout
+	case flag.Variants != nil:
+		cols.flagType = "variant"
+		if cols.variants, err = json.Marshal(flag.Variants.Variants); err != nil {
+			return cols, err
+		}
+	}
+
+	rules := flag.Rules
+	if rules == nil {
+		rules = []ff.Rule{}
+	}
+	cols.rules, err = json.Marshal(rules)
+	return cols, err
+}
+
 func (f *featureFlagStore) DeleteFeatureFlag(ctx context.Context, name string) error {
 	const deleteFeatureFlagFmtStr = `
 		UPDATE feature_flags
@@ -185,16 +229,16 @@ var ErrInvalidColumnState = errors.New("encountered column that is unexpectedly
 func scanFeatureFlagAndOverride(scanner dbutil.Scanner) (*ff.FeatureFlag, *bool, error) {
 	var (
 		res      ff.FeatureFlag
-		flagType string
-		boolVal  *bool
-		rollout  *int32
+		cols     featureFlagColumns
 		override *bool
 	)
 	err := scanner.Scan(
 		&res.Name,
-		&flagType,
-		&boolVal,
-		&rollout,
+		&cols.flagType,
+		&cols.boolVal,
+		&cols.rollout,
+		&cols.variants,
+		&cols.rules,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 		&res.DeletedAt,
@@ -204,40 +248,24 @@ func scanFeatureFlagAndOverride(scanner dbutil.Scanner) (*ff.FeatureFlag, *bool,
 		return nil, nil, err
 	}
 
-	switch flagType {
-	case "bool":
-		if boolVal == nil {
-			return nil, nil, ErrInvalidColumnState
-		}
-		res.Bool = &ff.FeatureFlagBool{
-			Value: *boolVal,
-		}
-	case "rollout":
-		if rollout == nil {
-			return nil, nil, ErrInvalidColumnState
-		}
-		res.Rollout = &ff.FeatureFlagRollout{
-			Rollout: *rollout,
-		}
-	default:
-		return nil, nil, ErrInvalidColumnState
+	if err := cols.decode(&res); err != nil {
+		return nil, nil, err
 	}
-
 	return &res, override, nil
 }
 
 func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
 	var (
-		res      ff.FeatureFlag
-		flagType string
-		boolVal  *bool
-		rollout  *int32
+		res  ff.FeatureFlag
+		cols featureFlagColumns
 	)
 	err := scanner.Scan(
 		&res.Name,
-		&flagType,
-		&boolVal,
-		&rollout,
+		&cols.flagType,
+		&cols.boolVal,
+		&cols.rollout,
+		&cols.variants,
+		&cols.rules,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 		&res.DeletedAt,
@@ -246,26 +274,49 @@ func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
 		return nil, err
 	}
 
-	switch flagType {
+	if err := cols.decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// decode sets the type and rules of the flag from the scanned column values.
+func (cols *featureFlagColumns) decode(res *ff.FeatureFlag) error {
+	switch cols.flagType {
 	case "bool":
-		if boolVal == nil {
-			return nil, ErrInvalidColumnState
+		if cols.boolVal == nil {
+			return ErrInvalidColumnState
 		}
 		res.Bool = &ff.FeatureFlagBool{
-			Value: *boolVal,
+			Value: *cols.boolVal,
 		}
 	case "rollout":
-		if rollout == nil {
-			return nil, ErrInvalidColumnState
+		if cols.rollout == nil {
+			return ErrInvalidColumnState
 		}
 		res.Rollout = &ff.FeatureFlagRollout{
-			Rollout: *rollout,
+			Rollout: *cols.rollout,
+		}
+	case "variant":
+		if cols.variants == nil {
+			return ErrInvalidColumnState
+		}
+		res.Variants = &ff.FeatureFlagVariants{}
+		if err := json.Unmarshal(cols.variants, &res.Variants.Variants); err != nil {
+			return errors.Wrap(err, "decoding feature flag variants")
 		}
 	default:
-		return nil, ErrInvalidColumnState
+		return ErrInvalidColumnState
 	}
 
-	return &res, nil
+	var rules []ff.Rule
+	if err := json.Unmarshal(cols.rules, &rules); err != nil {
+		return errors.Wrap(err, "decoding feature flag rules")
+	}
+	if len(rules) > 0 {
+		res.Rules = rules
+	}
+	return nil
 }
 
 func (f *featureFlagStore) GetFeatureFlag(ctx context.Context, flagName string) (*ff.FeatureFlag, error) {
@@ -275,6 +326,8 @@ func (f *featureFlagStore) GetFeatureFlag(ctx context.Context, flagName string)
 			flag_type,
 			bool_value,
 			rollout,
+			variants,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -294,6 +347,8 @@ func (f *featureFlagStore) GetFeatureFlags(ctx context.Context) ([]*ff.FeatureFl
 			flag_type,
 			bool_value,
 			rollout,
+			variants,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -318,7 +373,37 @@ func (f *featureFlagStore) GetFeatureFlags(ctx context.Context) ([]*ff.FeatureFl
 	return res, nil
 }
 
+// errVariantOverride is returned when writing an override for a multivariate flag. Overrides hold
+// boolean values, so they can't apply to multivariate flags.
+var errVariantOverride = errors.New("overrides are not supported for multivariate feature flags")
+
+// checkOverridable returns errVariantOverride if the given flag is a multivariate flag.
+func (f *featureFlagStore) checkOverridable(ctx context.Context, flagName string) error {
+	const isVariantFlagFmtStr = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM feature_flags
+			WHERE flag_name = %s
+				AND flag_type = 'variant'
+				AND deleted_at IS NULL
+		);
+	`
+
+	isVariant, _, err := basestore.ScanFirstBool(f.Query(ctx, sqlf.Sprintf(isVariantFlagFmtStr, flagName)))
+	if err != nil {
+		return err
+	}
+	if isVariant {
+		return errVariantOverride
+	}
+	return nil
+}
+
 func (f *featureFlagStore) CreateOverride(ctx context.Context, override *ff.Override) (*ff.Override, error) {
+	if err := f.checkOverridable(ctx, override.FlagName); err != nil {
+		return nil, err
+	}
+
 	const newFeatureFlagOverrideFmtStr = `
 		INSERT INTO feature_flag_overrides (
 			namespace_org_id,
@@ -392,6 +477,10 @@ func (f *featureFlagStore) UpdateOverride(ctx context.Context, orgID, userID *in
 		return nil, errors.New("must set either orgID or userID")
 	}
 
+	if err := f.checkOverridable(ctx, flagName); err != nil {
+		return nil, err
+	}
+
 	row := f.QueryRow(ctx, sqlf.Sprintf(
 		newFeatureFlagOverrideFmtStr,
 		newValue,
@@ -518,8 +607,19 @@ func scanFeatureFlagOverride(scanner dbutil.Scanner) (*ff.Override, error) {
 
 // GetUserFlags returns the calculated values for feature flags for the given userID. This should
 // be the primary entrypoint for getting the user flags since it handles retrieving all the flags,
-// the org overrides, and the user overrides, and merges them in priority order.
+// the org overrides, and the user overrides, and merges them in priority order. Multivariate
+// flags are not included, use GetUserVariants for those.
 func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[string]bool, error) {
+	return f.getUserFlags(ctx, userID, "")
+}
+
+// GetUserFlagsForRepo is like GetUserFlags, but also applies the targeting rules that match on
+// the given repository.
+func (f *featureFlagStore) GetUserFlagsForRepo(ctx context.Context, userID int32, repoName string) (map[string]bool, error) {
+	return f.getUserFlags(ctx, userID, repoName)
+}
+
+func (f *featureFlagStore) getUserFlags(ctx context.Context, userID int32, repoName string) (map[string]bool, error) {
 	const listUserOverridesFmtString = `
 		WITH user_overrides AS (
 			SELECT
@@ -547,6 +647,8 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 			flag_type,
 			bool_value,
 			rollout,
+			variants,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at,
@@ -557,6 +659,7 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 		LEFT JOIN org_overrides oo ON ff.flag_name = oo.flag_name
 		LEFT JOIN user_overrides uo ON ff.flag_name = uo.flag_name
 		WHERE deleted_at IS NULL
+			AND flag_type <> 'variant'
 	`
 	rows, err := f.Query(ctx, sqlf.Sprintf(listUserOverridesFmtString, userID, userID))
 	if err != nil {
@@ -564,19 +667,137 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 	}
 	defer rows.Close()
 
-	res := make(map[string]bool)
+	type flagAndOverride struct {
+		flag     *ff.FeatureFlag
+		override *bool
+	}
+	var flags []flagAndOverride
 	for rows.Next() {
 		flag, override, err := scanFeatureFlagAndOverride(rows)
 		if err != nil {
 			return nil, err
 		}
-		if override != nil {
-			res[flag.Name] = *override
+		flags = append(flags, flagAndOverride{flag, override})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var needsContext bool
+	for _, fo := range flags {
+		needsContext = needsContext || (fo.override == nil && len(fo.flag.Rules) > 0)
+	}
+	ec, err := f.evaluationContext(ctx, userID, needsContext)
+	if err != nil {
+		return nil, err
+	}
+	ec.RepoName = repoName
+
+	res := make(map[string]bool, len(flags))
+	for _, fo := range flags {
+		if fo.override != nil {
+			res[fo.flag.Name] = *fo.override
 		} else {
-			res[flag.Name] = flag.EvaluateForUser(userID)
+			res[fo.flag.Name] = fo.flag.EvaluateForUser(ec)
 		}
 	}
-	return res, rows.Err()
+	return res, nil
+}
+
+// GetUserVariants returns the calculated values of the multivariate feature flags for the given
+// userID. Overrides do not apply to multivariate flags.
+func (f *featureFlagStore) GetUserVariants(ctx context.Context, userID int32) (map[string]string, error) {
+	flags, err := f.getVariantFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var needsContext bool
+	for _, flag := range flags {
+		needsContext = needsContext || len(flag.Rules) > 0
+	}
+	ec, err := f.evaluationContext(ctx, userID, needsContext)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		res[flag.Name] = flag.EvaluateVariantForUser(ec)
+	}
+	return res, nil
+}
+
+// GetAnonymousUserVariants returns the calculated values of the multivariate feature flags for
+// the given anonymousUID.
+func (f *featureFlagStore) GetAnonymousUserVariants(ctx context.Context, anonymousUID string) (map[string]string, error) {
+	flags, err := f.getVariantFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		res[flag.Name] = flag.EvaluateVariantForAnonymousUser(anonymousUID)
+	}
+	return res, nil
+}
+
+func (f *featureFlagStore) getVariantFlags(ctx context.Context) ([]*ff.FeatureFlag, error) {
+	flags, err := f.GetFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	variantFlags := flags[:0]
+	for _, flag := range flags {
+		if flag.Variants != nil {
+			variantFlags = append(variantFlags, flag)
+		}
+	}
+	return variantFlags, nil
+}
+
+// evaluationContext loads the attributes of the given user that targeting rules match on. When
+// load is false, only the user ID is set.
+func (f *featureFlagStore) evaluationContext(ctx context.Context, userID int32, load bool) (ff.EvaluationContext, error) {
+	const evaluationContextFmtStr = `
+		SELECT
+			u.site_admin,
+			u.tags,
+			ARRAY(
+				SELECT orgs.name
+				FROM org_members
+				JOIN orgs ON orgs.id = org_members.org_id
+				WHERE org_members.user_id = u.id
+					AND orgs.deleted_at IS NULL
+			),
+			ARRAY(
+				SELECT DISTINCT lower(split_part(email, '@', 2))
+				FROM user_emails
+				WHERE user_emails.user_id = u.id
+					AND verified_at IS NOT NULL
+			)
+		FROM users u
+		WHERE u.id = %s
+			AND u.deleted_at IS NULL
+	`
+
+	ec := ff.EvaluationContext{UserID: userID}
+	if !load {
+		return ec, nil
+	}
+
+	err := f.QueryRow(ctx, sqlf.Sprintf(evaluationContextFmtStr, userID)).Scan(
+		&ec.SiteAdmin,
+		pq.Array(&ec.Tags),
+		pq.Array(&ec.OrgNames),
+		pq.Array(&ec.EmailDomains),
+	)
+	if err == sql.ErrNoRows {
+		return ec, nil
+	}
+	return ec, err
 }
 
 // GetAnonymousUserFlags returns the calculated values for feature flags for the given anonymousUID
@@ -588,6 +809,9 @@ func (f *featureFlagStore) GetAnonymousUserFlags(ctx context.Context, anonymousU
 
 	res := make(map[string]bool, len(flags))
 	for _, flag := range flags {
+		if flag.Variants != nil {
+			continue
+		}
 		res[flag.Name] = flag.EvaluateForAnonymousUser(anonymousUID)
 	}
 
@@ -637,7 +861,9 @@ func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, f
 	if override != nil {
 		return override.Value, nil
 	} else if globalFlag != nil {
-		return globalFlag.Bool.Value, nil
+		if val, ok := globalFlag.EvaluateGlobal(); ok {
+			return val, nil
+		}
 	}
 
 	return false, nil
@@ -29,6 +29,8 @@ func TestFeatureFlagStore(t *testing.T) {
 		t.Run("ListOrgOverrides", testListOrgOverrides)
 	})
 	t.Run("UserFlags", testUserFlags)
+	t.Run("UserFlagRules", testUserFlagRules)
+	t.Run("Variants", testVariants)
 	t.Run("AnonymousUserFlags", testAnonymousUserFlags)
 	t.Run("UserlessFeatureFlags", testUserlessFeatureFlags)
 	t.Run("OrganizationFeatureFlag", testOrgFeatureFlag)
@@ -90,10 +92,27 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			flag:      &ff.FeatureFlag{Name: "err_too_low_rollout", Rollout: &ff.FeatureFlagRollout{Rollout: -1}},
 			assertErr: errorContains(`violates check constraint "feature_flags_rollout_check"`),
 		},
+		{
+			flag: &ff.FeatureFlag{Name: "variants", Variants: &ff.FeatureFlagVariants{Variants: []ff.Variant{
+				{Value: "a", Weight: 4000},
+				{Value: "b", Weight: 6000},
+			}}},
+		},
+		{
+			flag: &ff.FeatureFlag{Name: "bool_with_rules", Bool: &ff.FeatureFlagBool{Value: false}, Rules: []ff.Rule{
+				{Conditions: []ff.Condition{{Type: ff.ConditionSiteAdmin}}, Value: true},
+			}},
+		},
 		{
 			flag:      &ff.FeatureFlag{Name: "err_no_types"},
 			assertErr: errorContains(`feature flag must have exactly one type`),
 		},
+		{
+			flag: &ff.FeatureFlag{Name: "err_invalid_rule", Bool: &ff.FeatureFlagBool{Value: false}, Rules: []ff.Rule{
+				{Conditions: []ff.Condition{{Type: ff.ConditionUserTag}}, Value: true},
+			}},
+			assertErr: errorContains(`must have at least one value`),
+		},
 	}
 
 	for _, tc := range cases {
@@ -110,6 +129,8 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			require.Equal(t, tc.flag.Name, res.Name)
 			require.Equal(t, tc.flag.Bool, res.Bool)
 			require.Equal(t, tc.flag.Rollout, res.Rollout)
+			require.Equal(t, tc.flag.Variants, res.Variants)
+			require.Equal(t, tc.flag.Rules, res.Rules)
 		})
 	}
 }
@@ -551,6 +572,113 @@ func testUserFlags(t *testing.T) {
 	})
 }
 
+func testUserFlagRules(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	flagStore := db.FeatureFlags()
+	ctx := actor.WithInternalActor(context.Background())
+
+	org, err := db.Orgs().Create(ctx, "search", nil)
+	require.NoError(t, err)
+
+	member, err := db.Users().Create(ctx, NewUser{Username: "member", Email: "member@example.com", EmailIsVerified: true})
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, org.ID, member.ID)
+	require.NoError(t, err)
+	_, err = db.Handle().ExecContext(ctx, `UPDATE users SET tags = '{beta}' WHERE id = $1`, member.ID)
+	require.NoError(t, err)
+
+	other, err := db.Users().Create(ctx, NewUser{Username: "other", Email: "other@example.com", EmailVerificationCode: "c"})
+	require.NoError(t, err)
+
+	mkFlag := func(name string, conditions ...ff.Condition) {
+		_, err := flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{
+			Name:  name,
+			Bool:  &ff.FeatureFlagBool{Value: false},
+			Rules: []ff.Rule{{Conditions: conditions, Value: true}},
+		})
+		require.NoError(t, err)
+	}
+	mkFlag("org", ff.Condition{Type: ff.ConditionOrgMember, Values: []string{"search"}})
+	mkFlag("tag", ff.Condition{Type: ff.ConditionUserTag, Values: []string{"beta"}})
+	mkFlag("domain", ff.Condition{Type: ff.ConditionEmailDomain, Values: []string{"example.com"}})
+	mkFlag("repo", ff.Condition{Type: ff.ConditionRepoName, Values: []string{"github.com/sourcegraph/*"}})
+	mkFlag("site-admin", ff.Condition{Type: ff.ConditionSiteAdmin})
+
+	// The first user is a site admin.
+	got, err := flagStore.GetUserFlags(ctx, member.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"org": true, "tag": true, "domain": true, "repo": false, "site-admin": true}, got)
+
+	// Unverified email addresses don't count.
+	got, err = flagStore.GetUserFlags(ctx, other.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"org": false, "tag": false, "domain": false, "repo": false, "site-admin": false}, got)
+
+	got, err = flagStore.GetUserFlagsForRepo(ctx, other.ID, "github.com/sourcegraph/sourcegraph")
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"org": false, "tag": false, "domain": false, "repo": true, "site-admin": false}, got)
+
+	// Overrides take precedence over rules.
+	_, err = flagStore.CreateOverride(ctx, &ff.Override{UserID: &member.ID, FlagName: "org", Value: false})
+	require.NoError(t, err)
+	got, err = flagStore.GetUserFlags(ctx, member.ID)
+	require.NoError(t, err)
+	require.False(t, got["org"])
+}
+
+func testVariants(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	flagStore := db.FeatureFlags()
+	ctx := actor.WithInternalActor(context.Background())
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u"})
+	require.NoError(t, err)
+
+	_, err = flagStore.CreateBool(ctx, "bool", true)
+	require.NoError(t, err)
+	_, err = flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{
+		Name:     "ranking",
+		Variants: &ff.FeatureFlagVariants{Variants: []ff.Variant{{Value: "a", Weight: 10000}, {Value: "b"}}},
+		Rules:    []ff.Rule{{Conditions: []ff.Condition{{Type: ff.ConditionSiteAdmin}}, Variant: "b"}},
+	})
+	require.NoError(t, err)
+
+	got, err := flagStore.GetUserVariants(ctx, user.ID)
+	require.NoError(t, err)
+	// The first user is a site admin.
+	require.Equal(t, map[string]string{"ranking": "b"}, got)
+
+	got, err = flagStore.GetAnonymousUserVariants(ctx, "anonymous")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ranking": "a"}, got)
+
+	// Multivariate flags are not part of the boolean flags.
+	userFlags, err := flagStore.GetUserFlags(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"bool": true}, userFlags)
+
+	anonymousFlags, err := flagStore.GetAnonymousUserFlags(ctx, "anonymous")
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"bool": true}, anonymousFlags)
+
+	// Overrides can't be set on multivariate flags.
+	_, err = flagStore.CreateOverride(ctx, &ff.Override{UserID: &user.ID, FlagName: "ranking", Value: true})
+	require.ErrorIs(t, err, errVariantOverride)
+
+	// Nor can a flag with overrides be made multivariate.
+	_, err = flagStore.CreateOverride(ctx, &ff.Override{UserID: &user.ID, FlagName: "bool", Value: false})
+	require.NoError(t, err)
+	_, err = flagStore.UpdateFeatureFlag(ctx, &ff.FeatureFlag{
+		Name:     "bool",
+		Variants: &ff.FeatureFlagVariants{Variants: []ff.Variant{{Value: "a", Weight: 10000}}},
+	})
+	require.ErrorIs(t, err, errVariantOverride)
+}
+
 func testAnonymousUserFlags(t *testing.T) {
 	t.Parallel()
 	logger := logtest.Scoped(t)
@@ -4016,6 +4016,9 @@ type MockConfStore struct {
 	// SiteCreateIfUpToDateFunc is an instance of a mock function object
 	// controlling the behavior of the method SiteCreateIfUpToDate.
 	SiteCreateIfUpToDateFunc *ConfStoreSiteCreateIfUpToDateFunc
+	// SiteGetByIDFunc is an instance of a mock function object controlling
+	// the behavior of the method SiteGetByID.
+	SiteGetByIDFunc *ConfStoreSiteGetByIDFunc
 	// SiteGetLatestFunc is an instance of a mock function object
 	// controlling the behavior of the method SiteGetLatest.
 	SiteGetLatestFunc *ConfStoreSiteGetLatestFunc
@@ -4053,6 +4056,11 @@ func NewMockConfStore() *MockConfStore {
 				return
 			},
 		},
+		SiteGetByIDFunc: &ConfStoreSiteGetByIDFunc{
+			defaultHook: func(context.Context, int32) (r0 *SiteConfig, r1 error) {
+				return
+			},
+		},
 		SiteGetLatestFunc: &ConfStoreSiteGetLatestFunc{
 			defaultHook: func(context.Context) (r0 *SiteConfig, r1 error) {
 				return
@@ -4095,6 +4103,11 @@ func NewStrictMockConfStore() *MockConfStore {
 				panic("unexpected invocation of MockConfStore.SiteCreateIfUpToDate")
 			},
 		},
+		SiteGetByIDFunc: &ConfStoreSiteGetByIDFunc{
+			defaultHook: func(context.Context, int32) (*SiteConfig, error) {
+				panic("unexpected invocation of MockConfStore.SiteGetByID")
+			},
+		},
 		SiteGetLatestFunc: &ConfStoreSiteGetLatestFunc{
 			defaultHook: func(context.Context) (*SiteConfig, error) {
 				panic("unexpected invocation of MockConfStore.SiteGetLatest")
@@ -4127,6 +4140,9 @@ func NewMockConfStoreFrom(i ConfStore) *MockConfStore {
 		SiteCreateIfUpToDateFunc: &ConfStoreSiteCreateIfUpToDateFunc{
 			defaultHook: i.SiteCreateIfUpToDate,
 		},
+		SiteGetByIDFunc: &ConfStoreSiteGetByIDFunc{
+			defaultHook: i.SiteGetByID,
+		},
 		SiteGetLatestFunc: &ConfStoreSiteGetLatestFunc{
 			defaultHook: i.SiteGetLatest,
 		},
@@ -4668,6 +4684,114 @@ func (c ConfStoreSiteCreateIfUpToDateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ConfStoreSiteGetByIDFunc describes the behavior when the SiteGetByID
+// method of the parent MockConfStore instance is invoked.
+type ConfStoreSiteGetByIDFunc struct {
+	defaultHook func(context.Context, int32) (*SiteConfig, error)
+	hooks       []func(context.Context, int32) (*SiteConfig, error)
+	history     []ConfStoreSiteGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// SiteGetByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockConfStore) SiteGetByID(v0 context.Context, v1 int32) (*SiteConfig, error) {
+	r0, r1 := m.SiteGetByIDFunc.nextHook()(v0, v1)
+	m.SiteGetByIDFunc.appendCall(ConfStoreSiteGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SiteGetByID method
+// of the parent MockConfStore instance is invoked and the hook queue is
+// empty.
+func (f *ConfStoreSiteGetByIDFunc) SetDefaultHook(hook func(context.Context, int32) (*SiteConfig, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SiteGetByID method of the parent MockConfStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ConfStoreSiteGetByIDFunc) PushHook(hook func(context.Context, int32) (*SiteConfig, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ConfStoreSiteGetByIDFunc) SetDefaultReturn(r0 *SiteConfig, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*SiteConfig, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ConfStoreSiteGetByIDFunc) PushReturn(r0 *SiteConfig, r1 error) {
+	f.PushHook(func(context.Context, int32) (*SiteConfig, error) {
+		return r0, r1
+	})
+}
+
+func (f *ConfStoreSiteGetByIDFunc) nextHook() func(context.Context, int32) (*SiteConfig, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ConfStoreSiteGetByIDFunc) appendCall(r0 ConfStoreSiteGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ConfStoreSiteGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *ConfStoreSiteGetByIDFunc) History() []ConfStoreSiteGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ConfStoreSiteGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ConfStoreSiteGetByIDFuncCall is an object that describes an invocation of
+// method SiteGetByID on an instance of MockConfStore.
+type ConfStoreSiteGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SiteConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ConfStoreSiteGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ConfStoreSiteGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ConfStoreSiteGetLatestFunc describes the behavior when the SiteGetLatest
 // method of the parent MockConfStore instance is invoked.
 type ConfStoreSiteGetLatestFunc struct {
@@ -22384,6 +22508,9 @@ type MockFeatureFlagStore struct {
 	// GetAnonymousUserFlagsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAnonymousUserFlags.
 	GetAnonymousUserFlagsFunc *FeatureFlagStoreGetAnonymousUserFlagsFunc
+	// GetAnonymousUserVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetAnonymousUserVariants.
+	GetAnonymousUserVariantsFunc *FeatureFlagStoreGetAnonymousUserVariantsFunc
 	// GetFeatureFlagFunc is an instance of a mock function object
 	// controlling the behavior of the method GetFeatureFlag.
 	GetFeatureFlagFunc *FeatureFlagStoreGetFeatureFlagFunc
@@ -22408,9 +22535,15 @@ type MockFeatureFlagStore struct {
 	// GetUserFlagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUserFlags.
 	GetUserFlagsFunc *FeatureFlagStoreGetUserFlagsFunc
+	// GetUserFlagsForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserFlagsForRepo.
+	GetUserFlagsForRepoFunc *FeatureFlagStoreGetUserFlagsForRepoFunc
 	// GetUserOverridesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUserOverrides.
 	GetUserOverridesFunc *FeatureFlagStoreGetUserOverridesFunc
+	// GetUserVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserVariants.
+	GetUserVariantsFunc *FeatureFlagStoreGetUserVariantsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *FeatureFlagStoreHandleFunc
@@ -22468,6 +22601,11 @@ func NewMockFeatureFlagStore() *MockFeatureFlagStore {
 				return
 			},
 		},
+		GetAnonymousUserVariantsFunc: &FeatureFlagStoreGetAnonymousUserVariantsFunc{
+			defaultHook: func(context.Context, string) (r0 map[string]string, r1 error) {
+				return
+			},
+		},
 		GetFeatureFlagFunc: &FeatureFlagStoreGetFeatureFlagFunc{
 			defaultHook: func(context.Context, string) (r0 *featureflag.FeatureFlag, r1 error) {
 				return
@@ -22508,11 +22646,21 @@ func NewMockFeatureFlagStore() *MockFeatureFlagStore {
 				return
 			},
 		},
+		GetUserFlagsForRepoFunc: &FeatureFlagStoreGetUserFlagsForRepoFunc{
+			defaultHook: func(context.Context, int32, string) (r0 map[string]bool, r1 error) {
+				return
+			},
+		},
 		GetUserOverridesFunc: &FeatureFlagStoreGetUserOverridesFunc{
 			defaultHook: func(context.Context, int32) (r0 []*featureflag.Override, r1 error) {
 				return
 			},
 		},
+		GetUserVariantsFunc: &FeatureFlagStoreGetUserVariantsFunc{
+			defaultHook: func(context.Context, int32) (r0 map[string]string, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &FeatureFlagStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -22580,6 +22728,11 @@ func NewStrictMockFeatureFlagStore() *MockFeatureFlagStore {
 				panic("unexpected invocation of MockFeatureFlagStore.GetAnonymousUserFlags")
 			},
 		},
+		GetAnonymousUserVariantsFunc: &FeatureFlagStoreGetAnonymousUserVariantsFunc{
+			defaultHook: func(context.Context, string) (map[string]string, error) {
+				panic("unexpected invocation of MockFeatureFlagStore.GetAnonymousUserVariants")
+			},
+		},
 		GetFeatureFlagFunc: &FeatureFlagStoreGetFeatureFlagFunc{
 			defaultHook: func(context.Context, string) (*featureflag.FeatureFlag, error) {
 				panic("unexpected invocation of MockFeatureFlagStore.GetFeatureFlag")
@@ -22620,11 +22773,21 @@ func NewStrictMockFeatureFlagStore() *MockFeatureFlagStore {
 				panic("unexpected invocation of MockFeatureFlagStore.GetUserFlags")
 			},
 		},
+		GetUserFlagsForRepoFunc: &FeatureFlagStoreGetUserFlagsForRepoFunc{
+			defaultHook: func(context.Context, int32, string) (map[string]bool, error) {
+				panic("unexpected invocation of MockFeatureFlagStore.GetUserFlagsForRepo")
+			},
+		},
 		GetUserOverridesFunc: &FeatureFlagStoreGetUserOverridesFunc{
 			defaultHook: func(context.Context, int32) ([]*featureflag.Override, error) {
 				panic("unexpected invocation of MockFeatureFlagStore.GetUserOverrides")
 			},
 		},
+		GetUserVariantsFunc: &FeatureFlagStoreGetUserVariantsFunc{
+			defaultHook: func(context.Context, int32) (map[string]string, error) {
+				panic("unexpected invocation of MockFeatureFlagStore.GetUserVariants")
+			},
+		},
 		HandleFunc: &FeatureFlagStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockFeatureFlagStore.Handle")
@@ -22679,6 +22842,9 @@ func NewMockFeatureFlagStoreFrom(i FeatureFlagStore) *MockFeatureFlagStore {
 		GetAnonymousUserFlagsFunc: &FeatureFlagStoreGetAnonymousUserFlagsFunc{
 			defaultHook: i.GetAnonymousUserFlags,
 		},
+		GetAnonymousUserVariantsFunc: &FeatureFlagStoreGetAnonymousUserVariantsFunc{
+			defaultHook: i.GetAnonymousUserVariants,
+		},
 		GetFeatureFlagFunc: &FeatureFlagStoreGetFeatureFlagFunc{
 			defaultHook: i.GetFeatureFlag,
 		},
@@ -22703,9 +22869,15 @@ func NewMockFeatureFlagStoreFrom(i FeatureFlagStore) *MockFeatureFlagStore {
 		GetUserFlagsFunc: &FeatureFlagStoreGetUserFlagsFunc{
 			defaultHook: i.GetUserFlags,
 		},
+		GetUserFlagsForRepoFunc: &FeatureFlagStoreGetUserFlagsForRepoFunc{
+			defaultHook: i.GetUserFlagsForRepo,
+		},
 		GetUserOverridesFunc: &FeatureFlagStoreGetUserOverridesFunc{
 			defaultHook: i.GetUserOverrides,
 		},
+		GetUserVariantsFunc: &FeatureFlagStoreGetUserVariantsFunc{
+			defaultHook: i.GetUserVariants,
+		},
 		HandleFunc: &FeatureFlagStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
@@ -23502,6 +23674,118 @@ func (c FeatureFlagStoreGetAnonymousUserFlagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// FeatureFlagStoreGetAnonymousUserVariantsFunc describes the behavior when
+// the GetAnonymousUserVariants method of the parent MockFeatureFlagStore
+// instance is invoked.
+type FeatureFlagStoreGetAnonymousUserVariantsFunc struct {
+	defaultHook func(context.Context, string) (map[string]string, error)
+	hooks       []func(context.Context, string) (map[string]string, error)
+	history     []FeatureFlagStoreGetAnonymousUserVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAnonymousUserVariants delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockFeatureFlagStore) GetAnonymousUserVariants(v0 context.Context, v1 string) (map[string]string, error) {
+	r0, r1 := m.GetAnonymousUserVariantsFunc.nextHook()(v0, v1)
+	m.GetAnonymousUserVariantsFunc.appendCall(FeatureFlagStoreGetAnonymousUserVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAnonymousUserVariants method of the parent MockFeatureFlagStore
+// instance is invoked and the hook queue is empty.
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) SetDefaultHook(hook func(context.Context, string) (map[string]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAnonymousUserVariants method of the parent MockFeatureFlagStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) PushHook(hook func(context.Context, string) (map[string]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) SetDefaultReturn(r0 map[string]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) PushReturn(r0 map[string]string, r1 error) {
+	f.PushHook(func(context.Context, string) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) nextHook() func(context.Context, string) (map[string]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) appendCall(r0 FeatureFlagStoreGetAnonymousUserVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// FeatureFlagStoreGetAnonymousUserVariantsFuncCall objects describing the
+// invocations of this function.
+func (f *FeatureFlagStoreGetAnonymousUserVariantsFunc) History() []FeatureFlagStoreGetAnonymousUserVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]FeatureFlagStoreGetAnonymousUserVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// FeatureFlagStoreGetAnonymousUserVariantsFuncCall is an object that
+// describes an invocation of method GetAnonymousUserVariants on an instance
+// of MockFeatureFlagStore.
+type FeatureFlagStoreGetAnonymousUserVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c FeatureFlagStoreGetAnonymousUserVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c FeatureFlagStoreGetAnonymousUserVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // FeatureFlagStoreGetFeatureFlagFunc describes the behavior when the
 // GetFeatureFlag method of the parent MockFeatureFlagStore instance is
 // invoked.
@@ -24390,6 +24674,120 @@ func (c FeatureFlagStoreGetUserFlagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// FeatureFlagStoreGetUserFlagsForRepoFunc describes the behavior when the
+// GetUserFlagsForRepo method of the parent MockFeatureFlagStore instance is
+// invoked.
+type FeatureFlagStoreGetUserFlagsForRepoFunc struct {
+	defaultHook func(context.Context, int32, string) (map[string]bool, error)
+	hooks       []func(context.Context, int32, string) (map[string]bool, error)
+	history     []FeatureFlagStoreGetUserFlagsForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserFlagsForRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockFeatureFlagStore) GetUserFlagsForRepo(v0 context.Context, v1 int32, v2 string) (map[string]bool, error) {
+	r0, r1 := m.GetUserFlagsForRepoFunc.nextHook()(v0, v1, v2)
+	m.GetUserFlagsForRepoFunc.appendCall(FeatureFlagStoreGetUserFlagsForRepoFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserFlagsForRepo
+// method of the parent MockFeatureFlagStore instance is invoked and the
+// hook queue is empty.
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) SetDefaultHook(hook func(context.Context, int32, string) (map[string]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserFlagsForRepo method of the parent MockFeatureFlagStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) PushHook(hook func(context.Context, int32, string) (map[string]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) SetDefaultReturn(r0 map[string]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) PushReturn(r0 map[string]bool, r1 error) {
+	f.PushHook(func(context.Context, int32, string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) nextHook() func(context.Context, int32, string) (map[string]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) appendCall(r0 FeatureFlagStoreGetUserFlagsForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of FeatureFlagStoreGetUserFlagsForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *FeatureFlagStoreGetUserFlagsForRepoFunc) History() []FeatureFlagStoreGetUserFlagsForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]FeatureFlagStoreGetUserFlagsForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// FeatureFlagStoreGetUserFlagsForRepoFuncCall is an object that describes
+// an invocation of method GetUserFlagsForRepo on an instance of
+// MockFeatureFlagStore.
+type FeatureFlagStoreGetUserFlagsForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c FeatureFlagStoreGetUserFlagsForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c FeatureFlagStoreGetUserFlagsForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // FeatureFlagStoreGetUserOverridesFunc describes the behavior when the
 // GetUserOverrides method of the parent MockFeatureFlagStore instance is
 // invoked.
@@ -24501,6 +24899,117 @@ func (c FeatureFlagStoreGetUserOverridesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// FeatureFlagStoreGetUserVariantsFunc describes the behavior when the
+// GetUserVariants method of the parent MockFeatureFlagStore instance is
+// invoked.
+type FeatureFlagStoreGetUserVariantsFunc struct {
+	defaultHook func(context.Context, int32) (map[string]string, error)
+	hooks       []func(context.Context, int32) (map[string]string, error)
+	history     []FeatureFlagStoreGetUserVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserVariants delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockFeatureFlagStore) GetUserVariants(v0 context.Context, v1 int32) (map[string]string, error) {
+	r0, r1 := m.GetUserVariantsFunc.nextHook()(v0, v1)
+	m.GetUserVariantsFunc.appendCall(FeatureFlagStoreGetUserVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserVariants
+// method of the parent MockFeatureFlagStore instance is invoked and the
+// hook queue is empty.
+func (f *FeatureFlagStoreGetUserVariantsFunc) SetDefaultHook(hook func(context.Context, int32) (map[string]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserVariants method of the parent MockFeatureFlagStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *FeatureFlagStoreGetUserVariantsFunc) PushHook(hook func(context.Context, int32) (map[string]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *FeatureFlagStoreGetUserVariantsFunc) SetDefaultReturn(r0 map[string]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *FeatureFlagStoreGetUserVariantsFunc) PushReturn(r0 map[string]string, r1 error) {
+	f.PushHook(func(context.Context, int32) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *FeatureFlagStoreGetUserVariantsFunc) nextHook() func(context.Context, int32) (map[string]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *FeatureFlagStoreGetUserVariantsFunc) appendCall(r0 FeatureFlagStoreGetUserVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of FeatureFlagStoreGetUserVariantsFuncCall
+// objects describing the invocations of this function.
+func (f *FeatureFlagStoreGetUserVariantsFunc) History() []FeatureFlagStoreGetUserVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]FeatureFlagStoreGetUserVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// FeatureFlagStoreGetUserVariantsFuncCall is an object that describes an
+// invocation of method GetUserVariants on an instance of
+// MockFeatureFlagStore.
+type FeatureFlagStoreGetUserVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c FeatureFlagStoreGetUserVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c FeatureFlagStoreGetUserVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // FeatureFlagStoreHandleFunc describes the behavior when the Handle method
 // of the parent MockFeatureFlagStore instance is invoked.
 type FeatureFlagStoreHandleFunc struct {
@@ -45,6 +45,8 @@ const (
 
 	SecurityEventNameAccessGranted SecurityEventName = "AccessGranted"
 
+	SecurityEventNameSiteConfigRestored SecurityEventName = "SiteConfigRestored"
+
 	SecurityEventAccessTokenCreated             SecurityEventName = "AccessTokenCreated"
 	SecurityEventAccessTokenDeleted             SecurityEventName = "AccessTokenDeleted"
 	SecurityEventAccessTokenHardDeleted         SecurityEventName = "AccessTokenHardDeleted"
@@ -157,6 +159,7 @@ func (s *securityEventLogsStore) InsertList(ctx context.Context, events []*Secur
 			event.Timestamp.UTC(),
 		)
 	}
+
 	query := sqlf.Sprintf("INSERT INTO security_event_logs(name, url, user_id, anonymous_user_id, source, argument, version, timestamp) VALUES %s", sqlf.Join(vals, ","))
 
 	if _, err := s.Handle().ExecContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
@@ -165,8 +168,9 @@ func (s *securityEventLogsStore) InsertList(ctx context.Context, events []*Secur
 
 	for _, event := range events {
 		audit.Log(ctx, s.logger, audit.Record{
-			Entity: "security events",
-			Action: string(event.Name),
+			Entity:        "security events",
+			Action:        string(event.Name),
+			SecurityEvent: true,
 			Fields: []log.Field{
 				log.Object("event",
 					log.String("URL", event.URL),
@@ -178,6 +182,17 @@ func (s *securityEventLogsStore) InsertList(ctx context.Context, events []*Secur
 					log.String("timestamp", event.Timestamp.UTC().String()),
 				),
 			},
+			Data: map[string]any{
+				"event": map[string]any{
+					"URL":             event.URL,
+					"UserID":          event.UserID,
+					"AnonymousUserID": event.AnonymousUserID,
+					"source":          event.Source,
+					"argument":        event.marshalArgumentAsJSON(),
+					"version":         version.Version(),
+					"timestamp":       event.Timestamp.UTC().String(),
+				},
+			},
 		})
 	}
 	return nil
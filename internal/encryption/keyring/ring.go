@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cache"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/cloudkms"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/mounted"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/rotation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -134,9 +135,42 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if len(keyConfig.PreviousKeys) > 0 {
+		previousKeys := make([]encryption.Key, 0, len(keyConfig.PreviousKeys))
+		for _, k := range keyConfig.PreviousKeys {
+			previousKey, err := NewKey(ctx, k, keyConfig)
+			if err != nil {
+				return nil, errors.Wrap(err, "previousKeys")
+			}
+			previousKeys = append(previousKeys, previousKey)
+		}
+
+		r.withPreviousKeys(previousKeys)
+	}
+
 	return &r, nil
 }
 
+// withPreviousKeys wraps each configured key so that values encrypted with one of the
+// given previous keys can still be decrypted. Values are always encrypted with the
+// configured key.
+func (r *Ring) withPreviousKeys(previousKeys []encryption.Key) {
+	for _, key := range []*encryption.Key{
+		&r.BatchChangesCredentialKey,
+		&r.ExternalServiceKey,
+		&r.GitHubAppKey,
+		&r.OutboundWebhookKey,
+		&r.UserExternalAccountKey,
+		&r.WebhookKey,
+		&r.WebhookLogKey,
+		&r.ExecutorSecretKey,
+	} {
+		if *key != nil {
+			*key = rotation.New(*key, previousKeys...)
+		}
+	}
+}
+
 type Ring struct {
 	BatchChangesCredentialKey encryption.Key
 	ExternalServiceKey        encryption.Key
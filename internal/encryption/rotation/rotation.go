@@ -0,0 +1,44 @@
+package rotation
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+// New returns a rotation.Key that encrypts values with the given primary key and is able
+// to decrypt values that were encrypted with the primary key or any of the previous keys.
+func New(primary encryption.Key, previous ...encryption.Key) *Key {
+	return &Key{
+		Key:      primary,
+		previous: previous,
+	}
+}
+
+// Key wraps a primary encryption.Key along with a set of keys that have been rotated out.
+// Encryption and versioning are always delegated to the primary key, so new and re-encrypted
+// values are written with the primary key only. The previous keys are only used to decrypt
+// values that have not yet been re-encrypted.
+type Key struct {
+	encryption.Key
+
+	previous []encryption.Key
+}
+
+// Decrypt attempts to decrypt the ciphertext with the primary key. If that fails, each of
+// the previous keys is tried in order. If no key can decrypt the value, the error from the
+// primary key is returned.
+func (k *Key) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secret, error) {
+	secret, err := k.Key.Decrypt(ctx, ciphertext)
+	if err == nil {
+		return secret, nil
+	}
+
+	for _, previous := range k.previous {
+		if secret, previousErr := previous.Decrypt(ctx, ciphertext); previousErr == nil {
+			return secret, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,71 @@
+package rotation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRotationKey(t *testing.T) {
+	ctx := context.Background()
+	oldKey := &prefixKey{prefix: "old:"}
+	newKey := &prefixKey{prefix: "new:"}
+
+	oldCiphertext, err := oldKey.Encrypt(ctx, []byte("foobar"))
+	require.NoError(t, err)
+
+	key := New(newKey, oldKey)
+
+	// new values are always written with the primary key
+	newCiphertext, err := key.Encrypt(ctx, []byte("foobaz"))
+	require.NoError(t, err)
+	assert.Equal(t, "new:foobaz", string(newCiphertext))
+
+	version, err := key.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "new:", version.Name)
+
+	// values written with the primary key are readable
+	secret, err := key.Decrypt(ctx, newCiphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "foobaz", secret.Secret())
+
+	// values written with a previous key are readable
+	secret, err = key.Decrypt(ctx, oldCiphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", secret.Secret())
+
+	// values written with an unknown key are not readable
+	_, err = key.Decrypt(ctx, []byte("unknown:foobar"))
+	require.Error(t, err)
+
+	// once the previous key is retired, old values are no longer readable
+	_, err = New(newKey).Decrypt(ctx, oldCiphertext)
+	require.Error(t, err)
+}
+
+type prefixKey struct {
+	prefix string
+}
+
+func (k *prefixKey) Encrypt(ctx context.Context, value []byte) ([]byte, error) {
+	return append([]byte(k.prefix), value...), nil
+}
+
+func (k *prefixKey) Decrypt(ctx context.Context, ciphertext []byte) (*encryption.Secret, error) {
+	if len(ciphertext) < len(k.prefix) || string(ciphertext[:len(k.prefix)]) != k.prefix {
+		return nil, errors.New("incorrect prefix")
+	}
+
+	s := encryption.NewSecret(string(ciphertext[len(k.prefix):]))
+	return &s, nil
+}
+
+func (k *prefixKey) Version(ctx context.Context) (encryption.KeyVersion, error) {
+	return encryption.KeyVersion{Type: "prefix", Name: k.prefix}, nil
+}
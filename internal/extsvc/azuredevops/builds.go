@@ -0,0 +1,29 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// RetryBuild queues the failed jobs of the build with the given ID again,
+// returns the updated build.
+func (c *client) RetryBuild(ctx context.Context, org, project string, buildID int) (Build, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/build/builds/%d", org, project, buildID)}
+	q := reqURL.Query()
+	q.Set("retry", "true")
+	reqURL.RawQuery = q.Encode()
+
+	// The API expects a build as the request body, but ignores it when
+	// retrying.
+	req, err := http.NewRequest("PATCH", reqURL.String(), bytes.NewBufferString("{}"))
+	if err != nil {
+		return Build{}, err
+	}
+
+	var b Build
+	_, err = c.do(ctx, req, "", &b)
+	return b, err
+}
@@ -41,6 +41,8 @@ type Client interface {
 	UpdatePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestUpdateInput) (PullRequest, error)
 	CreatePullRequestCommentThread(ctx context.Context, args PullRequestCommonArgs, input PullRequestCommentInput) (PullRequestCommentResponse, error)
 	CompletePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestCompleteInput) (PullRequest, error)
+	AddPullRequestReviewer(ctx context.Context, args PullRequestCommonArgs, reviewerID string) (Reviewer, error)
+	AddPullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) (PullRequestLabel, error)
 	GetRepo(ctx context.Context, args OrgProjectRepoArgs) (Repository, error)
 	ListRepositoriesByProjectOrOrg(ctx context.Context, args ListRepositoriesByProjectOrOrgArgs) ([]Repository, error)
 	ForkRepository(ctx context.Context, org string, input ForkRepositoryInput) (Repository, error)
@@ -48,6 +50,7 @@ type Client interface {
 	GetProject(ctx context.Context, org, project string) (Project, error)
 	GetAuthorizedProfile(ctx context.Context) (Profile, error)
 	ListAuthorizedUserOrganizations(ctx context.Context, profile Profile) ([]Org, error)
+	RetryBuild(ctx context.Context, org, project string, buildID int) (Build, error)
 	SetWaitForRateLimit(wait bool)
 }
 
@@ -165,3 +165,49 @@ func (c *client) CompletePullRequest(ctx context.Context, args PullRequestCommon
 
 	return pr, nil
 }
+
+// AddPullRequestReviewer adds the identity with the given ID as a reviewer to
+// the specified PR, returns the added reviewer.
+func (c *client) AddPullRequestReviewer(ctx context.Context, args PullRequestCommonArgs, reviewerID string) (Reviewer, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/reviewers/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID, reviewerID)}
+
+	data, err := json.Marshal(Reviewer{ID: reviewerID})
+	if err != nil {
+		return Reviewer{}, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest("PUT", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return Reviewer{}, err
+	}
+
+	var reviewer Reviewer
+	if _, err = c.do(ctx, req, "", &reviewer); err != nil {
+		return Reviewer{}, err
+	}
+
+	return reviewer, nil
+}
+
+// AddPullRequestLabel adds the label with the given name to the specified PR,
+// returns the added label.
+func (c *client) AddPullRequestLabel(ctx context.Context, args PullRequestCommonArgs, name string) (PullRequestLabel, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/labels", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	data, err := json.Marshal(PullRequestLabel{Name: name})
+	if err != nil {
+		return PullRequestLabel{}, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return PullRequestLabel{}, err
+	}
+
+	var label PullRequestLabel
+	if _, err = c.do(ctx, req, "", &label); err != nil {
+		return PullRequestLabel{}, err
+	}
+
+	return label, nil
+}
@@ -113,6 +113,12 @@ type Reviewer struct {
 	UniqueName  string `json:"uniqueName"`
 }
 
+type PullRequestLabel struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Active bool   `json:"active,omitempty"`
+}
+
 type PullRequestCommonArgs struct {
 	PullRequestID string
 	Org           string
@@ -211,12 +217,29 @@ type PullRequestStatuses struct {
 }
 
 type PullRequestBuildStatus struct {
-	ID           int                    `json:"id"`
-	State        PullRequestStatusState `json:"state"`
-	Description  string                 `json:"description"`
-	CreationDate time.Time              `json:"creationDate"`
-	UpdateDate   time.Time              `json:"updatedDate"`
-	CreatedBy    CreatorInfo            `json:"createdBy"`
+	ID           int                      `json:"id"`
+	State        PullRequestStatusState   `json:"state"`
+	Description  string                   `json:"description"`
+	Context      PullRequestStatusContext `json:"context"`
+	TargetURL    string                   `json:"targetUrl"`
+	CreationDate time.Time                `json:"creationDate"`
+	UpdateDate   time.Time                `json:"updatedDate"`
+	CreatedBy    CreatorInfo              `json:"createdBy"`
+}
+
+// PullRequestStatusContext identifies the service that posted a status. Builds
+// of Azure Pipelines have the genre "continuous-integration".
+type PullRequestStatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre"`
+}
+
+type Build struct {
+	ID          int    `json:"id"`
+	BuildNumber string `json:"buildNumber"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	URL         string `json:"url"`
 }
 
 type PullRequestStatusState string
@@ -198,11 +198,16 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		Repository *repository `json:"repository,omitempty"`
 	}
 
+	type account struct {
+		UUID string `json:"uuid"`
+	}
+
 	type request struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description,omitempty"`
-		Source      source  `json:"source"`
-		Destination *source `json:"destination,omitempty"`
+		Title       string    `json:"title"`
+		Description string    `json:"description,omitempty"`
+		Source      source    `json:"source"`
+		Destination *source   `json:"destination,omitempty"`
+		Reviewers   []account `json:"reviewers,omitempty"`
 	}
 
 	req := request{
@@ -212,6 +217,9 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 			Branch: branch{Name: input.SourceBranch},
 		},
 	}
+	for _, reviewer := range input.Reviewers {
+		req.Reviewers = append(req.Reviewers, account{UUID: reviewer.UUID})
+	}
 	if input.SourceRepo != nil {
 		req.Source.Repository = &repository{
 			FullName: input.SourceRepo.FullName,
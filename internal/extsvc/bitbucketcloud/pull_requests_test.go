@@ -2,6 +2,7 @@ package bitbucketcloud
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -389,3 +390,19 @@ func TestClient_UpdatePullRequest(t *testing.T) {
 		assertGolden(t, updated)
 	})
 }
+
+func TestPullRequestInput_MarshalJSON(t *testing.T) {
+	input := PullRequestInput{
+		Title:        "title",
+		SourceBranch: "branch",
+		Reviewers:    []Account{{UUID: "{a}", DisplayName: "A"}, {UUID: "{b}"}},
+	}
+
+	data, err := json.Marshal(&input)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"title": "title",
+		"source": {"branch": {"name": "branch"}},
+		"reviewers": [{"uuid": "{a}"}, {"uuid": "{b}"}]
+	}`, string(data))
+}
@@ -96,11 +96,18 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID   string
+	Name string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
-	Conclusion string
+	Conclusion  string
+	DetailsURL  string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	// The ID of the check suite the run belongs to. Only set when the run was
+	// received via a webhook, otherwise the run is nested in its suite.
+	CheckSuiteID string
 	// When the run was received via a webhook
 	ReceivedAt time.Time
 }
@@ -133,6 +140,7 @@ type CommitStatus struct {
 	SHA        string
 	Context    string
 	State      string
+	TargetURL  string
 	ReceivedAt time.Time
 }
 
@@ -147,6 +155,7 @@ type Context struct {
 	Context     string
 	Description string
 	State       string
+	TargetURL   string
 }
 
 type Label struct {
@@ -1013,6 +1022,226 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+const updatePullRequestBranchMutation = `
+mutation UpdatePullRequestBranch($input: UpdatePullRequestBranchInput!) {
+  updatePullRequestBranch(input: $input) {
+	  pullRequest {
+		  ...pr
+	  }
+  }
+}
+`
+
+// UpdatePullRequestBranch merges the latest changes of the base branch of the
+// PullRequest into its head branch on Github.
+func (c *V4Client) UpdatePullRequestBranch(ctx context.Context, pr *PullRequest) error {
+	version := c.determineGitHubVersion(ctx)
+	prFragment, err := pullRequestFragments(version)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems TimelineItemConnection
+			} `json:"pullRequest"`
+		} `json:"updatePullRequestBranch"`
+	}
+
+	input := map[string]any{"input": struct {
+		PullRequestID   string `json:"pullRequestId"`
+		ExpectedHeadOid string `json:"expectedHeadOid,omitempty"`
+	}{
+		PullRequestID:   pr.ID,
+		ExpectedHeadOid: pr.HeadRefOid,
+	}}
+	if err := c.requestGraphQL(ctx, prFragment+"\n"+updatePullRequestBranchMutation, input, &result); err != nil {
+		return err
+	}
+
+	ti := result.UpdatePullRequestBranch.PullRequest.TimelineItems
+	*pr = result.UpdatePullRequestBranch.PullRequest.PullRequest
+	pr.TimelineItems = ti.Nodes
+	pr.Participants = result.UpdatePullRequestBranch.PullRequest.Participants.Nodes
+
+	items, err := c.loadRemainingTimelineItems(ctx, pr.ID, ti.PageInfo)
+	if err != nil {
+		return err
+	}
+	pr.TimelineItems = append(pr.TimelineItems, items...)
+	return nil
+}
+
+const requestReviewsMutation = `
+mutation RequestReviews($input: RequestReviewsInput!) {
+  requestReviews(input: $input) {
+    pullRequest { id }
+  }
+}
+`
+
+// RequestPullRequestReviews requests reviews on the PullRequest from the users
+// with the given logins, in addition to the already requested reviewers.
+func (c *V4Client) RequestPullRequestReviews(ctx context.Context, pr *PullRequest, logins []string) error {
+	userIDs, err := c.getUserIDs(ctx, logins)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		RequestReviews struct {
+			PullRequest struct {
+				ID string
+			} `json:"pullRequest"`
+		} `json:"requestReviews"`
+	}
+
+	input := map[string]any{"input": struct {
+		PullRequestID string   `json:"pullRequestId"`
+		UserIDs       []string `json:"userIds"`
+		Union         bool     `json:"union"`
+	}{PullRequestID: pr.ID, UserIDs: userIDs, Union: true}}
+	return c.requestGraphQL(ctx, requestReviewsMutation, input, &result)
+}
+
+const addAssigneesMutation = `
+mutation AddAssignees($input: AddAssigneesToAssignableInput!) {
+  addAssigneesToAssignable(input: $input) {
+    clientMutationId
+  }
+}
+`
+
+// AddPullRequestAssignees assigns the users with the given logins to the
+// PullRequest.
+func (c *V4Client) AddPullRequestAssignees(ctx context.Context, pr *PullRequest, logins []string) error {
+	userIDs, err := c.getUserIDs(ctx, logins)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		AddAssigneesToAssignable struct {
+			ClientMutationID string
+		} `json:"addAssigneesToAssignable"`
+	}
+
+	input := map[string]any{"input": struct {
+		AssignableID string   `json:"assignableId"`
+		AssigneeIDs  []string `json:"assigneeIds"`
+	}{AssignableID: pr.ID, AssigneeIDs: userIDs}}
+	return c.requestGraphQL(ctx, addAssigneesMutation, input, &result)
+}
+
+const addLabelsMutation = `
+mutation AddLabels($input: AddLabelsToLabelableInput!) {
+  addLabelsToLabelable(input: $input) {
+    clientMutationId
+  }
+}
+`
+
+// AddPullRequestLabels adds the labels with the given names to the
+// PullRequest. The labels must already exist in the repository.
+func (c *V4Client) AddPullRequestLabels(ctx context.Context, pr *PullRequest, names []string) error {
+	owner, repo, err := SplitRepositoryNameWithOwner(pr.RepoWithOwner)
+	if err != nil {
+		return err
+	}
+
+	var q strings.Builder
+	q.WriteString("query {\n")
+	q.WriteString(fmt.Sprintf("repository(owner: %q, name: %q) {\n", owner, repo))
+	for i, name := range names {
+		q.WriteString(fmt.Sprintf("l%d: label(name: %q) { id }\n", i, name))
+	}
+	q.WriteString("}\n}")
+
+	var labels struct {
+		Repository map[string]*struct{ ID string }
+	}
+	if err := c.requestGraphQL(ctx, q.String(), nil, &labels); err != nil {
+		return err
+	}
+
+	labelIDs := make([]string, 0, len(names))
+	for i, name := range names {
+		label := labels.Repository[fmt.Sprintf("l%d", i)]
+		if label == nil {
+			return errors.Errorf("label %q does not exist in repository %s", name, pr.RepoWithOwner)
+		}
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	var result struct {
+		AddLabelsToLabelable struct {
+			ClientMutationID string
+		} `json:"addLabelsToLabelable"`
+	}
+
+	input := map[string]any{"input": struct {
+		LabelableID string   `json:"labelableId"`
+		LabelIDs    []string `json:"labelIds"`
+	}{LabelableID: pr.ID, LabelIDs: labelIDs}}
+	return c.requestGraphQL(ctx, addLabelsMutation, input, &result)
+}
+
+const rerequestCheckSuiteMutation = `
+mutation RerequestCheckSuite($input: RerequestCheckSuiteInput!) {
+  rerequestCheckSuite(input: $input) {
+    checkSuite { id }
+  }
+}
+`
+
+// RerequestCheckSuite asks GitHub to run the check suite with the given ID
+// again, without pushing a new commit. For GitHub Actions this re-runs the
+// workflow of the suite.
+func (c *V4Client) RerequestCheckSuite(ctx context.Context, repositoryID, checkSuiteID string) error {
+	var result struct {
+		RerequestCheckSuite struct {
+			CheckSuite struct {
+				ID string
+			} `json:"checkSuite"`
+		} `json:"rerequestCheckSuite"`
+	}
+
+	input := map[string]any{"input": struct {
+		RepositoryID string `json:"repositoryId"`
+		CheckSuiteID string `json:"checkSuiteId"`
+	}{RepositoryID: repositoryID, CheckSuiteID: checkSuiteID}}
+	return c.requestGraphQL(ctx, rerequestCheckSuiteMutation, input, &result)
+}
+
+// getUserIDs resolves the given logins to the node IDs of the users.
+func (c *V4Client) getUserIDs(ctx context.Context, logins []string) ([]string, error) {
+	var q strings.Builder
+	q.WriteString("query {\n")
+	for i, login := range logins {
+		q.WriteString(fmt.Sprintf("u%d: user(login: %q) { id }\n", i, login))
+	}
+	q.WriteString("}")
+
+	var users map[string]*struct{ ID string }
+	if err := c.requestGraphQL(ctx, q.String(), nil, &users); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(logins))
+	for i, login := range logins {
+		user := users[fmt.Sprintf("u%d", i)]
+		if user == nil {
+			return nil, errors.Errorf("user %q not found", login)
+		}
+		ids = append(ids, user.ID)
+	}
+
+	return ids, nil
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1354,6 +1583,7 @@ fragment commitWithChecks on Commit {
       context
       state
       description
+      targetUrl
     }
   }
   checkSuites(last: 20) {
@@ -1364,8 +1594,12 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20) {
         nodes {
           id
+          name
           status
           conclusion
+          detailsUrl
+          startedAt
+          completedAt
         }
       }
     }
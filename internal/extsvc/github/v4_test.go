@@ -1184,3 +1184,24 @@ func TestClient_Releases(t *testing.T) {
 		releases,
 	)
 }
+
+func TestClient_getUserIDs(t *testing.T) {
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+
+	t.Run("found", func(t *testing.T) {
+		mock := mockHTTPResponseBody{responseBody: `{"data": {"u0": {"id": "U_alice"}, "u1": {"id": "U_bob"}}}`}
+		c := NewV4Client("Test", apiURL, nil, &mock)
+
+		ids, err := c.getUserIDs(context.Background(), []string{"alice", "bob"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"U_alice", "U_bob"}, ids)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock := mockHTTPResponseBody{responseBody: `{"data": {"u0": {"id": "U_alice"}, "u1": null}}`}
+		c := NewV4Client("Test", apiURL, nil, &mock)
+
+		_, err := c.getUserIDs(context.Background(), []string{"alice", "bob"})
+		assert.EqualError(t, err, `user "bob" not found`)
+	})
+}
@@ -51,6 +51,9 @@ type MergeRequest struct {
 	// `Email` and `Identities`. If we need more, we need to issue an additional API
 	// request. Otherwise, we should use a different type here.
 	Author User `json:"author"`
+	// Assignees and Reviewers are partial User objects, too.
+	Assignees []User `json:"assignees,omitempty"`
+	Reviewers []User `json:"reviewers,omitempty"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -220,6 +223,10 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	AssigneeIDs  []int32                      `json:"assignee_ids,omitempty"`
+	ReviewerIDs  []int32                      `json:"reviewer_ids,omitempty"`
+	// AddLabels is a comma-separated list of labels to add to the merge request.
+	AddLabels string `json:"add_labels,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -303,6 +310,31 @@ func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *Me
 	return resp, nil
 }
 
+// RebaseMergeRequest rebases the source branch of the merge request onto its
+// target branch. GitLab performs the rebase asynchronously.
+func (c *Client) RebaseMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) error {
+	if MockRebaseMergeRequest != nil {
+		return MockRebaseMergeRequest(c, ctx, project, mr)
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/rebase", project.ID, mr.IID), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request to rebase a merge request")
+	}
+
+	var resp struct {
+		RebaseInProgress bool `json:"rebase_in_progress"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return aerr
+		}
+		return errors.Wrap(err, "sending request to rebase a merge request")
+	}
+
+	return nil
+}
+
 func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, mr *MergeRequest, body string) error {
 	if MockCreateMergeRequestNote != nil {
 		return MockCreateMergeRequestNote(c, ctx, project, mr, body)
@@ -37,6 +37,10 @@ var MockGetMergeRequestNotes func(c *Client, ctx context.Context, project *Proje
 // Client.GetMergeRequestPipelines
 var MockGetMergeRequestPipelines func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*Pipeline, error)
 
+// MockRetryPipeline, if non-nil, will be called instead of
+// Client.RetryPipeline
+var MockRetryPipeline func(c *Client, ctx context.Context, project *Project, id ID) (*Pipeline, error)
+
 // MockGetOpenMergeRequestByRefs, if non-nil, will be called instead of
 // Client.GetOpenMergeRequestByRefs
 var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *Project, source, target string) (*MergeRequest, error)
@@ -49,6 +53,10 @@ var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project
 // Client.MergeMergeRequest
 var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
 
+// MockRebaseMergeRequest, if non-nil, will be called instead of
+// Client.RebaseMergeRequest
+var MockRebaseMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) error
+
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
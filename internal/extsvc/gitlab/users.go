@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/peterhellberg/link"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type User struct {
@@ -46,6 +49,18 @@ func (c *Client) ListUsers(ctx context.Context, urlStr string) (users []*User, n
 	return users, nextPageURL, nil
 }
 
+// GetUserByUsername returns the user with the given username.
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*User, error) {
+	users, _, err := c.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, errors.Errorf("user %q not found", username)
+	}
+	return users[0], nil
+}
+
 func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
 	if MockGetUser != nil {
 		return MockGetUser(c, ctx, id)
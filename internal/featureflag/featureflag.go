@@ -11,23 +11,58 @@ type FeatureFlag struct {
 
 	// A feature flag is one of the following types.
 	// Exactly one of the following will be set.
-	Bool    *FeatureFlagBool
-	Rollout *FeatureFlagRollout
+	Bool     *FeatureFlagBool
+	Rollout  *FeatureFlagRollout
+	Variants *FeatureFlagVariants
+
+	// Rules target specific values at the users matching them. They are
+	// evaluated in order, and the first matching rule determines the value.
+	// When no rule matches, the value is determined by the flag type.
+	Rules []Rule
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
-// EvaluateForUser evaluates the feature flag for a userID.
-func (f *FeatureFlag) EvaluateForUser(userID int32) bool {
+// EvaluateForUser evaluates the feature flag for a user, taking the targeting
+// rules into account. Multivariate flags evaluate to false, use
+// EvaluateVariantForUser instead.
+func (f *FeatureFlag) EvaluateForUser(ec EvaluationContext) bool {
+	if rule := f.matchingRule(ec); rule != nil {
+		return rule.Value
+	}
+
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
 	case f.Rollout != nil:
-		return hashUserAndFlag(userID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+		return hashUserAndFlag(ec.UserID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+	case f.Variants != nil:
+		return false
+	}
+	panic("one of Bool, Rollout or Variants must be set")
+}
+
+// EvaluateVariantForUser evaluates a multivariate feature flag for a user, taking
+// the targeting rules into account. Other flag types evaluate to the empty string.
+func (f *FeatureFlag) EvaluateVariantForUser(ec EvaluationContext) string {
+	if f.Variants == nil {
+		return ""
+	}
+	if rule := f.matchingRule(ec); rule != nil {
+		return rule.Variant
+	}
+	return f.Variants.pick(hashUserAndFlag(ec.UserID, f.Name))
+}
+
+func (f *FeatureFlag) matchingRule(ec EvaluationContext) *Rule {
+	for i := range f.Rules {
+		if f.Rules[i].Matches(ec) {
+			return &f.Rules[i]
+		}
 	}
-	panic("one of Bool or Rollout must be set")
+	return nil
 }
 
 func hashUserAndFlag(userID int32, flagName string) uint32 {
@@ -38,14 +73,26 @@ func hashUserAndFlag(userID int32, flagName string) uint32 {
 }
 
 // EvaluateForAnonymousUser evaluates the feature flag for an anonymous user ID.
+// Targeting rules only apply to signed-in users and are ignored.
 func (f *FeatureFlag) EvaluateForAnonymousUser(anonymousUID string) bool {
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
 	case f.Rollout != nil:
 		return hashAnonymousUserAndFlag(anonymousUID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+	case f.Variants != nil:
+		return false
 	}
-	panic("one of Bool or Rollout must be set")
+	panic("one of Bool, Rollout or Variants must be set")
+}
+
+// EvaluateVariantForAnonymousUser evaluates a multivariate feature flag for an
+// anonymous user ID. Other flag types evaluate to the empty string.
+func (f *FeatureFlag) EvaluateVariantForAnonymousUser(anonymousUID string) string {
+	if f.Variants == nil {
+		return ""
+	}
+	return f.Variants.pick(hashAnonymousUserAndFlag(anonymousUID, f.Name))
 }
 
 func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
@@ -57,7 +104,8 @@ func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
 
 // EvaluateGlobal returns the evaluated feature flag for a global context (no user
 // is associated with the request). If the flag is not evaluatable in the global context
-// (i.e. the flag type is a rollout), then the second parameter will return false.
+// (i.e. the flag type is a rollout or multivariate), then the second parameter will
+// return false.
 func (f *FeatureFlag) EvaluateGlobal() (res bool, ok bool) {
 	switch {
 	case f.Bool != nil:
@@ -78,6 +126,32 @@ type FeatureFlagRollout struct {
 	Rollout int32
 }
 
+// FeatureFlagVariants is a multivariate flag that evaluates to one of a set of
+// string values.
+type FeatureFlagVariants struct {
+	Variants []Variant
+}
+
+type Variant struct {
+	Value string `json:"value"`
+	// Weight is an integer between 0 and 10000, representing the percent of
+	// users that are assigned this variant in increments of 0.01%. The weights
+	// of all variants of a flag add up to 10000.
+	Weight int32 `json:"weight"`
+}
+
+// pick returns the variant for the given user hash.
+func (v *FeatureFlagVariants) pick(hash uint32) string {
+	bucket := int32(hash % 10000)
+	for _, variant := range v.Variants {
+		if bucket < variant.Weight {
+			return variant.Value
+		}
+		bucket -= variant.Weight
+	}
+	return ""
+}
+
 type Override struct {
 	UserID   *int32
 	OrgID    *int32
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 )
@@ -35,6 +36,9 @@ func (f EvaluatedFlagSet) Json() json.RawMessage {
 type FlagSet struct {
 	flags map[string]bool
 	actor *actor.Actor
+	// variants lazily loads the values of the multivariate flags. It is nil if
+	// the flag set has no multivariate flags.
+	variants *lazyVariants
 }
 
 // Returns (flagValue, true) if flag exist, otherwise (false, false)
@@ -57,6 +61,23 @@ func (f *FlagSet) GetBoolOr(flag string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// Returns (variant, true) if the multivariate flag exists, otherwise ("", false)
+func (f *FlagSet) GetVariant(flag string) (string, bool) {
+	if f == nil || f.variants == nil {
+		return "", false
+	}
+	v, ok := f.variants.get()[flag]
+	return v, ok
+}
+
+// Returns "variant" or "defaultVal" if the multivariate flag doesn't exist
+func (f *FlagSet) GetVariantOr(flag string, defaultVal string) string {
+	if v, ok := f.GetVariant(flag); ok {
+		return v
+	}
+	return defaultVal
+}
+
 func (f *FlagSet) String() string {
 	var sb strings.Builder
 	if f == nil {
@@ -69,3 +90,19 @@ func (f *FlagSet) String() string {
 	}
 	return sb.String()
 }
+
+// lazyVariants loads the multivariate flags on first use, as most requests don't
+// read any.
+type lazyVariants struct {
+	once   sync.Once
+	fetch  func() (map[string]string, error)
+	values map[string]string
+}
+
+func (v *lazyVariants) get() map[string]string {
+	v.once.Do(func() {
+		// Errors are treated like missing flags, same as for boolean flags.
+		v.values, _ = v.fetch()
+	})
+	return v.values
+}
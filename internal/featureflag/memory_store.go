@@ -39,3 +39,15 @@ func (m *memoryStore) GetAnonymousUserFlags(context.Context, string) (map[string
 func (m *memoryStore) GetGlobalFeatureFlags(context.Context) (map[string]bool, error) {
 	return m.globalFlags, nil
 }
+
+func (m *memoryStore) GetUserFlagsForRepo(context.Context, int32, string) (map[string]bool, error) {
+	return m.userFlags, nil
+}
+
+func (m *memoryStore) GetUserVariants(context.Context, int32) (map[string]string, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) GetAnonymousUserVariants(context.Context, string) (map[string]string, error) {
+	return nil, nil
+}
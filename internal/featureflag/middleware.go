@@ -14,6 +14,11 @@ type Store interface {
 	GetUserFlags(context.Context, int32) (map[string]bool, error)
 	GetAnonymousUserFlags(context.Context, string) (map[string]bool, error)
 	GetGlobalFeatureFlags(context.Context) (map[string]bool, error)
+	// GetUserFlagsForRepo is like GetUserFlags, but also applies the targeting
+	// rules matching the given repository name.
+	GetUserFlagsForRepo(ctx context.Context, userID int32, repoName string) (map[string]bool, error)
+	GetUserVariants(context.Context, int32) (map[string]string, error)
+	GetAnonymousUserVariants(context.Context, string) (map[string]string, error)
 }
 
 // Middleware evaluates the feature flags for the current user and adds the
@@ -46,6 +51,15 @@ type flagSetFetcher struct {
 	actor *actor.Actor
 	// flagSet is the once-populated set of flags for the actor at the time of population
 	flagSet *FlagSet
+
+	repoMu sync.Mutex
+	// repoFlagSets caches the flag sets evaluated for a repository by ForRepo
+	repoFlagSets map[repoFlagSetKey]*FlagSet
+}
+
+type repoFlagSetKey struct {
+	userID   int32
+	repoName string
 }
 
 func (f *flagSetFetcher) fetch(ctx context.Context) *FlagSet {
@@ -68,7 +82,9 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	if a.IsAuthenticated() {
 		flags, err := f.ffs.GetUserFlags(ctx, a.UID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			return &FlagSet{flags: flags, actor: f.actor, variants: &lazyVariants{fetch: func() (map[string]string, error) {
+				return f.ffs.GetUserVariants(ctx, a.UID)
+			}}}
 		}
 		// Continue if err != nil
 	}
@@ -76,7 +92,9 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	if a.AnonymousUID != "" {
 		flags, err := f.ffs.GetAnonymousUserFlags(ctx, a.AnonymousUID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			return &FlagSet{flags: flags, actor: f.actor, variants: &lazyVariants{fetch: func() (map[string]string, error) {
+				return f.ffs.GetAnonymousUserVariants(ctx, a.AnonymousUID)
+			}}}
 		}
 		// Continue if err != nil
 	}
@@ -89,6 +107,34 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	return &FlagSet{actor: f.actor}
 }
 
+func (f *flagSetFetcher) fetchForRepo(ctx context.Context, repoName string) *FlagSet {
+	flagSet := f.fetch(ctx)
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		// Targeting rules only apply to signed-in users.
+		return flagSet
+	}
+
+	key := repoFlagSetKey{userID: a.UID, repoName: repoName}
+	f.repoMu.Lock()
+	defer f.repoMu.Unlock()
+	if cached, ok := f.repoFlagSets[key]; ok {
+		return cached
+	}
+
+	flags, err := f.ffs.GetUserFlagsForRepo(ctx, a.UID, repoName)
+	if err != nil {
+		return flagSet
+	}
+	repoFlagSet := &FlagSet{flags: flags, actor: a, variants: flagSet.variants}
+	if f.repoFlagSets == nil {
+		f.repoFlagSets = make(map[repoFlagSetKey]*FlagSet)
+	}
+	f.repoFlagSets[key] = repoFlagSet
+	return repoFlagSet
+}
+
 // FromContext retrieves the current set of flags from the current
 // request's context.
 func FromContext(ctx context.Context) *FlagSet {
@@ -98,6 +144,16 @@ func FromContext(ctx context.Context) *FlagSet {
 	return nil
 }
 
+// ForRepo retrieves the current set of flags from the current request's
+// context, evaluated for the given repository. Use it instead of FromContext
+// when a flag may be targeted at repositories.
+func ForRepo(ctx context.Context, repoName string) *FlagSet {
+	if flags := ctx.Value(flagContextKey{}); flags != nil {
+		return flags.(*flagSetFetcher).fetchForRepo(ctx, repoName)
+	}
+	return nil
+}
+
 func GetEvaluatedFlagSet(ctx context.Context) EvaluatedFlagSet {
 	if flagSet := FromContext(ctx); flagSet != nil {
 		return getEvaluatedFlagSetFromCache(flagSet)
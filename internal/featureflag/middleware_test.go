@@ -189,3 +189,35 @@ func setupRedisTest(t *testing.T) {
 
 	evalStore = redispool.RedisKeyValue(&redis.Pool{Dial: func() (redis.Conn, error) { return mockConn, nil }, MaxIdle: 10})
 }
+
+func TestContextFlags_ForRepo(t *testing.T) {
+	mockStore := NewMockStore()
+	mockStore.GetUserFlagsFunc.SetDefaultReturn(map[string]bool{"experiment": false}, nil)
+	mockStore.GetUserFlagsForRepoFunc.SetDefaultHook(func(_ context.Context, _ int32, repoName string) (map[string]bool, error) {
+		return map[string]bool{"experiment": repoName == "github.com/sourcegraph/sourcegraph"}, nil
+	})
+	mockStore.GetUserVariantsFunc.SetDefaultReturn(map[string]string{"ranking": "bm25"}, nil)
+
+	ctx := WithFlags(context.Background(), mockStore)
+	ctx = actor.WithActor(ctx, actor.FromUser(1))
+
+	require.False(t, FromContext(ctx).GetBoolOr("experiment", true))
+	require.True(t, ForRepo(ctx, "github.com/sourcegraph/sourcegraph").GetBoolOr("experiment", false))
+	require.True(t, ForRepo(ctx, "github.com/sourcegraph/sourcegraph").GetBoolOr("experiment", false))
+	require.False(t, ForRepo(ctx, "github.com/sourcegraph/other").GetBoolOr("experiment", true))
+	// Flag sets are cached per repository.
+	mockrequire.CalledN(t, mockStore.GetUserFlagsForRepoFunc, 2)
+
+	require.Equal(t, "bm25", ForRepo(ctx, "github.com/sourcegraph/sourcegraph").GetVariantOr("ranking", ""))
+	require.Equal(t, "bm25", FromContext(ctx).GetVariantOr("ranking", ""))
+	mockrequire.CalledOnce(t, mockStore.GetUserVariantsFunc)
+
+	t.Run("anonymous users get the flags without rules", func(t *testing.T) {
+		mockStore.GetAnonymousUserFlagsFunc.SetDefaultReturn(map[string]bool{"experiment": false}, nil)
+		ctx := WithFlags(context.Background(), mockStore)
+		ctx = actor.WithActor(ctx, &actor.Actor{AnonymousUID: "anonymous"})
+
+		require.False(t, ForRepo(ctx, "github.com/sourcegraph/sourcegraph").GetBoolOr("experiment", true))
+		mockrequire.CalledN(t, mockStore.GetUserFlagsForRepoFunc, 2)
+	})
+}
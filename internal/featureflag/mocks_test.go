@@ -18,12 +18,21 @@ type MockStore struct {
 	// GetAnonymousUserFlagsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetAnonymousUserFlags.
 	GetAnonymousUserFlagsFunc *StoreGetAnonymousUserFlagsFunc
+	// GetAnonymousUserVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetAnonymousUserVariants.
+	GetAnonymousUserVariantsFunc *StoreGetAnonymousUserVariantsFunc
 	// GetGlobalFeatureFlagsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetGlobalFeatureFlags.
 	GetGlobalFeatureFlagsFunc *StoreGetGlobalFeatureFlagsFunc
 	// GetUserFlagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUserFlags.
 	GetUserFlagsFunc *StoreGetUserFlagsFunc
+	// GetUserFlagsForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserFlagsForRepo.
+	GetUserFlagsForRepoFunc *StoreGetUserFlagsForRepoFunc
+	// GetUserVariantsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUserVariants.
+	GetUserVariantsFunc *StoreGetUserVariantsFunc
 }
 
 // NewMockStore creates a new mock of the Store interface. All methods
@@ -35,6 +44,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetAnonymousUserVariantsFunc: &StoreGetAnonymousUserVariantsFunc{
+			defaultHook: func(context.Context, string) (r0 map[string]string, r1 error) {
+				return
+			},
+		},
 		GetGlobalFeatureFlagsFunc: &StoreGetGlobalFeatureFlagsFunc{
 			defaultHook: func(context.Context) (r0 map[string]bool, r1 error) {
 				return
@@ -45,6 +59,16 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUserFlagsForRepoFunc: &StoreGetUserFlagsForRepoFunc{
+			defaultHook: func(context.Context, int32, string) (r0 map[string]bool, r1 error) {
+				return
+			},
+		},
+		GetUserVariantsFunc: &StoreGetUserVariantsFunc{
+			defaultHook: func(context.Context, int32) (r0 map[string]string, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -57,6 +81,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetAnonymousUserFlags")
 			},
 		},
+		GetAnonymousUserVariantsFunc: &StoreGetAnonymousUserVariantsFunc{
+			defaultHook: func(context.Context, string) (map[string]string, error) {
+				panic("unexpected invocation of MockStore.GetAnonymousUserVariants")
+			},
+		},
 		GetGlobalFeatureFlagsFunc: &StoreGetGlobalFeatureFlagsFunc{
 			defaultHook: func(context.Context) (map[string]bool, error) {
 				panic("unexpected invocation of MockStore.GetGlobalFeatureFlags")
@@ -67,6 +96,16 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUserFlags")
 			},
 		},
+		GetUserFlagsForRepoFunc: &StoreGetUserFlagsForRepoFunc{
+			defaultHook: func(context.Context, int32, string) (map[string]bool, error) {
+				panic("unexpected invocation of MockStore.GetUserFlagsForRepo")
+			},
+		},
+		GetUserVariantsFunc: &StoreGetUserVariantsFunc{
+			defaultHook: func(context.Context, int32) (map[string]string, error) {
+				panic("unexpected invocation of MockStore.GetUserVariants")
+			},
+		},
 	}
 }
 
@@ -77,12 +116,21 @@ func NewMockStoreFrom(i Store) *MockStore {
 		GetAnonymousUserFlagsFunc: &StoreGetAnonymousUserFlagsFunc{
 			defaultHook: i.GetAnonymousUserFlags,
 		},
+		GetAnonymousUserVariantsFunc: &StoreGetAnonymousUserVariantsFunc{
+			defaultHook: i.GetAnonymousUserVariants,
+		},
 		GetGlobalFeatureFlagsFunc: &StoreGetGlobalFeatureFlagsFunc{
 			defaultHook: i.GetGlobalFeatureFlags,
 		},
 		GetUserFlagsFunc: &StoreGetUserFlagsFunc{
 			defaultHook: i.GetUserFlags,
 		},
+		GetUserFlagsForRepoFunc: &StoreGetUserFlagsForRepoFunc{
+			defaultHook: i.GetUserFlagsForRepo,
+		},
+		GetUserVariantsFunc: &StoreGetUserVariantsFunc{
+			defaultHook: i.GetUserVariants,
+		},
 	}
 }
 
@@ -194,6 +242,116 @@ func (c StoreGetAnonymousUserFlagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetAnonymousUserVariantsFunc describes the behavior when the
+// GetAnonymousUserVariants method of the parent MockStore instance is
+// invoked.
+type StoreGetAnonymousUserVariantsFunc struct {
+	defaultHook func(context.Context, string) (map[string]string, error)
+	hooks       []func(context.Context, string) (map[string]string, error)
+	history     []StoreGetAnonymousUserVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAnonymousUserVariants delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetAnonymousUserVariants(v0 context.Context, v1 string) (map[string]string, error) {
+	r0, r1 := m.GetAnonymousUserVariantsFunc.nextHook()(v0, v1)
+	m.GetAnonymousUserVariantsFunc.appendCall(StoreGetAnonymousUserVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetAnonymousUserVariants method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetAnonymousUserVariantsFunc) SetDefaultHook(hook func(context.Context, string) (map[string]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAnonymousUserVariants method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetAnonymousUserVariantsFunc) PushHook(hook func(context.Context, string) (map[string]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetAnonymousUserVariantsFunc) SetDefaultReturn(r0 map[string]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetAnonymousUserVariantsFunc) PushReturn(r0 map[string]string, r1 error) {
+	f.PushHook(func(context.Context, string) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetAnonymousUserVariantsFunc) nextHook() func(context.Context, string) (map[string]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetAnonymousUserVariantsFunc) appendCall(r0 StoreGetAnonymousUserVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetAnonymousUserVariantsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetAnonymousUserVariantsFunc) History() []StoreGetAnonymousUserVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetAnonymousUserVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetAnonymousUserVariantsFuncCall is an object that describes an
+// invocation of method GetAnonymousUserVariants on an instance of
+// MockStore.
+type StoreGetAnonymousUserVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetAnonymousUserVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetAnonymousUserVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetGlobalFeatureFlagsFunc describes the behavior when the
 // GetGlobalFeatureFlags method of the parent MockStore instance is invoked.
 type StoreGetGlobalFeatureFlagsFunc struct {
@@ -405,3 +563,222 @@ func (c StoreGetUserFlagsFuncCall) Args() []interface{} {
 func (c StoreGetUserFlagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// StoreGetUserFlagsForRepoFunc describes the behavior when the
+// GetUserFlagsForRepo method of the parent MockStore instance is invoked.
+type StoreGetUserFlagsForRepoFunc struct {
+	defaultHook func(context.Context, int32, string) (map[string]bool, error)
+	hooks       []func(context.Context, int32, string) (map[string]bool, error)
+	history     []StoreGetUserFlagsForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserFlagsForRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUserFlagsForRepo(v0 context.Context, v1 int32, v2 string) (map[string]bool, error) {
+	r0, r1 := m.GetUserFlagsForRepoFunc.nextHook()(v0, v1, v2)
+	m.GetUserFlagsForRepoFunc.appendCall(StoreGetUserFlagsForRepoFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserFlagsForRepo
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUserFlagsForRepoFunc) SetDefaultHook(hook func(context.Context, int32, string) (map[string]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserFlagsForRepo method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetUserFlagsForRepoFunc) PushHook(hook func(context.Context, int32, string) (map[string]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUserFlagsForRepoFunc) SetDefaultReturn(r0 map[string]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUserFlagsForRepoFunc) PushReturn(r0 map[string]bool, r1 error) {
+	f.PushHook(func(context.Context, int32, string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUserFlagsForRepoFunc) nextHook() func(context.Context, int32, string) (map[string]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUserFlagsForRepoFunc) appendCall(r0 StoreGetUserFlagsForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUserFlagsForRepoFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUserFlagsForRepoFunc) History() []StoreGetUserFlagsForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUserFlagsForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUserFlagsForRepoFuncCall is an object that describes an
+// invocation of method GetUserFlagsForRepo on an instance of MockStore.
+type StoreGetUserFlagsForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUserFlagsForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUserFlagsForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUserVariantsFunc describes the behavior when the GetUserVariants
+// method of the parent MockStore instance is invoked.
+type StoreGetUserVariantsFunc struct {
+	defaultHook func(context.Context, int32) (map[string]string, error)
+	hooks       []func(context.Context, int32) (map[string]string, error)
+	history     []StoreGetUserVariantsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserVariants delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUserVariants(v0 context.Context, v1 int32) (map[string]string, error) {
+	r0, r1 := m.GetUserVariantsFunc.nextHook()(v0, v1)
+	m.GetUserVariantsFunc.appendCall(StoreGetUserVariantsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserVariants
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUserVariantsFunc) SetDefaultHook(hook func(context.Context, int32) (map[string]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserVariants method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetUserVariantsFunc) PushHook(hook func(context.Context, int32) (map[string]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUserVariantsFunc) SetDefaultReturn(r0 map[string]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUserVariantsFunc) PushReturn(r0 map[string]string, r1 error) {
+	f.PushHook(func(context.Context, int32) (map[string]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUserVariantsFunc) nextHook() func(context.Context, int32) (map[string]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUserVariantsFunc) appendCall(r0 StoreGetUserVariantsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUserVariantsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUserVariantsFunc) History() []StoreGetUserVariantsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUserVariantsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUserVariantsFuncCall is an object that describes an invocation of
+// method GetUserVariants on an instance of MockStore.
+type StoreGetUserVariantsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUserVariantsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUserVariantsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
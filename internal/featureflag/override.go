@@ -63,6 +63,20 @@ func (s *overrideStore) GetGlobalFeatureFlags(ctx context.Context) (map[string]b
 	return s.override(s.store.GetGlobalFeatureFlags(ctx))
 }
 
+func (s *overrideStore) GetUserFlagsForRepo(ctx context.Context, userID int32, repoName string) (map[string]bool, error) {
+	return s.override(s.store.GetUserFlagsForRepo(ctx, userID, repoName))
+}
+
+// GetUserVariants does not apply the request overrides, they only apply to
+// boolean flags.
+func (s *overrideStore) GetUserVariants(ctx context.Context, userID int32) (map[string]string, error) {
+	return s.store.GetUserVariants(ctx, userID)
+}
+
+func (s *overrideStore) GetAnonymousUserVariants(ctx context.Context, anonUID string) (map[string]string, error) {
+	return s.store.GetAnonymousUserVariants(ctx, anonUID)
+}
+
 func (s *overrideStore) override(flags map[string]bool, err error) (map[string]bool, error) {
 	if err != nil {
 		return nil, err
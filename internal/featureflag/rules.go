@@ -0,0 +1,194 @@
+package featureflag
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// EvaluationContext holds the attributes of a user that targeting rules are
+// matched against.
+type EvaluationContext struct {
+	UserID    int32
+	SiteAdmin bool
+	// OrgNames are the names of the organizations the user is a member of.
+	OrgNames []string
+	Tags     []string
+	// EmailDomains are the domains of the verified email addresses of the user.
+	EmailDomains []string
+	// RepoName is the name of the repository the flag is evaluated for. It is
+	// empty when the flag is not evaluated for a specific repository.
+	RepoName string
+}
+
+// Rule targets a value at the users matching all of its conditions.
+type Rule struct {
+	Conditions []Condition `json:"conditions"`
+	// Value is the value of a boolean or rollout flag for matching users.
+	Value bool `json:"value,omitempty"`
+	// Variant is the value of a multivariate flag for matching users.
+	Variant string `json:"variant,omitempty"`
+}
+
+// Matches returns true if the evaluation context satisfies all conditions of the
+// rule.
+func (r *Rule) Matches(ec EvaluationContext) bool {
+	for _, c := range r.Conditions {
+		if !c.Matches(ec) {
+			return false
+		}
+	}
+	return len(r.Conditions) > 0
+}
+
+type ConditionType string
+
+const (
+	// ConditionOrgMember matches members of any of the organizations named in
+	// the condition values.
+	ConditionOrgMember ConditionType = "ORG_MEMBER"
+	// ConditionSiteAdmin matches site admins. It takes no values.
+	ConditionSiteAdmin ConditionType = "SITE_ADMIN"
+	// ConditionUserTag matches users that have any of the tags in the condition
+	// values.
+	ConditionUserTag ConditionType = "USER_TAG"
+	// ConditionEmailDomain matches users with a verified email address in any of
+	// the domains in the condition values.
+	ConditionEmailDomain ConditionType = "EMAIL_DOMAIN"
+	// ConditionRepoName matches evaluations for a repository whose name matches
+	// any of the glob patterns in the condition values, e.g.
+	// "github.com/sourcegraph/*".
+	ConditionRepoName ConditionType = "REPO_NAME"
+)
+
+// Condition is a single criterion of a rule. A condition with several values
+// matches if any of the values matches.
+type Condition struct {
+	Type   ConditionType `json:"type"`
+	Values []string      `json:"values,omitempty"`
+}
+
+// Matches returns true if the evaluation context satisfies the condition.
+func (c *Condition) Matches(ec EvaluationContext) bool {
+	switch c.Type {
+	case ConditionOrgMember:
+		return containsAny(ec.OrgNames, c.Values, strings.EqualFold)
+	case ConditionSiteAdmin:
+		return ec.SiteAdmin
+	case ConditionUserTag:
+		return containsAny(ec.Tags, c.Values, func(a, b string) bool { return a == b })
+	case ConditionEmailDomain:
+		return containsAny(ec.EmailDomains, c.Values, strings.EqualFold)
+	case ConditionRepoName:
+		if ec.RepoName == "" {
+			return false
+		}
+		for _, pattern := range c.Values {
+			if ok, _ := path.Match(pattern, ec.RepoName); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAny(have, want []string, equal func(a, b string) bool) bool {
+	for _, h := range have {
+		for _, w := range want {
+			if equal(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the flag type, variants or rules are invalid.
+func (f *FeatureFlag) Validate() error {
+	types := 0
+	for _, set := range []bool{f.Bool != nil, f.Rollout != nil, f.Variants != nil} {
+		if set {
+			types++
+		}
+	}
+	if types != 1 {
+		return errors.New("feature flag must have exactly one type")
+	}
+
+	if f.Variants != nil {
+		if len(f.Variants.Variants) == 0 {
+			return errors.New("multivariate feature flag must have at least one variant")
+		}
+		var total int32
+		seen := make(map[string]struct{}, len(f.Variants.Variants))
+		for _, v := range f.Variants.Variants {
+			if v.Value == "" {
+				return errors.New("variant value must not be empty")
+			}
+			if _, ok := seen[v.Value]; ok {
+				return errors.Newf("duplicate variant %q", v.Value)
+			}
+			seen[v.Value] = struct{}{}
+			if v.Weight < 0 {
+				return errors.Newf("weight of variant %q must not be negative", v.Value)
+			}
+			total += v.Weight
+		}
+		if total != 10000 {
+			return errors.Newf("variant weights must add up to 10000, got %d", total)
+		}
+	}
+
+	for i, rule := range f.Rules {
+		if len(rule.Conditions) == 0 {
+			return errors.Newf("rule %d must have at least one condition", i+1)
+		}
+		for _, c := range rule.Conditions {
+			if err := c.validate(); err != nil {
+				return errors.Wrapf(err, "rule %d", i+1)
+			}
+		}
+		if f.Variants != nil {
+			if !f.hasVariant(rule.Variant) {
+				return errors.Newf("rule %d: unknown variant %q", i+1, rule.Variant)
+			}
+		} else if rule.Variant != "" {
+			return errors.Newf("rule %d: only multivariate feature flags can target variants", i+1)
+		}
+	}
+	return nil
+}
+
+func (f *FeatureFlag) hasVariant(value string) bool {
+	for _, v := range f.Variants.Variants {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Condition) validate() error {
+	switch c.Type {
+	case ConditionSiteAdmin:
+		if len(c.Values) > 0 {
+			return errors.Newf("condition %s takes no values", c.Type)
+		}
+		return nil
+	case ConditionOrgMember, ConditionUserTag, ConditionEmailDomain:
+	case ConditionRepoName:
+		for _, pattern := range c.Values {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Newf("invalid repository name pattern %q", pattern)
+			}
+		}
+	default:
+		return errors.Newf("unknown condition type %q", c.Type)
+	}
+
+	if len(c.Values) == 0 {
+		return errors.Newf("condition %s must have at least one value", c.Type)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleMatches(t *testing.T) {
+	ec := EvaluationContext{
+		UserID:       1,
+		OrgNames:     []string{"Search"},
+		Tags:         []string{"beta"},
+		EmailDomains: []string{"example.com"},
+		RepoName:     "github.com/sourcegraph/sourcegraph",
+	}
+
+	tests := []struct {
+		name       string
+		conditions []Condition
+		want       bool
+	}{
+		{"no conditions", nil, false},
+		{"org member", []Condition{{Type: ConditionOrgMember, Values: []string{"other", "search"}}}, true},
+		{"not an org member", []Condition{{Type: ConditionOrgMember, Values: []string{"other"}}}, false},
+		{"not a site admin", []Condition{{Type: ConditionSiteAdmin}}, false},
+		{"user tag", []Condition{{Type: ConditionUserTag, Values: []string{"beta"}}}, true},
+		{"user tags are case sensitive", []Condition{{Type: ConditionUserTag, Values: []string{"BETA"}}}, false},
+		{"email domain", []Condition{{Type: ConditionEmailDomain, Values: []string{"EXAMPLE.com"}}}, true},
+		{"repo name", []Condition{{Type: ConditionRepoName, Values: []string{"github.com/sourcegraph/*"}}}, true},
+		{"repo name mismatch", []Condition{{Type: ConditionRepoName, Values: []string{"gitlab.com/*"}}}, false},
+		{
+			"all conditions must match",
+			[]Condition{
+				{Type: ConditionOrgMember, Values: []string{"search"}},
+				{Type: ConditionSiteAdmin},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := Rule{Conditions: tt.conditions}
+			require.Equal(t, tt.want, rule.Matches(ec))
+		})
+	}
+
+	t.Run("repo name without repository", func(t *testing.T) {
+		rule := Rule{Conditions: []Condition{{Type: ConditionRepoName, Values: []string{"*"}}}}
+		require.False(t, rule.Matches(EvaluationContext{UserID: 1}))
+	})
+}
+
+func TestEvaluateForUser(t *testing.T) {
+	flag := &FeatureFlag{
+		Name:    "search-experiment",
+		Rollout: &FeatureFlagRollout{Rollout: 0},
+		Rules: []Rule{
+			{Conditions: []Condition{{Type: ConditionUserTag, Values: []string{"opt-out"}}}, Value: false},
+			{Conditions: []Condition{{Type: ConditionOrgMember, Values: []string{"search"}}}, Value: true},
+		},
+	}
+
+	require.False(t, flag.EvaluateForUser(EvaluationContext{UserID: 1}))
+	require.True(t, flag.EvaluateForUser(EvaluationContext{UserID: 1, OrgNames: []string{"search"}}))
+	// The first matching rule wins.
+	require.False(t, flag.EvaluateForUser(EvaluationContext{UserID: 1, OrgNames: []string{"search"}, Tags: []string{"opt-out"}}))
+	// Rules don't apply to anonymous users.
+	require.False(t, flag.EvaluateForAnonymousUser("anonymous"))
+}
+
+func TestEvaluateVariantForUser(t *testing.T) {
+	flag := &FeatureFlag{
+		Name: "ranking",
+		Variants: &FeatureFlagVariants{Variants: []Variant{
+			{Value: "a", Weight: 2500},
+			{Value: "b", Weight: 7500},
+			{Value: "c", Weight: 0},
+		}},
+		Rules: []Rule{
+			{Conditions: []Condition{{Type: ConditionSiteAdmin}}, Variant: "c"},
+		},
+	}
+
+	counts := map[string]int{}
+	for i := int32(0); i < 10000; i++ {
+		v := flag.EvaluateVariantForUser(EvaluationContext{UserID: i})
+		require.Equal(t, v, flag.EvaluateVariantForUser(EvaluationContext{UserID: i}), "evaluation must be stable")
+		counts[v]++
+	}
+	require.InDelta(t, 2500, counts["a"], 250)
+	require.InDelta(t, 7500, counts["b"], 250)
+	require.Zero(t, counts["c"])
+
+	require.Equal(t, "c", flag.EvaluateVariantForUser(EvaluationContext{UserID: 1, SiteAdmin: true}))
+	require.Contains(t, []string{"a", "b"}, flag.EvaluateVariantForAnonymousUser("anonymous"))
+	require.False(t, flag.EvaluateForUser(EvaluationContext{UserID: 1}))
+
+	boolFlag := &FeatureFlag{Name: "bool", Bool: &FeatureFlagBool{Value: true}}
+	require.Empty(t, boolFlag.EvaluateVariantForUser(EvaluationContext{UserID: 1}))
+}
+
+func TestValidate(t *testing.T) {
+	variants := &FeatureFlagVariants{Variants: []Variant{{Value: "a", Weight: 5000}, {Value: "b", Weight: 5000}}}
+	orgRule := func(variant string) Rule {
+		return Rule{Conditions: []Condition{{Type: ConditionOrgMember, Values: []string{"search"}}}, Variant: variant}
+	}
+
+	tests := []struct {
+		flag    FeatureFlag
+		wantErr string
+	}{
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}}},
+		{flag: FeatureFlag{Variants: variants, Rules: []Rule{orgRule("a")}}},
+		{flag: FeatureFlag{}, wantErr: "exactly one type"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Variants: variants}, wantErr: "exactly one type"},
+		{flag: FeatureFlag{Variants: &FeatureFlagVariants{}}, wantErr: "at least one variant"},
+		{flag: FeatureFlag{Variants: &FeatureFlagVariants{Variants: []Variant{{Value: "a", Weight: 10000}, {Value: "a"}}}}, wantErr: "duplicate variant"},
+		{flag: FeatureFlag{Variants: &FeatureFlagVariants{Variants: []Variant{{Value: "a", Weight: 9000}}}}, wantErr: "add up to 10000"},
+		{flag: FeatureFlag{Variants: variants, Rules: []Rule{orgRule("c")}}, wantErr: "unknown variant"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{orgRule("a")}}, wantErr: "only multivariate"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{{}}}, wantErr: "at least one condition"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{{Conditions: []Condition{{Type: "BOGUS"}}}}}, wantErr: "unknown condition type"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{{Conditions: []Condition{{Type: ConditionSiteAdmin, Values: []string{"x"}}}}}}, wantErr: "takes no values"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{{Conditions: []Condition{{Type: ConditionUserTag}}}}}, wantErr: "at least one value"},
+		{flag: FeatureFlag{Bool: &FeatureFlagBool{}, Rules: []Rule{{Conditions: []Condition{{Type: ConditionRepoName, Values: []string{"["}}}}}}, wantErr: "invalid repository name pattern"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			err := tt.flag.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type reencryptionMigrator struct {
+	logger  log.Logger
+	store   *database.RecordEncrypter
+	keyring *keyring.Ring
+	configs []database.EncryptionConfig
+
+	// cursors holds the ID of the last record visited in each table, so that records
+	// that cannot be decrypted are skipped by the next batch instead of being retried
+	// forever. A table's cursor is reset once it has been scanned to the end.
+	cursors map[string]int
+}
+
+var _ oobmigration.Migrator = &reencryptionMigrator{}
+
+// NewReencryptionMigrator returns a migrator that re-encrypts records that were encrypted
+// with a key other than the one currently configured for their table. Rotating a key is done
+// by moving the old key to encryption.keys.previousKeys and configuring the new key in its
+// place. Once this migration reports full progress, the previous key can be removed.
+func NewReencryptionMigrator(store *basestore.Store, keyring *keyring.Ring) *reencryptionMigrator {
+	return &reencryptionMigrator{
+		logger:  log.Scoped("ReencryptionMigrator", ""),
+		store:   &database.RecordEncrypter{Store: store},
+		keyring: keyring,
+		configs: database.EncryptionConfigs,
+		cursors: map[string]int{},
+	}
+}
+
+func (m *reencryptionMigrator) ID() int                 { return 24 }
+func (m *reencryptionMigrator) Interval() time.Duration { return time.Second * 3 }
+
+// Progress returns the percentage (ranged [0, 1]) of encrypted records across all encrypted
+// tables that are encrypted with the currently configured key for that table.
+func (m *reencryptionMigrator) Progress(ctx context.Context, _ bool) (float64, error) {
+	var current, total int
+	for _, config := range m.configs {
+		numCurrent, numPrevious, err := m.store.CountByKey(ctx, config, config.RingKey(*m.keyring))
+		if err != nil {
+			return 0, err
+		}
+
+		current += numCurrent
+		total += numCurrent + numPrevious
+	}
+
+	if total == 0 {
+		return 1, nil
+	}
+
+	return float64(current) / float64(total), nil
+}
+
+// Up re-encrypts one batch of records for each encrypted table that still contains records
+// written with a previous key. A failure to re-encrypt the records of one table does not
+// prevent the remaining tables from being re-encrypted. Records that cannot be decrypted
+// with the configured keys are skipped and reported in the returned error; they keep the
+// migration from completing until they are fixed or deleted.
+func (m *reencryptionMigrator) Up(ctx context.Context) (err error) {
+	for _, config := range m.configs {
+		result, reencryptErr := m.store.ReencryptBatch(ctx, config, config.RingKey(*m.keyring), m.cursors[config.TableName])
+		if reencryptErr != nil {
+			err = errors.Append(err, errors.Wrapf(reencryptErr, "failed to re-encrypt %s", config.TableName))
+			continue
+		}
+
+		// Start over from the beginning of the table once the end has been reached.
+		m.cursors[config.TableName] = result.LastID
+
+		if result.Count > 0 {
+			m.logger.Debug("re-encrypted records", log.String("tableName", config.TableName), log.Int("count", result.Count))
+		}
+		if len(result.FailedIDs) > 0 {
+			err = errors.Append(err, errors.Newf("failed to decrypt records of %s with IDs %v", config.TableName, result.FailedIDs))
+		}
+	}
+
+	return err
+}
+
+func (*reencryptionMigrator) Down(context.Context) error {
+	// Records are re-encrypted with the key currently configured in the keyring; there
+	// is no previous state to restore.
+	return nil
+}
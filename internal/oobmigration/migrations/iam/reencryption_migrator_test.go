@@ -0,0 +1,210 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/rotation"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+)
+
+func TestReencryptionMigrator(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := basestore.NewWithHandle(db.Handle())
+
+	if err := store.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	oldKey := et.TestKey{}
+	newKey := et.ByteaTestKey{}
+	ring := &keyring.Ring{ExternalServiceKey: rotation.New(newKey, oldKey)}
+
+	migrator := NewReencryptionMigrator(store, ring)
+	migrator.configs = []database.EncryptionConfig{{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		RingKey:             func(r keyring.Ring) encryption.Key { return r.ExternalServiceKey },
+		Limit:               5,
+	}}
+
+	progress, err := migrator.Progress(ctx, false)
+	require.NoError(t, err)
+	if have, want := progress, 1.0; have != want {
+		t.Fatalf("got invalid progress with no DB entries, want=%f have=%f", want, have)
+	}
+
+	for i := 0; i < 10; i++ {
+		// Unencrypted records are not the concern of this migration
+		encrypted, keyID := fmt.Sprintf("plaintext-%d", i), ""
+		if i < 8 {
+			encrypted, keyID, err = encryption.MaybeEncrypt(ctx, oldKey, fmt.Sprintf("data-%d", i))
+			require.NoError(t, err)
+		}
+
+		if err := store.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i+1, keyID, encrypted)); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+	}
+
+	progress, err = migrator.Progress(ctx, false)
+	require.NoError(t, err)
+	if have, want := progress, 0.0; have != want {
+		t.Fatalf("got invalid progress with unmigrated entries, want=%f have=%f", want, have)
+	}
+
+	require.NoError(t, migrator.Up(ctx))
+
+	progress, err = migrator.Progress(ctx, false)
+	require.NoError(t, err)
+	if have, want := progress, 5.0/8.0; have != want {
+		t.Fatalf("got invalid progress with one migrated batch, want=%f have=%f", want, have)
+	}
+
+	require.NoError(t, migrator.Up(ctx))
+
+	progress, err = migrator.Progress(ctx, false)
+	require.NoError(t, err)
+	if have, want := progress, 1.0; have != want {
+		t.Fatalf("got invalid progress after up migration, want=%f have=%f", want, have)
+	}
+
+	// With the previous key retired, all encrypted records are still readable
+	values, err := basestore.NewMapScanner(scanEncryptedString)(store.Query(ctx, sqlf.Sprintf("SELECT id, encryption_key_id, data FROM test_encryptable")))
+	require.NoError(t, err)
+	for id, value := range values {
+		decrypted, err := encryption.MaybeDecrypt(ctx, newKey, value.Values[0], value.KeyID)
+		require.NoError(t, err)
+
+		want := fmt.Sprintf("data-%d", id-1)
+		if id > 8 {
+			want = fmt.Sprintf("plaintext-%d", id-1)
+		}
+		if decrypted != want {
+			t.Errorf("unexpected value for record %d. want=%q have=%q", id, want, decrypted)
+		}
+	}
+}
+
+func TestReencryptionMigratorTableError(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := basestore.NewWithHandle(db.Handle())
+
+	if err := store.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	oldKey := et.TestKey{}
+	newKey := et.ByteaTestKey{}
+	ring := &keyring.Ring{ExternalServiceKey: rotation.New(newKey, oldKey)}
+
+	config := database.EncryptionConfig{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		RingKey:             func(r keyring.Ring) encryption.Key { return r.ExternalServiceKey },
+		Limit:               5,
+	}
+	missingTableConfig := config
+	missingTableConfig.TableName = "test_missing"
+
+	migrator := NewReencryptionMigrator(store, ring)
+	migrator.configs = []database.EncryptionConfig{missingTableConfig, config}
+
+	encrypted, keyID, err := encryption.MaybeEncrypt(ctx, oldKey, "data")
+	require.NoError(t, err)
+	if err := store.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (1, %s, %s)", keyID, encrypted)); err != nil {
+		t.Fatalf("failed to insert test data: %s", err)
+	}
+
+	// The failing table is reported, but does not block re-encrypting the other tables
+	require.Error(t, migrator.Up(ctx))
+
+	numCurrent, numPrevious, err := migrator.store.CountByKey(ctx, config, newKey)
+	require.NoError(t, err)
+	if numCurrent != 1 || numPrevious != 0 {
+		t.Fatalf("unexpected counts. want current=1 previous=0 have current=%d previous=%d", numCurrent, numPrevious)
+	}
+}
+
+func TestReencryptionMigratorUndecryptableRecords(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := basestore.NewWithHandle(db.Handle())
+
+	if err := store.Exec(ctx, sqlf.Sprintf("CREATE TABLE test_encryptable (id int, encryption_key_id text, data text)")); err != nil {
+		t.Fatalf("failed to create test table: %s", err)
+	}
+
+	oldKey := et.TestKey{}
+	newKey := et.ByteaTestKey{}
+	ring := &keyring.Ring{ExternalServiceKey: rotation.New(newKey, oldKey)}
+
+	migrator := NewReencryptionMigrator(store, ring)
+	migrator.configs = []database.EncryptionConfig{{
+		TableName:           "test_encryptable",
+		IDFieldName:         "id",
+		KeyIDFieldName:      "encryption_key_id",
+		EncryptedFieldNames: []string{"data"},
+		Scan:                basestore.NewMapScanner(scanEncryptedString),
+		RingKey:             func(r keyring.Ring) encryption.Key { return r.ExternalServiceKey },
+		Limit:               2,
+	}}
+
+	for i := 1; i <= 4; i++ {
+		encrypted, keyID, err := encryption.MaybeEncrypt(ctx, oldKey, fmt.Sprintf("data-%d", i))
+		require.NoError(t, err)
+		if i == 1 {
+			// Written with a key that is no longer configured
+			keyID, encrypted = "retired-key", "corrupt!"
+		}
+
+		if err := store.Exec(ctx, sqlf.Sprintf("INSERT INTO test_encryptable VALUES (%s, %s, %s)", i, keyID, encrypted)); err != nil {
+			t.Fatalf("failed to insert test data: %s", err)
+		}
+	}
+
+	// The undecryptable record is reported, but does not block the records after it
+	err := migrator.Up(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decrypt records of test_encryptable with IDs [1]")
+	require.NoError(t, migrator.Up(ctx))
+
+	progress, err := migrator.Progress(ctx, false)
+	require.NoError(t, err)
+	if have, want := progress, 3.0/4.0; have != want {
+		t.Fatalf("got invalid progress with an undecryptable record, want=%f have=%f", want, have)
+	}
+
+	// Once the end of the table is reached, the migration starts over and reports the
+	// record again
+	require.NoError(t, migrator.Up(ctx))
+	require.Error(t, migrator.Up(ctx))
+}
+
+func scanEncryptedString(scanner dbutil.Scanner) (id int, e database.Encrypted, err error) {
+	e.Values = make([]string, 1)
+	err = scanner.Scan(&id, &e.KeyID, &e.Values[0])
+	return
+}
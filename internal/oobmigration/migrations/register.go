@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration/migrations/batches"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration/migrations/iam"
 )
 
 func RegisterOSSMigrators(ctx context.Context, db database.DB, runner *oobmigration.Runner) error {
@@ -55,6 +56,7 @@ func registerOSSMigrators(runner *oobmigration.Runner, noDelay bool, deps migrat
 	return RegisterAll(runner, noDelay, []TaggedMigrator{
 		batches.NewExternalServiceWebhookMigratorWithDB(deps.store, deps.keyring.ExternalServiceKey, 50),
 		batches.NewUserRoleAssignmentMigrator(deps.store, 250),
+		iam.NewReencryptionMigrator(deps.store, deps.keyring),
 	})
 }
 
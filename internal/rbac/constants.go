@@ -4,3 +4,21 @@ package rbac
 const BatchChangesReadPermission string = "BATCH_CHANGES#READ"
 
 const BatchChangesWritePermission string = "BATCH_CHANGES#WRITE"
+
+const CodeInsightsWritePermission string = "CODE_INSIGHTS#WRITE"
+
+const CodeMonitorsWritePermission string = "CODE_MONITORS#WRITE"
+
+const NotebooksWritePermission string = "NOTEBOOKS#WRITE"
+
+const SearchContextsWritePermission string = "SEARCH_CONTEXTS#WRITE"
+
+const ExecutorSecretsReadPermission string = "EXECUTOR_SECRETS#READ"
+
+const ExecutorSecretsWritePermission string = "EXECUTOR_SECRETS#WRITE"
+
+const RepoManagementReadPermission string = "REPO_MANAGEMENT#READ"
+
+const RepoManagementWritePermission string = "REPO_MANAGEMENT#WRITE"
+
+const SiteConfigReadPermission string = "SITE_CONFIG#READ"
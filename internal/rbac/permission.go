@@ -67,3 +67,22 @@ func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, permissi
 
 	return nil
 }
+
+// CheckCurrentUserIsSiteAdminOrHasPermission returns an error if the current user is
+// neither a site admin nor has the permission assigned to them. It guards areas that used
+// to be restricted to site admins, so auth.ErrMustBeSiteAdmin is returned when the user
+// lacks the permission.
+func CheckCurrentUserIsSiteAdminOrHasPermission(ctx context.Context, db database.DB, permission string) error {
+	err := auth.CheckCurrentUserIsSiteAdmin(ctx, db)
+	if err != auth.ErrMustBeSiteAdmin {
+		return err
+	}
+
+	if permErr := CheckCurrentUserHasPermission(ctx, db, permission); permErr != nil {
+		if errors.HasType(permErr, &ErrNotAuthorized{}) {
+			return err
+		}
+		return permErr
+	}
+	return nil
+}
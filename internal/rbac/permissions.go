@@ -7,6 +7,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	rtypes "github.com/sourcegraph/sourcegraph/internal/rbac/types"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -96,3 +97,16 @@ func ComparePermissions(dbPerms []*types.Permission, schemaPerms Schema) (added
 
 	return
 }
+
+// SystemRolesForNamespace returns the system roles that newly created permissions in the
+// given namespace are assigned to. Permissions are granted to every user by default so that
+// introducing a permission doesn't break the current experience, unless the namespace is
+// restricted to site admins.
+func (s Schema) SystemRolesForNamespace(namespace rtypes.PermissionNamespace) []types.SystemRole {
+	for _, n := range s.Namespaces {
+		if n.Name == namespace && n.SiteAdminOnly {
+			return []types.SystemRole{types.SiteAdministratorSystemRole}
+		}
+	}
+	return []types.SystemRole{types.SiteAdministratorSystemRole, types.UserSystemRole}
+}
@@ -124,4 +124,25 @@ func TestComparePermissions(t *testing.T) {
 	})
 }
 
+func TestSchema_SystemRolesForNamespace(t *testing.T) {
+	schema := Schema{
+		Namespaces: []Namespace{
+			{Name: "TEST-NAMESPACE", Actions: []rtypes.NamespaceAction{"READ", "WRITE"}},
+			{Name: "TEST-ADMIN-NAMESPACE", Actions: []rtypes.NamespaceAction{"READ"}, SiteAdminOnly: true},
+		},
+	}
+
+	require.Equal(t, []types.SystemRole{types.SiteAdministratorSystemRole, types.UserSystemRole}, schema.SystemRolesForNamespace("TEST-NAMESPACE"))
+	require.Equal(t, []types.SystemRole{types.SiteAdministratorSystemRole}, schema.SystemRolesForNamespace("TEST-ADMIN-NAMESPACE"))
+	require.Equal(t, []types.SystemRole{types.SiteAdministratorSystemRole, types.UserSystemRole}, schema.SystemRolesForNamespace("UNKNOWN-NAMESPACE"))
+}
+
+func TestRBACSchema_AdminNamespaces(t *testing.T) {
+	// Granting these to every user by default would give them access to areas that used to be
+	// restricted to site admins.
+	for _, namespace := range []rtypes.PermissionNamespace{rtypes.ExecutorSecretsNamespace, rtypes.RepoManagementNamespace, rtypes.SiteConfigNamespace} {
+		require.Equal(t, []types.SystemRole{types.SiteAdministratorSystemRole}, RBACSchema.SystemRolesForNamespace(namespace), namespace)
+	}
+}
+
 func sortDeletePermissionOptSlice(a, b database.DeletePermissionOpts) bool { return a.ID < b.ID }
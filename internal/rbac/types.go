@@ -12,4 +12,7 @@ type Schema struct {
 type Namespace struct {
 	Name    rtypes.PermissionNamespace `yaml:"name"`
 	Actions []rtypes.NamespaceAction   `yaml:"actions"`
+	// SiteAdminOnly is set for namespaces guarding areas that were previously restricted
+	// to site admins. Their permissions are not granted to the USER system role by default.
+	SiteAdminOnly bool `yaml:"siteAdminOnly"`
 }
@@ -10,3 +10,12 @@ func (a NamespaceAction) String() string {
 
 const BatchChangesReadAction NamespaceAction = "READ"
 const BatchChangesWriteAction NamespaceAction = "WRITE"
+const CodeInsightsWriteAction NamespaceAction = "WRITE"
+const CodeMonitorsWriteAction NamespaceAction = "WRITE"
+const NotebooksWriteAction NamespaceAction = "WRITE"
+const SearchContextsWriteAction NamespaceAction = "WRITE"
+const ExecutorSecretsReadAction NamespaceAction = "READ"
+const ExecutorSecretsWriteAction NamespaceAction = "WRITE"
+const RepoManagementReadAction NamespaceAction = "READ"
+const RepoManagementWriteAction NamespaceAction = "WRITE"
+const SiteConfigReadAction NamespaceAction = "READ"
@@ -10,11 +10,18 @@ func (n PermissionNamespace) String() string {
 }
 
 const BatchChangesNamespace PermissionNamespace = "BATCH_CHANGES"
+const CodeInsightsNamespace PermissionNamespace = "CODE_INSIGHTS"
+const CodeMonitorsNamespace PermissionNamespace = "CODE_MONITORS"
+const NotebooksNamespace PermissionNamespace = "NOTEBOOKS"
+const SearchContextsNamespace PermissionNamespace = "SEARCH_CONTEXTS"
+const ExecutorSecretsNamespace PermissionNamespace = "EXECUTOR_SECRETS"
+const RepoManagementNamespace PermissionNamespace = "REPO_MANAGEMENT"
+const SiteConfigNamespace PermissionNamespace = "SITE_CONFIG"
 
 // Valid checks if a namespace is valid and supported by Sourcegraph's RBAC system.
 func (n PermissionNamespace) Valid() bool {
 	switch n {
-	case BatchChangesNamespace:
+	case BatchChangesNamespace, CodeInsightsNamespace, CodeMonitorsNamespace, NotebooksNamespace, SearchContextsNamespace, ExecutorSecretsNamespace, RepoManagementNamespace, SiteConfigNamespace:
 		return true
 	default:
 		return false
@@ -62,6 +62,17 @@ func testKeyValue(t *testing.T, kv redispool.KeyValue) {
 		require.Works(err)
 		require.Equal(kv.Get("incr-set"), 6)
 		require.Equal(kv.Get("incr-unset"), 1)
+
+		// IncrBy
+		n, err := kv.IncrBy("incr-set", 4)
+		require.Works(err)
+		if n != 10 {
+			t.Fatalf("got %d, wanted 10", n)
+		}
+		_, err = kv.IncrBy("incrby-unset", -2)
+		require.Works(err)
+		require.Equal(kv.Get("incr-set"), 10)
+		require.Equal(kv.Get("incrby-unset"), -2)
 	})
 
 	t.Run("hash", func(t *testing.T) {
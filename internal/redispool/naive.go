@@ -96,21 +96,25 @@ func (kv *naiveKeyValue) SetEx(key string, ttlSeconds int, value any) error {
 }
 
 func (kv *naiveKeyValue) Incr(key string) (int, error) {
-	return kv.maybeUpdateGroup(redisGroupString, key, func(value redisValue, found bool) (redisValue, updaterOp, error) {
+	return kv.IncrBy(key, 1)
+}
+
+func (kv *naiveKeyValue) IncrBy(key string, value int) (int, error) {
+	return kv.maybeUpdateGroup(redisGroupString, key, func(v redisValue, found bool) (redisValue, updaterOp, error) {
 		if !found {
 			return redisValue{
 				Group: redisGroupString,
-				Reply: int64(1),
+				Reply: int64(value),
 			}, write, nil
 		}
 
-		num, err := redis.Int(value.Reply, nil)
+		num, err := redis.Int(v.Reply, nil)
 		if err != nil {
-			return value, readOnly, err
+			return v, readOnly, err
 		}
 
-		value.Reply = int64(num + 1)
-		return value, write, nil
+		v.Reply = int64(num + value)
+		return v, write, nil
 	}).Int()
 }
 
@@ -0,0 +1,344 @@
+// Package admission decides whether a search may run, based on its estimated cost
+// and the searches the same user is already running.
+//
+// Two kinds of limits are enforced per user, both configured in the
+// "search.limits.admission" site configuration:
+//
+//   - a concurrency limit: the number of searches that may run at the same time.
+//     Searches over the limit wait for a free slot until the queue timeout elapses.
+//   - a cost budget: the total estimated cost of the searches a user may run within
+//     a time window.
+//
+// The counters are held in Redis so that the limits apply across all frontend
+// replicas.
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	defaultCostBudgetWindow = 60 * time.Second
+
+	// defaultPollInterval is how often a queued search checks for a free
+	// concurrency slot.
+	defaultPollInterval = 250 * time.Millisecond
+
+	// metricUserBuckets is the number of buckets users are spread over in the
+	// user_bucket metric label. Labelling by user ID would create series for
+	// every user of the instance.
+	metricUserBuckets = 64
+)
+
+// Decisions recorded in metrics.
+const (
+	decisionAdmitted              = "admitted"
+	decisionQueued                = "queued"
+	decisionRejectedCost          = "rejected_cost"
+	decisionRejectedBudget        = "rejected_budget"
+	decisionRejectedConcurrency   = "rejected_concurrency"
+	decisionRejectedQueueCanceled = "rejected_canceled"
+)
+
+var (
+	// The per-user metrics are labelled by user bucket rather than user ID, so
+	// that the number of series stays bounded on instances with many users.
+	metricDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_search_admission_decisions_total",
+		Help: "Total number of search admission decisions, by user bucket and decision.",
+	}, []string{"user_bucket", "decision"})
+
+	metricCostUnits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_search_admission_cost_units_total",
+		Help: "Total estimated cost of admitted searches, by user bucket.",
+	}, []string{"user_bucket"})
+
+	metricInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_search_admission_in_flight",
+		Help: "Number of admitted searches currently running on this instance, by user bucket.",
+	}, []string{"user_bucket"})
+
+	metricQueueDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_search_admission_queue_duration_seconds",
+		Help:    "Time searches spent waiting for a concurrency slot.",
+		Buckets: []float64{0.25, 0.5, 1, 2, 5, 10, 30, 60},
+	}, []string{"decision"})
+)
+
+// Controller enforces the search admission limits.
+type Controller struct {
+	store        redispool.KeyValue
+	pollInterval time.Duration
+}
+
+// NewController returns a controller that keeps its counters in store.
+func NewController(store redispool.KeyValue) *Controller {
+	return &Controller{store: store, pollInterval: defaultPollInterval}
+}
+
+// Enabled returns true if any admission limit is configured.
+func Enabled(c *schema.SearchAdmission) bool {
+	return c != nil && (c.MaxConcurrentSearchesPerUser > 0 || c.CostBudgetPerUser > 0 || c.MaxCostPerSearch > 0)
+}
+
+// Admit decides whether a search with the given cost may run for the actor in ctx.
+//
+// If the search is admitted, the returned release function must be called once it
+// finishes. If the search is rejected, the returned alert explains why and release
+// is nil. maxSearchDuration is the longest a search can run for, and bounds how long
+// a concurrency slot is held should release never be called.
+func (c *Controller) Admit(ctx context.Context, cfg *schema.SearchAdmission, maxSearchDuration time.Duration, cost Cost) (release func(), alert *search.Alert, err error) {
+	noop := func() {}
+	if !Enabled(cfg) {
+		return noop, nil, nil
+	}
+
+	a := actor.FromContext(ctx)
+	if a.IsInternal() {
+		return noop, nil, nil
+	}
+	key := actorKey(ctx, a)
+	bucket := userBucket(a)
+
+	if cfg.MaxCostPerSearch > 0 && cost.Units > cfg.MaxCostPerSearch {
+		metricDecisions.WithLabelValues(bucket, decisionRejectedCost).Inc()
+		return nil, alertForCost(cost, cfg.MaxCostPerSearch), nil
+	}
+
+	store := c.store.WithContext(ctx)
+
+	refund := noop
+	if cfg.CostBudgetPerUser > 0 {
+		budgetAlert, err := c.spendBudget(store, key, cfg, cost)
+		if err != nil {
+			return nil, nil, err
+		}
+		if budgetAlert != nil {
+			metricDecisions.WithLabelValues(bucket, decisionRejectedBudget).Inc()
+			return nil, budgetAlert, nil
+		}
+		refund = func() {
+			// Use the store without ctx, which may be canceled by now.
+			_, _ = c.store.IncrBy(budgetKey(key), -cost.Units)
+		}
+	}
+
+	decision := decisionAdmitted
+	releaseSlot := noop
+	if cfg.MaxConcurrentSearchesPerUser > 0 {
+		var queued bool
+		releaseSlot, queued, alert, err = c.acquireSlot(ctx, key, cfg, maxSearchDuration)
+		if err != nil || alert != nil {
+			refund()
+			if alert != nil {
+				metricDecisions.WithLabelValues(bucket, decisionRejectedConcurrency).Inc()
+			}
+			return nil, alert, err
+		}
+		if queued {
+			decision = decisionQueued
+		}
+	}
+
+	metricDecisions.WithLabelValues(bucket, decision).Inc()
+	metricCostUnits.WithLabelValues(bucket).Add(float64(cost.Units))
+	metricInFlight.WithLabelValues(bucket).Inc()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			metricInFlight.WithLabelValues(bucket).Dec()
+			releaseSlot()
+		})
+	}, nil, nil
+}
+
+// spendBudget adds the cost of a search to the budget counter of the user. If that
+// exceeds the budget the cost is taken off again, and an alert is returned.
+func (c *Controller) spendBudget(store redispool.KeyValue, key string, cfg *schema.SearchAdmission, cost Cost) (*search.Alert, error) {
+	window := defaultCostBudgetWindow
+	if cfg.CostBudgetWindowSeconds > 0 {
+		window = time.Duration(cfg.CostBudgetWindowSeconds) * time.Second
+	}
+
+	key = budgetKey(key)
+	used, err := store.IncrBy(key, cost.Units)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to increase search cost budget counter")
+	}
+
+	// Start the window on the first search in it. Like the completions rate
+	// limiter, incrementing and setting the expiry is not atomic: if the expiry
+	// fails to be set it is set by the next search.
+	ttl, err := store.TTL(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get TTL for search cost budget counter")
+	}
+	if ttl < 0 {
+		ttl = int(window / time.Second)
+		if err := store.Expire(key, ttl); err != nil {
+			return nil, errors.Wrap(err, "failed to set expiry for search cost budget counter")
+		}
+	}
+
+	if used > cfg.CostBudgetPerUser {
+		if _, err := store.IncrBy(key, -cost.Units); err != nil {
+			return nil, errors.Wrap(err, "failed to decrease search cost budget counter")
+		}
+		return alertForBudget(cfg.CostBudgetPerUser, window, time.Duration(ttl)*time.Second), nil
+	}
+	return nil, nil
+}
+
+// acquireSlot takes one of the concurrency slots of the user, waiting for up to the
+// configured queue timeout for one to become free.
+func (c *Controller) acquireSlot(ctx context.Context, key string, cfg *schema.SearchAdmission, maxSearchDuration time.Duration) (release func(), queued bool, alert *search.Alert, err error) {
+	key = concurrencyKey(key)
+	store := c.store.WithContext(ctx)
+
+	// The counter expires well after the longest time a search can take, so
+	// that slots held by a process that died without releasing them are
+	// eventually freed.
+	ttl := int((2*maxSearchDuration + time.Second - 1) / time.Second)
+	if ttl <= 0 {
+		ttl = 1
+	}
+
+	start := time.Now()
+	deadline := start.Add(time.Duration(cfg.QueueTimeoutSeconds) * time.Second)
+	for {
+		n, err := store.Incr(key)
+		if err != nil {
+			return nil, false, nil, errors.Wrap(err, "failed to increase search concurrency counter")
+		}
+		// Only the search that creates the counter sets its expiry. Refreshing it
+		// on every attempt would keep the counter, and slots leaked by a dead
+		// process, alive for as long as searches keep polling.
+		if n == 1 {
+			if err := store.Expire(key, ttl); err != nil {
+				_, _ = c.store.IncrBy(key, -1)
+				return nil, false, nil, errors.Wrap(err, "failed to set expiry for search concurrency counter")
+			}
+		}
+		if n <= cfg.MaxConcurrentSearchesPerUser {
+			break
+		}
+
+		// No free slot, give ours back.
+		if _, err := store.IncrBy(key, -1); err != nil {
+			return nil, false, nil, errors.Wrap(err, "failed to decrease search concurrency counter")
+		}
+
+		if !time.Now().Add(c.pollInterval).Before(deadline) {
+			metricQueueDuration.WithLabelValues(decisionRejectedConcurrency).Observe(time.Since(start).Seconds())
+			return nil, false, alertForConcurrency(cfg.MaxConcurrentSearchesPerUser, cfg.QueueTimeoutSeconds), nil
+		}
+
+		queued = true
+		select {
+		case <-ctx.Done():
+			metricQueueDuration.WithLabelValues(decisionRejectedQueueCanceled).Observe(time.Since(start).Seconds())
+			return nil, false, nil, ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+	if queued {
+		metricQueueDuration.WithLabelValues(decisionQueued).Observe(time.Since(start).Seconds())
+	}
+
+	return func() {
+		// Use the store without ctx, which is usually canceled once the search
+		// finished.
+		n, err := c.store.IncrBy(key, -1)
+		if err == nil && n < 0 {
+			// The counter expired while the search was running.
+			_ = c.store.Del(key)
+		}
+	}, queued, nil, nil
+}
+
+// actorKey returns the key the counters of the actor are stored under.
+func actorKey(ctx context.Context, a *actor.Actor) string {
+	if a.IsAuthenticated() {
+		return "user:" + strconv.Itoa(int(a.UID))
+	}
+
+	// Fall back to the remote address for anonymous users. X-Forwarded-For is
+	// set by the client, so it's not used: anyone could pick a new value for
+	// every search to escape the limits.
+	var ip string
+	if req := requestclient.FromContext(ctx); req != nil {
+		ip = req.IP
+	}
+	return "anon:" + ip
+}
+
+// userBucket returns the user_bucket metric label of the actor: the user ID
+// modulo metricUserBuckets for users, or "anonymous" for anonymous users.
+func userBucket(a *actor.Actor) string {
+	if !a.IsAuthenticated() {
+		return "anonymous"
+	}
+	return strconv.Itoa(int(a.UID) % metricUserBuckets)
+}
+
+func budgetKey(key string) string {
+	return key + ":search_cost"
+}
+
+func concurrencyKey(key string) string {
+	return key + ":search_concurrency"
+}
+
+func alertForCost(cost Cost, limit int) *search.Alert {
+	return &search.Alert{
+		PrometheusType: decisionRejectedCost,
+		Title:          "Search is too expensive",
+		Description: fmt.Sprintf(
+			"This search is estimated to cost %d units (across about %d repositories), which is more than the limit of %d units per search. Narrow the search to fewer repositories with a `repo:` filter, search fewer revisions, or lower the `count:`.",
+			cost.Units, cost.Repos, limit,
+		),
+		Priority: 5,
+	}
+}
+
+func alertForBudget(budget int, window, retryAfter time.Duration) *search.Alert {
+	return &search.Alert{
+		PrometheusType: decisionRejectedBudget,
+		Title:          "Search quota exceeded",
+		Description: fmt.Sprintf(
+			"You have used up your search quota of %d units per %s. Try again in %s, or run searches over fewer repositories.",
+			budget, window, retryAfter.Round(time.Second),
+		),
+		Priority: 5,
+	}
+}
+
+func alertForConcurrency(limit, queueTimeoutSeconds int) *search.Alert {
+	description := fmt.Sprintf("You can run up to %d searches at the same time.", limit)
+	if queueTimeoutSeconds > 0 {
+		description += fmt.Sprintf(" This search waited for %s for another one to finish.", time.Duration(queueTimeoutSeconds)*time.Second)
+	}
+	description += " Wait for your other searches to finish and try again."
+
+	return &search.Alert{
+		PrometheusType: decisionRejectedConcurrency,
+		Title:          "Too many concurrent searches",
+		Description:    description,
+		Priority:       5,
+	}
+}
@@ -0,0 +1,199 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestController_Admit(t *testing.T) {
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	newController := func() *Controller {
+		c := NewController(redispool.MemoryKeyValue())
+		c.pollInterval = time.Millisecond
+		return c
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		release, alert, err := newController().Admit(userCtx, &schema.SearchAdmission{}, time.Minute, Cost{Units: 1 << 20})
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		release()
+	})
+
+	t.Run("internal actor is exempt", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxCostPerSearch: 10}
+		ctx := actor.WithInternalActor(context.Background())
+		release, alert, err := newController().Admit(ctx, cfg, time.Minute, Cost{Units: 100})
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		release()
+	})
+
+	t.Run("max cost per search", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxCostPerSearch: 10}
+		c := newController()
+
+		release, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Repos: 10, Units: 10})
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		release()
+
+		release, alert, err = c.Admit(userCtx, cfg, time.Minute, Cost{Repos: 11, Units: 11})
+		require.NoError(t, err)
+		assert.Nil(t, release)
+		require.NotNil(t, alert)
+		assert.Equal(t, "rejected_cost", alert.PrometheusType)
+	})
+
+	t.Run("cost budget", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{CostBudgetPerUser: 100}
+		c := newController()
+
+		for i := 0; i < 2; i++ {
+			release, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 40})
+			require.NoError(t, err)
+			assert.Nil(t, alert)
+			release()
+		}
+
+		// 120 > 100
+		_, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 40})
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, "rejected_budget", alert.PrometheusType)
+
+		// The rejected search was not charged, so a cheaper one still fits.
+		release, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 20})
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		release()
+
+		// Other users have their own budget.
+		otherCtx := actor.WithActor(context.Background(), actor.FromUser(2))
+		release, alert, err = c.Admit(otherCtx, cfg, time.Minute, Cost{Units: 100})
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		release()
+
+		ttl, err := c.store.TTL(budgetKey("user:1"))
+		require.NoError(t, err)
+		assert.Equal(t, 60, ttl)
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxConcurrentSearchesPerUser: 2}
+		c := newController()
+
+		release1, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+		release2, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+
+		_, alert, err = c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, "rejected_concurrency", alert.PrometheusType)
+
+		// Releasing twice only frees one slot.
+		release1()
+		release1()
+		release3, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+
+		_, alert, err = c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+
+		release2()
+		release3()
+		n, err := c.store.Get(concurrencyKey("user:1")).Int()
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("concurrency queue", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxConcurrentSearchesPerUser: 1, QueueTimeoutSeconds: 10}
+		c := newController()
+
+		release1, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			release1()
+		}()
+
+		release2, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+		release2()
+	})
+
+	t.Run("concurrency queue canceled", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxConcurrentSearchesPerUser: 1, QueueTimeoutSeconds: 10, CostBudgetPerUser: 10}
+		c := newController()
+
+		release1, alert, err := c.Admit(userCtx, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+		defer release1()
+
+		ctx, cancel := context.WithTimeout(userCtx, 20*time.Millisecond)
+		defer cancel()
+		_, _, err = c.Admit(ctx, cfg, time.Minute, Cost{Units: 5})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The canceled search is not charged against the budget.
+		used, err := c.store.Get(budgetKey("user:1")).Int()
+		require.NoError(t, err)
+		assert.Equal(t, 1, used)
+	})
+
+	t.Run("anonymous users are limited by IP", func(t *testing.T) {
+		cfg := &schema.SearchAdmission{MaxConcurrentSearchesPerUser: 1}
+		c := newController()
+
+		ctx1 := requestclient.WithClient(context.Background(), &requestclient.Client{IP: "192.0.2.1"})
+		ctx2 := requestclient.WithClient(context.Background(), &requestclient.Client{IP: "192.0.2.2"})
+
+		release1, alert, err := c.Admit(ctx1, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+		defer release1()
+
+		release2, alert, err := c.Admit(ctx2, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.Nil(t, alert)
+		defer release2()
+
+		_, alert, err = c.Admit(ctx1, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+
+		// X-Forwarded-For is set by the client and doesn't escape the limit.
+		ctx3 := requestclient.WithClient(context.Background(), &requestclient.Client{IP: "192.0.2.1", ForwardedFor: "198.51.100.1"})
+		_, alert, err = c.Admit(ctx3, cfg, time.Minute, Cost{Units: 1})
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+	})
+}
+
+func TestUserBucket(t *testing.T) {
+	assert.Equal(t, "anonymous", userBucket(&actor.Actor{}))
+	assert.Equal(t, "1", userBucket(actor.FromUser(1)))
+	assert.Equal(t, "1", userBucket(actor.FromUser(metricUserBuckets+1)))
+}
@@ -0,0 +1,167 @@
+package admission
+
+import (
+	"context"
+	"math"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/structural"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Cost is the estimated cost of running a search.
+type Cost struct {
+	// Repos is the estimated number of repositories the search runs over.
+	Repos int
+	// Units is the estimated cost of the search, in arbitrary units. It is what
+	// per-search limits and per-user budgets are expressed in.
+	Units int
+}
+
+// Relative weights of the backends a search can run on, per repository. Text
+// and symbol search is the cheapest, commit and diff search have to walk the
+// history of each repository.
+const (
+	weightText       = 1
+	weightCommit     = 20
+	weightDiff       = 40
+	weightStructural = 50
+
+	// weightUnindexed is the multiplier for each revision other than the default
+	// branch. Only the default branch is indexed, so other revisions are searched
+	// by reading files from gitserver.
+	weightUnindexed = 10
+
+	// weightRefGlob is the multiplier for a repository revision specified as a ref
+	// glob, which can expand to an unknown number of revisions.
+	weightRefGlob = 10
+
+	// maxLimitFactor bounds how much a large count: can increase the cost of a
+	// search.
+	maxLimitFactor = 10
+)
+
+// EstimateCost estimates the cost of running the given job, which was planned for
+// inputs. The cost is the estimated number of repositories searched multiplied by
+// the weight of the most expensive backend in the job tree, the revisions searched
+// per repository, the pattern type and the result limit.
+func EstimateCost(ctx context.Context, db database.DB, inputs *search.Inputs, j job.Job) (Cost, error) {
+	repos := 0
+	revs := 1
+	for _, b := range inputs.Plan {
+		n, err := estimateRepos(ctx, db, b, inputs.UserSettings)
+		if err != nil {
+			return Cost{}, err
+		}
+		repos += n
+		revs = max(revs, revisionFactor(b))
+	}
+
+	units := repos * jobWeight(j) * revs * patternFactor(inputs.PatternType) * limitFactor(inputs)
+	if units < 0 || units > math.MaxInt32 {
+		// Overflowed, or so large that it doesn't matter.
+		units = math.MaxInt32
+	}
+
+	return Cost{Repos: repos, Units: units}, nil
+}
+
+// estimateRepos returns the number of repositories b is searched over. It applies
+// the same repository filters, search context and fork and archived defaults as the
+// search, but ignores filters on the contents of repositories, so the estimate is an
+// upper bound.
+func estimateRepos(ctx context.Context, db database.DB, b query.Basic, userSettings *schema.Settings) (int, error) {
+	n, err := searchrepos.Count(ctx, db, jobutil.ToRepoOptions(b, userSettings))
+	if err != nil {
+		return 0, errors.Wrap(err, "counting repositories")
+	}
+	return n, nil
+}
+
+// revisionFactor returns how much more expensive searching the revisions in b is
+// than searching the default branch of each repository.
+func revisionFactor(b query.Basic) int {
+	include, _ := b.Repositories()
+
+	factor := 1
+	for _, r := range include {
+		n := 0
+		for _, rev := range r.Revs {
+			switch {
+			case rev.HasRefGlob():
+				n += weightUnindexed * weightRefGlob
+			case rev.RevSpec == "":
+				n++
+			default:
+				n += weightUnindexed
+			}
+		}
+		factor = max(factor, n)
+	}
+	return factor
+}
+
+// jobWeight returns the weight of the most expensive search backend in the job
+// tree.
+//
+// Text searches are planned with both an indexed and an unindexed search job,
+// the latter searching revisions and repositories that aren't indexed. Which of
+// them does the work depends on the revisions searched, which revisionFactor
+// accounts for.
+func jobWeight(j job.Job) int {
+	weight := weightText
+	job.Visit(j, func(d job.Describer) {
+		switch v := d.(type) {
+		case *commit.SearchJob:
+			if v.Diff {
+				weight = max(weight, weightDiff)
+			} else {
+				weight = max(weight, weightCommit)
+			}
+		case *structural.SearchJob:
+			weight = max(weight, weightStructural)
+		}
+	})
+	return weight
+}
+
+// patternFactor returns how much more expensive the pattern type is than a literal
+// search. Regular expressions can't always make use of the trigram index.
+func patternFactor(t query.SearchType) int {
+	if t == query.SearchTypeRegex {
+		return 2
+	}
+	return 1
+}
+
+// limitFactor returns how much more expensive the search is because of its result
+// limit, compared to a search with the default limit.
+func limitFactor(inputs *search.Inputs) int {
+	factor := inputs.MaxResults() / limits.DefaultMaxSearchResultsStreaming
+	if factor < 1 {
+		return 1
+	}
+	return min(factor, maxLimitFactor)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestEstimateCost(t *testing.T) {
+	repoStore := database.NewMockRepoStore()
+	repoStore.CountFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) (int, error) {
+		if len(opts.IncludePatterns) == 0 {
+			// All repositories.
+			return 1000, nil
+		}
+		return 10, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repoStore)
+
+	cases := []struct {
+		query       string
+		patternType query.SearchType
+		want        Cost
+	}{{
+		query:       "foo",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 1000, Units: 1000},
+	}, {
+		query:       "repo:sourcegraph foo",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10},
+	}, {
+		query:       "repo:sourcegraph fo+",
+		patternType: query.SearchTypeRegex,
+		want:        Cost{Repos: 10, Units: 20},
+	}, {
+		// Unindexed revisions are searched with searcher.
+		query:       "repo:sourcegraph@main:v1.0.0 foo",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10 * weightUnindexed * 2},
+	}, {
+		query:       "repo:sourcegraph@*refs/heads/* foo",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10 * weightUnindexed * weightRefGlob},
+	}, {
+		query:       "repo:sourcegraph type:diff foo",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10 * weightDiff},
+	}, {
+		query:       "repo:sourcegraph foo count:5000",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10 * 10},
+	}, {
+		query:       "repo:sourcegraph foo count:all",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 10, Units: 10 * maxLimitFactor},
+	}, {
+		query:       "repo:sourcegraph foo or repo:gitlab bar",
+		patternType: query.SearchTypeLiteral,
+		want:        Cost{Repos: 20, Units: 20},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, tc.patternType))
+			require.NoError(t, err)
+
+			inputs := &search.Inputs{
+				Plan:         plan,
+				Query:        plan.ToQ(),
+				UserSettings: &schema.Settings{},
+				PatternType:  tc.patternType,
+				Protocol:     search.Streaming,
+				Features:     &search.Features{},
+			}
+			j, err := jobutil.NewPlanJob(inputs, plan, jobutil.NewUnimplementedEnterpriseJobs())
+			require.NoError(t, err)
+
+			got, err := EstimateCost(context.Background(), db, inputs, j)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEstimateReposAppliesSearchDefaults(t *testing.T) {
+	var got database.ReposListOptions
+	repoStore := database.NewMockRepoStore()
+	repoStore.CountFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) (int, error) {
+		got = opts
+		return 1, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repoStore)
+
+	estimate := func(q string) {
+		t.Helper()
+		plan, err := query.Pipeline(query.Init(q, query.SearchTypeLiteral))
+		require.NoError(t, err)
+		_, err = estimateRepos(context.Background(), db, plan[0], &schema.Settings{})
+		require.NoError(t, err)
+	}
+
+	// Forks and archived repositories are excluded by default.
+	estimate("foo")
+	assert.True(t, got.NoForks)
+	assert.True(t, got.NoArchived)
+
+	// Unless a single repository is searched.
+	estimate("repo:^github\\.com/sourcegraph/sourcegraph$ foo")
+	assert.False(t, got.NoForks)
+	assert.False(t, got.NoArchived)
+
+	estimate("fork:only visibility:private foo")
+	assert.True(t, got.OnlyForks)
+	assert.True(t, got.OnlyPrivate)
+}
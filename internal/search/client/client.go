@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/grafana/regexp"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,9 +18,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/admission"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -56,6 +60,7 @@ func NewSearchClient(logger log.Logger, db database.DB, zoektStreamer zoekt.Stre
 		zoekt:          zoektStreamer,
 		searcherURLs:   searcherURLs,
 		enterpriseJobs: enterpriseJobs,
+		admission:      admission.NewController(redispool.Store),
 	}
 }
 
@@ -65,6 +70,7 @@ type searchClient struct {
 	zoekt          zoekt.Streamer
 	searcherURLs   *endpoint.Map
 	enterpriseJobs jobutil.EnterpriseJobs
+	admission      *admission.Controller
 }
 
 func (s *searchClient) Plan(
@@ -148,9 +154,33 @@ func (s *searchClient) Execute(
 		return nil, err
 	}
 
+	release, alert, err := s.admit(ctx, inputs, planJob)
+	if err != nil || alert != nil {
+		return alert, err
+	}
+	defer release()
+
 	return planJob.Run(ctx, s.JobClients(), stream)
 }
 
+// admit applies the search admission limits configured in the site configuration
+// to the planned job. If the search may not run, an alert explaining why is
+// returned.
+func (s *searchClient) admit(ctx context.Context, inputs *search.Inputs, planJob job.Job) (release func(), _ *search.Alert, err error) {
+	searchLimits := limits.SearchLimits(conf.Get())
+	if !admission.Enabled(searchLimits.Admission) || actor.FromContext(ctx).IsInternal() {
+		return func() {}, nil, nil
+	}
+
+	cost, err := admission.EstimateCost(ctx, s.db, inputs, planJob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxSearchDuration := time.Duration(searchLimits.MaxTimeoutSeconds) * time.Second
+	return s.admission.Admit(ctx, searchLimits.Admission, maxSearchDuration, cost)
+}
+
 func (s *searchClient) JobClients() job.RuntimeClients {
 	return job.RuntimeClients{
 		Logger:       s.logger,
@@ -13,6 +13,7 @@ import (
 
 type EnterpriseJobs interface {
 	FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job
+	FileHasInferredOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job
 	SelectFileOwnerJob(child job.Job, features *search.Features) job.Job
 }
 
@@ -26,6 +27,10 @@ func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Feature
 	return NewUnimplementedJob("`file:has.owner` searches are not available on this instance")
 }
 
+func (e *enterpriseJobs) FileHasInferredOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
+	return NewUnimplementedJob("`file:has.inferred.owner` searches are not available on this instance")
+}
+
 func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Features) job.Job {
 	return NewUnimplementedJob("`select:file.owners` searches are not available on this instance")
 }
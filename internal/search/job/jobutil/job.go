@@ -88,7 +88,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		resultTypes := computeResultTypes(b, inputs.PatternType)
 		fileMatchLimit := int32(computeFileMatchLimit(b, inputs.Protocol))
 		selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
-		repoOptions := toRepoOptions(b, inputs.UserSettings)
+		repoOptions := ToRepoOptions(b, inputs.UserSettings)
 		repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos := jobMode(b, repoOptions, resultTypes, inputs.PatternType, inputs.OnSourcegraphDotCom)
 
 		builder := &jobBuilder{
@@ -188,6 +188,9 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		if includeOwners, excludeOwners, ok := isOwnershipSearch(b); ok {
 			basicJob = enterpriseJobs.FileHasOwnerJob(basicJob, inputs.Features, includeOwners, excludeOwners)
 		}
+		if includeOwners, excludeOwners, ok := isInferredOwnershipSearch(b); ok {
+			basicJob = enterpriseJobs.FileHasInferredOwnerJob(basicJob, inputs.Features, includeOwners, excludeOwners)
+		}
 	}
 
 	{ // Apply subrepo permissions checks
@@ -307,7 +310,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	// searcher to use full deadline if timeout: set or we are streaming.
 	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming
 
-	repoOptions := toRepoOptions(f.ToBasic(), searchInputs.UserSettings)
+	repoOptions := ToRepoOptions(f.ToBasic(), searchInputs.UserSettings)
 
 	_, skipRepoSubsetSearch, _ := jobMode(f.ToBasic(), repoOptions, resultTypes, searchInputs.PatternType, searchInputs.OnSourcegraphDotCom)
 
@@ -522,6 +525,11 @@ func computeFileMatchLimit(b query.Basic, p search.Protocol) int {
 		// This is the int equivalent of count:all.
 		return query.CountAllLimit
 	}
+	if _, _, ok := isInferredOwnershipSearch(b); ok {
+		// Unlike CODEOWNERS rules, inferred owners are not shared by the files
+		// of a repository, so the search backends are not asked for all results.
+		return limits.MaxInferredOwnershipMatches
+	}
 	if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 		sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 		if isSelectOwnersSearch(sp) {
@@ -550,6 +558,13 @@ func isOwnershipSearch(b query.Basic) (include, exclude []string, ok bool) {
 	return nil, nil, false
 }
 
+func isInferredOwnershipSearch(b query.Basic) (include, exclude []string, ok bool) {
+	if includeOwners, excludeOwners := b.FileHasInferredOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+		return includeOwners, excludeOwners, true
+	}
+	return nil, nil, false
+}
+
 func isSelectOwnersSearch(sp filter.SelectPath) bool {
 	// If the filter is for file.owners, this is a select:file.owners search, and we should apply special limits.
 	return sp.Root() == filter.File && len(sp) == 2 && sp[1] == "owners"
@@ -677,7 +692,9 @@ func computeResultTypes(b query.Basic, searchType query.SearchType) result.Types
 	return rts
 }
 
-func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
+// ToRepoOptions returns the options to resolve the repositories searched by b,
+// applying the fork and archived defaults of the user settings.
+func ToRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
 	repoFilters, minusRepoFilters := b.Repositories()
 
 	var settingForks, settingArchived bool
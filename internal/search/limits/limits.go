@@ -12,6 +12,11 @@ const (
 	DefaultMaxSearchResults          = 30
 	DefaultMaxSearchResultsStreaming = 500
 
+	// MaxInferredOwnershipMatches is the number of files whose owners are
+	// inferred by a file:has.inferred.owner() search. Inferring the owners of a
+	// file queries the database for each of its signals.
+	MaxInferredOwnershipMatches = 1000
+
 	// The default timeout to use for queries.
 	DefaultTimeout = 20 * time.Second
 )
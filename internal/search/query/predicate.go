@@ -44,9 +44,10 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains": func() Predicate { return &RepoContainsPredicate{} },
 	},
 	FieldFile: {
-		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
-		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
-		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"contains.content":   func() Predicate { return &FileContainsContentPredicate{} },
+		"has.content":        func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":          func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.inferred.owner": func() Predicate { return &FileHasInferredOwnerPredicate{} },
 	},
 }
 
@@ -518,3 +519,19 @@ func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
 
 func (f FileHasOwnerPredicate) Field() string { return FieldFile }
 func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.inferred.owner(pattern) */
+
+type FileHasInferredOwnerPredicate struct {
+	Owner   string
+	Negated bool
+}
+
+func (f *FileHasInferredOwnerPredicate) Unmarshal(params string, negated bool) error {
+	f.Owner = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasInferredOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasInferredOwnerPredicate) Name() string  { return "has.inferred.owner" }
@@ -276,3 +276,45 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasInferredOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasInferredOwnerPredicate
+		}
+
+		valid := []test{
+			{`handle`, `@alice`, false, &FileHasInferredOwnerPredicate{Owner: "@alice"}},
+			{`email`, `alice@example.com`, false, &FileHasInferredOwnerPredicate{Owner: "alice@example.com"}},
+			{`negated`, `@alice`, true, &FileHasInferredOwnerPredicate{Owner: "@alice", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasInferredOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		plan, err := Pipeline(Init(`file:has.inferred.owner(@alice) -file:has.inferred.owner(bob@example.com) foo`, SearchTypeLiteral))
+		if err != nil {
+			t.Fatal(err)
+		}
+		include, exclude := plan[0].FileHasInferredOwner()
+		if !reflect.DeepEqual(include, []string{"@alice"}) || !reflect.DeepEqual(exclude, []string{"bob@example.com"}) {
+			t.Fatalf("unexpected include %v, exclude %v", include, exclude)
+		}
+	})
+}
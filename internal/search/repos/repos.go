@@ -122,7 +122,6 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		tr.Finish()
 	}()
 
-	excludePatterns := op.MinusRepoFilters
 	includePatterns, includePatternRevs := findPatternRevs(op.RepoFilters)
 
 	limit := op.Limit
@@ -135,60 +134,20 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		return Resolved{}, errs
 	}
 
-	kvpFilters := make([]database.RepoKVPFilter, 0, len(op.HasKVPs))
-	for _, filter := range op.HasKVPs {
-		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
-			Key:     filter.Key,
-			Value:   filter.Value,
-			Negated: filter.Negated,
-			KeyOnly: filter.KeyOnly,
-		})
-	}
-
-	topicFilters := make([]database.RepoTopicFilter, 0, len(op.HasTopics))
-	for _, filter := range op.HasTopics {
-		topicFilters = append(topicFilters, database.RepoTopicFilter{
-			Topic:   filter.Topic,
-			Negated: filter.Negated,
-		})
-	}
-
-	options := database.ReposListOptions{
-		IncludePatterns:       includePatterns,
-		ExcludePattern:        query.UnionRegExps(excludePatterns),
-		DescriptionPatterns:   op.DescriptionPatterns,
-		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
-		KVPFilters:            kvpFilters,
-		TopicFilters:          topicFilters,
-		Cursors:               op.Cursors,
-		// List N+1 repos so we can see if there are repos omitted due to our repo limit.
-		LimitOffset:  &database.LimitOffset{Limit: limit + 1},
-		NoForks:      op.NoForks,
-		OnlyForks:    op.OnlyForks,
-		NoArchived:   op.NoArchived,
-		OnlyArchived: op.OnlyArchived,
-		NoPrivate:    op.Visibility == query.Public,
-		OnlyPrivate:  op.Visibility == query.Private,
-		OnlyCloned:   op.OnlyCloned,
-		OrderBy: database.RepoListOrderBy{
-			{
-				Field:      database.RepoListStars,
-				Descending: true,
-				Nulls:      "LAST",
-			},
-			{
-				Field:      database.RepoListID,
-				Descending: true,
-			},
+	options := listOptions(op, includePatterns, searchContext)
+	options.Cursors = op.Cursors
+	// List N+1 repos so we can see if there are repos omitted due to our repo limit.
+	options.LimitOffset = &database.LimitOffset{Limit: limit + 1}
+	options.OrderBy = database.RepoListOrderBy{
+		{
+			Field:      database.RepoListStars,
+			Descending: true,
+			Nulls:      "LAST",
+		},
+		{
+			Field:      database.RepoListID,
+			Descending: true,
 		},
-	}
-
-	// Filter by search context repository revisions only if this search context doesn't have
-	// a query, which replaces the context:foo term at query parsing time.
-	if searchContext.Query == "" {
-		options.SearchContextID = searchContext.ID
-		options.UserID = searchContext.NamespaceUserID
-		options.OrgID = searchContext.NamespaceOrgID
 	}
 
 	tr.LazyPrintf("Repos.ListMinimalRepos - start")
@@ -285,6 +244,67 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	}, err
 }
 
+// listOptions returns the options to list the repositories matched by op within the
+// given search context.
+func listOptions(op search.RepoOptions, includePatterns []string, searchContext *types.SearchContext) database.ReposListOptions {
+	kvpFilters := make([]database.RepoKVPFilter, 0, len(op.HasKVPs))
+	for _, filter := range op.HasKVPs {
+		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
+			Key:     filter.Key,
+			Value:   filter.Value,
+			Negated: filter.Negated,
+			KeyOnly: filter.KeyOnly,
+		})
+	}
+
+	topicFilters := make([]database.RepoTopicFilter, 0, len(op.HasTopics))
+	for _, filter := range op.HasTopics {
+		topicFilters = append(topicFilters, database.RepoTopicFilter{
+			Topic:   filter.Topic,
+			Negated: filter.Negated,
+		})
+	}
+
+	options := database.ReposListOptions{
+		IncludePatterns:       includePatterns,
+		ExcludePattern:        query.UnionRegExps(op.MinusRepoFilters),
+		DescriptionPatterns:   op.DescriptionPatterns,
+		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
+		KVPFilters:            kvpFilters,
+		TopicFilters:          topicFilters,
+		NoForks:               op.NoForks,
+		OnlyForks:             op.OnlyForks,
+		NoArchived:            op.NoArchived,
+		OnlyArchived:          op.OnlyArchived,
+		NoPrivate:             op.Visibility == query.Public,
+		OnlyPrivate:           op.Visibility == query.Private,
+		OnlyCloned:            op.OnlyCloned,
+	}
+
+	// Filter by search context repository revisions only if this search context doesn't have
+	// a query, which replaces the context:foo term at query parsing time.
+	if searchContext.Query == "" {
+		options.SearchContextID = searchContext.ID
+		options.UserID = searchContext.NamespaceUserID
+		options.OrgID = searchContext.NamespaceOrgID
+	}
+	return options
+}
+
+// Count returns the number of repositories matched by op, without resolving them.
+// Like Resolve, it applies the repository filters, the search context and the fork,
+// archived and visibility options of op. Filters that inspect the contents of
+// repositories, like repo:has.file and repo:has.commit.after, are not applied, so
+// the count is an upper bound of the repositories Resolve returns.
+func Count(ctx context.Context, db database.DB, op search.RepoOptions) (int, error) {
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, db, op.SearchContextSpec)
+	if err != nil {
+		return 0, err
+	}
+	includePatterns, _ := findPatternRevs(op.RepoFilters)
+	return db.Repos().Count(ctx, listOptions(op, includePatterns, searchContext))
+}
+
 // associateReposWithRevs re-associates revisions with the repositories fetched from the db
 func (r *Resolver) associateReposWithRevs(
 	repos []types.MinimalRepo,
@@ -0,0 +1,419 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// azureAPI is the subset of the Azure Blob Storage REST API used by azureStore.
+type azureAPI interface {
+	CreateContainer(ctx context.Context, container string) error
+	GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error)
+	StageBlock(ctx context.Context, container, name, blockID string, content []byte) error
+	CommitBlockList(ctx context.Context, container, name string, blockIDs []string) error
+	DeleteBlob(ctx context.Context, container, name string) error
+	ListBlobs(ctx context.Context, container, prefix, marker string) (*azureBlobList, error)
+}
+
+type azureBlobList struct {
+	Blobs      []azureBlob `xml:"Blobs>Blob"`
+	NextMarker string      `xml:"NextMarker"`
+}
+
+type azureBlob struct {
+	Name       string `xml:"Name"`
+	Properties struct {
+		CreationTime azureTime `xml:"Creation-Time"`
+		LastModified azureTime `xml:"Last-Modified"`
+	} `xml:"Properties"`
+}
+
+// azureTime parses the RFC1123 timestamps returned by the Blob service.
+type azureTime struct{ time.Time }
+
+func (t *azureTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(http.TimeFormat, s)
+	if err != nil {
+		return err
+	}
+
+	t.Time = parsed
+	return nil
+}
+
+// azureAPIVersion is the version of the Blob service REST API targeted by the client.
+const azureAPIVersion = "2021-08-06"
+
+type azureAPIClient struct {
+	endpoint   *url.URL
+	authorizer azureAuthorizer
+	client     *http.Client
+}
+
+var _ azureAPI = &azureAPIClient{}
+
+func newAzureAPIClient(endpoint string, authorizer azureAuthorizer, client *http.Client) (*azureAPIClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure Blob Storage endpoint")
+	}
+
+	return &azureAPIClient{
+		endpoint:   u,
+		authorizer: authorizer,
+		client:     client,
+	}, nil
+}
+
+var errAzureContainerAlreadyExists = errors.New("container already exists")
+
+func (c *azureAPIClient) CreateContainer(ctx context.Context, container string) error {
+	resp, err := c.do(ctx, http.MethodPut, []string{container}, url.Values{"restype": {"container"}}, nil, nil)
+	if err != nil {
+		if isAzureErrorCode(err, "ContainerAlreadyExists") {
+			return errAzureContainerAlreadyExists
+		}
+
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (c *azureAPIClient) GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, []string{container, name}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (c *azureAPIClient) StageBlock(ctx context.Context, container, name, blockID string, content []byte) error {
+	query := url.Values{"comp": {"block"}, "blockid": {blockID}}
+
+	resp, err := c.do(ctx, http.MethodPut, []string{container, name}, query, nil, content)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (c *azureAPIClient) CommitBlockList(ctx context.Context, container, name string, blockIDs []string) error {
+	type blockList struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}
+
+	content, err := xml.Marshal(blockList{Latest: blockIDs})
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{"Content-Type": {"application/xml"}}
+	resp, err := c.do(ctx, http.MethodPut, []string{container, name}, url.Values{"comp": {"blocklist"}}, headers, append([]byte(xml.Header), content...))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (c *azureAPIClient) DeleteBlob(ctx context.Context, container, name string) error {
+	resp, err := c.do(ctx, http.MethodDelete, []string{container, name}, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (c *azureAPIClient) ListBlobs(ctx context.Context, container, prefix, marker string) (*azureBlobList, error) {
+	query := url.Values{"restype": {"container"}, "comp": {"list"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, []string{container}, query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var list azureBlobList
+	if err := xml.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, errors.Wrap(err, "failed to decode blob list")
+	}
+
+	return &list, nil
+}
+
+// azureError is returned for responses of the Blob service with a non-2xx status code.
+type azureError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *azureError) Error() string {
+	return fmt.Sprintf("azure blob storage: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func isAzureErrorCode(err error, code string) bool {
+	var e *azureError
+	return errors.As(err, &e) && e.Code == code
+}
+
+func (c *azureAPIClient) do(ctx context.Context, method string, path []string, query url.Values, headers http.Header, content []byte) (*http.Response, error) {
+	u := *c.endpoint
+	for _, segment := range path {
+		u = *u.JoinPath(segment)
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(content))
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	if err := c.authorizer.Authorize(ctx, req); err != nil {
+		return nil, errors.Wrap(err, "failed to authorize request")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		var body struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		}
+		_ = xml.NewDecoder(resp.Body).Decode(&body)
+		if body.Code == "" {
+			body.Code = resp.Header.Get("x-ms-error-code")
+		}
+
+		return nil, &azureError{StatusCode: resp.StatusCode, Code: body.Code, Message: body.Message}
+	}
+
+	return resp, nil
+}
+
+// azureAuthorizer adds credentials to a request to the Blob service.
+type azureAuthorizer interface {
+	Authorize(ctx context.Context, req *http.Request) error
+}
+
+// azureSharedKeyAuthorizer signs requests with a storage account access key.
+// See https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+type azureSharedKeyAuthorizer struct {
+	accountName string
+	accountKey  []byte
+}
+
+func newAzureSharedKeyAuthorizer(accountName, accountKey string) (*azureSharedKeyAuthorizer, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure storage account key")
+	}
+
+	return &azureSharedKeyAuthorizer{accountName: accountName, accountKey: key}, nil
+}
+
+func (a *azureSharedKeyAuthorizer) Authorize(_ context.Context, req *http.Request) error {
+	mac := hmac.New(sha256.New, a.accountKey)
+	mac.Write([]byte(a.stringToSign(req)))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", a.accountName, signature))
+	return nil
+}
+
+func (a *azureSharedKeyAuthorizer) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date is sent as x-ms-date instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		a.canonicalizedHeaders(req) + a.canonicalizedResource(req),
+	}, "\n")
+}
+
+func (a *azureSharedKeyAuthorizer) canonicalizedHeaders(req *http.Request) string {
+	var names []string
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteString(":")
+		b.WriteString(strings.TrimSpace(req.Header.Get(name)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (a *azureSharedKeyAuthorizer) canonicalizedResource(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString("/")
+	b.WriteString(a.accountName)
+	b.WriteString(req.URL.EscapedPath())
+
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+
+		b.WriteString("\n")
+		b.WriteString(strings.ToLower(name))
+		b.WriteString(":")
+		b.WriteString(strings.Join(values, ","))
+	}
+
+	return b.String()
+}
+
+// azureManagedIdentityAuthorizer authorizes requests with an OAuth token for the managed
+// identity of the Azure VM or container the process runs in. Tokens are fetched from the
+// instance metadata service and cached until shortly before they expire.
+// See https://learn.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token.
+type azureManagedIdentityAuthorizer struct {
+	tokenEndpoint string
+	clientID      string
+	client        *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+const (
+	azureInstanceMetadataTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureStorageResource               = "https://storage.azure.com/"
+)
+
+func newAzureManagedIdentityAuthorizer(clientID string, client *http.Client) *azureManagedIdentityAuthorizer {
+	return &azureManagedIdentityAuthorizer{
+		tokenEndpoint: azureInstanceMetadataTokenEndpoint,
+		clientID:      clientID,
+		client:        client,
+	}
+}
+
+func (a *azureManagedIdentityAuthorizer) Authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.getToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *azureManagedIdentityAuthorizer) getToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Until(a.expiresAt) > 5*time.Minute {
+		return a.token, nil
+	}
+
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {azureStorageResource}}
+	if a.clientID != "" {
+		query.Set("client_id", a.clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.tokenEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request managed identity token")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", errors.Newf("failed to request managed identity token: %d %s", resp.StatusCode, string(body))
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", errors.Wrap(err, "failed to decode managed identity token")
+	}
+
+	expiresOn, err := strconv.ParseInt(payload.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse managed identity token expiry")
+	}
+
+	a.token = payload.AccessToken
+	a.expiresAt = time.Unix(expiresOn, 0)
+	return a.token, nil
+}
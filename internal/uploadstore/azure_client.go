@@ -0,0 +1,269 @@
+package uploadstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureStore struct {
+	container    string
+	manageBucket bool
+	client       azureAPI
+	blockSize    int
+	operations   *Operations
+}
+
+var _ Store = &azureStore{}
+
+type AzureConfig struct {
+	// AccountName is the name of the storage account containing the container.
+	AccountName string
+
+	// AccountKey is an access key of the storage account. If empty, requests are
+	// authorized with the managed identity of the host.
+	AccountKey string
+
+	// ManagedIdentityClientID selects a user-assigned managed identity. If empty,
+	// the system-assigned managed identity is used.
+	ManagedIdentityClientID string
+
+	// Endpoint overrides the default blob service endpoint of the storage account,
+	// e.g. for sovereign clouds or the Azurite emulator.
+	Endpoint string
+}
+
+// azureBlockSize is the size of the blocks staged when uploading or composing blobs.
+// Blobs are limited to 50,000 blocks, which allows for blobs up to ~780GiB.
+const azureBlockSize = 16 * 1024 * 1024
+
+// newAzureFromConfig creates a new store backed by Azure Blob Storage.
+func newAzureFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	authorizer, err := azureClientAuthorizer(config.Azure)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := config.Azure.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.Azure.AccountName)
+	}
+
+	client, err := newAzureAPIClient(endpoint, authorizer, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAzureWithClient(client, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newAzureWithClient(client azureAPI, container string, manageBucket bool, operations *Operations) *azureStore {
+	return &azureStore{
+		container:    container,
+		manageBucket: manageBucket,
+		client:       client,
+		blockSize:    azureBlockSize,
+		operations:   operations,
+	}
+}
+
+func (s *azureStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	if err := s.client.CreateContainer(ctx, s.container); err != nil && err != errAzureContainerAlreadyExists {
+		return errors.Wrap(err, "failed to create container")
+	}
+
+	return nil
+}
+
+func (s *azureStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rc, err := s.client.GetBlob(ctx, s.container, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return rc, nil
+}
+
+func (s *azureStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var blockIDs []string
+	n, err := s.stageBlocks(ctx, key, r, &blockIDs)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	if err := s.client.CommitBlockList(ctx, s.container, key, blockIDs); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+// Compose stages the content of each source object as blocks of the destination blob and
+// commits them in order. Blocks are staged from the content of the sources as the Blob
+// service only supports server-side block copies from publicly readable or pre-signed
+// sources.
+func (s *azureStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(ctx, sources); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	var (
+		total    int64
+		blockIDs []string
+	)
+	for _, source := range sources {
+		n, err := func() (int64, error) {
+			rc, err := s.client.GetBlob(ctx, s.container, source)
+			if err != nil {
+				return 0, err
+			}
+			defer rc.Close()
+
+			return s.stageBlocks(ctx, destination, rc, &blockIDs)
+		}()
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to compose objects")
+		}
+
+		total += n
+	}
+
+	// Uncommitted blocks are discarded by the Blob service after a week, so there is
+	// nothing to clean up if committing fails.
+	if err := s.client.CommitBlockList(ctx, s.container, destination, blockIDs); err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return total, nil
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.client.DeleteBlob(ctx, s.container, key), "failed to delete object")
+}
+
+func (s *azureStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marker := ""
+	for {
+		list, err := s.client.ListBlobs(ctx, s.container, prefix, marker)
+		if err != nil {
+			s.operations.ExpireObjects.Logger.Error("Failed to list Azure container", sglog.Error(err))
+			break // we'll try again later
+		}
+
+		for _, blob := range list.Blobs {
+			if time.Since(blob.Properties.CreationTime.Time) >= maxAge {
+				if err := s.client.DeleteBlob(ctx, s.container, blob.Name); err != nil {
+					s.operations.ExpireObjects.Logger.Error("Failed to delete expired Azure blob",
+						sglog.Error(err),
+						sglog.String("container", s.container),
+						sglog.String("blob", blob.Name))
+					continue
+				}
+			}
+		}
+
+		if list.NextMarker == "" {
+			break
+		}
+		marker = list.NextMarker
+	}
+
+	return nil
+}
+
+// stageBlocks reads the given reader in chunks of the store's block size and stages each
+// chunk as an uncommitted block of the given blob. The identifiers of the staged blocks
+// are appended to the given slice in order. The number of bytes read is returned.
+func (s *azureStore) stageBlocks(ctx context.Context, key string, r io.Reader, blockIDs *[]string) (int64, error) {
+	var (
+		total int64
+		buf   = make([]byte, s.blockSize)
+	)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			// All block identifiers of a blob must have the same length
+			blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%010d", len(*blockIDs))))
+			if err := s.client.StageBlock(ctx, s.container, key, blockID, buf[:n]); err != nil {
+				return 0, err
+			}
+
+			*blockIDs = append(*blockIDs, blockID)
+			total += int64(n)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return total, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (s *azureStore) deleteSources(ctx context.Context, sources []string) error {
+	return goroutine.RunWorkersOverStrings(sources, func(index int, source string) error {
+		if err := s.client.DeleteBlob(ctx, s.container, source); err != nil {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
+
+func azureClientAuthorizer(config AzureConfig) (azureAuthorizer, error) {
+	if config.AccountKey != "" {
+		return newAzureSharedKeyAuthorizer(config.AccountName, config.AccountKey)
+	}
+
+	return newAzureManagedIdentityAuthorizer(config.ManagedIdentityClientID, http.DefaultClient), nil
+}
@@ -0,0 +1,376 @@
+package uploadstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestAzureInit(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, true)
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if _, ok := server.containers["test-container"]; !ok {
+		t.Fatalf("expected container to be created")
+	}
+
+	// Creating an existing container is not an error
+	if err := testAzureClient(t, server, true).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+}
+
+func TestAzureUnmanagedInit(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, false)
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if _, ok := server.containers["test-container"]; ok {
+		t.Fatalf("unexpected container creation")
+	}
+}
+
+func TestAzureUploadAndGet(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, true)
+
+	// Payload spans multiple blocks
+	payload := strings.Repeat("TEST PAYLOAD ", 10)
+
+	size, err := client.Upload(context.Background(), "test/key", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if size != int64(len(payload)) {
+		t.Errorf("unexpected size. want=%d have=%d", len(payload), size)
+	}
+
+	rc, err := client.Get(context.Background(), "test/key")
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(contents) != payload {
+		t.Fatalf("unexpected contents. want=%s have=%s", payload, contents)
+	}
+
+	if _, err := client.Get(context.Background(), "test/missing"); err == nil {
+		t.Fatalf("expected error getting missing object")
+	}
+}
+
+func TestAzureCompose(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, true)
+
+	sources := []string{"test-src1", "test-src2", "test-src3"}
+	for i, source := range sources {
+		if _, err := client.Upload(context.Background(), source, strings.NewReader(strings.Repeat(fmt.Sprintf("%d", i+1), 10*(i+1)))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-dest", sources...)
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 60 {
+		t.Errorf("unexpected size. want=%d have=%d", 60, size)
+	}
+
+	expected := strings.Repeat("1", 10) + strings.Repeat("2", 20) + strings.Repeat("3", 30)
+	if have := string(server.containers["test-container"]["test-dest"].content); have != expected {
+		t.Errorf("unexpected composed contents. want=%s have=%s", expected, have)
+	}
+	if diff := cmp.Diff([]string{"test-dest"}, server.blobNames("test-container")); diff != "" {
+		t.Errorf("unexpected blobs after compose (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureDelete(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, true)
+
+	if _, err := client.Upload(context.Background(), "test-key", strings.NewReader("TEST PAYLOAD")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+
+	if blobs := server.blobNames("test-container"); len(blobs) != 0 {
+		t.Errorf("unexpected blobs after delete: %v", blobs)
+	}
+}
+
+func TestAzureExpireObjects(t *testing.T) {
+	server := newFakeAzureBlobServer(t)
+	client := testAzureClient(t, server, true)
+
+	for _, key := range []string{"uploads/a", "uploads/b", "uploads/c", "uploads/d", "other/a"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"uploads/a", "uploads/c", "uploads/d", "other/a"} {
+		server.containers["test-container"][key].created = old
+	}
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"other/a", "uploads/b"}, server.blobNames("test-container")); diff != "" {
+		t.Errorf("unexpected blobs after expiry (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureManagedIdentityAuthorizer(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("Metadata") != "true" {
+			t.Errorf("expected metadata header")
+		}
+		if have := r.URL.Query().Get("client_id"); have != "test-client-id" {
+			t.Errorf("unexpected client id. want=%s have=%s", "test-client-id", have)
+		}
+
+		fmt.Fprintf(w, `{"access_token": "test-token-%d", "expires_on": "%d"}`, requests, time.Now().Add(time.Hour).Unix())
+	}))
+	t.Cleanup(server.Close)
+
+	authorizer := newAzureManagedIdentityAuthorizer("test-client-id", server.Client())
+	authorizer.tokenEndpoint = server.URL
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/container", nil)
+		if err := authorizer.Authorize(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error authorizing request: %s", err)
+		}
+
+		// Token is cached until it is about to expire
+		if have := req.Header.Get("Authorization"); have != "Bearer test-token-1" {
+			t.Errorf("unexpected authorization header. want=%s have=%s", "Bearer test-token-1", have)
+		}
+	}
+}
+
+func TestAzureSharedKeyStringToSign(t *testing.T) {
+	authorizer, err := newAzureSharedKeyAuthorizer("account", base64.StdEncoding.EncodeToString([]byte("key")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "https://account.blob.core.windows.net/container/some/blob?comp=block&blockid=MDAwMA%3D%3D", strings.NewReader("payload"))
+	req.Header.Set("x-ms-date", "Mon, 01 May 2023 00:00:00 GMT")
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	expected := strings.Join([]string{
+		"PUT",
+		"",
+		"",
+		"7",
+		"",
+		"",
+		"",
+		"",
+		"",
+		"",
+		"",
+		"",
+		"x-ms-date:Mon, 01 May 2023 00:00:00 GMT",
+		"x-ms-version:" + azureAPIVersion,
+		"/account/container/some/blob",
+		"blockid:MDAwMA==",
+		"comp:block",
+	}, "\n")
+	if diff := cmp.Diff(expected, authorizer.stringToSign(req)); diff != "" {
+		t.Errorf("unexpected string to sign (-want +got):\n%s", diff)
+	}
+}
+
+func testAzureClient(t *testing.T, server *fakeAzureBlobServer, manageBucket bool) Store {
+	authorizer, err := newAzureSharedKeyAuthorizer("account", base64.StdEncoding.EncodeToString([]byte("key")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := newAzureAPIClient(server.URL, authorizer, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := newAzureWithClient(api, "test-container", manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	store.blockSize = 16
+	return newLazyStore(store)
+}
+
+type fakeAzureBlob struct {
+	content []byte
+	created time.Time
+}
+
+// fakeAzureBlobServer implements the subset of the Blob service REST API used by
+// azureAPIClient over in-memory containers.
+type fakeAzureBlobServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	containers map[string]map[string]*fakeAzureBlob
+	blocks     map[string][]byte
+}
+
+func newFakeAzureBlobServer(t *testing.T) *fakeAzureBlobServer {
+	s := &fakeAzureBlobServer{
+		containers: map[string]map[string]*fakeAzureBlob{},
+		blocks:     map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Server.Close)
+	return s
+}
+
+func (s *fakeAzureBlobServer) blobNames(container string) (names []string) {
+	for name := range s.containers[container] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *fakeAzureBlobServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") || r.Header.Get("x-ms-version") == "" {
+		writeFakeAzureError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+
+	containerName, blobName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	container, containerExists := s.containers[containerName]
+
+	switch {
+	case r.Method == http.MethodPut && query.Get("restype") == "container":
+		if containerExists {
+			writeFakeAzureError(w, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+		s.containers[containerName] = map[string]*fakeAzureBlob{}
+		w.WriteHeader(http.StatusCreated)
+		return
+
+	case !containerExists:
+		writeFakeAzureError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+
+	case r.Method == http.MethodGet && query.Get("comp") == "list":
+		var names []string
+		for name := range container {
+			if strings.HasPrefix(name, query.Get("prefix")) && name > query.Get("marker") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		// Paginate aggressively to exercise markers
+		nextMarker := ""
+		if len(names) > 2 {
+			nextMarker = names[1]
+			names = names[:2]
+		}
+
+		var b strings.Builder
+		b.WriteString("<EnumerationResults><Blobs>")
+		for _, name := range names {
+			created := container[name].created.UTC().Format(http.TimeFormat)
+			fmt.Fprintf(&b, "<Blob><Name>%s</Name><Properties><Creation-Time>%s</Creation-Time><Last-Modified>%s</Last-Modified></Properties></Blob>", name, created, created)
+		}
+		fmt.Fprintf(&b, "</Blobs><NextMarker>%s</NextMarker></EnumerationResults>", nextMarker)
+		_, _ = io.WriteString(w, b.String())
+
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		content, _ := io.ReadAll(r.Body)
+		s.blocks[blobName+"/"+query.Get("blockid")] = content
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList struct {
+			Latest []string `xml:"Latest"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&blockList); err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+
+		var content []byte
+		for _, blockID := range blockList.Latest {
+			block, ok := s.blocks[blobName+"/"+blockID]
+			if !ok {
+				writeFakeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			content = append(content, block...)
+		}
+		for key := range s.blocks {
+			if strings.HasPrefix(key, blobName+"/") {
+				delete(s.blocks, key)
+			}
+		}
+
+		container[blobName] = &fakeAzureBlob{content: content, created: time.Now()}
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet:
+		blob, ok := container[blobName]
+		if !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		_, _ = w.Write(blob.content)
+
+	case r.Method == http.MethodDelete:
+		if _, ok := container[blobName]; !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(container, blobName)
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeFakeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+func writeFakeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
@@ -0,0 +1,294 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type filesystemStore struct {
+	root         string
+	manageBucket bool
+	operations   *Operations
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Root is the directory under which buckets are created. Each bucket is a
+	// directory named after the bucket within the root directory.
+	Root string
+}
+
+// tempFilePrefix is the prefix of files that are being written but have not yet
+// been moved into place. Such files are never visible as objects in the store.
+const tempFilePrefix = ".tmp-"
+
+// newFilesystemFromConfig creates a new store backed by a directory on the local disk.
+func newFilesystemFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Root == "" {
+		return nil, errors.New("no root directory configured for filesystem upload store")
+	}
+
+	return newFilesystemWithRoot(filepath.Join(config.Filesystem.Root, config.Bucket), config.ManageBucket, operations), nil
+}
+
+func newFilesystemWithRoot(root string, manageBucket bool, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		root:         root,
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if s.manageBucket {
+		if err := os.MkdirAll(s.root, os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to create bucket")
+		}
+
+		return nil
+	}
+
+	if info, err := os.Stat(s.root); err != nil {
+		return errors.Wrap(err, "failed to stat bucket")
+	} else if !info.IsDir() {
+		return errors.Newf("bucket %q is not a directory", s.root)
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := s.writeAtomically(path, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	destinationPath, err := s.path(destination)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePaths := make([]string, 0, len(sources))
+	for _, source := range sources {
+		sourcePath, err := s.path(source)
+		if err != nil {
+			return 0, err
+		}
+
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sourcePaths); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	n, err := s.writeAtomically(destinationPath, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, sourcePath := range sourcePaths {
+			n, err := copyFile(w, sourcePath)
+			total += n
+			if err != nil {
+				return total, err
+			}
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(os.Remove(path), "failed to delete object")
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		key, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+
+		// Partially written objects are expired along with regular objects so that
+		// interrupted uploads do not accumulate on disk.
+		name := entry.Name()
+		if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(name, tempFilePrefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if time.Since(info.ModTime()) >= maxAge {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				s.operations.ExpireObjects.Logger.Error("Failed to delete expired file",
+					sglog.Error(err),
+					sglog.String("root", s.root),
+					sglog.String("object", key))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		s.operations.ExpireObjects.Logger.Error("Failed to walk filesystem bucket", sglog.Error(err))
+		// we'll try again later
+	}
+
+	return nil
+}
+
+// path returns the absolute path of the file storing the object with the given key.
+// Keys that would resolve to a path outside of the bucket directory are rejected.
+func (s *filesystemStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if rel, err := filepath.Rel(s.root, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Newf("invalid key %q", key)
+	}
+
+	return path, nil
+}
+
+// writeAtomically invokes the given function with a temporary file in the same directory
+// as the given path. If the function succeeds, the temporary file is moved to the target
+// path so that readers never observe partially written objects.
+func (s *filesystemStore) writeAtomically(path string, fn func(w io.Writer) (int64, error)) (_ int64, err error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), tempFilePrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	n, err := fn(f)
+	if closeErr := f.Close(); closeErr != nil {
+		err = errors.Append(err, errors.Wrap(closeErr, "failed to close file"))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) deleteSources(sources []string) (err error) {
+	for _, source := range sources {
+		if removeErr := os.Remove(source); removeErr != nil {
+			err = errors.Append(err, errors.Wrap(removeErr, "failed to delete source object"))
+		}
+	}
+
+	return err
+}
+
+func copyFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
@@ -0,0 +1,169 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+
+	client := testFilesystemClient(root, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if info, err := os.Stat(root); err != nil {
+		t.Fatalf("unexpected error statting bucket: %s", err)
+	} else if !info.IsDir() {
+		t.Fatalf("expected bucket to be a directory")
+	}
+}
+
+func TestFilesystemUnmanagedInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+
+	client := testFilesystemClient(root, false)
+	if err := client.Init(context.Background()); err == nil {
+		t.Fatalf("expected error initializing client with missing bucket")
+	}
+
+	if err := os.Mkdir(root, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := testFilesystemClient(root, false).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+}
+
+func TestFilesystemUploadAndGet(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	size, err := client.Upload(context.Background(), "test/key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	rc, err := client.Get(context.Background(), "test/key")
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(contents) != "TEST PAYLOAD" {
+		t.Fatalf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, true)
+
+	for i, source := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := client.Upload(context.Background(), source, strings.NewReader(strings.Repeat("x", i+1))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-dest", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 6 {
+		t.Errorf("unexpected size. want=%d have=%d", 6, size)
+	}
+
+	if diff := cmp.Diff([]string{"test-dest"}, listFiles(t, root)); diff != "" {
+		t.Errorf("unexpected files after compose (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, true)
+
+	if _, err := client.Upload(context.Background(), "test-key", strings.NewReader("TEST PAYLOAD")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+
+	if files := listFiles(t, root); len(files) != 0 {
+		t.Errorf("unexpected files after delete: %v", files)
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, true)
+
+	for _, key := range []string{"uploads/old", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"uploads/old", "other/old"} {
+		if err := os.Chtimes(filepath.Join(root, key), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"other/old", "uploads/new"}, listFiles(t, root)); diff != "" {
+		t.Errorf("unexpected files after expiry (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilesystemInvalidKey(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	for _, key := range []string{"", "..", "../escape", "a/../../escape"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err == nil {
+			t.Errorf("expected error uploading object with key %q", key)
+		}
+	}
+}
+
+func testFilesystemClient(root string, manageBucket bool) Store {
+	return newLazyStore(newFilesystemWithRoot(root, manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore")))
+}
+
+func listFiles(t *testing.T, root string) (files []string) {
+	if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(files)
+	return files
+}
@@ -73,6 +73,7 @@ type OnQueryOrRepository struct {
 	Repository                string   `json:"repository,omitempty" yaml:"repository"`
 	Branch                    string   `json:"branch,omitempty" yaml:"branch"`
 	Branches                  []string `json:"branches,omitempty" yaml:"branches"`
+	If                        any      `json:"if,omitempty" yaml:"if,omitempty"`
 }
 
 var ErrConflictingBranches = NewValidationError(errors.New("both branch and branches specified"))
@@ -87,6 +88,12 @@ func (oqor *OnQueryOrRepository) GetBranches() ([]string, error) {
 	return oqor.Branches, nil
 }
 
+// IfCondition returns the condition a repository matched by the entry has to
+// fulfill to be included, or an empty string if there is none.
+func (oqor *OnQueryOrRepository) IfCondition() string {
+	return ifCondition(oqor.If)
+}
+
 type Step struct {
 	Run       string            `json:"run,omitempty" yaml:"run"`
 	Container string            `json:"container,omitempty" yaml:"container"`
@@ -98,7 +105,11 @@ type Step struct {
 }
 
 func (s *Step) IfCondition() string {
-	switch v := s.If.(type) {
+	return ifCondition(s.If)
+}
+
+func ifCondition(cond any) string {
+	switch v := cond.(type) {
 	case bool:
 		if v {
 			return "true"
@@ -209,7 +220,7 @@ func IsValidationError(err error) bool {
 }
 
 // SkippedStepsForRepo calculates the steps required to run on the given repo.
-func SkippedStepsForRepo(spec *BatchSpec, repoName string, fileMatches []string) (skipped map[int]struct{}, err error) {
+func SkippedStepsForRepo(spec *BatchSpec, repo template.Repository) (skipped map[int]struct{}, err error) {
 	skipped = map[int]struct{}{}
 
 	for idx, step := range spec.Steps {
@@ -226,10 +237,7 @@ func SkippedStepsForRepo(spec *BatchSpec, repoName string, fileMatches []string)
 		// We can at least optimize further here and do more static evaluation
 		// when we have a cached result for the previous step.
 		stepCtx := &template.StepContext{
-			Repository: template.Repository{
-				Name:        repoName,
-				FileMatches: fileMatches,
-			},
+			Repository:  repo,
 			BatchChange: batchChange,
 		}
 		static, boolVal, err := template.IsStaticBool(step.IfCondition(), stepCtx)
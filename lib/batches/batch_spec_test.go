@@ -8,6 +8,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 func TestParseBatchSpec(t *testing.T) {
@@ -348,11 +350,30 @@ func TestSkippedStepsForRepo(t *testing.T) {
 			},
 			wantSkipped: []int{},
 		},
+
+		"if expression on repository metadata": {
+			spec: &BatchSpec{
+				Steps: []Step{
+					{Run: "echo 1", If: `${{ not (has_key repository.key_value_pairs "frozen") }}`},
+					{Run: "echo 2", If: `${{ contains repository.topics "go" }}`},
+					{Run: "echo 3", If: `${{ contains repository.code_owners "@sourcegraph/code-intel" }}`},
+				},
+			},
+			wantSkipped: []int{0, 2},
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			haveSkipped, err := SkippedStepsForRepo(tt.spec, "github.com/sourcegraph/src-cli", []string{})
+			haveSkipped, err := SkippedStepsForRepo(tt.spec, template.Repository{
+				Name:        "github.com/sourcegraph/src-cli",
+				FileMatches: []string{},
+				Metadata: &template.RepositoryMetadata{
+					KeyValuePairs: map[string]string{"frozen": ""},
+					Topics:        []string{"cli", "go"},
+					CodeOwners:    []string{"@sourcegraph/batches"},
+				},
+			})
 			if err != nil {
 				t.Fatalf("unexpected err: %s", err)
 			}
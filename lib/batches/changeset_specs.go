@@ -22,6 +22,10 @@ type Repository struct {
 	BaseRef     string
 	BaseRev     string
 	FileMatches []string
+	// Metadata is the repository metadata that's available in templates. It's
+	// omitted when empty, so that the cache keys of repositories without
+	// metadata don't change.
+	Metadata *template.RepositoryMetadata `json:",omitempty"`
 }
 
 type ChangesetSpecInput struct {
@@ -52,6 +56,7 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 			Name:        input.Repository.Name,
 			Branch:      strings.TrimPrefix(input.Repository.BaseRef, "refs/heads/"),
 			FileMatches: input.Repository.FileMatches,
+			Metadata:    input.Repository.Metadata,
 		},
 	}
 
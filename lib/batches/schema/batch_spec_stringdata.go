@@ -38,6 +38,24 @@ const BatchSpecJSON = `{
                 "type": "string",
                 "description": "A Sourcegraph search query that matches a set of repositories (and branches). If the query matches files, symbols, or some other object inside a repository, the object's repository is included.",
                 "examples": ["file:README.md"]
+              },
+              "if": {
+                "oneOf": [
+                  {
+                    "type": "boolean"
+                  },
+                  {
+                    "type": "string"
+                  },
+                  {
+                    "type": "null"
+                  }
+                ],
+                "description": "A condition that a repository (and branch) matched by this entry must fulfill to be included. Supports templating with the repository and batch change fields. The value 'true' is interpreted as true.",
+                "examples": [
+                  "${{ not (has_key repository.key_value_pairs \"frozen\") }}",
+                  "${{ contains repository.topics \"go\" }}"
+                ]
               }
             }
           },
@@ -63,6 +81,24 @@ const BatchSpecJSON = `{
                 "items": {
                   "type": "string"
                 }
+              },
+              "if": {
+                "oneOf": [
+                  {
+                    "type": "boolean"
+                  },
+                  {
+                    "type": "string"
+                  },
+                  {
+                    "type": "null"
+                  }
+                ],
+                "description": "A condition that a repository (and branch) matched by this entry must fulfill to be included. Supports templating with the repository and batch change fields. The value 'true' is interpreted as true.",
+                "examples": [
+                  "${{ not (has_key repository.key_value_pairs \"frozen\") }}",
+                  "${{ contains repository.topics \"go\" }}"
+                ]
               }
             },
             "$comment": "This is a convoluted way of saying either ` + "`" + `branch` + "`" + ` or ` + "`" + `branches` + "`" + ` can be provided, but not both at once, and neither are required.",
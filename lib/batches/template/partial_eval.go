@@ -28,18 +28,42 @@ func IsStaticBool(input string, ctx *StepContext) (isStatic bool, boolVal bool,
 		return false, false, err
 	}
 
-	isStatic = true
+	if !isStaticTemplate(t) {
+		return false, false, nil
+	}
+
+	return true, isTrueOutput(t.Tree.Root), nil
+}
+
+// IsStaticString parses the input as a text/template and attempts to evaluate
+// it with only the ahead-of-execution information available in StepContext,
+// like IsStaticBool does.
+//
+// If only text is left after evaluation, the first return value is true and
+// the second return value is the text. Otherwise the template is not "static"
+// and the first return value is false.
+func IsStaticString(input string, ctx *StepContext) (isStatic bool, val string, err error) {
+	t, err := parseAndPartialEval(input, ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !isStaticTemplate(t) {
+		return false, "", nil
+	}
+
+	return true, t.Tree.Root.String(), nil
+}
+
+// isStaticTemplate returns true if the partially evaluated template only
+// consists of text.
+func isStaticTemplate(t *template.Template) bool {
 	for _, n := range t.Tree.Root.Nodes {
 		if n.Type() != parse.NodeText {
-			isStatic = false
-			break
+			return false
 		}
 	}
-	if !isStatic {
-		return isStatic, false, nil
-	}
-
-	return true, isTrueOutput(t.Tree.Root), nil
+	return true
 }
 
 // parseAndPartialEval parses input as a text/template and then attempts to
@@ -180,33 +204,64 @@ func evalNode(ctx *StepContext, n parse.Node) (reflect.Value, bool) {
 		return reflect.ValueOf(n.Text), true
 
 	case *parse.ChainNode:
-		// For now we only support fields that are 1 level deep (see below).
-		// Should we ever want to support more than one level, we need to
-		// revise this.
+		ident, ok := n.Node.(*parse.IdentifierNode)
+		if !ok {
+			return noValue, false
+		}
+
+		// The only field that is 2 levels deep is a key of the repository
+		// key-value pairs. If the key doesn't exist we abort, since the
+		// template would fail to execute.
+		if len(n.Field) == 2 && ident.Ident == "repository" && n.Field[0] == "key_value_pairs" {
+			if ctx.Repository.Metadata == nil {
+				return noValue, false
+			}
+			v, ok := ctx.Repository.KeyValuePairs()[n.Field[1]]
+			if !ok {
+				return noValue, false
+			}
+			return reflect.ValueOf(v), true
+		}
+
+		// Other than that we only support fields that are 1 level deep (see
+		// below). Should we ever want to support more than one level, we need
+		// to revise this.
 		if len(n.Field) != 1 {
 			return noValue, false
 		}
 
-		if ident, ok := n.Node.(*parse.IdentifierNode); ok {
-			switch ident.Ident {
-			case "repository":
-				switch n.Field[0] {
-				case "search_result_paths":
-					// TODO: We don't eval search_result_paths for now, since it's a
-					// "complex" value, a slice of strings, and turning that
-					// into text might not be useful to the user. So we abort.
-					return noValue, false
-				case "name":
-					return reflect.ValueOf(ctx.Repository.Name), true
-				}
-
-			case "batch_change":
-				switch n.Field[0] {
-				case "name":
-					return reflect.ValueOf(ctx.BatchChange.Name), true
-				case "description":
-					return reflect.ValueOf(ctx.BatchChange.Description), true
-				}
+		switch ident.Ident {
+		case "repository":
+			switch n.Field[0] {
+			case "search_result_paths":
+				// TODO: We don't eval search_result_paths for now, since it's a
+				// "complex" value, a slice of strings, and turning that
+				// into text might not be useful to the user. So we abort.
+				return noValue, false
+			case "name":
+				return reflect.ValueOf(ctx.Repository.Name), true
+			}
+
+			// The metadata can only be evaluated if it has been loaded.
+			// Otherwise we'd evaluate conditions against empty values.
+			if ctx.Repository.Metadata == nil && !ctx.Repository.MetadataLoaded {
+				return noValue, false
+			}
+			switch n.Field[0] {
+			case "key_value_pairs":
+				return reflect.ValueOf(ctx.Repository.KeyValuePairs()), true
+			case "topics":
+				return reflect.ValueOf(ctx.Repository.Topics()), true
+			case "code_owners":
+				return reflect.ValueOf(ctx.Repository.CodeOwners()), true
+			}
+
+		case "batch_change":
+			switch n.Field[0] {
+			case "name":
+				return reflect.ValueOf(ctx.BatchChange.Name), true
+			case "description":
+				return reflect.ValueOf(ctx.BatchChange.Description), true
 			}
 		}
 		return noValue, false
@@ -248,6 +303,9 @@ func evalFunction(ctx *StepContext, name string, args []parse.Node) (val reflect
 	case "not":
 		return evalNotCall(ctx, args[1:])
 
+	case "index":
+		return evalIndexCall(ctx, args[1:])
+
 	default:
 		concreteFn, ok := builtins[name]
 		if !ok {
@@ -290,6 +348,28 @@ func evalNotCall(ctx *StepContext, args []parse.Node) (reflect.Value, bool) {
 	return reflect.ValueOf(!isTrue(arg)), true
 }
 
+func evalIndexCall(ctx *StepContext, args []parse.Node) (reflect.Value, bool) {
+	// We only support indexing maps with a single key for now:
+	if len(args) != 2 {
+		return noValue, false
+	}
+
+	item, ok := evalNode(ctx, args[0])
+	if !ok || item.Kind() != reflect.Map {
+		return noValue, false
+	}
+	key, ok := evalNode(ctx, args[1])
+	if !ok || !key.Type().AssignableTo(item.Type().Key()) {
+		return noValue, false
+	}
+
+	// Just like text/template, return the zero value for missing keys.
+	if v := item.MapIndex(key); v.IsValid() {
+		return v, true
+	}
+	return reflect.Zero(item.Type().Elem()), true
+}
+
 func evalEqCall(ctx *StepContext, args []parse.Node) (reflect.Value, bool) {
 	// We only support 2 args for now:
 	if len(args) != 2 {
@@ -291,3 +291,160 @@ func TestIsStaticBool(t *testing.T) {
 		})
 	}
 }
+
+func TestIsStaticString(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     string
+		wantIsStatic bool
+		wantVal      string
+	}{
+		{
+			name:         "text",
+			template:     "# Hello\n",
+			wantIsStatic: true,
+			wantVal:      "# Hello\n",
+		},
+		{
+			name:         "static values",
+			template:     `name: ${{ repository.name }} (${{ batch_change.name }})`,
+			wantIsStatic: true,
+			wantVal:      "name: github.com/sourcegraph/src-cli (test-batch-change)",
+		},
+		{
+			name:         "runtime value",
+			template:     `name: ${{ repository.name }} ${{ outputs.foo }}`,
+			wantIsStatic: false,
+			wantVal:      "",
+		},
+		{
+			name:         "previous step",
+			template:     `${{ previous_step.stdout }}`,
+			wantIsStatic: false,
+			wantVal:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isStatic, val, err := IsStaticString(tt.template, partialEvalStepCtx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if isStatic != tt.wantIsStatic {
+				t.Fatalf("wrong isStatic value. want=%t, got=%t", tt.wantIsStatic, isStatic)
+			}
+			if val != tt.wantVal {
+				t.Fatalf("wrong val value. want=%q, got=%q", tt.wantVal, val)
+			}
+		})
+	}
+}
+
+func TestIsStaticBool_RepositoryMetadata(t *testing.T) {
+	withMetadata := &StepContext{
+		Repository: Repository{
+			Name: "github.com/sourcegraph/src-cli",
+			Metadata: &RepositoryMetadata{
+				KeyValuePairs: map[string]string{"owner": "batches", "frozen": ""},
+				Topics:        []string{"cli", "go"},
+				CodeOwners:    []string{"@sourcegraph/batches"},
+			},
+		},
+	}
+	withoutMetadata := &StepContext{
+		Repository: Repository{Name: "github.com/sourcegraph/src-cli"},
+	}
+	withEmptyMetadata := &StepContext{
+		Repository: Repository{Name: "github.com/sourcegraph/src-cli", MetadataLoaded: true},
+	}
+
+	tests := []struct {
+		name         string
+		ctx          *StepContext
+		template     string
+		wantIsStatic bool
+		wantBoolVal  bool
+	}{
+		{
+			name:         "has_key true",
+			ctx:          withMetadata,
+			template:     `${{ has_key repository.key_value_pairs "frozen" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  true,
+		},
+		{
+			name:         "negated has_key",
+			ctx:          withMetadata,
+			template:     `${{ not (has_key repository.key_value_pairs "frozen") }}`,
+			wantIsStatic: true,
+			wantBoolVal:  false,
+		},
+		{
+			name:         "key value pair field",
+			ctx:          withMetadata,
+			template:     `${{ eq repository.key_value_pairs.owner "batches" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  true,
+		},
+		{
+			name:         "missing key value pair field",
+			ctx:          withMetadata,
+			template:     `${{ eq repository.key_value_pairs.unknown "batches" }}`,
+			wantIsStatic: false,
+			wantBoolVal:  false,
+		},
+		{
+			name:         "index of missing key",
+			ctx:          withMetadata,
+			template:     `${{ eq (index repository.key_value_pairs "unknown") "" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  true,
+		},
+		{
+			name:         "contains topic",
+			ctx:          withMetadata,
+			template:     `${{ contains repository.topics "go" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  true,
+		},
+		{
+			name:         "contains code owner",
+			ctx:          withMetadata,
+			template:     `${{ contains repository.code_owners "@sourcegraph/code-intel" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  false,
+		},
+		{
+			name:         "no metadata",
+			ctx:          withEmptyMetadata,
+			template:     `${{ has_key repository.key_value_pairs "frozen" }}`,
+			wantIsStatic: true,
+			wantBoolVal:  false,
+		},
+		{
+			name:         "metadata not loaded",
+			ctx:          withoutMetadata,
+			template:     `${{ has_key repository.key_value_pairs "frozen" }}`,
+			wantIsStatic: false,
+			wantBoolVal:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isStatic, boolVal, err := IsStaticBool(tt.template, tt.ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if isStatic != tt.wantIsStatic {
+				t.Fatalf("wrong isStatic value. want=%t, got=%t", tt.wantIsStatic, isStatic)
+			}
+			if boolVal != tt.wantBoolVal {
+				t.Fatalf("wrong boolVal value. want=%t, got=%t", tt.wantBoolVal, boolVal)
+			}
+		})
+	}
+}
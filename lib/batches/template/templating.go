@@ -39,6 +39,18 @@ var builtins = template.FuncMap{
 		}
 		return g.Match(in), nil
 	},
+	"has_key": func(m map[string]string, key string) bool {
+		_, ok := m[key]
+		return ok
+	},
+	"contains": func(list []string, elem string) bool {
+		for _, e := range list {
+			if e == elem {
+				return true
+			}
+		}
+		return false
+	},
 }
 
 // ValidateBatchSpecTemplate attempts to perform a dry run replacement of the whole batch
@@ -67,6 +79,11 @@ func ValidateBatchSpecTemplate(spec string) (bool, error) {
 	indexRe := regexp.MustCompile(`(?i)\$\{\{\s*index\s*[^}]*\}\}`)
 	spec = indexRe.ReplaceAllString(spec, "")
 
+	// And strip direct references to repository key-value pairs, since which keys
+	// exist differs from repository to repository.
+	kvpRe := regexp.MustCompile(`(?i)\$\{\{\s*[^}]*\s*repository\.key_value_pairs\.[^}]*\}\}`)
+	spec = kvpRe.ReplaceAllString(spec, "")
+
 	// By default, text/template will continue even if it encounters a key that is not
 	// indexed in any of the provided `FuncMap`s. A missing key is an indication of an
 	// unknown or mistyped template variable which would invalidate the batch spec, so we
@@ -161,6 +178,60 @@ type Repository struct {
 	Name        string
 	Branch      string
 	FileMatches []string
+	// Metadata is the Sourcegraph metadata of the repository. It's nil if no
+	// metadata was loaded for the repository, or if the repository has none.
+	Metadata *RepositoryMetadata
+	// MetadataLoaded is true if the metadata of the repository was loaded, so
+	// that a nil Metadata means that the repository has no metadata.
+	MetadataLoaded bool `json:"-"`
+}
+
+// RepositoryMetadata is the metadata of a repository that Sourcegraph knows
+// about and that can be referenced in templates.
+type RepositoryMetadata struct {
+	// KeyValuePairs are the key-value pairs set on the repository. Keys that
+	// are set without a value map to the empty string.
+	KeyValuePairs map[string]string `json:"keyValuePairs,omitempty"`
+	// Topics are the topics of the repository on the code host.
+	Topics []string `json:"topics,omitempty"`
+	// CodeOwners are the owners of the search result paths, or the default
+	// owners of the repository if there are none, according to its CODEOWNERS
+	// file.
+	CodeOwners []string `json:"codeOwners,omitempty"`
+}
+
+func (r Repository) KeyValuePairs() map[string]string {
+	if r.Metadata == nil || r.Metadata.KeyValuePairs == nil {
+		return map[string]string{}
+	}
+	return r.Metadata.KeyValuePairs
+}
+
+func (r Repository) Topics() []string {
+	if r.Metadata == nil || r.Metadata.Topics == nil {
+		return []string{}
+	}
+	return r.Metadata.Topics
+}
+
+func (r Repository) CodeOwners() []string {
+	if r.Metadata == nil || r.Metadata.CodeOwners == nil {
+		return []string{}
+	}
+	return r.Metadata.CodeOwners
+}
+
+// toFuncMapValue returns the value of the "repository" key in the template
+// FuncMaps.
+func (r Repository) toFuncMapValue() map[string]any {
+	return map[string]any{
+		"search_result_paths": r.SearchResultPaths(),
+		"name":                r.Name,
+		"branch":              r.Branch,
+		"key_value_pairs":     r.KeyValuePairs(),
+		"topics":              r.Topics(),
+		"code_owners":         r.CodeOwners(),
+	}
 }
 
 func (r Repository) SearchResultPaths() (list fileMatchPathList) {
@@ -234,11 +305,7 @@ func (stepCtx *StepContext) ToFuncMap() template.FuncMap {
 			return stepCtx.Outputs
 		},
 		"repository": func() map[string]any {
-			return map[string]any{
-				"search_result_paths": stepCtx.Repository.SearchResultPaths(),
-				"name":                stepCtx.Repository.Name,
-				"branch":              stepCtx.Repository.Branch,
-			}
+			return stepCtx.Repository.toFuncMapValue()
 		},
 		"batch_change": func() map[string]any {
 			return map[string]any{
@@ -279,11 +346,7 @@ type ChangesetTemplateContext struct {
 func (tmplCtx *ChangesetTemplateContext) ToFuncMap() template.FuncMap {
 	return template.FuncMap{
 		"repository": func() map[string]any {
-			return map[string]any{
-				"search_result_paths": tmplCtx.Repository.SearchResultPaths(),
-				"name":                tmplCtx.Repository.Name,
-				"branch":              tmplCtx.Repository.Branch,
-			}
+			return tmplCtx.Repository.toFuncMapValue()
 		},
 		"batch_change": func() map[string]any {
 			return map[string]any{
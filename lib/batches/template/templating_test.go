@@ -81,6 +81,15 @@ func TestValidateBatchSpecTemplate(t *testing.T) {
 				${{ index steps.modified_files 1 }}`,
 			wantValid: true,
 		},
+		{
+			name: "valid repository metadata",
+			batchSpec: `${{ repository.key_value_pairs.owner }}
+				${{ index repository.key_value_pairs "owner" }}
+				${{ has_key repository.key_value_pairs "frozen" }}
+				${{ join repository.topics "," }}
+				${{ contains repository.code_owners "@sourcegraph/batches" }}`,
+			wantValid: true,
+		},
 		{
 			name:      "invalid step template variable",
 			batchSpec: `${{ resipotory.search_result_paths }}`,
@@ -301,6 +310,35 @@ this is current step's stderr
 [.DS_Store]
 [new-filename.txt]
 sub/directory/of/repo
+`,
+		},
+		{
+			name: "repository metadata",
+			stepCtx: &StepContext{
+				Repository: Repository{
+					Name: "github.com/sourcegraph/src-cli",
+					Metadata: &RepositoryMetadata{
+						KeyValuePairs: map[string]string{"owner": "batches", "frozen": ""},
+						Topics:        []string{"cli", "go"},
+						CodeOwners:    []string{"@sourcegraph/batches", "alice@sourcegraph.com"},
+					},
+				},
+			},
+			run: `${{ repository.key_value_pairs.owner }}
+${{ index repository.key_value_pairs "unknown" }}
+${{ has_key repository.key_value_pairs "frozen" }}
+${{ has_key repository.key_value_pairs "unknown" }}
+${{ repository.topics }}
+${{ contains repository.topics "go" }}
+${{ join repository.code_owners "," }}
+`,
+			want: `batches
+
+true
+false
+[cli go]
+true
+@sourcegraph/batches,alice@sourcegraph.com
 `,
 		},
 		{
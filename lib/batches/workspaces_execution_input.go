@@ -13,6 +13,9 @@ type WorkspacesExecutionInput struct {
 	OnlyFetchWorkspace    bool            `json:"onlyFetchWorkspace"`
 	Steps                 []Step          `json:"steps"`
 	SearchResultPaths     []string        `json:"searchResultPaths"`
+	// RepositoryMetadata is the metadata of the repository that's available
+	// in templates. Nil if no metadata was loaded.
+	RepositoryMetadata *template.RepositoryMetadata `json:"repositoryMetadata,omitempty"`
 	// CachedStepResultFound is only required for V1 executions.
 	// TODO: Remove me once V2 is the only execution format.
 	CachedStepResultFound bool `json:"cachedStepResultFound"`
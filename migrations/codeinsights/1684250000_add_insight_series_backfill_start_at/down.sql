@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS insight_series
+	DROP COLUMN IF EXISTS backfill_start_at;
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS insight_series
+	ADD COLUMN IF NOT EXISTS backfill_start_at TIMESTAMP WITHOUT TIME ZONE;
+
+COMMENT ON COLUMN insight_series.backfill_start_at IS 'Timestamp of the first point to backfill for this series. When null the series is backfilled over a fixed number of points from its creation time.';
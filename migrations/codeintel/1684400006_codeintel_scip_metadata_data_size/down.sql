@@ -0,0 +1 @@
+ALTER TABLE codeintel_scip_metadata DROP COLUMN IF EXISTS data_size;
@@ -0,0 +1,3 @@
+ALTER TABLE codeintel_scip_metadata ADD COLUMN IF NOT EXISTS data_size bigint;
+
+COMMENT ON COLUMN codeintel_scip_metadata.data_size IS 'The approximate number of bytes the data of this SCIP index occupies in the codeintel-db, counting document payloads, symbol names and symbol ranges. Null for indexes written before the size was tracked until it is backfilled.';
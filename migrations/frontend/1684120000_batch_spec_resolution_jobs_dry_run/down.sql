@@ -0,0 +1,3 @@
+ALTER TABLE batch_spec_resolution_jobs
+    DROP COLUMN IF EXISTS dry_run,
+    DROP COLUMN IF EXISTS dry_run_result;
@@ -0,0 +1,3 @@
+ALTER TABLE batch_spec_resolution_jobs
+    ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS dry_run_result JSONB;
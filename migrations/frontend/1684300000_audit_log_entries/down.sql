@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_log_sink_cursors;
+DROP TABLE IF EXISTS audit_log_entries;
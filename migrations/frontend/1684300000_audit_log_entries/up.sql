@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS audit_log_entries (
+    id            BIGSERIAL PRIMARY KEY,
+    audit_id      TEXT NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor         TEXT NOT NULL,
+    ip            TEXT NOT NULL DEFAULT '',
+    forwarded_for TEXT NOT NULL DEFAULT '',
+    entity        TEXT NOT NULL,
+    action        TEXT NOT NULL,
+    fields        JSONB NOT NULL DEFAULT '{}'::jsonb,
+    previous_hash TEXT,
+    hash          TEXT
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entries_created_at ON audit_log_entries (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entries_actor ON audit_log_entries (actor, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entries_entity ON audit_log_entries (entity, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entries_action ON audit_log_entries (action, created_at);
+
+COMMENT ON TABLE audit_log_entries IS 'Durable audit trail. Every record passed to audit.Log is stored here when audit log storage is enabled.';
+COMMENT ON COLUMN audit_log_entries.audit_id IS 'The sampling immunity token of the audit record, also present in the process logs.';
+COMMENT ON COLUMN audit_log_entries.actor IS 'The user ID, anonymous user ID or "unknown" for the actor who took the action.';
+COMMENT ON COLUMN audit_log_entries.fields IS 'Additional context of the action as a JSON object.';
+COMMENT ON COLUMN audit_log_entries.previous_hash IS 'The hash of the preceding entry when tamper evidence is enabled.';
+COMMENT ON COLUMN audit_log_entries.hash IS 'SHA-256 over the entry contents and previous_hash when tamper evidence is enabled.';
+
+CREATE TABLE IF NOT EXISTS audit_log_sink_cursors (
+    sink          TEXT PRIMARY KEY,
+    last_entry_id BIGINT NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE audit_log_sink_cursors IS 'Tracks the last audit log entry streamed to each external audit log sink.';
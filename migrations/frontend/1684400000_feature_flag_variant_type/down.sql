@@ -0,0 +1,28 @@
+-- Enum values cannot be dropped, so the type is recreated without it.
+DELETE FROM feature_flags WHERE flag_type = 'variant';
+
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_bool_fields;
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_rollout_fields;
+
+ALTER TYPE feature_flag_type RENAME TO feature_flag_type_old;
+CREATE TYPE feature_flag_type AS ENUM ('bool', 'rollout');
+ALTER TABLE feature_flags ALTER COLUMN flag_type TYPE feature_flag_type USING flag_type::text::feature_flag_type;
+DROP TYPE feature_flag_type_old;
+
+ALTER TABLE feature_flags ADD CONSTRAINT required_bool_fields CHECK (
+    1 = CASE
+        WHEN flag_type = 'bool' AND bool_value IS NULL THEN 0
+        WHEN flag_type <> 'bool' AND bool_value IS NOT NULL THEN 0
+        ELSE 1
+    END
+);
+ALTER TABLE feature_flags ADD CONSTRAINT required_rollout_fields CHECK (
+    1 = CASE
+        WHEN flag_type = 'rollout' AND rollout IS NULL THEN 0
+        WHEN flag_type <> 'rollout' AND rollout IS NOT NULL THEN 0
+        ELSE 1
+    END
+);
+
+COMMENT ON CONSTRAINT required_bool_fields ON feature_flags IS 'Checks that bool_value is set IFF flag_type = bool';
+COMMENT ON CONSTRAINT required_rollout_fields ON feature_flags IS 'Checks that rollout is set IFF flag_type = rollout';
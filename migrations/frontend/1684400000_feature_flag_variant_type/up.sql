@@ -0,0 +1,3 @@
+-- The new value can only be used once this transaction is committed, the columns
+-- holding multivariate flag values are added in the next migration.
+ALTER TYPE feature_flag_type ADD VALUE IF NOT EXISTS 'variant';
@@ -0,0 +1,3 @@
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_variant_fields;
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS rules;
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS variants;
@@ -0,0 +1,15 @@
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS variants JSONB;
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS rules JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_variant_fields;
+ALTER TABLE feature_flags ADD CONSTRAINT required_variant_fields CHECK (
+    1 = CASE
+        WHEN flag_type = 'variant' AND variants IS NULL THEN 0
+        WHEN flag_type <> 'variant' AND variants IS NOT NULL THEN 0
+        ELSE 1
+    END
+);
+
+COMMENT ON COLUMN feature_flags.variants IS 'Variants and their weights, only defined when flag_type is variant';
+COMMENT ON COLUMN feature_flags.rules IS 'Targeting rules, evaluated in order before the value of the flag type';
+COMMENT ON CONSTRAINT required_variant_fields ON feature_flags IS 'Checks that variants is set IFF flag_type = variant';
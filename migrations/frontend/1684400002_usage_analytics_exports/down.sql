@@ -0,0 +1 @@
+DROP TABLE IF EXISTS usage_analytics_exports;
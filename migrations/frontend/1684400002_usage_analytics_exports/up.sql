@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS usage_analytics_exports (
+    id                SERIAL PRIMARY KEY,
+    state             TEXT NOT NULL DEFAULT 'queued',
+    failure_message   TEXT,
+    queued_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at        TIMESTAMP WITH TIME ZONE,
+    finished_at       TIMESTAMP WITH TIME ZONE,
+    process_after     TIMESTAMP WITH TIME ZONE,
+    num_resets        INTEGER NOT NULL DEFAULT 0,
+    num_failures      INTEGER NOT NULL DEFAULT 0,
+    last_heartbeat_at TIMESTAMP WITH TIME ZONE,
+    execution_logs    JSON[],
+    worker_hostname   TEXT NOT NULL DEFAULT '',
+    cancel            BOOLEAN NOT NULL DEFAULT FALSE,
+
+    initiator_id      INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    format            TEXT NOT NULL,
+    range_start       DATE NOT NULL,
+    range_end         DATE NOT NULL,
+    object_key        TEXT,
+    row_count         INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS usage_analytics_exports_state ON usage_analytics_exports (state);
+
+COMMENT ON TABLE usage_analytics_exports IS 'Background jobs that export aggregated daily usage from event_logs to the upload store.';
+COMMENT ON COLUMN usage_analytics_exports.format IS 'The file format of the export, either csv or parquet.';
+COMMENT ON COLUMN usage_analytics_exports.object_key IS 'The key of the exported file in the upload store, set once the export completed.';
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS batch_change_auto_merge_policies (
+    batch_change_id       BIGINT PRIMARY KEY REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    user_id               INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    merge_method          TEXT NOT NULL,
+    required_review_state TEXT,
+    required_checks       TEXT NOT NULL,
+    merge_window_days     TEXT[] NOT NULL DEFAULT '{}',
+    merge_window_start    TEXT NOT NULL DEFAULT '',
+    merge_window_end      TEXT NOT NULL DEFAULT '',
+    max_merges_per_hour   INTEGER NOT NULL DEFAULT 0,
+    created_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE batch_change_auto_merge_policies IS 'Policies that merge the open changesets of a batch change once their checks pass and reviews are approved.';
+COMMENT ON COLUMN batch_change_auto_merge_policies.user_id IS 'The user who configured the policy. Changesets are merged on their behalf.';
+COMMENT ON COLUMN batch_change_auto_merge_policies.required_review_state IS 'The review state a changeset must be in to be merged. NULL if the review state is ignored.';
+COMMENT ON COLUMN batch_change_auto_merge_policies.max_merges_per_hour IS 'The maximum number of merges in the batch change in any hour. 0 if unlimited.';
+
+CREATE TABLE IF NOT EXISTS changeset_auto_merge_blockers (
+    batch_change_id BIGINT NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id    BIGINT NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    reason          TEXT NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (batch_change_id, changeset_id)
+);
+
+COMMENT ON TABLE changeset_auto_merge_blockers IS 'The reasons why the auto-merge policy of a batch change did not merge an open changeset, as of the last evaluation.';
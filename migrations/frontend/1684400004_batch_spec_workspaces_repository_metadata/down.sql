@@ -0,0 +1 @@
+ALTER TABLE batch_spec_workspaces DROP COLUMN IF EXISTS repository_metadata;
@@ -0,0 +1,3 @@
+ALTER TABLE batch_spec_workspaces ADD COLUMN IF NOT EXISTS repository_metadata jsonb;
+
+COMMENT ON COLUMN batch_spec_workspaces.repository_metadata IS 'The repository metadata available in batch spec templates, such as key-value pairs, topics and code owners. NULL if it was not loaded when the workspace was resolved.';
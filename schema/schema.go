@@ -80,6 +80,54 @@ type AuditLog struct {
 	InternalTraffic bool `json:"internalTraffic"`
 	// SeverityLevel description: Severity logging level for the audit log.
 	SeverityLevel string `json:"severityLevel,omitempty"`
+	// Sinks description: External destinations the stored audit log is streamed to. Requires audit log storage to be enabled. Entries are delivered at least once, in order.
+	Sinks *AuditLogSinks `json:"sinks,omitempty"`
+	// Storage description: Store the audit log durably in the database, where site admins can query it through the GraphQL API and from where it is streamed to the configured sinks.
+	Storage *AuditLogStorage `json:"storage,omitempty"`
+}
+
+// AuditLogFileSink description: Write batches of entries as files of JSON lines to the audit log upload store, configured with the AUDIT_LOG_UPLOAD_* environment variables of the frontend.
+type AuditLogFileSink struct {
+	// Prefix description: Prefix of the keys of the written objects.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// AuditLogSinks description: External destinations the stored audit log is streamed to. Requires audit log storage to be enabled. Entries are delivered at least once, in order.
+type AuditLogSinks struct {
+	// File description: Write batches of entries as files of JSON lines to the audit log upload store, configured with the AUDIT_LOG_UPLOAD_* environment variables of the frontend.
+	File *AuditLogFileSink `json:"file,omitempty"`
+	// Syslog description: Send entries as RFC 5424 syslog messages.
+	Syslog *AuditLogSyslogSink `json:"syslog,omitempty"`
+	// Webhook description: POST batches of entries as a JSON array to an HTTP endpoint.
+	Webhook *AuditLogWebhookSink `json:"webhook,omitempty"`
+}
+
+// AuditLogStorage description: Store the audit log durably in the database, where site admins can query it through the GraphQL API and from where it is streamed to the configured sinks.
+type AuditLogStorage struct {
+	// Enabled description: Store audit log entries in the database.
+	Enabled bool `json:"enabled,omitempty"`
+	// RetentionDays description: Number of days stored audit log entries are kept for. Older entries are deleted.
+	RetentionDays int `json:"retentionDays,omitempty"`
+	// TamperEvident description: Chain every stored entry to its predecessor with a SHA-256 hash, so that modified, removed or reordered entries can be detected.
+	TamperEvident bool `json:"tamperEvident,omitempty"`
+}
+
+// AuditLogSyslogSink description: Send entries as RFC 5424 syslog messages.
+type AuditLogSyslogSink struct {
+	// Address description: Address of the syslog server, as host:port.
+	Address string `json:"address"`
+	// AppName description: APP-NAME of the syslog messages.
+	AppName string `json:"appName,omitempty"`
+	// Network description: Network used to reach the syslog server.
+	Network string `json:"network,omitempty"`
+}
+
+// AuditLogWebhookSink description: POST batches of entries as a JSON array to an HTTP endpoint.
+type AuditLogWebhookSink struct {
+	// Secret description: Secret used to sign request bodies. The hex encoded HMAC-SHA256 signature is sent in the X-Sourcegraph-Signature header.
+	Secret string `json:"secret,omitempty"`
+	// Url description: URL the entries are sent to.
+	Url string `json:"url"`
 }
 
 // AuthAccessRequest description: The config options for access requests
@@ -671,14 +719,16 @@ type EncryptionKeys struct {
 	// CacheSize description: number of values to keep in LRU cache
 	CacheSize int `json:"cacheSize,omitempty"`
 	// EnableCache description: enable LRU cache for decryption APIs
-	EnableCache            bool           `json:"enableCache,omitempty"`
-	ExecutorSecretKey      *EncryptionKey `json:"executorSecretKey,omitempty"`
-	ExternalServiceKey     *EncryptionKey `json:"externalServiceKey,omitempty"`
-	GitHubAppKey           *EncryptionKey `json:"gitHubAppKey,omitempty"`
-	OutboundWebhookKey     *EncryptionKey `json:"outboundWebhookKey,omitempty"`
-	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
-	WebhookKey             *EncryptionKey `json:"webhookKey,omitempty"`
-	WebhookLogKey          *EncryptionKey `json:"webhookLogKey,omitempty"`
+	EnableCache        bool           `json:"enableCache,omitempty"`
+	ExecutorSecretKey  *EncryptionKey `json:"executorSecretKey,omitempty"`
+	ExternalServiceKey *EncryptionKey `json:"externalServiceKey,omitempty"`
+	GitHubAppKey       *EncryptionKey `json:"gitHubAppKey,omitempty"`
+	OutboundWebhookKey *EncryptionKey `json:"outboundWebhookKey,omitempty"`
+	// PreviousKeys description: Keys that were previously configured in encryption.keys and have since been rotated out. They are only used to decrypt existing records while those records are re-encrypted in the background with the currently configured keys. A previous key can be removed once the key rotation out-of-band migration has completed.
+	PreviousKeys           []*EncryptionKey `json:"previousKeys,omitempty"`
+	UserExternalAccountKey *EncryptionKey   `json:"userExternalAccountKey,omitempty"`
+	WebhookKey             *EncryptionKey   `json:"webhookKey,omitempty"`
+	WebhookLogKey          *EncryptionKey   `json:"webhookLogKey,omitempty"`
 }
 type ExcludedAWSCodeCommitRepo struct {
 	// Id description: The ID of an AWS Code Commit repository (as returned by the AWS API) to exclude from mirroring. Use this to exclude the repository, even if renamed, or to differentiate between repositories with the same name in multiple regions.
@@ -1643,6 +1693,20 @@ type OutputVariable struct {
 	Value string `json:"value"`
 }
 
+// OwnInference description: Configures ownership inference, which ranks likely owners of a file by blending recent contributors, recent viewers and reviewers of changesets tracked in batch changes. Inferred owners can be searched with `file:has.inferred.owner()`.
+type OwnInference struct {
+	// MaxOwners description: The maximum number of inferred owners of a file.
+	MaxOwners int `json:"maxOwners,omitempty"`
+	// RecentContributorsWeight description: The weight of commits to the file in the last 90 days. Set to 0 to ignore this signal.
+	RecentContributorsWeight *float64 `json:"recentContributorsWeight,omitempty"`
+	// RecentViewsWeight description: The weight of views of the file on Sourcegraph. Set to 0 to ignore this signal.
+	RecentViewsWeight *float64 `json:"recentViewsWeight,omitempty"`
+	// ReviewersWeight description: The weight of reviews of changesets in the repository of the file in the last 90 days. Set to 0 to ignore this signal.
+	ReviewersWeight *float64 `json:"reviewersWeight,omitempty"`
+	// UseAsFallback description: Use inferred owners for files that have no CODEOWNERS entry in `file:has.owner()` and `select:file.owners` searches.
+	UseAsFallback bool `json:"useAsFallback,omitempty"`
+}
+
 // PagureConnection description: Configuration for a connection to Pagure.
 type PagureConnection struct {
 	// Forks description: If true, it includes forks in the returned projects.
@@ -1921,6 +1985,20 @@ type SMTPServerConfig struct {
 	// Username description: The username to use when communicating with the SMTP server.
 	Username string `json:"username,omitempty"`
 }
+
+// SearchAdmission description: Admission control for searches. Each search is assigned a cost estimated from its planned jobs (the number of repositories searched, the pattern type, non-default revisions and the result limit). Searches that exceed these limits are queued or rejected with an alert. Internal searches (such as code monitors and background jobs) are not subject to admission control. Unset limits are not enforced.
+type SearchAdmission struct {
+	// CostBudgetPerUser description: The total estimated cost of the searches a single user may run within costBudgetWindowSeconds.
+	CostBudgetPerUser int `json:"costBudgetPerUser,omitempty"`
+	// CostBudgetWindowSeconds description: The length of the window that costBudgetPerUser applies to. Defaults to 60 seconds.
+	CostBudgetWindowSeconds int `json:"costBudgetWindowSeconds,omitempty"`
+	// MaxConcurrentSearchesPerUser description: The maximum number of searches a single user may run at the same time. Additional searches wait for up to queueTimeoutSeconds for a slot before they are rejected.
+	MaxConcurrentSearchesPerUser int `json:"maxConcurrentSearchesPerUser,omitempty"`
+	// MaxCostPerSearch description: The maximum estimated cost of a single search. More expensive searches are rejected and the user is asked to narrow their query.
+	MaxCostPerSearch int `json:"maxCostPerSearch,omitempty"`
+	// QueueTimeoutSeconds description: How long a search waits for a concurrency slot before it is rejected. Defaults to 0, which rejects the search immediately.
+	QueueTimeoutSeconds int `json:"queueTimeoutSeconds,omitempty"`
+}
 type SearchIndexRevisionsRule struct {
 	// Name description: Regular expression which matches against the name of a repository (e.g. "^github\.com/owner/name$").
 	Name string `json:"name,omitempty"`
@@ -1930,6 +2008,8 @@ type SearchIndexRevisionsRule struct {
 
 // SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 type SearchLimits struct {
+	// Admission description: Admission control for searches. Each search is assigned a cost estimated from its planned jobs (the number of repositories searched, the pattern type, non-default revisions and the result limit). Searches that exceed these limits are queued or rejected with an alert. Internal searches (such as code monitors and background jobs) are not subject to admission control. Unset limits are not enforced.
+	Admission *SearchAdmission `json:"admission,omitempty"`
 	// CommitDiffMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit". The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffWithTimeFilterMaxRepos) when "after:" or "before:" is specified because those queries are faster. Defaults to 50.
 	CommitDiffMaxRepos int `json:"commitDiffMaxRepos,omitempty"`
 	// CommitDiffWithTimeFilterMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit" with a "after:" or "before:" filter. The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffMaxRepos) when "after:" or "before:" is not specified because those queries are slower. Defaults to 10000.
@@ -2493,6 +2573,8 @@ type SiteConfiguration struct {
 	OwnBackgroundRepoIndexRateLimit int `json:"own.background.repoIndexRateLimit,omitempty"`
 	// OwnBestEffortTeamMatching description: The Own service will attempt to match a Team by the last part of its handle if it contains a slash and no match is found for its full handle.
 	OwnBestEffortTeamMatching *bool `json:"own.bestEffortTeamMatching,omitempty"`
+	// OwnInference description: Configures ownership inference, which ranks likely owners of a file by blending recent contributors, recent viewers and reviewers of changesets tracked in batch changes. Inferred owners can be searched with `file:has.inferred.owner()`.
+	OwnInference *OwnInference `json:"own.inference,omitempty"`
 	// ParentSourcegraph description: URL to fetch unreachable repository details from. Defaults to "https://sourcegraph.com"
 	ParentSourcegraph *ParentSourcegraph `json:"parentSourcegraph,omitempty"`
 	// PermissionsSyncJobCleanupInterval description: Time interval (in seconds) of how often cleanup worker should remove old jobs from permissions sync jobs table.
@@ -2672,6 +2754,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "own.background.repoIndexRateBurstLimit")
 	delete(m, "own.background.repoIndexRateLimit")
 	delete(m, "own.bestEffortTeamMatching")
+	delete(m, "own.inference")
 	delete(m, "parentSourcegraph")
 	delete(m, "permissions.syncJobCleanupInterval")
 	delete(m, "permissions.syncJobsHistorySize")